@@ -1,10 +1,12 @@
 package main
 
 import (
-	"fmt"
+	"log"
+	"os"
+	// gatherings have IANA time zones, embed the database for images without one
+	_ "time/tzdata"
 
-	"github.com/hieronimusbudi/simple-go-api/internal/adapter"
-	"github.com/hieronimusbudi/simple-go-api/internal/config"
+	"github.com/hieronimusbudi/simple-go-api/internal/cli"
 )
 
 //	@title			Gathering App API
@@ -12,8 +14,13 @@ import (
 //	@description	# Introduction
 //	@description	This is documentation for Gathering App API
 
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
+//	@description				Access token from /auth/login, as "Bearer <token>"
+
 func main() {
-	config.SetConfig(".")
-	r := adapter.Router()
-	r.Run(fmt.Sprintf(":%s", config.Get().PORT))
+	if err := cli.Run(os.Args[1:]); err != nil {
+		log.Fatalln(err)
+	}
 }
@@ -0,0 +1,197 @@
+package adapter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/factory"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+)
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+// maxCalendarSize is the largest iCalendar file accepted on import
+const maxCalendarSize = 1 << 20
+
+// @Tags			Gathering
+// @Summary		Get Gathering Calendar
+// @Description	Get a gathering as an iCalendar file with its organizer, attendees and invitees
+// @Produce		text/calendar
+// @Param			id	path		int		true	"Gathering ID"
+// @Success		200	{string}	string	"iCalendar"
+// @Router			/gatherings/{id}.ics [get]
+func (ctr *Controller) GetGatheringCalendar(c *gin.Context) {
+	id, err := paramCalendarID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	gathering, err := ctr.GatheringUsecase.GetByID(c.Request.Context(), id)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	calendar, err := ctr.calendar(c.Request.Context(), gathering.Name, []domain.Gathering{gathering})
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.Data(http.StatusOK, calendarContentType, []byte(calendar))
+}
+
+// @Tags			Member
+// @Summary		Get Member Calendar
+// @Description	Get an iCalendar feed of gatherings the member attends or has an open invitation to, calendar apps can subscribe to it.
+// @Description	Private gatherings are left out unless the bearer token is of their creator, an attendee or an invitee.
+// @Produce		text/calendar
+// @Param			id	path		int		true	"Member ID"
+// @Success		200	{string}	string	"iCalendar"
+// @Router			/members/{id}/calendar.ics [get]
+func (ctr *Controller) GetMemberCalendar(c *gin.Context) {
+	id, err := paramID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	member, err := ctr.MemberUsecase.GetByID(c.Request.Context(), id)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	gatherings, err := ctr.GatheringUsecase.Get(c.Request.Context(), domain.GatheringArgs{
+		MemberIDs: []int64{id},
+	})
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	invitations, err := ctr.InvitationUsecase.Get(c.Request.Context(), domain.InvitationArgs{
+		MemberID: id,
+		Statuses: []valueobject.InvitationStatus{valueobject.INVITATION_CREATED, valueobject.INVITATION_WAITLISTED, valueobject.INVITATION_TENTATIVE},
+	})
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	attending := map[int64]bool{}
+	for _, g := range gatherings {
+		attending[g.ID] = true
+	}
+	invitedIDs := []int64{}
+	for _, inv := range invitations {
+		if !attending[inv.GatheringID] {
+			invitedIDs = append(invitedIDs, inv.GatheringID)
+		}
+	}
+	if len(invitedIDs) > 0 {
+		invited, err := ctr.GatheringUsecase.Get(c.Request.Context(), domain.GatheringArgs{
+			IDs: invitedIDs,
+		})
+		if err != nil {
+			errorResponse(c, err)
+			return
+		}
+		gatherings = append(gatherings, invited...)
+	}
+	calendar, err := ctr.calendar(c.Request.Context(), "Gatherings of "+member.FullName(), gatherings)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.Data(http.StatusOK, calendarContentType, []byte(calendar))
+}
+
+// @Tags			Gathering
+// @Summary		Import Gathering Calendar
+// @Description	Create gatherings from the VEVENTs of an iCalendar file, sent as the body or as the file field of a form.
+// @Description	The authenticated member is the creator, ATTENDEE emails of members are invited.
+// @Description	An event with a UID imported before is not created again.
+// @Accept			text/calendar,multipart/form-data
+// @Produce		json
+// @Param			file	formData	file															false	"iCalendar file"
+// @Success		200		{object}	helpers.ResponsePayload{data=[]domain.CalendarImportResult}	"Result per event"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}		"Invalid file"
+// @Security		BearerAuth
+// @Router			/gatherings/import [post]
+func (ctr *Controller) ImportGatheringCalendar(c *gin.Context) {
+	var body io.Reader = http.MaxBytesReader(c.Writer, c.Request.Body, maxCalendarSize)
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			errorResponse(c, domain.WrapError(domain.ErrValidation, "file is required", err))
+			return
+		}
+		if header.Size > maxCalendarSize {
+			errorResponse(c, domain.NewFieldError("file", domain.CodeTooLong, "file must not be larger than 1 MB"))
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			errorResponse(c, err)
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+	events, err := domain.ParseCalendar(body)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	results, err := ctr.CalendarUsecase.Import(c.Request.Context(), events)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusOK, "success", results)
+}
+
+// calendar loads members and invitations of the gatherings and encodes them as iCalendar
+func (ctr *Controller) calendar(ctx context.Context, name string, gatherings []domain.Gathering) (calendar string, err error) {
+	invitations := []domain.Invitation{}
+	members := []domain.Member{}
+	if len(gatherings) > 0 {
+		gatheringIDs := []int64{}
+		for _, g := range gatherings {
+			gatheringIDs = append(gatheringIDs, g.ID)
+		}
+		invitations, err = ctr.InvitationUsecase.Get(ctx, domain.InvitationArgs{
+			GatheringIDs: gatheringIDs,
+		})
+		if err != nil {
+			return
+		}
+		memberIDs := []int64{}
+		for _, g := range gatherings {
+			memberIDs = append(memberIDs, g.Creator.ID)
+			for _, m := range g.Attendees {
+				memberIDs = append(memberIDs, m.ID)
+			}
+		}
+		for _, inv := range invitations {
+			memberIDs = append(memberIDs, inv.Member.ID)
+		}
+		members, err = ctr.MemberUsecase.Get(ctx, domain.MemberArgs{
+			IDs:              memberIDs,
+			IsIncludeDiscard: true,
+		})
+		if err != nil {
+			return
+		}
+	}
+	gatheringFactory := factory.Gathering{}
+	invitationFactory := factory.Invitation{}
+	calendar = domain.Calendar{
+		Name:        name,
+		Gatherings:  gatheringFactory.Generate(gatherings, members),
+		Invitations: invitationFactory.Generate(invitations, nil, members),
+		Stamp:       time.Now(),
+	}.Encode()
+	return
+}
@@ -2,15 +2,15 @@ package adapter
 
 import (
 	"context"
-	"errors"
+	"log"
 	"net/http"
-	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/hieronimusbudi/simple-go-api/internal/adapter/docs"
-	"github.com/hieronimusbudi/simple-go-api/internal/adapter/mysql"
-	"github.com/hieronimusbudi/simple-go-api/internal/adapter/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/application/event"
 	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
+	"github.com/hieronimusbudi/simple-go-api/internal/config"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain/factory"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
@@ -21,57 +21,175 @@ import (
 
 // Controller is a controller
 type Controller struct {
-	MemberUsecase     usecase.IMemberUsecase
-	GatheringUsecase  usecase.IGatheringUsecase
-	InvitationUsecase usecase.IInvitationUsecase
+	MemberUsecase          usecase.IMemberUsecase
+	GatheringUsecase       usecase.IGatheringUsecase
+	InvitationUsecase      usecase.IInvitationUsecase
+	AuthUsecase            usecase.IAuthUsecase
+	CalendarUsecase        usecase.ICalendarUsecase
+	GroupUsecase           usecase.IGroupUsecase
+	WebhookUsecase         usecase.IWebhookUsecase
+	GatheringStreamUsecase usecase.IGatheringStreamUsecase
 }
 
 // Router is routing settings
 func Router() *gin.Engine {
 	r := gin.Default()
-	db := mysql.Connection()
-
-	memberRepository := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{DB: db})
-	gatheringRepository := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{DB: db})
-	invitationRepository := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{DB: db})
+	repositories := NewRepositories()
 
+	webhookUsecase := usecase.NewWebhookUsecase(usecase.WebhookUsecaseArgs{
+		WebhookRepository: repositories.Webhook,
+		LeaseRepository:   repositories.Lease,
+	})
+	// deliveries are queued before the response so a webhook is not missed when the process stops
+	bus := event.NewBus(event.BusArgs{})
+	bus.Subscribe(webhookUsecase.Handle)
 	memberUsecase := usecase.NewMemberUsecase(usecase.MemberUsecaseArgs{
-		MemberRepository: memberRepository,
+		MemberRepository: repositories.Member,
+		Events:           bus,
 	})
 	gatheringUsecase := usecase.NewGatheringUsecase(usecase.GatheringUsecaseArgs{
-		GatheringRepository: gatheringRepository,
+		GatheringRepository:  repositories.Gathering,
+		InvitationRepository: repositories.Invitation,
+		Events:               bus,
 	})
 	invitationUsecase := usecase.NewInvitationUsecase(usecase.InvitationUsecaseArgs{
-		InvitationRepository: invitationRepository,
+		InvitationRepository: repositories.Invitation,
+		GatheringRepository:  repositories.Gathering,
+		MemberRepository:     repositories.Member,
+		GroupRepository:      repositories.Group,
+		Events:               bus,
 	})
-
-	controller := Controller{
-		MemberUsecase:     memberUsecase,
+	groupUsecase := usecase.NewGroupUsecase(usecase.GroupUsecaseArgs{
+		GroupRepository:      repositories.Group,
+		MemberRepository:     repositories.Member,
+		GatheringRepository:  repositories.Gathering,
+		InvitationRepository: repositories.Invitation,
+		Events:               bus,
+	})
+	gatheringStreamUsecase := usecase.NewGatheringStreamUsecase(usecase.GatheringStreamUsecaseArgs{
+		GatheringUsecase: gatheringUsecase,
+	})
+	bus.SubscribeAsync(gatheringStreamUsecase.Handle)
+	calendarUsecase := usecase.NewCalendarUsecase(usecase.CalendarUsecaseArgs{
 		GatheringUsecase:  gatheringUsecase,
 		InvitationUsecase: invitationUsecase,
+		MemberUsecase:     memberUsecase,
+	})
+	if err := usecase.ValidateSecret(config.Get().JWTSECRET); err != nil {
+		log.Fatalln(err)
+	}
+	ttl, err := parseTTL(config.Get().JWTTTL)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	authUsecase := usecase.NewAuthUsecase(usecase.AuthUsecaseArgs{
+		MemberRepository:     repositories.Member,
+		CredentialRepository: repositories.Credential,
+		Secret:               config.Get().JWTSECRET,
+		TTL:                  ttl,
+	})
+
+	notificationUsecase := usecase.NewNotificationUsecase(usecase.NotificationUsecaseArgs{
+		NotificationRepository: repositories.Notification,
+		MemberRepository:       repositories.Member,
+		Notifier:               newNotifier(),
+		LeaseRepository:        repositories.Lease,
+	})
+	interval, err := parseInterval("NOTIFYINTERVAL", config.Get().NOTIFYINTERVAL, usecase.DefaultNotificationInterval)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	go notificationUsecase.Run(context.Background(), interval)
+	webhookInterval, err := parseInterval("WEBHOOKINTERVAL", config.Get().WEBHOOKINTERVAL, usecase.DefaultWebhookInterval)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	go webhookUsecase.Run(context.Background(), webhookInterval)
+	reminderOffsets, err := domain.ParseReminderOffsets(config.Get().REMINDEROFFSETS)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	reminderUsecase := usecase.NewReminderUsecase(usecase.ReminderUsecaseArgs{
+		GatheringRepository:  repositories.Gathering,
+		InvitationRepository: repositories.Invitation,
+		ReminderRepository:   repositories.Reminder,
+		LeaseRepository:      repositories.Lease,
+		Offsets:              reminderOffsets,
+	})
+	reminderInterval, err := parseInterval("REMINDERINTERVAL", config.Get().REMINDERINTERVAL, usecase.DefaultReminderInterval)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	go reminderUsecase.Run(context.Background(), reminderInterval)
+
+	controller := Controller{
+		MemberUsecase:          memberUsecase,
+		GatheringUsecase:       gatheringUsecase,
+		InvitationUsecase:      invitationUsecase,
+		AuthUsecase:            authUsecase,
+		CalendarUsecase:        calendarUsecase,
+		GroupUsecase:           groupUsecase,
+		WebhookUsecase:         webhookUsecase,
+		GatheringStreamUsecase: gatheringStreamUsecase,
 	}
 
+	authRoutes := r.Group("/auth")
+	authRoutes.POST("/login", controller.Login)
+	authRoutes.GET("/me", controller.Authenticate, controller.Me)
+
 	memberRoutes := r.Group("/members")
 	memberRoutes.POST("", controller.CreateMember)
 	memberRoutes.GET("", controller.GetMembers)
 	memberRoutes.GET("/:id", controller.GetMember)
-	memberRoutes.PUT("/:id", controller.UpdateMember)
-	memberRoutes.DELETE("/:id", controller.DeleteMember)
+	memberRoutes.GET("/:id/calendar.ics", controller.Identify, controller.GetMemberCalendar)
+	memberRoutes.GET("/:id/conflicts", controller.Authenticate, controller.GetMemberConflicts)
+	memberRoutes.PUT("/:id", controller.Authenticate, controller.UpdateMember)
+	memberRoutes.DELETE("/:id", controller.Authenticate, controller.DeleteMember)
 
 	gatheringRoutes := r.Group("/gatherings")
-	gatheringRoutes.POST("", controller.CreateGathering)
-	gatheringRoutes.GET("", controller.GetGatherings)
-	gatheringRoutes.GET("/:id", controller.GetGathering)
-	gatheringRoutes.PUT("/:id", controller.UpdateGathering)
-	gatheringRoutes.DELETE("/:id", controller.DeleteGathering)
+	gatheringRoutes.POST("", controller.Authenticate, controller.CreateGathering)
+	gatheringRoutes.GET("", controller.Identify, controller.GetGatherings)
+	gatheringRoutes.POST("/import", controller.Authenticate, controller.ImportGatheringCalendar)
+	gatheringRoutes.GET("/:id", controller.Identify, controller.GetGathering)
+	gatheringRoutes.PUT("/:id", controller.Authenticate, controller.UpdateGathering)
+	gatheringRoutes.DELETE("/:id", controller.Authenticate, controller.DeleteGathering)
+	gatheringRoutes.POST("/:id/join", controller.Authenticate, controller.JoinGathering)
+	gatheringRoutes.POST("/:id/leave", controller.Authenticate, controller.LeaveGathering)
+	gatheringRoutes.GET("/:id/occurrences", controller.Identify, controller.GetOccurrences)
+	gatheringRoutes.GET("/:id/events", controller.Identify, controller.GetGatheringEvents)
+	gatheringRoutes.POST("/:id/invitations", controller.Authenticate, controller.InviteGroup)
+	// gin paths cannot have a literal colon, :action captures the method of /:id/invitations:batch
+	gatheringRoutes.POST("/:id/invitations:action", controller.Authenticate, controller.BatchInvitations)
+	gatheringRoutes.PUT("/:id/occurrences/:recurrence_id", controller.Authenticate, controller.UpdateOccurrence)
+	gatheringRoutes.DELETE("/:id/occurrences/:recurrence_id", controller.Authenticate, controller.CancelOccurrence)
+
+	groupRoutes := r.Group("/groups")
+	groupRoutes.POST("", controller.Authenticate, controller.CreateGroup)
+	groupRoutes.GET("", controller.GetGroups)
+	groupRoutes.GET("/:id", controller.GetGroup)
+	groupRoutes.PUT("/:id", controller.Authenticate, controller.UpdateGroup)
+	groupRoutes.DELETE("/:id", controller.Authenticate, controller.DeleteGroup)
+	groupRoutes.POST("/:id/members", controller.Authenticate, controller.AddGroupMember)
+	groupRoutes.PUT("/:id/members/:member_id", controller.Authenticate, controller.UpdateGroupMember)
+	groupRoutes.DELETE("/:id/members/:member_id", controller.Authenticate, controller.RemoveGroupMember)
 
 	invitationRoutes := r.Group("/invitations")
-	invitationRoutes.POST("", controller.CreateInvitation)
-	invitationRoutes.GET("", controller.GetInvitations)
-	invitationRoutes.GET("/:id", controller.GetInvitation)
-	invitationRoutes.PUT("/:id/accept", controller.AcceptInvitation)
-	invitationRoutes.PUT("/:id/reject", controller.RejectInvitation)
-	invitationRoutes.PUT("/:id/cancel", controller.CancelInvitation)
+	invitationRoutes.POST("", controller.Authenticate, controller.CreateInvitation)
+	invitationRoutes.GET("", controller.Identify, controller.GetInvitations)
+	invitationRoutes.GET("/:id", controller.Identify, controller.GetInvitation)
+	invitationRoutes.PUT("/:id/accept", controller.Authenticate, controller.AcceptInvitation)
+	invitationRoutes.PUT("/:id/tentative", controller.Authenticate, controller.TentativeInvitation)
+	invitationRoutes.PUT("/:id/reject", controller.Authenticate, controller.RejectInvitation)
+	invitationRoutes.PUT("/:id/cancel", controller.Authenticate, controller.CancelInvitation)
+
+	webhookRoutes := r.Group("/webhooks", controller.Authenticate)
+	webhookRoutes.POST("", controller.CreateWebhook)
+	webhookRoutes.GET("", controller.GetWebhooks)
+	webhookRoutes.GET("/:id", controller.GetWebhook)
+	webhookRoutes.PUT("/:id", controller.UpdateWebhook)
+	webhookRoutes.DELETE("/:id", controller.DeleteWebhook)
+	webhookRoutes.GET("/:id/deliveries", controller.GetWebhookDeliveries)
+	webhookRoutes.POST("/:id/deliveries/:delivery_id/replay", controller.ReplayWebhookDelivery)
 
 	docs.SwaggerInfo.Title = "Gathering App API"
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -81,28 +199,42 @@ func Router() *gin.Engine {
 
 // @Tags			Member
 // @Summary		Create Member
-// @Description	Create Member
+// @Description	Create Member, set password to let the member login
 // @Accept			json
 // @Produce		json
-// @Param			payload	body		swaggermodel.Member									true	"Payload"
+// @Param			payload	body		swaggermodel.CreateMember							true	"Payload"
 // @Success		200		{object}	helpers.ResponsePayload{data=swaggermodel.Member}	"Member"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}	"Invalid fields"
 // @Router			/members [post]
 func (ctr *Controller) CreateMember(c *gin.Context) {
 	member := domain.Member{}
-	if err := c.BindJSON(&member); err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+	if err := bindJSON(c, &member); err != nil {
+		errorResponse(c, err)
 		return
 	}
 	err := member.Validate()
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	member, err = ctr.MemberUsecase.Create(context.Background(), member)
+	password := member.Password
+	if password != "" {
+		if err = domain.ValidatePassword(password); err != nil {
+			errorResponse(c, err)
+			return
+		}
+	}
+	member, err = ctr.MemberUsecase.Create(c.Request.Context(), member)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
+	if password != "" {
+		if err = ctr.AuthUsecase.SetPassword(c.Request.Context(), member.ID, password); err != nil {
+			errorResponse(c, err)
+			return
+		}
+	}
 	helpers.NewResponse(c, http.StatusCreated, "success", member)
 }
 
@@ -111,15 +243,27 @@ func (ctr *Controller) CreateMember(c *gin.Context) {
 // @Description	Get Members
 // @Accept			json
 // @Produce		json
-// @Success		200	{array}	helpers.ResponsePayload{data=swaggermodel.Member}	"Member"
+// @Param			limit	query		int																		false	"Page size, default 20, max 100"
+// @Param			offset	query		int																		false	"Rows to skip, cannot be combined with cursor"
+// @Param			cursor	query		string																	false	"Next cursor from previous page meta"
+// @Param			sort	query		string																	false	"Sort by id, created_at or name, prefix with - for descending"
+// @Param			email	query		string																	false	"Filter by email"
+// @Param			name	query		string																	false	"Filter by first or last name"
+// @Success		200		{object}	helpers.ResponsePayload{data=[]swaggermodel.Member,meta=domain.Page}	"Member"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}	"Invalid fields"
 // @Router			/members [get]
 func (ctr *Controller) GetMembers(c *gin.Context) {
-	members, err := ctr.MemberUsecase.Get(context.Background(), domain.MemberArgs{})
+	args, err := bindMemberArgs(c)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	helpers.NewResponse(c, http.StatusOK, "success", members)
+	members, page, err := ctr.MemberUsecase.List(c.Request.Context(), args)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponseWithMeta(c, http.StatusOK, "success", members, page)
 }
 
 // @Tags			Member
@@ -131,58 +275,87 @@ func (ctr *Controller) GetMembers(c *gin.Context) {
 // @Success		200	{object}	helpers.ResponsePayload{data=swaggermodel.Member}	"Member"
 // @Router			/members/{id} [get]
 func (ctr *Controller) GetMember(c *gin.Context) {
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	id, err := paramID(c)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	members, err := ctr.MemberUsecase.GetByID(context.Background(), id)
+	members, err := ctr.MemberUsecase.GetByID(c.Request.Context(), id)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
 	helpers.NewResponse(c, http.StatusOK, "success", members)
 }
 
+// @Tags			Member
+// @Summary		Get Member Conflicts
+// @Description	Get overlapping occurrences of gatherings the member attends, only the member or an admin.
+// @Description	A gathering without an end is taken to last an hour.
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int																true	"Member ID"
+// @Param			from	query		string															false	"Start of the range, RFC 3339, default now"
+// @Param			to		query		string															false	"End of the range, at most and by default 366 days after from"
+// @Success		200		{object}	helpers.ResponsePayload{data=[]domain.ScheduleConflict}		"Conflicts"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}			"Invalid fields"
+// @Security		BearerAuth
+// @Router			/members/{id}/conflicts [get]
+func (ctr *Controller) GetMemberConflicts(c *gin.Context) {
+	args, err := bindScheduleArgs(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	conflicts, err := ctr.GatheringUsecase.ScheduleConflicts(c.Request.Context(), args)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusOK, "success", conflicts)
+}
+
 // @Tags			Member
 // @Summary		Update Member
-// @Description	Update Member
+// @Description	Update Member, only the member or an admin
 // @Accept			json
 // @Produce		json
 // @Param			id		path		int							true	"Member ID"
 // @Param			payload	body		swaggermodel.Member			true	"Payload"
 // @Success		200		{object}	helpers.ResponsePayload{}	"Member"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}	"Invalid fields"
+// @Security		BearerAuth
 // @Router			/members/{id} [put]
 func (ctr *Controller) UpdateMember(c *gin.Context) {
 	member := domain.Member{}
-	if err := c.BindJSON(&member); err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+	if err := bindJSON(c, &member); err != nil {
+		errorResponse(c, err)
 		return
 	}
 	err := member.Validate()
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	id, err := paramID(c)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	_, err = ctr.MemberUsecase.GetByID(context.Background(), id)
+	_, err = ctr.MemberUsecase.GetByID(c.Request.Context(), id)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
 	member.ID = id
-	err = ctr.MemberUsecase.Update(context.Background(), member)
+	err = ctr.MemberUsecase.Update(c.Request.Context(), member)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	member, err = ctr.MemberUsecase.GetByID(context.Background(), id)
+	member, err = ctr.MemberUsecase.GetByID(c.Request.Context(), id)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
 	helpers.NewResponse(c, http.StatusOK, "success", member)
@@ -190,26 +363,27 @@ func (ctr *Controller) UpdateMember(c *gin.Context) {
 
 // @Tags			Member
 // @Summary		Delete Member
-// @Description	Delete Member
+// @Description	Delete Member, only the member or an admin
 // @Accept			json
 // @Produce		json
 // @Param			id	path		int							true	"Member ID"
 // @Success		200	{object}	helpers.ResponsePayload{}	"Member"
+// @Security		BearerAuth
 // @Router			/members/{id} [delete]
 func (ctr *Controller) DeleteMember(c *gin.Context) {
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	id, err := paramID(c)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	_, err = ctr.MemberUsecase.GetByID(context.Background(), id)
+	_, err = ctr.MemberUsecase.GetByID(c.Request.Context(), id)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	err = ctr.MemberUsecase.Delete(context.Background(), domain.MemberArgs{ID: id})
+	err = ctr.MemberUsecase.Delete(c.Request.Context(), domain.MemberArgs{ID: id})
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
 	helpers.NewResponse(c, http.StatusOK, "success", nil)
@@ -217,33 +391,48 @@ func (ctr *Controller) DeleteMember(c *gin.Context) {
 
 // @Tags			Gathering
 // @Summary		Create Gathering
-// @Description	Create Gathering
+// @Description	Create Gathering, the authenticated member is the creator.
+// @Description	A gathering overlapping one its attendees attend is refused with 409 listing the conflicts, with force=true it is created and they are returned as warnings.
 // @Accept			json
 // @Produce		json
-// @Param			payload	body		swaggermodel.Gathering									true	"Payload"
-// @Success		200		{object}	helpers.ResponsePayload{data=swaggermodel.Gathering}	"Gathering"
+// @Param			force	query		bool																					false	"Create despite schedule conflicts"
+// @Param			payload	body		swaggermodel.Gathering																	true	"Payload"
+// @Success		201		{object}	helpers.ResponsePayload{data=swaggermodel.Gathering,warnings=[]domain.ScheduleConflict}	"Gathering"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}										"Invalid fields"
+// @Failure		409		{object}	helpers.ResponsePayload{errors=[]domain.ScheduleConflict}								"Schedule conflicts"
+// @Security		BearerAuth
 // @Router			/gatherings [post]
 func (ctr *Controller) CreateGathering(c *gin.Context) {
+	force, err := queryBool(c, "force")
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
 	gathering := domain.Gathering{}
-	if err := c.BindJSON(&gathering); err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+	if err = bindJSON(c, &gathering); err != nil {
+		errorResponse(c, err)
 		return
 	}
-	err := gathering.Validate()
+	// the authenticated member is the creator when the payload has none
+	if gathering.Creator.ID == 0 {
+		member, _ := domain.MemberFromContext(c.Request.Context())
+		gathering.Creator.ID = member.ID
+	}
+	err = gathering.Validate()
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
 	// creator will also treated as attendee
-	creator, err := ctr.MemberUsecase.GetByID(context.Background(), gathering.Creator.ID)
+	creator, err := ctr.MemberUsecase.GetByID(c.Request.Context(), gathering.Creator.ID)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
 	gathering.Attendees = append(gathering.Attendees, creator)
-	gathering, err = ctr.GatheringUsecase.Create(context.Background(), gathering)
+	gathering, conflicts, err := ctr.GatheringUsecase.Create(c.Request.Context(), gathering, force)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
 	memberIDs := []int64{}
@@ -252,29 +441,47 @@ func (ctr *Controller) CreateGathering(c *gin.Context) {
 			memberIDs = append(memberIDs, m.ID)
 		}
 	}
-	members, err := ctr.MemberUsecase.Get(context.Background(), domain.MemberArgs{
+	members, err := ctr.MemberUsecase.Get(c.Request.Context(), domain.MemberArgs{
 		IDs: memberIDs,
 	})
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
+		return
 	}
 	members = append(members, creator)
 	gatheringFactory := factory.Gathering{}
 	gathering = gatheringFactory.Generate([]domain.Gathering{gathering}, members)[0]
-	helpers.NewResponse(c, http.StatusCreated, "success", gathering)
+	helpers.NewResponseWithWarnings(c, http.StatusCreated, "success", gathering, conflicts)
 }
 
 // @Tags			Gathering
 // @Summary		Get Gatherings
-// @Description	Get Gatherings
+// @Description	Get Gatherings, private ones only when the bearer token is of their creator, an attendee or an invitee
 // @Accept			json
 // @Produce		json
-// @Success		200	{array}	helpers.ResponsePayload{data=swaggermodel.Gathering}	"Gathering"
+// @Param			limit			query		int																		false	"Page size, default 20, max 100"
+// @Param			offset			query		int																		false	"Rows to skip, cannot be combined with cursor"
+// @Param			cursor			query		string																	false	"Next cursor from previous page meta"
+// @Param			sort			query		string																	false	"Sort by id, created_at, scheduled_at or name, prefix with - for descending"
+// @Param			creator_id		query		int																		false	"Filter by creator"
+// @Param			member_id		query		int																		false	"Filter by attendee"
+// @Param			type			query		[]int																	false	"Filter by type"	collectionFormat(csv)
+// @Param			name			query		string																	false	"Filter by name"
+// @Param			location		query		string																	false	"Filter by location"
+// @Param			scheduled_from	query		string																	false	"Scheduled at or after, RFC 3339 e.g. 2023-10-06T00:00:00+07:00"
+// @Param			scheduled_to	query		string																	false	"Scheduled at or before, RFC 3339 e.g. 2023-10-06T23:59:59+07:00"
+// @Success		200				{object}	helpers.ResponsePayload{data=[]swaggermodel.Gathering,meta=domain.Page}	"Gathering"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}	"Invalid fields"
 // @Router			/gatherings [get]
 func (ctr *Controller) GetGatherings(c *gin.Context) {
-	gatherings, err := ctr.GatheringUsecase.Get(context.Background(), domain.GatheringArgs{})
+	args, err := bindGatheringArgs(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	gatherings, page, err := ctr.GatheringUsecase.List(c.Request.Context(), args)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
 	memberIDs := []int64{}
@@ -284,49 +491,65 @@ func (ctr *Controller) GetGatherings(c *gin.Context) {
 			memberIDs = append(memberIDs, m.ID)
 		}
 	}
-	members, err := ctr.MemberUsecase.Get(context.Background(), domain.MemberArgs{
+	members, err := ctr.MemberUsecase.Get(c.Request.Context(), domain.MemberArgs{
 		IDs: memberIDs,
 	})
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
 	gatheringFactory := factory.Gathering{}
 	gatherings = gatheringFactory.Generate(gatherings, members)
-	helpers.NewResponse(c, http.StatusOK, "success", gatherings)
+	helpers.NewResponseWithMeta(c, http.StatusOK, "success", gatherings, page)
 }
 
 // @Tags			Gathering
 // @Summary		Get Gathering By ID
-// @Description	Get Gathering By ID
+// @Description	Get Gathering By ID with its confirmed, tentative and total headcount.
+// @Description	A private gathering is not found unless the bearer token is of its creator, an attendee or an invitee.
 // @Accept			json
 // @Produce		json
 // @Param			id	path		int														true	"Gathering ID"
 // @Success		200	{object}	helpers.ResponsePayload{data=swaggermodel.Gathering}	"Gathering"
 // @Router			/gatherings/{id} [get]
 func (ctr *Controller) GetGathering(c *gin.Context) {
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	// the router cannot have both /:id and /:id.ics, the calendar is served from here
+	if strings.HasSuffix(c.Param("id"), ".ics") {
+		ctr.GetGatheringCalendar(c)
+		return
+	}
+	id, err := paramID(c)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	gathering, err := ctr.GatheringUsecase.GetByID(context.Background(), id)
+	gathering, err := ctr.GatheringUsecase.GetByID(c.Request.Context(), id)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
 	memberIDs := []int64{gathering.Creator.ID}
 	for _, m := range gathering.Attendees {
 		memberIDs = append(memberIDs, m.ID)
 	}
-	members, err := ctr.MemberUsecase.Get(context.Background(), domain.MemberArgs{
+	members, err := ctr.MemberUsecase.Get(c.Request.Context(), domain.MemberArgs{
 		IDs:              memberIDs,
 		IsIncludeDiscard: true,
 	})
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
+	invitations, err := ctr.InvitationUsecase.Get(c.Request.Context(), domain.InvitationArgs{
+		GatheringID: gathering.ID,
+		Statuses:    []valueobject.InvitationStatus{valueobject.INVITATION_ACCEPT, valueobject.INVITATION_TENTATIVE},
+	})
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	headcount := gathering.CountHeads(invitations)
+	gathering.Headcount = &headcount
 	gatheringFactory := factory.Gathering{}
 	gatherings := gatheringFactory.Generate([]domain.Gathering{gathering}, members)
 	helpers.NewResponse(c, http.StatusOK, "success", gatherings[0])
@@ -334,43 +557,53 @@ func (ctr *Controller) GetGathering(c *gin.Context) {
 
 // @Tags			Gathering
 // @Summary		Update Gathering
-// @Description	Update Gathering
+// @Description	Update Gathering, only the creator
 // @Accept			json
 // @Produce		json
 // @Param			id		path		int								true	"Gathering ID"
 // @Param			payload	body		swaggermodel.UpdateGathering	true	"Payload"
 // @Success		200		{object}	helpers.ResponsePayload{}		"Gathering"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}	"Invalid fields"
+// @Security		BearerAuth
 // @Router			/gatherings/{id} [put]
 func (ctr *Controller) UpdateGathering(c *gin.Context) {
 	gathering := domain.Gathering{}
-	if err := c.BindJSON(&gathering); err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+	if err := bindJSON(c, &gathering); err != nil {
+		errorResponse(c, err)
 		return
 	}
-	err := gathering.Validate()
+	id, err := paramID(c)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	current, err := ctr.GatheringUsecase.GetByID(c.Request.Context(), id)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	_, err = ctr.GatheringUsecase.GetByID(context.Background(), id)
+	// creator cannot be changed, update payload does not carry it
+	gathering.Creator = current.Creator
+	if gathering.TimeZone == "" {
+		gathering.TimeZone = current.TimeZone
+	}
+	if gathering.Recurrence == "" {
+		gathering.Recurrence = current.Recurrence
+	}
+	err = gathering.Validate()
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
 	gathering.ID = id
-	err = ctr.GatheringUsecase.Update(context.Background(), gathering)
+	err = ctr.GatheringUsecase.Update(c.Request.Context(), gathering)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	gathering, err = ctr.GatheringUsecase.GetByID(context.Background(), id)
+	gathering, err = ctr.GatheringUsecase.GetByID(c.Request.Context(), id)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
 	helpers.NewResponse(c, http.StatusOK, "success", gathering)
@@ -378,26 +611,166 @@ func (ctr *Controller) UpdateGathering(c *gin.Context) {
 
 // @Tags			Gathering
 // @Summary		Delete Gathering
-// @Description	Delete Gathering
+// @Description	Delete Gathering, only the creator
 // @Accept			json
 // @Produce		json
 // @Param			id	path		int							true	"Gathering ID"
 // @Success		200	{object}	helpers.ResponsePayload{}	"Gathering"
+// @Security		BearerAuth
 // @Router			/gatherings/{id} [delete]
 func (ctr *Controller) DeleteGathering(c *gin.Context) {
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	id, err := paramID(c)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	_, err = ctr.GatheringUsecase.GetByID(context.Background(), id)
+	_, err = ctr.GatheringUsecase.GetByID(c.Request.Context(), id)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	err = ctr.GatheringUsecase.Delete(context.Background(), domain.GatheringArgs{ID: id})
+	err = ctr.GatheringUsecase.Delete(c.Request.Context(), domain.GatheringArgs{ID: id})
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusOK, "success", nil)
+}
+
+// @Tags			Gathering
+// @Summary		Join Gathering
+// @Description	Attend an upcoming public gathering without an invitation, the gathering must have a free seat.
+// @Description	Schedule conflicts are refused with 409 unless force=true, then they are returned as warnings.
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int															true	"Gathering ID"
+// @Param			force	query		bool														false	"Join despite schedule conflicts"
+// @Success		200		{object}	helpers.ResponsePayload{warnings=[]domain.ScheduleConflict}	"Gathering"
+// @Failure		409		{object}	helpers.ResponsePayload{errors=[]domain.ScheduleConflict}	"Schedule conflicts"
+// @Security		BearerAuth
+// @Router			/gatherings/{id}/join [post]
+func (ctr *Controller) JoinGathering(c *gin.Context) {
+	id, err := paramID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	force, err := queryBool(c, "force")
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	conflicts, err := ctr.GatheringUsecase.Join(c.Request.Context(), id, force)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponseWithWarnings(c, http.StatusOK, "success", nil, conflicts)
+}
+
+// @Tags			Gathering
+// @Summary		Leave Gathering
+// @Description	Stop attending a gathering, an accepted invitation is rejected and the freed seats go to the waitlist. The creator cannot leave.
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int							true	"Gathering ID"
+// @Success		200	{object}	helpers.ResponsePayload{}	"Gathering"
+// @Security		BearerAuth
+// @Router			/gatherings/{id}/leave [post]
+func (ctr *Controller) LeaveGathering(c *gin.Context) {
+	id, err := paramID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	if err = ctr.GatheringUsecase.Leave(c.Request.Context(), id); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusOK, "success", nil)
+}
+
+// @Tags			Gathering
+// @Summary		Get Occurrences
+// @Description	Get occurrences of a gathering starting within from and to, a single gathering has one. Canceled occurrences are included with canceled set.
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int														true	"Gathering ID"
+// @Param			from	query		string													true	"Start of the range, RFC 3339 e.g. 2023-10-01T00:00:00+07:00"
+// @Param			to		query		string													true	"End of the range, at most 366 days after from"
+// @Success		200		{object}	helpers.ResponsePayload{data=[]swaggermodel.Occurrence}	"Occurrences"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}	"Invalid fields"
+// @Router			/gatherings/{id}/occurrences [get]
+func (ctr *Controller) GetOccurrences(c *gin.Context) {
+	args, err := bindOccurrenceArgs(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	if err = args.ValidateRange(); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	occurrences, err := ctr.GatheringUsecase.Occurrences(c.Request.Context(), args)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusOK, "success", occurrences)
+}
+
+// @Tags			Gathering
+// @Summary		Update Occurrence
+// @Description	Edit one occurrence of a recurring gathering, or with scope=following it and every later one, which then become a new gathering. Only the creator.
+// @Accept			json
+// @Produce		json
+// @Param			id				path		int													true	"Gathering ID"
+// @Param			recurrence_id	path		string												true	"Recurrence ID of the occurrence, RFC 3339"
+// @Param			scope			query		string												false	"this (default) or following"
+// @Param			payload			body		swaggermodel.UpdateOccurrence						true	"Payload"
+// @Success		200				{object}	helpers.ResponsePayload{data=swaggermodel.Occurrence}	"Occurrence"
+// @Failure		400				{object}	helpers.ResponsePayload{errors=[]domain.FieldError}	"Invalid fields"
+// @Security		BearerAuth
+// @Router			/gatherings/{id}/occurrences/{recurrence_id} [put]
+func (ctr *Controller) UpdateOccurrence(c *gin.Context) {
+	edit := domain.Occurrence{}
+	if err := bindJSON(c, &edit); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	args, err := bindOccurrenceArgs(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	occurrence, err := ctr.GatheringUsecase.UpdateOccurrence(c.Request.Context(), args, edit)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusOK, "success", occurrence)
+}
+
+// @Tags			Gathering
+// @Summary		Cancel Occurrence
+// @Description	Cancel one occurrence of a recurring gathering, or with scope=following end the series before it. Only the creator.
+// @Accept			json
+// @Produce		json
+// @Param			id				path		int							true	"Gathering ID"
+// @Param			recurrence_id	path		string						true	"Recurrence ID of the occurrence, RFC 3339"
+// @Param			scope			query		string						false	"this (default) or following"
+// @Success		200				{object}	helpers.ResponsePayload{}	"Occurrence"
+// @Security		BearerAuth
+// @Router			/gatherings/{id}/occurrences/{recurrence_id} [delete]
+func (ctr *Controller) CancelOccurrence(c *gin.Context) {
+	args, err := bindOccurrenceArgs(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	err = ctr.GatheringUsecase.CancelOccurrence(c.Request.Context(), args)
+	if err != nil {
+		errorResponse(c, err)
 		return
 	}
 	helpers.NewResponse(c, http.StatusOK, "success", nil)
@@ -405,39 +778,41 @@ func (ctr *Controller) DeleteGathering(c *gin.Context) {
 
 // @Tags			Invitation
 // @Summary		Create Invitation
-// @Description	Create Invitation
+// @Description	Invite a member to a gathering, only the creator of the gathering
 // @Accept			json
 // @Produce		json
 // @Param			payload	body		swaggermodel.Invitation									true	"Payload"
 // @Success		200		{object}	helpers.ResponsePayload{data=swaggermodel.Invitation}	"Invitation"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}	"Invalid fields"
+// @Security		BearerAuth
 // @Router			/invitations [post]
 func (ctr *Controller) CreateInvitation(c *gin.Context) {
 	invitation := domain.Invitation{}
-	if err := c.BindJSON(&invitation); err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+	if err := bindJSON(c, &invitation); err != nil {
+		errorResponse(c, err)
 		return
 	}
 	err := invitation.Validate()
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	member, err := ctr.MemberUsecase.GetByID(context.Background(), invitation.Member.ID)
+	member, err := ctr.MemberUsecase.GetByID(c.Request.Context(), invitation.Member.ID)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	gathering, err := ctr.GatheringUsecase.GetByID(context.Background(), invitation.Gathering.ID)
+	gathering, err := ctr.GatheringUsecase.GetByID(c.Request.Context(), invitation.Gathering.ID)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
 	invitation.Member = member
 	invitation.Gathering = gathering
 	invitation.Status = valueobject.INVITATION_CREATED
-	invitation, err = ctr.InvitationUsecase.Create(context.Background(), invitation)
+	invitation, err = ctr.InvitationUsecase.Create(c.Request.Context(), invitation)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
 	invitationFactory := factory.Invitation{}
@@ -445,17 +820,98 @@ func (ctr *Controller) CreateInvitation(c *gin.Context) {
 	helpers.NewResponse(c, http.StatusCreated, "success", invitation)
 }
 
+// @Tags			Invitation
+// @Summary		Bulk Create Invitations
+// @Description	Invite members by ID or email to a gathering, only the creator, at most 100 recipients.
+// @Description	Members already invited or attending are skipped, the others are invited in one transaction.
+// @Description	Each recipient has a status: invited, already_invited, already_attending, not_found or duplicate.
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int																true	"Gathering ID"
+// @Param			payload	body		swaggermodel.InvitationBatch									true	"Recipients"
+// @Success		200		{object}	helpers.ResponsePayload{data=[]domain.InvitationBatchResult}	"Recipients"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}				"Invalid fields"
+// @Security		BearerAuth
+// @Router			/gatherings/{id}/invitations:batch [post]
+func (ctr *Controller) BatchInvitations(c *gin.Context) {
+	if c.Param("action") != ":batch" {
+		errorResponse(c, domain.NewError(domain.ErrNotFound, "page not found"))
+		return
+	}
+	id, err := paramID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	batch := domain.InvitationBatch{}
+	if err = bindJSON(c, &batch); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	results, err := ctr.InvitationUsecase.CreateBatch(c.Request.Context(), id, batch)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusOK, "success", results)
+}
+
+// @Tags			Invitation
+// @Summary		Invite Group
+// @Description	Invite every member of a group to a gathering, only the creator who must be a member of the group.
+// @Description	Members are reported like bulk invitations. With auto_invite, members who join the group later are
+// @Description	invited too while the gathering is upcoming.
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int																true	"Gathering ID"
+// @Param			payload	body		swaggermodel.GroupInvitation									true	"Group"
+// @Success		200		{object}	helpers.ResponsePayload{data=[]domain.InvitationBatchResult}	"Recipients"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}				"Invalid fields"
+// @Security		BearerAuth
+// @Router			/gatherings/{id}/invitations [post]
+func (ctr *Controller) InviteGroup(c *gin.Context) {
+	id, err := paramID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	invitation := domain.GroupInvitation{}
+	if err = bindJSON(c, &invitation); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	results, err := ctr.InvitationUsecase.InviteGroup(c.Request.Context(), id, invitation)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusOK, "success", results)
+}
+
 // @Tags			Invitation
 // @Summary		Get Invitations
-// @Description	Get Invitations
+// @Description	Get Invitations, those of a private gathering are listed only to its creator, attendees and invitees
 // @Accept			json
 // @Produce		json
-// @Success		200	{array}	helpers.ResponsePayload{data=swaggermodel.Invitation}	"Invitation"
+// @Param			limit			query		int																			false	"Page size, default 20, max 100"
+// @Param			offset			query		int																			false	"Rows to skip, cannot be combined with cursor"
+// @Param			cursor			query		string																		false	"Next cursor from previous page meta"
+// @Param			sort			query		string																		false	"Sort by id or created_at, prefix with - for descending"
+// @Param			member_id		query		int																			false	"Filter by member"
+// @Param			gathering_id	query		int																			false	"Filter by gathering"
+// @Param			status			query		[]int																		false	"Filter by status"	collectionFormat(csv)
+// @Success		200				{object}	helpers.ResponsePayload{data=[]swaggermodel.Invitation,meta=domain.Page}	"Invitation"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}	"Invalid fields"
 // @Router			/invitations [get]
 func (ctr *Controller) GetInvitations(c *gin.Context) {
-	invitations, err := ctr.InvitationUsecase.Get(context.Background(), domain.InvitationArgs{})
+	args, err := bindInvitationArgs(c)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
+		return
+	}
+	invitations, page, err := ctr.InvitationUsecase.List(c.Request.Context(), args)
+	if err != nil {
+		errorResponse(c, err)
 		return
 	}
 	memberIDs := []int64{}
@@ -464,140 +920,166 @@ func (ctr *Controller) GetInvitations(c *gin.Context) {
 		memberIDs = append(memberIDs, inv.Member.ID)
 		gatheringIDs = append(gatheringIDs, inv.Gathering.ID)
 	}
-	members, err := ctr.MemberUsecase.Get(context.Background(), domain.MemberArgs{
+	members, err := ctr.MemberUsecase.Get(c.Request.Context(), domain.MemberArgs{
 		IDs: memberIDs,
 	})
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	gatherings, err := ctr.GatheringUsecase.Get(context.Background(), domain.GatheringArgs{
+	gatherings, err := ctr.GatheringUsecase.Get(c.Request.Context(), domain.GatheringArgs{
 		IDs: gatheringIDs,
 	})
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
 	invitationFactory := factory.Invitation{}
 	invitations = invitationFactory.Generate(invitations, gatherings, members)
-	helpers.NewResponse(c, http.StatusOK, "success", invitations)
+	helpers.NewResponseWithMeta(c, http.StatusOK, "success", invitations, page)
 }
 
 // @Tags			Invitation
 // @Summary		Get Invitation By ID
-// @Description	Get Invitation By ID
+// @Description	Get Invitation By ID, one of a private gathering is not found unless the bearer token is of its creator, an attendee or an invitee
 // @Accept			json
 // @Produce		json
 // @Param			id	path		int														true	"Invitation ID"
 // @Success		200	{object}	helpers.ResponsePayload{data=swaggermodel.Invitation}	"Invitation"
 // @Router			/invitations/{id} [get]
 func (ctr *Controller) GetInvitation(c *gin.Context) {
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	id, err := paramID(c)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	invitation, err := ctr.InvitationUsecase.GetByID(context.Background(), id)
+	invitation, err := ctr.invitation(c.Request.Context(), id)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	memberIDs := []int64{invitation.Member.ID}
-	gatheringIDs := []int64{invitation.Gathering.ID}
-	members, err := ctr.MemberUsecase.Get(context.Background(), domain.MemberArgs{
-		IDs:              memberIDs,
+	helpers.NewResponse(c, http.StatusOK, "success", invitation)
+}
+
+// invitation returns an invitation with its member and gathering
+func (ctr *Controller) invitation(ctx context.Context, id int64) (invitation domain.Invitation, err error) {
+	invitation, err = ctr.InvitationUsecase.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	members, err := ctr.MemberUsecase.Get(ctx, domain.MemberArgs{
+		IDs:              []int64{invitation.Member.ID},
 		IsIncludeDiscard: true,
 	})
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
 		return
 	}
-	gatherings, err := ctr.GatheringUsecase.Get(context.Background(), domain.GatheringArgs{
-		IDs:              gatheringIDs,
+	gatherings, err := ctr.GatheringUsecase.Get(ctx, domain.GatheringArgs{
+		IDs:              []int64{invitation.Gathering.ID},
 		IsIncludeDiscard: true,
 	})
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
 		return
 	}
 	invitationFactory := factory.Invitation{}
-	invitations := invitationFactory.Generate([]domain.Invitation{invitation}, gatherings, members)
-	helpers.NewResponse(c, http.StatusOK, "success", invitations[0])
+	invitation = invitationFactory.Generate([]domain.Invitation{invitation}, gatherings, members)[0]
+	return
 }
 
 // @Tags			Invitation
 // @Summary		Accept Invitation
-// @Description	Accept Invitation
+// @Description	Accept Invitation, only the invited member, a rejected or canceled invitation cannot be accepted.
+// @Description	The invitation is waitlisted when the gathering has no seats for the member and the guests, it is accepted once they are free.
+// @Description	A gathering overlapping one the member attends is refused with 409 listing the conflicts, with force=true it is accepted and they are returned as warnings.
 // @Accept			json
 // @Produce		json
-// @Param			id	path		int							true	"Invitation ID"
-// @Success		200	{object}	helpers.ResponsePayload{}	"Invitation"
+// @Param			id		path		int																						true	"Invitation ID"
+// @Param			force	query		bool																					false	"Accept despite schedule conflicts"
+// @Param			payload	body		swaggermodel.InvitationResponse															false	"Guests and note"
+// @Success		200		{object}	helpers.ResponsePayload{data=swaggermodel.Invitation,warnings=[]domain.ScheduleConflict}	"Invitation, accepted or waitlisted"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}										"Invalid fields"
+// @Failure		409		{object}	helpers.ResponsePayload{errors=[]domain.ScheduleConflict}								"Schedule conflicts"
+// @Security		BearerAuth
 // @Router			/invitations/{id}/accept [put]
 func (ctr *Controller) AcceptInvitation(c *gin.Context) {
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	force, err := queryBool(c, "force")
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	invitation, err := ctr.InvitationUsecase.GetByID(context.Background(), id)
+	ctr.respondInvitation(c, func(ctx context.Context, args domain.InvitationArgs) ([]domain.ScheduleConflict, error) {
+		args.IsIgnoreConflicts = force
+		return ctr.InvitationUsecase.Accept(ctx, args)
+	})
+}
+
+// @Tags			Invitation
+// @Summary		Tentatively Accept Invitation
+// @Description	Answer maybe, only the invited member. The member does not attend, the member and the guests count toward tentative headcount.
+// @Description	An accepted invitation answered maybe frees its seats for the waitlist.
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int														true	"Invitation ID"
+// @Param			payload	body		swaggermodel.InvitationResponse							false	"Guests and note"
+// @Success		200		{object}	helpers.ResponsePayload{data=swaggermodel.Invitation}	"Invitation"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}		"Invalid fields"
+// @Security		BearerAuth
+// @Router			/invitations/{id}/tentative [put]
+func (ctr *Controller) TentativeInvitation(c *gin.Context) {
+	ctr.respondInvitation(c, func(ctx context.Context, args domain.InvitationArgs) ([]domain.ScheduleConflict, error) {
+		return nil, ctr.InvitationUsecase.Tentative(ctx, args)
+	})
+}
+
+// respondInvitation stores the answer of the invited member and responds with the invitation and the schedule conflicts let through
+func (ctr *Controller) respondInvitation(c *gin.Context, respond func(ctx context.Context, args domain.InvitationArgs) ([]domain.ScheduleConflict, error)) {
+	id, err := paramID(c)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	if invitation.Status == valueobject.INVITATION_ACCEPT {
-		err = errors.New("the member has accepted the invitation")
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+	response, err := bindInvitationResponse(c)
+	if err != nil {
+		errorResponse(c, err)
 		return
 	}
-	err = ctr.InvitationUsecase.Accept(context.Background(), domain.InvitationArgs{
-		ID:          id,
-		MemberID:    invitation.MemberID,
-		GatheringID: invitation.GatheringID,
-		Status:      valueobject.INVITATION_ACCEPT,
-	})
+	conflicts, err := respond(c.Request.Context(), domain.InvitationArgs{ID: id, Response: response})
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	helpers.NewResponse(c, http.StatusOK, "success", nil)
+	invitation, err := ctr.invitation(c.Request.Context(), id)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponseWithWarnings(c, http.StatusOK, "success", invitation, conflicts)
 }
 
 // @Tags			Invitation
 // @Summary		Reject Invitation
-// @Description	Reject Invitation, used by member to reject the invitation
+// @Description	Reject Invitation, only the invited member, an accepted invitation can still be rejected and its seat goes to the first waitlisted member
 // @Accept			json
 // @Produce		json
-// @Param			id	path		int							true	"Invitation ID"
-// @Success		200	{object}	helpers.ResponsePayload{}	"Invitation"
+// @Param			id		path		int							true	"Invitation ID"
+// @Param			payload	body		swaggermodel.RejectInvitation	false	"Note"
+// @Success		200		{object}	helpers.ResponsePayload{}	"Invitation"
+// @Security		BearerAuth
 // @Router			/invitations/{id}/reject [put]
 func (ctr *Controller) RejectInvitation(c *gin.Context) {
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	id, err := paramID(c)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	invitation, err := ctr.InvitationUsecase.GetByID(context.Background(), id)
+	response, err := bindInvitationResponse(c)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	if invitation.Status == valueobject.INVITATION_CANCELED {
-		err = errors.New("the invitation for this member has canceled")
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
-		return
-	} else if invitation.Status == valueobject.INVITATION_REJECT {
-		err = errors.New("the member has rejected the invitation")
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
-		return
-	}
-	err = ctr.InvitationUsecase.Reject(context.Background(), domain.InvitationArgs{
-		ID:          id,
-		MemberID:    invitation.MemberID,
-		GatheringID: invitation.GatheringID,
-		Status:      valueobject.INVITATION_REJECT,
-	})
+	err = ctr.InvitationUsecase.Reject(c.Request.Context(), domain.InvitationArgs{ID: id, Response: response})
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
 	helpers.NewResponse(c, http.StatusOK, "success", nil)
@@ -605,41 +1087,64 @@ func (ctr *Controller) RejectInvitation(c *gin.Context) {
 
 // @Tags			Invitation
 // @Summary		Cancel Invitation
-// @Description	Cancel Invitation, will remove member from attendee list
+// @Description	Cancel Invitation, will remove member from attendee list and promote the first waitlisted member, only the gathering creator, a rejected invitation cannot be canceled
 // @Accept			json
 // @Produce		json
 // @Param			id	path		int							true	"Invitation ID"
 // @Success		200	{object}	helpers.ResponsePayload{}	"Invitation"
+// @Security		BearerAuth
 // @Router			/invitations/{id}/cancel [put]
 func (ctr *Controller) CancelInvitation(c *gin.Context) {
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	id, err := paramID(c)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	invitation, err := ctr.InvitationUsecase.GetByID(context.Background(), id)
+	err = ctr.InvitationUsecase.Cancel(c.Request.Context(), domain.InvitationArgs{ID: id})
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	if invitation.Status == valueobject.INVITATION_CANCELED {
-		err = errors.New("the invitation for this member has canceled")
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+	helpers.NewResponse(c, http.StatusOK, "success", nil)
+}
+
+// @Tags			Auth
+// @Summary		Login
+// @Description	Login with member email and password, send the access token as "Authorization: Bearer <token>" header
+// @Accept			json
+// @Produce		json
+// @Param			payload	body		swaggermodel.Login									true	"Payload"
+// @Success		200		{object}	helpers.ResponsePayload{data=swaggermodel.Token}	"Token"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}	"Invalid fields"
+// @Router			/auth/login [post]
+func (ctr *Controller) Login(c *gin.Context) {
+	login := domain.Login{}
+	if err := bindJSON(c, &login); err != nil {
+		errorResponse(c, err)
 		return
-	} else if invitation.Status == valueobject.INVITATION_REJECT {
-		err = errors.New("the member has rejected the invitation")
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+	}
+	err := login.Validate()
+	if err != nil {
+		errorResponse(c, err)
 		return
 	}
-	err = ctr.InvitationUsecase.Cancel(context.Background(), domain.InvitationArgs{
-		ID:          id,
-		MemberID:    invitation.MemberID,
-		GatheringID: invitation.GatheringID,
-		Status:      valueobject.INVITATION_CANCELED,
-	})
+	token, err := ctr.AuthUsecase.Login(c.Request.Context(), login)
 	if err != nil {
-		helpers.NewResponse(c, http.StatusBadRequest, err.Error(), nil)
+		errorResponse(c, err)
 		return
 	}
-	helpers.NewResponse(c, http.StatusOK, "success", nil)
+	helpers.NewResponse(c, http.StatusOK, "success", token)
+}
+
+// @Tags			Auth
+// @Summary		Current Member
+// @Description	Get the authenticated member
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	helpers.ResponsePayload{data=swaggermodel.Member}	"Member"
+// @Security		BearerAuth
+// @Router			/auth/me [get]
+func (ctr *Controller) Me(c *gin.Context) {
+	member, _ := domain.MemberFromContext(c.Request.Context())
+	helpers.NewResponse(c, http.StatusOK, "success", member)
 }
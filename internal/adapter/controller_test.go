@@ -1,15 +1,23 @@
 package adapter_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/hieronimusbudi/simple-go-api/internal/adapter"
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/memory"
+	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
 	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
 	"github.com/hieronimusbudi/simple-go-api/internal/mocks"
 	"github.com/stretchr/testify/mock"
@@ -46,6 +54,8 @@ func TestController_CreateMember(t *testing.T) {
 		args         args
 		funcCreate   helpers.TestFuncCall
 		expectedCode int
+		// expectedErrors are the invalid fields listed in the response body
+		expectedErrors []domain.FieldError
 	}{
 		{
 			name: "success",
@@ -65,6 +75,30 @@ func TestController_CreateMember(t *testing.T) {
 				reqPayload: strings.NewReader(string(jsonMember2)),
 			},
 			expectedCode: http.StatusBadRequest,
+			expectedErrors: []domain.FieldError{
+				{Field: "email", Code: domain.CodeRequired, Message: "email is required"},
+			},
+		},
+		{
+			name: "every invalid field",
+			args: args{
+				reqPayload: strings.NewReader(`{"email":"john"}`),
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErrors: []domain.FieldError{
+				{Field: "email", Code: domain.CodeInvalid, Message: "invalid email format"},
+				{Field: "first_name", Code: domain.CodeRequired, Message: "first name is required"},
+			},
+		},
+		{
+			name: "invalid field type",
+			args: args{
+				reqPayload: strings.NewReader(`{"email":"john@mail.com","first_name":1}`),
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErrors: []domain.FieldError{
+				{Field: "first_name", Code: domain.CodeInvalidType, Message: "first_name must be a string"},
+			},
 		},
 		{
 			name: "create fail",
@@ -76,7 +110,19 @@ func TestController_CreateMember(t *testing.T) {
 				Input:  []interface{}{mock.Anything, mock.Anything},
 				Output: []interface{}{domain.Member{}, errors.New("create error")},
 			},
-			expectedCode: http.StatusBadRequest,
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name: "email already used",
+			args: args{
+				reqPayload: strings.NewReader(string(jsonMember)),
+			},
+			funcCreate: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{domain.Member{}, domain.NewError(domain.ErrConflict, "email is already used")},
+			},
+			expectedCode: http.StatusConflict,
 		},
 	}
 
@@ -93,11 +139,22 @@ func TestController_CreateMember(t *testing.T) {
 			c, w := helpers.CreateGinContext(http.MethodPost, "/members", tt.args.reqPayload)
 			ctr.CreateMember(c)
 			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+			if tt.expectedErrors != nil {
+				var body struct {
+					Errors []domain.FieldError `json:"errors"`
+				}
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+				require.Equal(t, tt.expectedErrors, body.Errors)
+			}
 		})
 	}
 }
 
 func TestController_GetMembers(t *testing.T) {
+	type args struct {
+		target string
+	}
+
 	// success
 	members := []domain.Member{{
 		ID:        0,
@@ -105,44 +162,85 @@ func TestController_GetMembers(t *testing.T) {
 		LastName:  "doe",
 		Email:     "john@mail.com",
 	}}
+	page := domain.Page{Total: 1, Limit: domain.DefaultLimit}
 
 	tests := []struct {
 		name         string
-		funcGet      helpers.TestFuncCall
+		args         args
+		funcList     helpers.TestFuncCall
 		expectedCode int
 	}{
 		{
 			name: "success",
-			funcGet: helpers.TestFuncCall{
+			args: args{
+				target: "/members",
+			},
+			funcList: helpers.TestFuncCall{
 				Called: true,
 				Input:  []interface{}{mock.Anything, mock.Anything},
-				Output: []interface{}{members, nil},
+				Output: []interface{}{members, page, nil},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "success with pagination and filter",
+			args: args{
+				target: "/members?limit=10&sort=-created_at&name=john",
+			},
+			funcList: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, domain.MemberArgs{
+					Name: "john",
+					Pagination: domain.Pagination{
+						Limit: 10,
+						Sort:  "-created_at",
+					},
+				}},
+				Output: []interface{}{members, page, nil},
 			},
 			expectedCode: http.StatusOK,
 		},
+		{
+			name: "invalid sort",
+			args: args{
+				target: "/members?sort=email",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid limit",
+			args: args{
+				target: "/members?limit=1000",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
 		{
 			name: "get fail",
-			funcGet: helpers.TestFuncCall{
+			args: args{
+				target: "/members",
+			},
+			funcList: helpers.TestFuncCall{
 				Called: true,
 				Input:  []interface{}{mock.Anything, mock.Anything},
-				Output: []interface{}{[]domain.Member{}, errors.New("get error")},
+				Output: []interface{}{[]domain.Member{}, domain.Page{}, errors.New("get error")},
 			},
-			expectedCode: http.StatusBadRequest,
+			expectedCode: http.StatusInternalServerError,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockMemberUsecase := new(mocks.IMemberUsecase)
-			if tt.funcGet.Called {
-				mockMemberUsecase.On("Get", tt.funcGet.Input...).
-					Return(tt.funcGet.Output...)
+			if tt.funcList.Called {
+				mockMemberUsecase.On("List", tt.funcList.Input...).
+					Return(tt.funcList.Output...)
 			}
 			ctr := &adapter.Controller{
 				MemberUsecase: mockMemberUsecase,
 			}
-			c, w := helpers.CreateGinContext(http.MethodGet, "/members", nil)
+			c, w := helpers.CreateGinContext(http.MethodGet, tt.args.target, nil)
 			ctr.GetMembers(c)
 			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+			mockMemberUsecase.AssertExpectations(t)
 		})
 	}
 }
@@ -227,7 +325,20 @@ func TestController_UpdateMember(t *testing.T) {
 				Input:  []interface{}{mock.Anything, mock.Anything},
 				Output: []interface{}{errors.New("update error")},
 			},
-			expectedCode: http.StatusBadRequest,
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name: "member not found",
+			args: args{
+				id:         "1",
+				reqPayload: strings.NewReader(string(jsonMember)),
+			},
+			funcGetByID1: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{domain.Member{}, domain.NewError(domain.ErrNotFound, "cannot find member")},
+			},
+			expectedCode: http.StatusNotFound,
 		},
 	}
 	for _, tt := range tests {
@@ -305,7 +416,23 @@ func TestController_DeleteMember(t *testing.T) {
 				Input:  []interface{}{mock.Anything, mock.Anything},
 				Output: []interface{}{errors.New("delete error")},
 			},
-			expectedCode: http.StatusBadRequest,
+			expectedCode: http.StatusInternalServerError,
+		}, {
+			name: "forbidden",
+			args: args{
+				id: "1",
+			},
+			funcGetByID1: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{member, nil},
+			},
+			funcDelete: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{fmt.Errorf("%w: only the member or an admin can change this member", domain.ErrForbidden)},
+			},
+			expectedCode: http.StatusForbidden,
 		},
 	}
 	for _, tt := range tests {
@@ -329,3 +456,1026 @@ func TestController_DeleteMember(t *testing.T) {
 		})
 	}
 }
+
+func TestController_CreateMember_withPassword(t *testing.T) {
+	member := domain.Member{ID: 1, FirstName: "john", Email: "john@mail.com"}
+	tests := []struct {
+		name            string
+		reqPayload      string
+		funcCreate      helpers.TestFuncCall
+		funcSetPassword helpers.TestFuncCall
+		expectedCode    int
+	}{
+		{
+			name:       "success",
+			reqPayload: `{"first_name":"john","email":"john@mail.com","password":"secret-password"}`,
+			funcCreate: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{member, nil},
+			},
+			funcSetPassword: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(1), "secret-password"},
+				Output: []interface{}{nil},
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "password too short",
+			reqPayload:   `{"first_name":"john","email":"john@mail.com","password":"secret"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMemberUsecase := new(mocks.IMemberUsecase)
+			mockAuthUsecase := new(mocks.IAuthUsecase)
+			if tt.funcCreate.Called {
+				mockMemberUsecase.On("Create", tt.funcCreate.Input...).Return(tt.funcCreate.Output...)
+			}
+			if tt.funcSetPassword.Called {
+				mockAuthUsecase.On("SetPassword", tt.funcSetPassword.Input...).Return(tt.funcSetPassword.Output...)
+			}
+			ctr := &adapter.Controller{
+				MemberUsecase: mockMemberUsecase,
+				AuthUsecase:   mockAuthUsecase,
+			}
+			c, w := helpers.CreateGinContext(http.MethodPost, "/members", strings.NewReader(tt.reqPayload))
+			ctr.CreateMember(c)
+			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+			require.NotContains(t, w.Body.String(), "secret-password")
+			mockAuthUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestController_Login(t *testing.T) {
+	token := domain.Token{AccessToken: "token", TokenType: "Bearer"}
+	tests := []struct {
+		name         string
+		reqPayload   string
+		funcLogin    helpers.TestFuncCall
+		expectedCode int
+	}{
+		{
+			name:       "success",
+			reqPayload: `{"email":"john@mail.com","password":"secret-password"}`,
+			funcLogin: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.Login{Email: "john@mail.com", Password: "secret-password"}},
+				Output: []interface{}{token, nil},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "validation fail",
+			reqPayload:   `{"email":"john@mail.com"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid credentials",
+			reqPayload: `{"email":"john@mail.com","password":"wrong-password"}`,
+			funcLogin: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{domain.Token{}, domain.ErrInvalidCredentials},
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthUsecase := new(mocks.IAuthUsecase)
+			if tt.funcLogin.Called {
+				mockAuthUsecase.On("Login", tt.funcLogin.Input...).Return(tt.funcLogin.Output...)
+			}
+			ctr := &adapter.Controller{
+				AuthUsecase: mockAuthUsecase,
+			}
+			c, w := helpers.CreateGinContext(http.MethodPost, "/auth/login", strings.NewReader(tt.reqPayload))
+			ctr.Login(c)
+			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+		})
+	}
+}
+
+func TestController_Authenticate(t *testing.T) {
+	member := domain.Member{ID: 1, FirstName: "john", Email: "john@mail.com"}
+	tests := []struct {
+		name             string
+		authorization    string
+		funcAuthenticate helpers.TestFuncCall
+		expectedCode     int
+	}{
+		{
+			name:          "success",
+			authorization: "Bearer token",
+			funcAuthenticate: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, "token"},
+				Output: []interface{}{member, nil},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "missing header",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "not bearer",
+			authorization: "Basic am9objpzZWNyZXQ=",
+			expectedCode:  http.StatusUnauthorized,
+		},
+		{
+			name:          "invalid token",
+			authorization: "Bearer expired",
+			funcAuthenticate: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, "expired"},
+				Output: []interface{}{domain.Member{}, domain.ErrInvalidToken},
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthUsecase := new(mocks.IAuthUsecase)
+			if tt.funcAuthenticate.Called {
+				mockAuthUsecase.On("Authenticate", tt.funcAuthenticate.Input...).Return(tt.funcAuthenticate.Output...)
+			}
+			ctr := &adapter.Controller{
+				AuthUsecase: mockAuthUsecase,
+			}
+			r := gin.New()
+			r.GET("/auth/me", ctr.Authenticate, ctr.Me)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/auth/me", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			r.ServeHTTP(w, req)
+			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+			if tt.expectedCode == http.StatusOK {
+				require.Contains(t, w.Body.String(), member.Email)
+			}
+		})
+	}
+}
+
+func TestController_Identify(t *testing.T) {
+	member := domain.Member{ID: 1, FirstName: "john", Email: "john@mail.com"}
+	tests := []struct {
+		name             string
+		authorization    string
+		funcAuthenticate helpers.TestFuncCall
+		expectedCode     int
+		expectedViewer   string
+	}{
+		{
+			name:          "member",
+			authorization: "Bearer token",
+			funcAuthenticate: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, "token"},
+				Output: []interface{}{member, nil},
+			},
+			expectedCode:   http.StatusOK,
+			expectedViewer: member.Email,
+		},
+		{
+			name:           "anonymous",
+			expectedCode:   http.StatusOK,
+			expectedViewer: "anonymous",
+		},
+		{
+			name:          "invalid token",
+			authorization: "Bearer expired",
+			funcAuthenticate: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, "expired"},
+				Output: []interface{}{domain.Member{}, domain.ErrInvalidToken},
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthUsecase := new(mocks.IAuthUsecase)
+			if tt.funcAuthenticate.Called {
+				mockAuthUsecase.On("Authenticate", tt.funcAuthenticate.Input...).Return(tt.funcAuthenticate.Output...)
+			}
+			ctr := &adapter.Controller{
+				AuthUsecase: mockAuthUsecase,
+			}
+			r := gin.New()
+			r.GET("/viewer", ctr.Identify, func(c *gin.Context) {
+				viewer := "anonymous"
+				if m, ok := domain.MemberFromContext(c.Request.Context()); ok {
+					viewer = m.Email
+				}
+				c.String(http.StatusOK, viewer)
+			})
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/viewer", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			r.ServeHTTP(w, req)
+			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+			if tt.expectedCode == http.StatusOK {
+				require.Equal(t, tt.expectedViewer, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestController_GetOccurrences(t *testing.T) {
+	type args struct {
+		target string
+	}
+	from := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	occurrences := []domain.Occurrence{{GatheringID: 1, RecurrenceID: from, ScheduledAt: from, Name: "standup", Location: "room 1"}}
+
+	tests := []struct {
+		name            string
+		args            args
+		funcOccurrences helpers.TestFuncCall
+		expectedCode    int
+	}{
+		{
+			name: "success",
+			args: args{
+				target: "/gatherings/1/occurrences?from=2023-10-01T00:00:00Z&to=2023-10-31T00:00:00Z",
+			},
+			funcOccurrences: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, domain.OccurrenceArgs{
+					GatheringID: 1,
+					Scope:       domain.OccurrenceScopeThis,
+					From:        from,
+					To:          from.AddDate(0, 0, 30),
+				}},
+				Output: []interface{}{occurrences, nil},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "missing range",
+			args: args{
+				target: "/gatherings/1/occurrences?from=2023-10-01T00:00:00Z",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "range too long",
+			args: args{
+				target: "/gatherings/1/occurrences?from=2023-10-01T00:00:00Z&to=2025-10-01T00:00:00Z",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "gathering not found",
+			args: args{
+				target: "/gatherings/1/occurrences?from=2023-10-01T00:00:00Z&to=2023-10-31T00:00:00Z",
+			},
+			funcOccurrences: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Occurrence{}, domain.NewError(domain.ErrNotFound, "cannot find gathering")},
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGatheringUsecase := new(mocks.IGatheringUsecase)
+			if tt.funcOccurrences.Called {
+				mockGatheringUsecase.On("Occurrences", tt.funcOccurrences.Input...).
+					Return(tt.funcOccurrences.Output...)
+			}
+			ctr := &adapter.Controller{
+				GatheringUsecase: mockGatheringUsecase,
+			}
+			c, w := helpers.CreateGinContext(http.MethodGet, tt.args.target, nil)
+			c.Params = gin.Params{{Key: "id", Value: "1"}}
+			ctr.GetOccurrences(c)
+			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+			mockGatheringUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestController_GetGatheringCalendar(t *testing.T) {
+	scheduledAt := time.Date(2023, 10, 6, 12, 0, 0, 0, time.UTC)
+	gathering := domain.Gathering{ID: 1, Creator: domain.Member{ID: 1}, ScheduledAt: scheduledAt, Name: "dinner", Location: "home"}
+	members := []domain.Member{{ID: 1, FirstName: "linus", Email: "linus@mail.com"}}
+
+	tests := []struct {
+		name         string
+		id           string
+		funcGetByID  helpers.TestFuncCall
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "success",
+			id:   "1.ics",
+			funcGetByID: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(1)},
+				Output: []interface{}{gathering, nil},
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "DTSTART:20231006T120000Z",
+		},
+		{
+			name:         "invalid id",
+			id:           "one.ics",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			id:   "1.ics",
+			funcGetByID: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(1)},
+				Output: []interface{}{domain.Gathering{}, domain.NewError(domain.ErrNotFound, "cannot find gathering")},
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGatheringUsecase := new(mocks.IGatheringUsecase)
+			mockInvitationUsecase := new(mocks.IInvitationUsecase)
+			mockMemberUsecase := new(mocks.IMemberUsecase)
+			if tt.funcGetByID.Called {
+				mockGatheringUsecase.On("GetByID", tt.funcGetByID.Input...).Return(tt.funcGetByID.Output...)
+			}
+			if tt.expectedCode == http.StatusOK {
+				mockInvitationUsecase.On("Get", mock.Anything, domain.InvitationArgs{GatheringIDs: []int64{1}}).Return([]domain.Invitation{}, nil)
+				mockMemberUsecase.On("Get", mock.Anything, mock.Anything).Return(members, nil)
+			}
+			ctr := &adapter.Controller{
+				GatheringUsecase:  mockGatheringUsecase,
+				InvitationUsecase: mockInvitationUsecase,
+				MemberUsecase:     mockMemberUsecase,
+			}
+			c, w := helpers.CreateGinContext(http.MethodGet, "/gatherings/"+tt.id, nil)
+			c.Params = gin.Params{{Key: "id", Value: tt.id}}
+			ctr.GetGathering(c)
+			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+			if tt.expectedBody != "" {
+				require.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+				require.Contains(t, w.Body.String(), tt.expectedBody)
+				require.Contains(t, w.Body.String(), "ORGANIZER;CN=\"linus\":mailto:linus@mail.com")
+			}
+			mockGatheringUsecase.AssertExpectations(t)
+			mockInvitationUsecase.AssertExpectations(t)
+			mockMemberUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestController_ImportGatheringCalendar(t *testing.T) {
+	calendar := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:standup@example.com\r\nDTSTART:20231002T020000Z\r\n" +
+		"SUMMARY:standup\r\nLOCATION:room 1\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	results := []domain.CalendarImportResult{{UID: "standup@example.com", GatheringID: 1, Status: domain.CalendarImportCreated, Invited: []string{}, UnknownAttendees: []string{}}}
+
+	tests := []struct {
+		name         string
+		contentType  string
+		body         func() io.Reader
+		funcImport   helpers.TestFuncCall
+		expectedCode int
+	}{
+		{
+			name:        "success",
+			contentType: "text/calendar",
+			body:        func() io.Reader { return strings.NewReader(calendar) },
+			funcImport: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.MatchedBy(func(events []domain.CalendarEvent) bool { return len(events) == 1 })},
+				Output: []interface{}{results, nil},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "not a calendar",
+			contentType:  "text/calendar",
+			body:         func() io.Reader { return strings.NewReader("name,location\n") },
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "form without file",
+			contentType:  "multipart/form-data; boundary=x",
+			body:         func() io.Reader { return strings.NewReader("--x--\r\n") },
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "usecase error",
+			contentType: "text/calendar",
+			body:        func() io.Reader { return strings.NewReader(calendar) },
+			funcImport: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.CalendarImportResult{}, errors.New("error")},
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCalendarUsecase := new(mocks.ICalendarUsecase)
+			if tt.funcImport.Called {
+				mockCalendarUsecase.On("Import", tt.funcImport.Input...).Return(tt.funcImport.Output...)
+			}
+			ctr := &adapter.Controller{
+				CalendarUsecase: mockCalendarUsecase,
+			}
+			c, w := helpers.CreateGinContext(http.MethodPost, "/gatherings/import", tt.body())
+			c.Request.Header.Set("Content-Type", tt.contentType)
+			ctr.ImportGatheringCalendar(c)
+			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+			mockCalendarUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestController_GetGathering(t *testing.T) {
+	gathering := domain.Gathering{ID: 1, Creator: domain.Member{ID: 1}, Name: "dinner", Location: "home", Attendees: []domain.Member{{ID: 1}, {ID: 2}}}
+	mockGatheringUsecase := new(mocks.IGatheringUsecase)
+	mockGatheringUsecase.On("GetByID", mock.Anything, int64(1)).Return(gathering, nil)
+	mockMemberUsecase := new(mocks.IMemberUsecase)
+	mockMemberUsecase.On("Get", mock.Anything, mock.Anything).Return([]domain.Member{{ID: 1}, {ID: 2}}, nil)
+	mockInvitationUsecase := new(mocks.IInvitationUsecase)
+	mockInvitationUsecase.On("Get", mock.Anything, domain.InvitationArgs{
+		GatheringID: 1,
+		Statuses:    []valueobject.InvitationStatus{valueobject.INVITATION_ACCEPT, valueobject.INVITATION_TENTATIVE},
+	}).Return([]domain.Invitation{
+		{GatheringID: 1, MemberID: 2, Status: valueobject.INVITATION_ACCEPT, Guests: 1},
+		{GatheringID: 1, MemberID: 3, Status: valueobject.INVITATION_TENTATIVE, Guests: 2},
+	}, nil)
+	ctr := &adapter.Controller{
+		GatheringUsecase:  mockGatheringUsecase,
+		MemberUsecase:     mockMemberUsecase,
+		InvitationUsecase: mockInvitationUsecase,
+	}
+	c, w := helpers.CreateGinContext(http.MethodGet, "/gatherings/1", nil)
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+	ctr.GetGathering(c)
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	var body struct {
+		Data domain.Gathering `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	require.Equal(t, &domain.Headcount{Confirmed: 3, Tentative: 3, Total: 6}, body.Data.Headcount)
+}
+
+func TestController_JoinGathering(t *testing.T) {
+	scheduledAt := time.Date(2023, 10, 6, 12, 0, 0, 0, time.UTC)
+	conflicts := []domain.ScheduleConflict{{
+		MemberID:      2,
+		Occurrence:    domain.Occurrence{GatheringID: 1, ScheduledAt: scheduledAt},
+		ConflictsWith: domain.Occurrence{GatheringID: 2, ScheduledAt: scheduledAt},
+	}}
+	tests := []struct {
+		name         string
+		target       string
+		funcJoin     helpers.TestFuncCall
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:   "success",
+			target: "/gatherings/1/join",
+			funcJoin: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(1), false},
+				Output: []interface{}{[]domain.ScheduleConflict(nil), nil},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "schedule conflict",
+			target: "/gatherings/1/join",
+			funcJoin: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(1), false},
+				Output: []interface{}{[]domain.ScheduleConflict(nil), &domain.ScheduleConflictError{Conflicts: conflicts}},
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: `"errors":[{"member_id":2`,
+		},
+		{
+			name:   "schedule conflict with force",
+			target: "/gatherings/1/join?force=true",
+			funcJoin: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(1), true},
+				Output: []interface{}{conflicts, nil},
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"warnings":[{"member_id":2`,
+		},
+		{
+			name:         "invalid force",
+			target:       "/gatherings/1/join?force=maybe",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGatheringUsecase := new(mocks.IGatheringUsecase)
+			if tt.funcJoin.Called {
+				mockGatheringUsecase.On("Join", tt.funcJoin.Input...).Return(tt.funcJoin.Output...)
+			}
+			ctr := &adapter.Controller{
+				GatheringUsecase: mockGatheringUsecase,
+			}
+			c, w := helpers.CreateGinContext(http.MethodPost, tt.target, nil)
+			c.Params = gin.Params{{Key: "id", Value: "1"}}
+			ctr.JoinGathering(c)
+			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+			require.Contains(t, w.Body.String(), tt.expectedBody)
+			if tt.expectedCode == http.StatusOK && tt.expectedBody == "" {
+				require.NotContains(t, w.Body.String(), "warnings")
+			}
+			mockGatheringUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestController_TentativeInvitation(t *testing.T) {
+	invitation := domain.Invitation{ID: 1, MemberID: 2, GatheringID: 1, Status: valueobject.INVITATION_TENTATIVE, Guests: 1, Note: "if I am back"}
+	tests := []struct {
+		name          string
+		body          string
+		funcTentative helpers.TestFuncCall
+		expectedCode  int
+	}{
+		{
+			name: "success",
+			body: `{"guests":1,"note":"if I am back"}`,
+			funcTentative: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{ID: 1, Response: domain.InvitationResponse{Guests: 1, Note: "if I am back"}}},
+				Output: []interface{}{nil},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "without body",
+			funcTentative: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{ID: 1}},
+				Output: []interface{}{nil},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "guests of the wrong type",
+			body:         `{"guests":"one"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "invitation is closed",
+			funcTentative: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{&domain.TransitionError{From: valueobject.INVITATION_REJECT, To: valueobject.INVITATION_TENTATIVE}},
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInvitationUsecase := new(mocks.IInvitationUsecase)
+			if tt.funcTentative.Called {
+				mockInvitationUsecase.On("Tentative", tt.funcTentative.Input...).Return(tt.funcTentative.Output...)
+			}
+			mockInvitationUsecase.On("GetByID", mock.Anything, int64(1)).Return(invitation, nil).Maybe()
+			mockMemberUsecase := new(mocks.IMemberUsecase)
+			mockMemberUsecase.On("Get", mock.Anything, mock.Anything).Return([]domain.Member{{ID: 2}}, nil).Maybe()
+			mockGatheringUsecase := new(mocks.IGatheringUsecase)
+			mockGatheringUsecase.On("Get", mock.Anything, mock.Anything).Return([]domain.Gathering{{ID: 1}}, nil).Maybe()
+			ctr := &adapter.Controller{
+				InvitationUsecase: mockInvitationUsecase,
+				MemberUsecase:     mockMemberUsecase,
+				GatheringUsecase:  mockGatheringUsecase,
+			}
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			c, w := helpers.CreateGinContext(http.MethodPut, "/invitations/1/tentative", body)
+			c.Params = gin.Params{{Key: "id", Value: "1"}}
+			ctr.TentativeInvitation(c)
+			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+			if tt.expectedCode == http.StatusOK {
+				require.Contains(t, w.Body.String(), `"note":"if I am back"`)
+			}
+			mockInvitationUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestController_CreateInvitation(t *testing.T) {
+	gathering := domain.Gathering{ID: 1, CreatorID: 1, Creator: domain.Member{ID: 1}, Type: valueobject.PRIVATE}
+	invitation := domain.Invitation{ID: 5, MemberID: 2, GatheringID: 1, Member: domain.Member{ID: 2}, Gathering: gathering, Status: valueobject.INVITATION_CREATED}
+	tests := []struct {
+		name         string
+		actor        domain.Member
+		funcCreate   helpers.TestFuncCall
+		expectedCode int
+	}{
+		{
+			name:  "success",
+			actor: domain.Member{ID: 1},
+			funcCreate: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything, mock.Anything},
+				Output: []interface{}{invitation.ID, nil},
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "not the creator",
+			actor:        domain.Member{ID: 3},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMemberUsecase := new(mocks.IMemberUsecase)
+			mockMemberUsecase.On("GetByID", mock.Anything, int64(2)).Return(domain.Member{ID: 2}, nil)
+			mockGatheringUsecase := new(mocks.IGatheringUsecase)
+			mockGatheringUsecase.On("GetByID", mock.Anything, int64(1)).Return(gathering, nil)
+			mockGathering := new(mocks.IGathering)
+			mockGathering.On("Get", mock.Anything, domain.GatheringArgs{IDs: []int64{1}}).Return([]domain.Gathering{gathering}, nil)
+			mockInvitation := new(mocks.IInvitation)
+			if tt.funcCreate.Called {
+				mockInvitation.On("Create", tt.funcCreate.Input...).Return(tt.funcCreate.Output...)
+				mockInvitation.On("Get", mock.Anything, mock.Anything).Return([]domain.Invitation{invitation}, nil)
+			}
+			ctr := &adapter.Controller{
+				MemberUsecase:    mockMemberUsecase,
+				GatheringUsecase: mockGatheringUsecase,
+				InvitationUsecase: usecase.NewInvitationUsecase(usecase.InvitationUsecaseArgs{
+					InvitationRepository: mockInvitation,
+					GatheringRepository:  mockGathering,
+				}),
+			}
+			c, w := helpers.CreateGinContext(http.MethodPost, "/invitations", strings.NewReader(`{"member":{"id":2},"gathering":{"id":1}}`))
+			c.Request = c.Request.WithContext(domain.ContextWithMember(c.Request.Context(), tt.actor))
+			ctr.CreateInvitation(c)
+			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+			mockInvitation.AssertExpectations(t)
+		})
+	}
+}
+
+func TestController_GetInvitations_private(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	memberRepository := memory.NewMemberRepository(memory.MemberAdapterRepositoryArgs{Store: store})
+	gatheringRepository := memory.NewGatheringRepository(memory.GatheringAdapterRepositoryArgs{Store: store})
+	invitationRepository := memory.NewInvitationRepository(memory.InvitationAdapterRepositoryArgs{Store: store})
+	for _, email := range []string{"linus@mail.com", "ron@mail.com", "rob@mail.com"} {
+		_, err := memberRepository.Create(ctx, domain.Member{FirstName: "member", Email: email})
+		require.NoError(t, err)
+	}
+	gatheringID, err := gatheringRepository.Create(ctx, domain.Gathering{Creator: domain.Member{ID: 1}, Name: "dinner", Type: valueobject.PRIVATE})
+	require.NoError(t, err)
+	invitationID, err := invitationRepository.Create(ctx, domain.Invitation{Member: domain.Member{ID: 2}, Gathering: domain.Gathering{ID: gatheringID}, Status: valueobject.INVITATION_CREATED})
+	require.NoError(t, err)
+	ctr := &adapter.Controller{
+		MemberUsecase:    usecase.NewMemberUsecase(usecase.MemberUsecaseArgs{MemberRepository: memberRepository}),
+		GatheringUsecase: usecase.NewGatheringUsecase(usecase.GatheringUsecaseArgs{GatheringRepository: gatheringRepository}),
+		InvitationUsecase: usecase.NewInvitationUsecase(usecase.InvitationUsecaseArgs{
+			InvitationRepository: invitationRepository,
+			GatheringRepository:  gatheringRepository,
+		}),
+	}
+	tests := []struct {
+		name         string
+		viewer       *domain.Member
+		expectedRows int
+		expectedCode int
+	}{
+		{
+			name:         "anonymous",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "member not invited",
+			viewer:       &domain.Member{ID: 3},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invited member",
+			viewer:       &domain.Member{ID: 2},
+			expectedRows: 1,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "creator",
+			viewer:       &domain.Member{ID: 1},
+			expectedRows: 1,
+			expectedCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withViewer := func(c *gin.Context) {
+				if tt.viewer != nil {
+					c.Request = c.Request.WithContext(domain.ContextWithMember(c.Request.Context(), *tt.viewer))
+				}
+			}
+			c, w := helpers.CreateGinContext(http.MethodGet, fmt.Sprintf("/invitations?gathering_id=%d", gatheringID), nil)
+			withViewer(c)
+			ctr.GetInvitations(c)
+			require.Equal(t, http.StatusOK, w.Result().StatusCode)
+			var body struct {
+				Data []domain.Invitation `json:"data"`
+				Meta domain.Page         `json:"meta"`
+			}
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+			require.Len(t, body.Data, tt.expectedRows)
+			require.EqualValues(t, tt.expectedRows, body.Meta.Total)
+			if tt.expectedRows == 0 {
+				require.NotContains(t, w.Body.String(), "ron@mail.com")
+			}
+
+			c, w = helpers.CreateGinContext(http.MethodGet, fmt.Sprintf("/invitations/%d", invitationID), nil)
+			c.Params = gin.Params{{Key: "id", Value: fmt.Sprint(invitationID)}}
+			withViewer(c)
+			ctr.GetInvitation(c)
+			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+		})
+	}
+}
+
+func TestController_BatchInvitations(t *testing.T) {
+	results := []domain.InvitationBatchResult{{MemberID: 2, Status: domain.BatchInvitationInvited, InvitationID: 5}}
+	tests := []struct {
+		name            string
+		action          string
+		body            string
+		funcCreateBatch helpers.TestFuncCall
+		expectedCode    int
+	}{
+		{
+			name:   "success",
+			action: ":batch",
+			body:   `{"member_ids":[2],"emails":["ken@mail.com"]}`,
+			funcCreateBatch: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(1), domain.InvitationBatch{MemberIDs: []int64{2}, Emails: []string{"ken@mail.com"}}},
+				Output: []interface{}{results, nil},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "unknown method",
+			action:       ":merge",
+			body:         `{"member_ids":[2]}`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "ids of the wrong type",
+			action:       ":batch",
+			body:         `{"member_ids":["2"]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "not the creator",
+			action: ":batch",
+			body:   `{"member_ids":[2]}`,
+			funcCreateBatch: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(1), mock.Anything},
+				Output: []interface{}{[]domain.InvitationBatchResult(nil), domain.NewError(domain.ErrForbidden, "only the creator can change this gathering")},
+			},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInvitationUsecase := new(mocks.IInvitationUsecase)
+			if tt.funcCreateBatch.Called {
+				mockInvitationUsecase.On("CreateBatch", tt.funcCreateBatch.Input...).Return(tt.funcCreateBatch.Output...)
+			}
+			ctr := &adapter.Controller{
+				InvitationUsecase: mockInvitationUsecase,
+			}
+			c, w := helpers.CreateGinContext(http.MethodPost, "/gatherings/1/invitations"+tt.action, strings.NewReader(tt.body))
+			c.Params = gin.Params{{Key: "id", Value: "1"}, {Key: "action", Value: tt.action}}
+			ctr.BatchInvitations(c)
+			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+			if tt.expectedCode == http.StatusOK {
+				require.Contains(t, w.Body.String(), `"status":"invited"`)
+			}
+			mockInvitationUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestController_InviteGroup(t *testing.T) {
+	results := []domain.InvitationBatchResult{{MemberID: 2, Status: domain.BatchInvitationInvited, InvitationID: 5}}
+	tests := []struct {
+		name            string
+		body            string
+		funcInviteGroup helpers.TestFuncCall
+		expectedCode    int
+	}{
+		{
+			name: "success",
+			body: `{"group_id":4,"auto_invite":true}`,
+			funcInviteGroup: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(1), domain.GroupInvitation{GroupID: 4, AutoInvite: true}},
+				Output: []interface{}{results, nil},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "group id of the wrong type",
+			body:         `{"group_id":"4"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "outside the group",
+			body: `{"group_id":4}`,
+			funcInviteGroup: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(1), domain.GroupInvitation{GroupID: 4}},
+				Output: []interface{}{[]domain.InvitationBatchResult(nil), domain.NewError(domain.ErrForbidden, "only a member of the group can invite it")},
+			},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInvitationUsecase := new(mocks.IInvitationUsecase)
+			if tt.funcInviteGroup.Called {
+				mockInvitationUsecase.On("InviteGroup", tt.funcInviteGroup.Input...).Return(tt.funcInviteGroup.Output...)
+			}
+			ctr := &adapter.Controller{
+				InvitationUsecase: mockInvitationUsecase,
+			}
+			c, w := helpers.CreateGinContext(http.MethodPost, "/gatherings/1/invitations", strings.NewReader(tt.body))
+			c.Params = gin.Params{{Key: "id", Value: "1"}}
+			ctr.InviteGroup(c)
+			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+			if tt.expectedCode == http.StatusOK {
+				require.Contains(t, w.Body.String(), `"status":"invited"`)
+			}
+			mockInvitationUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestController_AddGroupMember(t *testing.T) {
+	group := domain.Group{ID: 4, Name: "book club", Members: []domain.GroupMember{
+		{GroupID: 4, MemberID: 1, Role: valueobject.GROUP_OWNER},
+		{GroupID: 4, MemberID: 2, Role: valueobject.GROUP_MEMBER},
+	}}
+	tests := []struct {
+		name          string
+		body          string
+		funcAddMember helpers.TestFuncCall
+		expectedCode  int
+	}{
+		{
+			name: "success",
+			body: `{"member_id":2}`,
+			funcAddMember: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GroupMember{GroupID: 4, MemberID: 2}},
+				Output: []interface{}{nil},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "already in the group",
+			body: `{"member_id":2}`,
+			funcAddMember: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{domain.NewError(domain.ErrConflict, "the member is already in the group")},
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGroupUsecase := new(mocks.IGroupUsecase)
+			if tt.funcAddMember.Called {
+				mockGroupUsecase.On("AddMember", tt.funcAddMember.Input...).Return(tt.funcAddMember.Output...)
+			}
+			mockGroupUsecase.On("GetByID", mock.Anything, int64(4)).Return(group, nil)
+			ctr := &adapter.Controller{
+				GroupUsecase: mockGroupUsecase,
+			}
+			c, w := helpers.CreateGinContext(http.MethodPost, "/groups/4/members", strings.NewReader(tt.body))
+			c.Params = gin.Params{{Key: "id", Value: "4"}}
+			ctr.AddGroupMember(c)
+			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+			if tt.expectedCode == http.StatusOK {
+				require.Contains(t, w.Body.String(), `{"member_id":2,"role":"member"`)
+			}
+		})
+	}
+}
+
+func TestController_GetGatheringEvents(t *testing.T) {
+	type args struct {
+		target      string
+		lastEventID string
+	}
+	event := domain.StreamEvent{
+		ID:    "ab12-7",
+		Name:  domain.EventAttendeeAdded,
+		Event: domain.AttendeeAdded{Gathering: domain.Gathering{ID: 1, Name: "standup"}, MemberID: 3},
+	}
+	tests := []struct {
+		name          string
+		args          args
+		funcSubscribe helpers.TestFuncCall
+		expectedCode  int
+		expectedBody  []string
+	}{
+		{
+			name: "replays from the header",
+			args: args{
+				target:      "/gatherings/1/events",
+				lastEventID: "ab12-6",
+			},
+			funcSubscribe: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(1), "ab12-6"},
+				Output: []interface{}{&usecase.GatheringSubscription{Replay: []domain.StreamEvent{event}}, nil},
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: []string{"retry: 1000\n\n", "id: ab12-7\nevent: attendee.added\ndata: {\"gathering\":{\"id\":1,", "\"member_id\":3}\n\n"},
+		},
+		{
+			name: "reset from the query",
+			args: args{
+				target: "/gatherings/1/events?last_event_id=ab12-1",
+			},
+			funcSubscribe: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(1), "ab12-1"},
+				Output: []interface{}{&usecase.GatheringSubscription{Replay: []domain.StreamEvent{{ID: "ab12-7", Name: domain.EventStreamReset}}}, nil},
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: []string{"id: ab12-7\nevent: stream.reset\ndata: {}\n\n"},
+		},
+		{
+			name: "gathering hidden from the member",
+			args: args{
+				target: "/gatherings/1/events",
+			},
+			funcSubscribe: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(1), ""},
+				Output: []interface{}{nil, domain.NewError(domain.ErrNotFound, "cannot find gathering")},
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStreamUsecase := new(mocks.IGatheringStreamUsecase)
+			if tt.funcSubscribe.Called {
+				if subscription, ok := tt.funcSubscribe.Output[0].(*usecase.GatheringSubscription); ok {
+					// the stream ends once the replay is written
+					events := make(chan domain.StreamEvent)
+					close(events)
+					subscription.Events = events
+				}
+				mockStreamUsecase.On("Subscribe", tt.funcSubscribe.Input...).Return(tt.funcSubscribe.Output...)
+			}
+			ctr := &adapter.Controller{
+				GatheringStreamUsecase: mockStreamUsecase,
+			}
+			c, w := helpers.CreateGinContext(http.MethodGet, tt.args.target, nil)
+			c.Params = gin.Params{{Key: "id", Value: "1"}}
+			if tt.args.lastEventID != "" {
+				c.Request.Header.Set("Last-Event-ID", tt.args.lastEventID)
+			}
+			ctr.GetGatheringEvents(c)
+			require.Equal(t, tt.expectedCode, w.Result().StatusCode)
+			for _, s := range tt.expectedBody {
+				require.Contains(t, w.Body.String(), s)
+			}
+			if tt.expectedCode == http.StatusOK {
+				require.Equal(t, "text/event-stream", w.Result().Header.Get("Content-Type"))
+			}
+			mockStreamUsecase.AssertExpectations(t)
+		})
+	}
+}
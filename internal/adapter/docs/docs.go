@@ -15,9 +15,116 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/auth/login": {
+            "post": {
+                "description": "Login with member email and password, send the access token as \"Authorization: Bearer \u003ctoken\u003e\" header",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swaggermodel.Login"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/swaggermodel.Token"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the authenticated member",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Current Member",
+                "responses": {
+                    "200": {
+                        "description": "Member",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/swaggermodel.Member"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
         "/gatherings": {
             "get": {
-                "description": "Get Gatherings",
+                "description": "Get Gatherings, private ones only when the bearer token is of their creator, an attendee or an invitee",
                 "consumes": [
                     "application/json"
                 ],
@@ -28,32 +135,133 @@ const docTemplate = `{
                     "Gathering"
                 ],
                 "summary": "Get Gatherings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, default 20, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rows to skip, cannot be combined with cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Next cursor from previous page meta",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by id, created_at, scheduled_at or name, prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by creator",
+                        "name": "creator_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by attendee",
+                        "name": "member_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by location",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Scheduled at or after, RFC 3339 e.g. 2023-10-06T00:00:00+07:00",
+                        "name": "scheduled_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Scheduled at or before, RFC 3339 e.g. 2023-10-06T23:59:59+07:00",
+                        "name": "scheduled_to",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "Gathering",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "allOf": [
-                                    {
-                                        "$ref": "#/definitions/helpers.ResponsePayload"
-                                    },
-                                    {
-                                        "type": "object",
-                                        "properties": {
-                                            "data": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
                                                 "$ref": "#/definitions/swaggermodel.Gathering"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/domain.Page"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
                                         }
                                     }
-                                ]
-                            }
+                                }
+                            ]
                         }
                     }
                 }
             },
             "post": {
-                "description": "Create Gathering",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create Gathering, the authenticated member is the creator.\nA gathering overlapping one its attendees attend is refused with 409 listing the conflicts, with force=true it is created and they are returned as warnings.",
                 "consumes": [
                     "application/json"
                 ],
@@ -65,6 +273,12 @@ const docTemplate = `{
                 ],
                 "summary": "Create Gathering",
                 "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Create despite schedule conflicts",
+                        "name": "force",
+                        "in": "query"
+                    },
                     {
                         "description": "Payload",
                         "name": "payload",
@@ -76,7 +290,7 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
+                    "201": {
                         "description": "Gathering",
                         "schema": {
                             "allOf": [
@@ -88,6 +302,127 @@ const docTemplate = `{
                                     "properties": {
                                         "data": {
                                             "$ref": "#/definitions/swaggermodel.Gathering"
+                                        },
+                                        "warnings": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule conflicts",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/gatherings/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create gatherings from the VEVENTs of an iCalendar file, sent as the body or as the file field of a form.\nThe authenticated member is the creator, ATTENDEE emails of members are invited.\nAn event with a UID imported before is not created again.",
+                "consumes": [
+                    "text/calendar",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gathering"
+                ],
+                "summary": "Import Gathering Calendar",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "iCalendar file",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result per event",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.CalendarImportResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
                                         }
                                     }
                                 }
@@ -99,7 +434,7 @@ const docTemplate = `{
         },
         "/gatherings/{id}": {
             "get": {
-                "description": "Get Gathering By ID",
+                "description": "Get Gathering By ID with its confirmed, tentative and total headcount.\nA private gathering is not found unless the bearer token is of its creator, an attendee or an invitee.",
                 "consumes": [
                     "application/json"
                 ],
@@ -141,7 +476,12 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update Gathering",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update Gathering, only the creator",
                 "consumes": [
                     "application/json"
                 ],
@@ -176,11 +516,37 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/helpers.ResponsePayload"
                         }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     }
                 }
             },
             "delete": {
-                "description": "Delete Gathering",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete Gathering, only the creator",
                 "consumes": [
                     "application/json"
                 ],
@@ -210,45 +576,84 @@ const docTemplate = `{
                 }
             }
         },
-        "/invitations": {
+        "/gatherings/{id}.ics": {
             "get": {
-                "description": "Get Invitations",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get a gathering as an iCalendar file with its organizer, attendees and invitees",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "Invitation"
+                    "Gathering"
+                ],
+                "summary": "Get Gathering Calendar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gathering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get Invitations",
                 "responses": {
                     "200": {
-                        "description": "Invitation",
+                        "description": "iCalendar",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "allOf": [
-                                    {
-                                        "$ref": "#/definitions/helpers.ResponsePayload"
-                                    },
-                                    {
-                                        "type": "object",
-                                        "properties": {
-                                            "data": {
-                                                "$ref": "#/definitions/swaggermodel.Invitation"
-                                            }
-                                        }
-                                    }
-                                ]
-                            }
+                            "type": "string"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/gatherings/{id}/events": {
+            "get": {
+                "description": "Stream changes of a gathering as Server-Sent Events: attendee.added, attendee.removed, invitation status changes\nsuch as invitation.accepted, gathering.updated, gathering.rescheduled, occurrence changes and gathering.deleted.\nEach event has an id, a client reconnecting with it in the Last-Event-ID header or the last_event_id query gets the\nevents it missed, or stream.reset when they are no longer kept and the gathering should be reloaded.\nA private gathering is not found unless the bearer token is of its creator, an attendee or an invitee.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Gathering"
+                ],
+                "summary": "Get Gathering Events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gathering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received, for clients that cannot set headers",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/gatherings/{id}/invitations": {
             "post": {
-                "description": "Create Invitation",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Invite every member of a group to a gathering, only the creator who must be a member of the group.\nMembers are reported like bulk invitations. With auto_invite, members who join the group later are\ninvited too while the gathering is upcoming.",
                 "consumes": [
                     "application/json"
                 ],
@@ -258,21 +663,28 @@ const docTemplate = `{
                 "tags": [
                     "Invitation"
                 ],
-                "summary": "Create Invitation",
+                "summary": "Invite Group",
                 "parameters": [
                     {
-                        "description": "Payload",
+                        "type": "integer",
+                        "description": "Gathering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Group",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swaggermodel.Invitation"
+                            "$ref": "#/definitions/swaggermodel.GroupInvitation"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Invitation",
+                        "description": "Recipients",
                         "schema": {
                             "allOf": [
                                 {
@@ -282,7 +694,31 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/swaggermodel.Invitation"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.InvitationBatchResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
                                         }
                                     }
                                 }
@@ -292,9 +728,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/invitations/{id}": {
-            "get": {
-                "description": "Get Invitation By ID",
+        "/gatherings/{id}/invitations:batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Invite members by ID or email to a gathering, only the creator, at most 100 recipients.\nMembers already invited or attending are skipped, the others are invited in one transaction.\nEach recipient has a status: invited, already_invited, already_attending, not_found or duplicate.",
                 "consumes": [
                     "application/json"
                 ],
@@ -304,19 +745,28 @@ const docTemplate = `{
                 "tags": [
                     "Invitation"
                 ],
-                "summary": "Get Invitation By ID",
+                "summary": "Bulk Create Invitations",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Invitation ID",
+                        "description": "Gathering ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Recipients",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swaggermodel.InvitationBatch"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Invitation",
+                        "description": "Recipients",
                         "schema": {
                             "allOf": [
                                 {
@@ -326,7 +776,31 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/swaggermodel.Invitation"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.InvitationBatchResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
                                         }
                                     }
                                 }
@@ -336,9 +810,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/invitations/{id}/accept": {
-            "put": {
-                "description": "Accept Invitation",
+        "/gatherings/{id}/join": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attend an upcoming public gathering without an invitation, the gathering must have a free seat.\nSchedule conflicts are refused with 409 unless force=true, then they are returned as warnings.",
                 "consumes": [
                     "application/json"
                 ],
@@ -346,31 +825,78 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Invitation"
+                    "Gathering"
                 ],
-                "summary": "Accept Invitation",
+                "summary": "Join Gathering",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Invitation ID",
+                        "description": "Gathering ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Join despite schedule conflicts",
+                        "name": "force",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Invitation",
+                        "description": "Gathering",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ResponsePayload"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "warnings": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule conflicts",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/invitations/{id}/cancel": {
-            "put": {
-                "description": "Cancel Invitation, will remove member from attendee list",
+        "/gatherings/{id}/leave": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop attending a gathering, an accepted invitation is rejected and the freed seats go to the waitlist. The creator cannot leave.",
                 "consumes": [
                     "application/json"
                 ],
@@ -378,13 +904,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Invitation"
+                    "Gathering"
                 ],
-                "summary": "Cancel Invitation",
+                "summary": "Leave Gathering",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Invitation ID",
+                        "description": "Gathering ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -392,7 +918,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Invitation",
+                        "description": "Gathering",
                         "schema": {
                             "$ref": "#/definitions/helpers.ResponsePayload"
                         }
@@ -400,9 +926,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/invitations/{id}/reject": {
-            "put": {
-                "description": "Reject Invitation, used by member to reject the invitation",
+        "/gatherings/{id}/occurrences": {
+            "get": {
+                "description": "Get occurrences of a gathering starting within from and to, a single gathering has one. Canceled occurrences are included with canceled set.",
                 "consumes": [
                     "application/json"
                 ],
@@ -410,67 +936,86 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Invitation"
+                    "Gathering"
                 ],
-                "summary": "Reject Invitation",
+                "summary": "Get Occurrences",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Invitation ID",
+                        "description": "Gathering ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range, RFC 3339 e.g. 2023-10-01T00:00:00+07:00",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range, at most 366 days after from",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Invitation",
+                        "description": "Occurrences",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ResponsePayload"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/swaggermodel.Occurrence"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
-                    }
-                }
-            }
-        },
-        "/members": {
-            "get": {
-                "description": "Get Members",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Member"
-                ],
-                "summary": "Get Members",
-                "responses": {
-                    "200": {
-                        "description": "Member",
+                    },
+                    "400": {
+                        "description": "Invalid fields",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "allOf": [
-                                    {
-                                        "$ref": "#/definitions/helpers.ResponsePayload"
-                                    },
-                                    {
-                                        "type": "object",
-                                        "properties": {
-                                            "data": {
-                                                "$ref": "#/definitions/swaggermodel.Member"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
                                             }
                                         }
                                     }
-                                ]
-                            }
+                                }
+                            ]
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create Member",
+            }
+        },
+        "/gatherings/{id}/occurrences/{recurrence_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Edit one occurrence of a recurring gathering, or with scope=following it and every later one, which then become a new gathering. Only the creator.",
                 "consumes": [
                     "application/json"
                 ],
@@ -478,23 +1023,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Member"
+                    "Gathering"
                 ],
-                "summary": "Create Member",
+                "summary": "Update Occurrence",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gathering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Recurrence ID of the occurrence, RFC 3339",
+                        "name": "recurrence_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "this (default) or following",
+                        "name": "scope",
+                        "in": "query"
+                    },
                     {
                         "description": "Payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swaggermodel.Member"
+                            "$ref": "#/definitions/swaggermodel.UpdateOccurrence"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Member",
+                        "description": "Occurrence",
                         "schema": {
                             "allOf": [
                                 {
@@ -504,19 +1069,88 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/swaggermodel.Member"
+                                            "$ref": "#/definitions/swaggermodel.Occurrence"
                                         }
                                     }
                                 }
                             ]
                         }
-                    }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel one occurrence of a recurring gathering, or with scope=following end the series before it. Only the creator.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gathering"
+                ],
+                "summary": "Cancel Occurrence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gathering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Recurrence ID of the occurrence, RFC 3339",
+                        "name": "recurrence_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "this (default) or following",
+                        "name": "scope",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Occurrence",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponsePayload"
+                        }
+                    }
                 }
             }
         },
-        "/members/{id}": {
+        "/groups": {
             "get": {
-                "description": "Get Member By ID",
+                "description": "Get Groups",
                 "consumes": [
                     "application/json"
                 ],
@@ -524,13 +1158,183 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Member"
+                    "Group"
                 ],
-                "summary": "Get Member By ID",
+                "summary": "Get Groups",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "member ID",
+                        "description": "Page size, default 20, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rows to skip, cannot be combined with cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Next cursor from previous page meta",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by id, created_at or name, prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by member",
+                        "name": "member_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by name",
+                        "name": "name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Group",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/swaggermodel.Group"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/domain.Page"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create Group, the authenticated member is its first owner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "Create Group",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swaggermodel.GroupPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Group",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/swaggermodel.Group"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{id}": {
+            "get": {
+                "description": "Get Group By ID with its members",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "Get Group By ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -538,7 +1342,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Member",
+                        "description": "Group",
                         "schema": {
                             "allOf": [
                                 {
@@ -548,7 +1352,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/swaggermodel.Member"
+                                            "$ref": "#/definitions/swaggermodel.Group"
                                         }
                                     }
                                 }
@@ -558,7 +1362,12 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update Member",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename Group, only an owner",
                 "consumes": [
                     "application/json"
                 ],
@@ -566,13 +1375,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Member"
+                    "Group"
                 ],
-                "summary": "Update Member",
+                "summary": "Update Group",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Member ID",
+                        "description": "Group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -583,21 +1392,59 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/swaggermodel.Member"
+                            "$ref": "#/definitions/swaggermodel.GroupPayload"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Member",
+                        "description": "Group",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ResponsePayload"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/swaggermodel.Group"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             },
             "delete": {
-                "description": "Delete Member",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete Group, only an owner. Invitations already sent to its members are kept.",
                 "consumes": [
                     "application/json"
                 ],
@@ -605,13 +1452,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Member"
+                    "Group"
                 ],
-                "summary": "Delete Member",
+                "summary": "Delete Group",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Member ID",
+                        "description": "Group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -619,83 +1466,1973 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Member",
+                        "description": "Group",
                         "schema": {
                             "$ref": "#/definitions/helpers.ResponsePayload"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "helpers.ResponsePayload": {
+        },
+        "/groups/{id}/members": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a member to the group, only an owner.\nThe member is invited to the upcoming gatherings the group was invited to with auto invite.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "Add Group Member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swaggermodel.GroupMember"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Group",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/swaggermodel.Group"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{id}/members/{member_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the role of a group member, only an owner. The last owner cannot step down.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "Update Group Member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "member_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swaggermodel.GroupMemberRole"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Group",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/swaggermodel.Group"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a member from the group, an owner removes anyone and a member may leave. The last owner cannot leave.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "Remove Group Member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "member_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Group",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponsePayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations": {
+            "get": {
+                "description": "Get Invitations, those of a private gathering are listed only to its creator, attendees and invitees",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitation"
+                ],
+                "summary": "Get Invitations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, default 20, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rows to skip, cannot be combined with cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Next cursor from previous page meta",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by id or created_at, prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by member",
+                        "name": "member_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by gathering",
+                        "name": "gathering_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/swaggermodel.Invitation"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/domain.Page"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Invite a member to a gathering, only the creator of the gathering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitation"
+                ],
+                "summary": "Create Invitation",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swaggermodel.Invitation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/swaggermodel.Invitation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{id}": {
+            "get": {
+                "description": "Get Invitation By ID, one of a private gathering is not found unless the bearer token is of its creator, an attendee or an invitee",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitation"
+                ],
+                "summary": "Get Invitation By ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/swaggermodel.Invitation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{id}/accept": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Accept Invitation, only the invited member, a rejected or canceled invitation cannot be accepted.\nThe invitation is waitlisted when the gathering has no seats for the member and the guests, it is accepted once they are free.\nA gathering overlapping one the member attends is refused with 409 listing the conflicts, with force=true it is accepted and they are returned as warnings.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitation"
+                ],
+                "summary": "Accept Invitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Accept despite schedule conflicts",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Guests and note",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/swaggermodel.InvitationResponse"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation, accepted or waitlisted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/swaggermodel.Invitation"
+                                        },
+                                        "warnings": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule conflicts",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{id}/cancel": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel Invitation, will remove member from attendee list and promote the first waitlisted member, only the gathering creator, a rejected invitation cannot be canceled",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitation"
+                ],
+                "summary": "Cancel Invitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponsePayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{id}/reject": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reject Invitation, only the invited member, an accepted invitation can still be rejected and its seat goes to the first waitlisted member",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitation"
+                ],
+                "summary": "Reject Invitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/swaggermodel.RejectInvitation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponsePayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{id}/tentative": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Answer maybe, only the invited member. The member does not attend, the member and the guests count toward tentative headcount.\nAn accepted invitation answered maybe frees its seats for the waitlist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitation"
+                ],
+                "summary": "Tentatively Accept Invitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Guests and note",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/swaggermodel.InvitationResponse"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitation",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/swaggermodel.Invitation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/members": {
+            "get": {
+                "description": "Get Members",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Member"
+                ],
+                "summary": "Get Members",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, default 20, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rows to skip, cannot be combined with cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Next cursor from previous page meta",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by id, created_at or name, prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by email",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by first or last name",
+                        "name": "name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Member",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/swaggermodel.Member"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/domain.Page"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create Member, set password to let the member login",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Member"
+                ],
+                "summary": "Create Member",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swaggermodel.CreateMember"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Member",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/swaggermodel.Member"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/members/{id}": {
+            "get": {
+                "description": "Get Member By ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Member"
+                ],
+                "summary": "Get Member By ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Member",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/swaggermodel.Member"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update Member, only the member or an admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Member"
+                ],
+                "summary": "Update Member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swaggermodel.Member"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Member",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponsePayload"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete Member, only the member or an admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Member"
+                ],
+                "summary": "Delete Member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Member",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponsePayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/members/{id}/calendar.ics": {
+            "get": {
+                "description": "Get an iCalendar feed of gatherings the member attends or has an open invitation to, calendar apps can subscribe to it.\nPrivate gatherings are left out unless the bearer token is of their creator, an attendee or an invitee.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Member"
+                ],
+                "summary": "Get Member Calendar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/members/{id}/conflicts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get overlapping occurrences of gatherings the member attends, only the member or an admin.\nA gathering without an end is taken to last an hour.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Member"
+                ],
+                "summary": "Get Member Conflicts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range, RFC 3339, default now",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range, at most and by default 366 days after from",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Conflicts",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get Webhooks without their secrets, only an admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Get Webhooks",
+                "responses": {
+                    "200": {
+                        "description": "Webhook",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/swaggermodel.Webhook"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribe a URL to events, only an admin. Deliveries are signed with the secret, it is only returned here.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Create Webhook",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swaggermodel.WebhookPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Webhook",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/swaggermodel.Webhook"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get Webhook By ID without its secret, only an admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Get Webhook By ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/swaggermodel.Webhook"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the URL, events or disabled flag of a webhook, only an admin. The secret is kept unless a new one is given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Update Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/swaggermodel.WebhookPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/swaggermodel.Webhook"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete Webhook and its delivery log, only an admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Delete Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponsePayload"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the delivery log of a webhook, only an admin.\nA failed delivery is retried with exponential backoff and is dead after 8 attempts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Get Webhook Deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, default 20, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rows to skip, cannot be combined with cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Next cursor from previous page meta",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by id or created_at, prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by status: pending, delivered or dead",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook Delivery",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/swaggermodel.WebhookDelivery"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/domain.Page"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "errors": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.FieldError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{delivery_id}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue the payload of a delivery again as a new delivery, only an admin. Usually a dead delivery once the receiver is fixed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Replay Webhook Delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Webhook Delivery",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/swaggermodel.WebhookDelivery"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.CalendarImportResult": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "description": "Conflicts are gatherings of the creator a created gathering overlaps, it is imported anyway",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ScheduleConflict"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FieldError"
+                    }
+                },
+                "gathering_id": {
+                    "type": "integer"
+                },
+                "invited": {
+                    "description": "Invited are emails of members invited by the import",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "description": "Status is CalendarImportCreated, CalendarImportExisting for a gathering imported before or CalendarImportFailed",
+                    "type": "string"
+                },
+                "uid": {
+                    "type": "string"
+                },
+                "unknown_attendees": {
+                    "description": "UnknownAttendees are emails of attendees who are not members",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.InvitationBatchResult": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "invitation_id": {
+                    "description": "InvitationID is the new invitation, or the existing one of an already invited member",
+                    "type": "integer"
+                },
+                "member_id": {
+                    "description": "MemberID is set for a recipient listed by ID or found by email",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Occurrence": {
+            "type": "object",
+            "properties": {
+                "canceled": {
+                    "type": "boolean"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "gathering_id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "recurrence_id": {
+                    "type": "string"
+                },
+                "scheduled_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Page": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ScheduleConflict": {
+            "type": "object",
+            "properties": {
+                "conflicts_with": {
+                    "$ref": "#/definitions/domain.Occurrence"
+                },
+                "member_id": {
+                    "type": "integer"
+                },
+                "occurrence": {
+                    "$ref": "#/definitions/domain.Occurrence"
+                }
+            }
+        },
+        "helpers.ResponsePayload": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "errors": {
+                    "description": "Errors lists the invalid fields of a failed request"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {},
+                "status_code": {
+                    "type": "integer"
+                },
+                "warnings": {
+                    "description": "Warnings lists what a successful request let through, such as schedule conflicts"
+                }
+            }
+        },
+        "swaggermodel.CreateMember": {
+            "type": "object",
+            "required": [
+                "email",
+                "first_name"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@mail.com"
+                },
+                "first_name": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Doe"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "secret-password"
+                }
+            }
+        },
+        "swaggermodel.Gathering": {
+            "type": "object",
+            "required": [
+                "location",
+                "name",
+                "scheduled_at",
+                "type"
+            ],
+            "properties": {
+                "attendees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swaggermodel.MemberPayload"
+                    }
+                },
+                "capacity": {
+                    "description": "Optional most people including the creator and guests, accepts beyond it are waitlisted, 0 is unlimited",
+                    "type": "integer",
+                    "example": 20
+                },
+                "creator": {
+                    "description": "Default to the authenticated member, which is the only creator allowed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/swaggermodel.MemberPayload"
+                        }
+                    ]
+                },
+                "duration_minutes": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "ends_at": {
+                    "description": "Optional end time in RFC 3339 format, or set duration_minutes instead",
+                    "type": "string",
+                    "example": "2023-10-06T21:00:00+07:00"
+                },
+                "headcount": {
+                    "description": "Only returned by Get Gathering By ID",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/swaggermodel.Headcount"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "gathering street"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Gathering Name"
+                },
+                "recurrence": {
+                    "description": "Optional RRULE making the gathering a series of FREQ, INTERVAL, BYDAY, COUNT and UNTIL, scheduled_at is the first occurrence",
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=FR;COUNT=10"
+                },
+                "scheduled_at": {
+                    "description": "Start time in RFC 3339 format, returned in the gathering time zone",
+                    "type": "string",
+                    "example": "2023-10-06T19:00:00+07:00"
+                },
+                "time_zone": {
+                    "description": "IANA time zone name, default to UTC",
+                    "type": "string",
+                    "example": "Asia/Jakarta"
+                },
+                "type": {
+                    "description": "Gathering type\n* 0 -\u003e Private\n* 1 -\u003e Public",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/valueobject.GatheringType"
+                        }
+                    ],
+                    "example": 1
+                }
+            }
+        },
+        "swaggermodel.GatheringPayload": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "swaggermodel.Group": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "gathering_ids": {
+                    "description": "Gatherings the group was invited to with auto invite",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/swaggermodel.GroupMember"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "book club"
+                }
+            }
+        },
+        "swaggermodel.GroupInvitation": {
+            "type": "object",
+            "required": [
+                "group_id"
+            ],
+            "properties": {
+                "auto_invite": {
+                    "description": "Also invite members who join the group later while the gathering is upcoming",
+                    "type": "boolean",
+                    "example": true
+                },
+                "group_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "swaggermodel.GroupMember": {
             "type": "object",
+            "required": [
+                "member_id"
+            ],
             "properties": {
-                "data": {},
-                "message": {
+                "created_at": {
                     "type": "string"
                 },
-                "status_code": {
-                    "type": "integer"
+                "member_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "role": {
+                    "description": "Owners manage the group, member or owner, default member",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/valueobject.GroupRole"
+                        }
+                    ],
+                    "example": "member"
                 }
             }
         },
-        "swaggermodel.Gathering": {
+        "swaggermodel.GroupMemberRole": {
             "type": "object",
             "required": [
-                "creator",
-                "location",
-                "name",
-                "scheduled_at",
-                "type"
+                "role"
             ],
             "properties": {
-                "attendees": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/swaggermodel.MemberPayload"
-                    }
-                },
-                "creator": {
-                    "$ref": "#/definitions/swaggermodel.MemberPayload"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "location": {
-                    "type": "string",
-                    "example": "gathering street"
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Gathering Name"
-                },
-                "scheduled_at": {
-                    "description": "Date using (YYYY-MM-DD MM:SS) format",
-                    "type": "string",
-                    "example": "2023-10-06 04:53"
-                },
-                "type": {
-                    "description": "Gathering type\n* 0 -\u003e Private\n* 1 -\u003e Public",
+                "role": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/valueobject.GatheringType"
+                            "$ref": "#/definitions/valueobject.GroupRole"
                         }
                     ],
-                    "example": 1
+                    "example": "owner"
                 }
             }
         },
-        "swaggermodel.GatheringPayload": {
+        "swaggermodel.GroupPayload": {
             "type": "object",
             "required": [
-                "id"
+                "name"
             ],
             "properties": {
-                "id": {
+                "name": {
+                    "type": "string",
+                    "example": "book club"
+                }
+            }
+        },
+        "swaggermodel.Headcount": {
+            "type": "object",
+            "properties": {
+                "confirmed": {
+                    "description": "Attendees and the guests of accepted invitations",
                     "type": "integer",
-                    "example": 1
+                    "example": 12
+                },
+                "tentative": {
+                    "description": "Members who answered maybe and their guests",
+                    "type": "integer",
+                    "example": 3
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 15
                 }
             }
         },
@@ -712,19 +3449,91 @@ const docTemplate = `{
                 "gathering": {
                     "$ref": "#/definitions/swaggermodel.GatheringPayload"
                 },
+                "guests": {
+                    "description": "Plus-ones of the member, set by accepting or answering maybe",
+                    "type": "integer",
+                    "example": 1
+                },
                 "id": {
                     "type": "integer"
                 },
                 "member": {
                     "$ref": "#/definitions/swaggermodel.MemberPayload"
                 },
+                "note": {
+                    "type": "string",
+                    "example": "coming with my partner"
+                },
                 "status": {
-                    "description": "Invitation status\n* 0 -\u003e Created\n* 1 -\u003e Accepted\n* 2 -\u003e Rejected\n* 3 -\u003e Cancelled",
+                    "description": "Invitation status\n* 0 -\u003e Created\n* 1 -\u003e Accepted\n* 2 -\u003e Rejected\n* 3 -\u003e Cancelled\n* 4 -\u003e Waitlisted, accepted while the gathering is full\n* 5 -\u003e Tentative",
                     "allOf": [
                         {
                             "$ref": "#/definitions/valueobject.InvitationStatus"
                         }
                     ]
+                },
+                "waitlisted_at": {
+                    "description": "Set while waitlisted, the waitlist is promoted in this order",
+                    "type": "string",
+                    "example": "2023-10-03 11:05:01"
+                }
+            }
+        },
+        "swaggermodel.InvitationBatch": {
+            "type": "object",
+            "properties": {
+                "emails": {
+                    "description": "Members to invite by email",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "ken@mail.com"
+                    ]
+                },
+                "member_ids": {
+                    "description": "Members to invite by ID",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2,
+                        3
+                    ]
+                }
+            }
+        },
+        "swaggermodel.InvitationResponse": {
+            "type": "object",
+            "properties": {
+                "guests": {
+                    "description": "Plus-ones, at most 10, they count toward headcount and capacity",
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "description": "At most 500 characters",
+                    "type": "string",
+                    "example": "coming with my partner"
+                }
+            }
+        },
+        "swaggermodel.Login": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@mail.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "secret-password"
                 }
             }
         },
@@ -764,6 +3573,67 @@ const docTemplate = `{
                 }
             }
         },
+        "swaggermodel.Occurrence": {
+            "type": "object",
+            "properties": {
+                "canceled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2023-10-06T21:00:00+07:00"
+                },
+                "gathering_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "location": {
+                    "type": "string",
+                    "example": "gathering street"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Gathering Name"
+                },
+                "recurrence_id": {
+                    "description": "Start generated by the recurrence, it identifies the occurrence in the occurrence endpoints",
+                    "type": "string",
+                    "example": "2023-10-06T19:00:00+07:00"
+                },
+                "scheduled_at": {
+                    "type": "string",
+                    "example": "2023-10-06T19:00:00+07:00"
+                }
+            }
+        },
+        "swaggermodel.RejectInvitation": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "description": "At most 500 characters",
+                    "type": "string",
+                    "example": "out of town that week"
+                }
+            }
+        },
+        "swaggermodel.Token": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2023-10-03T11:05:01Z"
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                }
+            }
+        },
         "swaggermodel.UpdateGathering": {
             "type": "object",
             "required": [
@@ -773,6 +3643,20 @@ const docTemplate = `{
                 "type"
             ],
             "properties": {
+                "capacity": {
+                    "description": "Optional most people including the creator and guests, 0 is unlimited",
+                    "type": "integer",
+                    "example": 20
+                },
+                "duration_minutes": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "ends_at": {
+                    "description": "Optional end time in RFC 3339 format, or set duration_minutes instead",
+                    "type": "string",
+                    "example": "2023-10-06T21:00:00+07:00"
+                },
                 "location": {
                     "type": "string",
                     "example": "gathering street"
@@ -781,10 +3665,20 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Gathering Name"
                 },
+                "recurrence": {
+                    "description": "Optional RRULE, default to the current one",
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=FR;COUNT=10"
+                },
                 "scheduled_at": {
-                    "description": "Date using (YYYY-MM-DD MM:SS) format",
+                    "description": "Start time in RFC 3339 format",
                     "type": "string",
-                    "example": "2023-10-06 04:53"
+                    "example": "2023-10-06T19:00:00+07:00"
+                },
+                "time_zone": {
+                    "description": "IANA time zone name, default to UTC",
+                    "type": "string",
+                    "example": "Asia/Jakarta"
                 },
                 "type": {
                     "description": "Gathering type\n* 0 -\u003e Private\n* 1 -\u003e Public",
@@ -797,6 +3691,154 @@ const docTemplate = `{
                 }
             }
         },
+        "swaggermodel.UpdateOccurrence": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "description": "Optional end time in RFC 3339 format, default to keep the duration",
+                    "type": "string",
+                    "example": "2023-10-06T22:00:00+07:00"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "gathering street"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Gathering Name"
+                },
+                "scheduled_at": {
+                    "description": "Optional start time in RFC 3339 format, default to the occurrence start",
+                    "type": "string",
+                    "example": "2023-10-06T20:00:00+07:00"
+                }
+            }
+        },
+        "swaggermodel.Webhook": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "description": "Events to deliver, * for every event",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "gathering.created",
+                        "invitation.accepted"
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_disabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "secret": {
+                    "description": "Only returned when the webhook is created",
+                    "type": "string",
+                    "example": "9f2c4e0b7d1a4c3e8b6f5a2d1c0e9f8a"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://hooks.example.com/gatherings"
+                }
+            }
+        },
+        "swaggermodel.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string",
+                    "example": "gathering.created"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "JSON body posted: id, event, occurred_at and data",
+                    "type": "string"
+                },
+                "replay_of": {
+                    "description": "The delivery this one replays",
+                    "type": "integer"
+                },
+                "response_status": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "status": {
+                    "description": "pending, delivered or dead",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/valueobject.WebhookDeliveryStatus"
+                        }
+                    ],
+                    "example": "delivered"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "swaggermodel.WebhookPayload": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "description": "member.created, member.updated, member.deleted, gathering.created, gathering.updated, gathering.deleted,\ninvitation.created, invitation.accepted, invitation.waitlisted, invitation.tentative, invitation.rejected,\ninvitation.canceled or * for every event",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "gathering.created",
+                        "invitation.accepted"
+                    ]
+                },
+                "is_disabled": {
+                    "description": "Stops new deliveries",
+                    "type": "boolean",
+                    "example": false
+                },
+                "secret": {
+                    "description": "Signs deliveries, at least 16 characters. Generated on create when empty, kept on update when empty.",
+                    "type": "string",
+                    "example": ""
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://hooks.example.com/gatherings"
+                }
+            }
+        },
         "valueobject.GatheringType": {
             "type": "integer",
             "enum": [
@@ -808,21 +3850,57 @@ const docTemplate = `{
                 "PUBLIC"
             ]
         },
+        "valueobject.GroupRole": {
+            "type": "string",
+            "enum": [
+                "member",
+                "owner"
+            ],
+            "x-enum-varnames": [
+                "GROUP_MEMBER",
+                "GROUP_OWNER"
+            ]
+        },
         "valueobject.InvitationStatus": {
             "type": "integer",
             "enum": [
                 0,
                 1,
                 2,
-                3
+                3,
+                4,
+                5
             ],
             "x-enum-varnames": [
                 "INVITATION_CREATED",
                 "INVITATION_ACCEPT",
                 "INVITATION_REJECT",
-                "INVITATION_CANCELED"
+                "INVITATION_CANCELED",
+                "INVITATION_WAITLISTED",
+                "INVITATION_TENTATIVE"
+            ]
+        },
+        "valueobject.WebhookDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "delivered",
+                "dead"
+            ],
+            "x-enum-varnames": [
+                "WEBHOOK_DELIVERY_PENDING",
+                "WEBHOOK_DELIVERY_DELIVERED",
+                "WEBHOOK_DELIVERY_DEAD"
             ]
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Access token from /auth/login, as \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
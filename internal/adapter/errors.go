@@ -0,0 +1,51 @@
+package adapter
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+)
+
+// errorStatus maps domain error kinds to response status, an error of no kind is internal
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrConflict):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// errorResponse writes err with its mapped status, every handler reports failures through it.
+// Internal errors are logged and hidden from clients, they may leak queries or hosts.
+// Validation errors list every invalid field in errors, schedule conflict errors list the conflicts.
+func errorResponse(c *gin.Context, err error) {
+	status := errorStatus(err)
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		log.Println(err)
+		message = "internal server error, please retry later"
+	}
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		helpers.NewErrorResponse(c, status, message, validationErr.Errors)
+		return
+	}
+	var conflictErr *domain.ScheduleConflictError
+	if errors.As(err, &conflictErr) {
+		helpers.NewErrorResponse(c, status, message, conflictErr.Conflicts)
+		return
+	}
+	helpers.NewErrorResponse(c, status, message, nil)
+}
@@ -0,0 +1,249 @@
+package adapter
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+)
+
+// @Tags			Group
+// @Summary		Create Group
+// @Description	Create Group, the authenticated member is its first owner
+// @Accept			json
+// @Produce		json
+// @Param			payload	body		swaggermodel.GroupPayload							true	"Payload"
+// @Success		201		{object}	helpers.ResponsePayload{data=swaggermodel.Group}	"Group"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}	"Invalid fields"
+// @Security		BearerAuth
+// @Router			/groups [post]
+func (ctr *Controller) CreateGroup(c *gin.Context) {
+	group := domain.Group{}
+	if err := bindJSON(c, &group); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	if err := group.Validate(); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	group, err := ctr.GroupUsecase.Create(c.Request.Context(), group)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusCreated, "success", group)
+}
+
+// @Tags			Group
+// @Summary		Get Groups
+// @Description	Get Groups
+// @Accept			json
+// @Produce		json
+// @Param			limit		query		int																	false	"Page size, default 20, max 100"
+// @Param			offset		query		int																	false	"Rows to skip, cannot be combined with cursor"
+// @Param			cursor		query		string																false	"Next cursor from previous page meta"
+// @Param			sort		query		string																false	"Sort by id, created_at or name, prefix with - for descending"
+// @Param			member_id	query		int																	false	"Filter by member"
+// @Param			name		query		string																false	"Filter by name"
+// @Success		200			{object}	helpers.ResponsePayload{data=[]swaggermodel.Group,meta=domain.Page}	"Group"
+// @Failure		400			{object}	helpers.ResponsePayload{errors=[]domain.FieldError}					"Invalid fields"
+// @Router			/groups [get]
+func (ctr *Controller) GetGroups(c *gin.Context) {
+	args, err := bindGroupArgs(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	groups, page, err := ctr.GroupUsecase.List(c.Request.Context(), args)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponseWithMeta(c, http.StatusOK, "success", groups, page)
+}
+
+// @Tags			Group
+// @Summary		Get Group By ID
+// @Description	Get Group By ID with its members
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int													true	"Group ID"
+// @Success		200	{object}	helpers.ResponsePayload{data=swaggermodel.Group}	"Group"
+// @Router			/groups/{id} [get]
+func (ctr *Controller) GetGroup(c *gin.Context) {
+	id, err := paramID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	group, err := ctr.GroupUsecase.GetByID(c.Request.Context(), id)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusOK, "success", group)
+}
+
+// @Tags			Group
+// @Summary		Update Group
+// @Description	Rename Group, only an owner
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int													true	"Group ID"
+// @Param			payload	body		swaggermodel.GroupPayload							true	"Payload"
+// @Success		200		{object}	helpers.ResponsePayload{data=swaggermodel.Group}	"Group"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}	"Invalid fields"
+// @Security		BearerAuth
+// @Router			/groups/{id} [put]
+func (ctr *Controller) UpdateGroup(c *gin.Context) {
+	group := domain.Group{}
+	if err := bindJSON(c, &group); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	if err := group.Validate(); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	id, err := paramID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	group.ID = id
+	if err = ctr.GroupUsecase.Update(c.Request.Context(), group); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	ctr.groupResponse(c, id)
+}
+
+// @Tags			Group
+// @Summary		Delete Group
+// @Description	Delete Group, only an owner. Invitations already sent to its members are kept.
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int							true	"Group ID"
+// @Success		200	{object}	helpers.ResponsePayload{}	"Group"
+// @Security		BearerAuth
+// @Router			/groups/{id} [delete]
+func (ctr *Controller) DeleteGroup(c *gin.Context) {
+	id, err := paramID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	if err = ctr.GroupUsecase.Delete(c.Request.Context(), domain.GroupArgs{ID: id}); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusOK, "success", nil)
+}
+
+// @Tags			Group
+// @Summary		Add Group Member
+// @Description	Add a member to the group, only an owner.
+// @Description	The member is invited to the upcoming gatherings the group was invited to with auto invite.
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int													true	"Group ID"
+// @Param			payload	body		swaggermodel.GroupMember							true	"Payload"
+// @Success		200		{object}	helpers.ResponsePayload{data=swaggermodel.Group}	"Group"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}	"Invalid fields"
+// @Security		BearerAuth
+// @Router			/groups/{id}/members [post]
+func (ctr *Controller) AddGroupMember(c *gin.Context) {
+	member := domain.GroupMember{}
+	if err := bindJSON(c, &member); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	id, err := paramID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	member.GroupID = id
+	if err = ctr.GroupUsecase.AddMember(c.Request.Context(), member); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	ctr.groupResponse(c, id)
+}
+
+// @Tags			Group
+// @Summary		Update Group Member
+// @Description	Change the role of a group member, only an owner. The last owner cannot step down.
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int													true	"Group ID"
+// @Param			member_id	path		int													true	"Member ID"
+// @Param			payload		body		swaggermodel.GroupMemberRole						true	"Payload"
+// @Success		200			{object}	helpers.ResponsePayload{data=swaggermodel.Group}	"Group"
+// @Failure		400			{object}	helpers.ResponsePayload{errors=[]domain.FieldError}	"Invalid fields"
+// @Security		BearerAuth
+// @Router			/groups/{id}/members/{member_id} [put]
+func (ctr *Controller) UpdateGroupMember(c *gin.Context) {
+	member := domain.GroupMember{}
+	if err := bindJSON(c, &member); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	id, err := paramID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	memberID, err := paramMemberID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	member.GroupID, member.MemberID = id, memberID
+	if err = ctr.GroupUsecase.UpdateMember(c.Request.Context(), member); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	ctr.groupResponse(c, id)
+}
+
+// @Tags			Group
+// @Summary		Remove Group Member
+// @Description	Remove a member from the group, an owner removes anyone and a member may leave. The last owner cannot leave.
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int							true	"Group ID"
+// @Param			member_id	path		int							true	"Member ID"
+// @Success		200			{object}	helpers.ResponsePayload{}	"Group"
+// @Security		BearerAuth
+// @Router			/groups/{id}/members/{member_id} [delete]
+func (ctr *Controller) RemoveGroupMember(c *gin.Context) {
+	id, err := paramID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	memberID, err := paramMemberID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	err = ctr.GroupUsecase.RemoveMember(c.Request.Context(), domain.GroupMember{GroupID: id, MemberID: memberID})
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusOK, "success", nil)
+}
+
+// groupResponse responds with the group as it is after a change
+func (ctr *Controller) groupResponse(c *gin.Context, id int64) {
+	group, err := ctr.GroupUsecase.GetByID(c.Request.Context(), id)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusOK, "success", group)
+}
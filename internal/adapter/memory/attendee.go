@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+// checkAttendee mimics attendees foreign keys and unique index, caller must hold the lock
+func (s *Store) checkAttendee(memberID int64, gatheringID int64, pending map[int64]bool) (err error) {
+	if _, ok := s.members[memberID]; !ok {
+		return foreignKeyError("attendees", "member_id")
+	}
+	if pending[memberID] {
+		return duplicateEntryError("an attendee is listed more than once", fmt.Sprintf("%d-%d", memberID, gatheringID), "attendees.unique_index")
+	}
+	if gatheringID == 0 {
+		return
+	}
+	if _, ok := s.gatherings[gatheringID]; !ok {
+		return foreignKeyError("attendees", "gathering_id")
+	}
+	for _, a := range s.attendees {
+		if a.memberID == memberID && a.gatheringID == gatheringID {
+			return duplicateEntryError("an attendee is listed more than once", fmt.Sprintf("%d-%d", memberID, gatheringID), "attendees.unique_index")
+		}
+	}
+	return
+}
+
+func (s *Store) createAttendee(memberID int64, gatheringID int64) (err error) {
+	if err = s.checkAttendee(memberID, gatheringID, nil); err != nil {
+		return
+	}
+	s.attendees = append(s.attendees, attendee{memberID: memberID, gatheringID: gatheringID})
+	return
+}
+
+func (s *Store) removeAttendee(memberID int64, gatheringID int64) {
+	attendees := []attendee{}
+	for _, a := range s.attendees {
+		if a.memberID != memberID || a.gatheringID != gatheringID {
+			attendees = append(attendees, a)
+		}
+	}
+	s.attendees = attendees
+}
+
+// purgeRelations removes invitations, attendees, group members and group links matching a purged member or gathering, caller must hold the lock
+func (s *Store) purgeRelations(purged func(memberID int64, gatheringID int64) bool) {
+	attendees := []attendee{}
+	for _, a := range s.attendees {
+		if !purged(a.memberID, a.gatheringID) {
+			attendees = append(attendees, a)
+		}
+	}
+	s.attendees = attendees
+	for id, inv := range s.invitations {
+		if purged(inv.MemberID, inv.GatheringID) {
+			delete(s.invitations, id)
+		}
+	}
+	for id, g := range s.groups {
+		members := []domain.GroupMember{}
+		for _, m := range g.Members {
+			if !purged(m.MemberID, 0) {
+				members = append(members, m)
+			}
+		}
+		gatheringIDs := []int64{}
+		for _, gatheringID := range g.GatheringIDs {
+			if !purged(0, gatheringID) {
+				gatheringIDs = append(gatheringIDs, gatheringID)
+			}
+		}
+		g.Members, g.GatheringIDs = members, gatheringIDs
+		s.groups[id] = g
+	}
+}
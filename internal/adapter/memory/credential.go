@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"log"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+)
+
+type (
+	credentialAdapterRepository struct {
+		store *Store
+	}
+
+	CredentialAdapterRepositoryArgs struct {
+		Store *Store
+	}
+)
+
+func NewCredentialRepository(args CredentialAdapterRepositoryArgs) repository.ICredential {
+	return &credentialAdapterRepository{
+		store: args.Store,
+	}
+}
+
+// Save creates the member credential or replaces its password hash
+func (r *credentialAdapterRepository) Save(ctx context.Context, credential domain.Credential) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if _, ok := r.store.members[credential.MemberID]; !ok {
+		err = foreignKeyError("member_credentials", "member_id")
+		log.Println(err)
+		return
+	}
+	existing, ok := r.store.credentials[credential.MemberID]
+	if ok {
+		existing.PasswordHash = credential.PasswordHash
+		r.store.credentials[credential.MemberID] = existing
+		return
+	}
+	r.store.credentials[credential.MemberID] = domain.Credential{
+		MemberID:     credential.MemberID,
+		PasswordHash: credential.PasswordHash,
+		CreatedAt:    now(),
+	}
+	return
+}
+
+func (r *credentialAdapterRepository) Get(ctx context.Context, args domain.CredentialArgs) (credentials []domain.Credential, err error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	credentials = []domain.Credential{}
+	if credential, ok := r.store.credentials[args.MemberID]; ok {
+		credentials = append(credentials, credential)
+	}
+	return
+}
@@ -0,0 +1,53 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/memory"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_credentialAdapterRepository_Save(t *testing.T) {
+	repo := memory.NewCredentialRepository(memory.CredentialAdapterRepositoryArgs{
+		Store: seed(t),
+	})
+	tests := []struct {
+		name       string
+		credential domain.Credential
+		wantErr    bool
+	}{
+		{
+			name:       "create",
+			credential: domain.Credential{MemberID: 1, PasswordHash: "hash"},
+		},
+		{
+			name:       "replace",
+			credential: domain.Credential{MemberID: 1, PasswordHash: "new hash"},
+		},
+		{
+			name:       "unknown member",
+			credential: domain.Credential{MemberID: 99, PasswordHash: "hash"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repo.Save(context.Background(), tt.credential)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			// check data
+			credentials, err := repo.Get(context.Background(), domain.CredentialArgs{MemberID: tt.credential.MemberID})
+			require.NoError(t, err)
+			require.Len(t, credentials, 1)
+			require.Equal(t, tt.credential.PasswordHash, credentials[0].PasswordHash)
+		})
+	}
+	credentials, err := repo.Get(context.Background(), domain.CredentialArgs{MemberID: 2})
+	require.NoError(t, err)
+	require.Empty(t, credentials)
+}
@@ -0,0 +1,373 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+type (
+	gatheringAdapterRepository struct {
+		store *Store
+	}
+
+	GatheringAdapterRepositoryArgs struct {
+		Store *Store
+	}
+)
+
+func NewGatheringRepository(args GatheringAdapterRepositoryArgs) repository.IGathering {
+	return &gatheringAdapterRepository{
+		store: args.Store,
+	}
+}
+
+func (r *gatheringAdapterRepository) Create(ctx context.Context, gathering domain.Gathering) (id int64, err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if _, ok := r.store.members[gathering.Creator.ID]; !ok {
+		err = foreignKeyError("gatherings", "creator")
+		log.Println(err)
+		return
+	}
+	if gathering.CalendarUID != "" {
+		for _, g := range r.store.gatherings {
+			if g.CreatorID == gathering.Creator.ID && g.CalendarUID == gathering.CalendarUID {
+				err = duplicateEntryError("gathering already exists", gathering.CalendarUID, "gatherings.unique_creator_calendar_uid")
+				log.Println(err)
+				return
+			}
+		}
+	}
+	// validate attendees first so a failure leaves nothing behind, like a rolled back transaction
+	seen := map[int64]bool{}
+	for _, member := range gathering.Attendees {
+		if err = r.store.checkAttendee(member.ID, 0, seen); err != nil {
+			log.Println(err)
+			return
+		}
+		seen[member.ID] = true
+	}
+	id = r.store.nextID("gatherings")
+	r.store.gatherings[id] = domain.Gathering{
+		ID:          id,
+		CreatorID:   gathering.Creator.ID,
+		Type:        gathering.Type,
+		ScheduledAt: gathering.ScheduledAt.UTC().Truncate(time.Second),
+		EndsAt:      utcTime(gathering.EndsAt),
+		TimeZone:    gathering.TimeZone,
+		Recurrence:  gathering.Recurrence,
+		CalendarUID: gathering.CalendarUID,
+		Name:        gathering.Name,
+		Location:    gathering.Location,
+		Capacity:    gathering.Capacity,
+		CreatedAt:   now(),
+	}
+	for _, member := range gathering.Attendees {
+		r.store.attendees = append(r.store.attendees, attendee{memberID: member.ID, gatheringID: id})
+	}
+	for _, exception := range gathering.Exceptions {
+		exception.GatheringID = id
+		r.store.saveException(exception)
+	}
+	return
+}
+
+func (r *gatheringAdapterRepository) Get(ctx context.Context, args domain.GatheringArgs) (gatherings []domain.Gathering, err error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	gatherings = r.store.filterGatherings(args)
+	gatherings, err = paginate(gatherings, args.Pagination, func(g domain.Gathering) int64 { return g.ID })
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for i, g := range gatherings {
+		g.Creator.ID = g.CreatorID
+		// copied so callers cannot change the stored exceptions
+		g.Exceptions = append([]domain.Occurrence(nil), g.Exceptions...)
+		g.Localize()
+		for _, a := range r.store.attendees {
+			if a.gatheringID == g.ID {
+				g.Attendees = append(g.Attendees, domain.Member{ID: a.memberID})
+			}
+		}
+		gatherings[i] = g
+	}
+	return
+}
+
+func (r *gatheringAdapterRepository) Count(ctx context.Context, args domain.GatheringArgs) (total int64, err error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	total = int64(len(r.store.filterGatherings(args)))
+	return
+}
+
+func (r *gatheringAdapterRepository) Update(ctx context.Context, gathering domain.Gathering, notifications ...domain.Notification) (promotedIDs []int64, err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	current, ok := r.store.gatherings[gathering.ID]
+	if !ok {
+		return
+	}
+	current.Type = gathering.Type
+	current.ScheduledAt = gathering.ScheduledAt.UTC().Truncate(time.Second)
+	current.EndsAt = utcTime(gathering.EndsAt)
+	current.TimeZone = gathering.TimeZone
+	current.Recurrence = gathering.Recurrence
+	current.Name = gathering.Name
+	current.Location = gathering.Location
+	current.Capacity = gathering.Capacity
+	r.store.gatherings[gathering.ID] = current
+	// a raised capacity frees seats for the waitlist
+	promotedIDs = r.store.promoteWaitlist(gathering.ID)
+	r.store.saveNotifications(notifications)
+	return
+}
+
+// SaveOccurrence stores an edited or canceled occurrence of a series, replacing an earlier edit of it
+func (r *gatheringAdapterRepository) SaveOccurrence(ctx context.Context, occurrence domain.Occurrence, notifications ...domain.Notification) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if _, ok := r.store.gatherings[occurrence.GatheringID]; !ok {
+		err = foreignKeyError("gathering_exceptions", "gathering_id")
+		log.Println(err)
+		return
+	}
+	r.store.saveException(occurrence)
+	r.store.saveNotifications(notifications)
+	return
+}
+
+// Split ends the current series and creates the following one starting at splitAt.
+// Attendees and invitations of the current series are copied to the following one.
+func (r *gatheringAdapterRepository) Split(ctx context.Context, current domain.Gathering, following domain.Gathering, splitAt time.Time, notifications ...domain.Notification) (id int64, err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	stored, ok := r.store.gatherings[current.ID]
+	if !ok {
+		err = foreignKeyError("gatherings", "id")
+		log.Println(err)
+		return
+	}
+	stored.Recurrence = current.Recurrence
+	exceptions := []domain.Occurrence{}
+	for _, e := range stored.Exceptions {
+		if e.RecurrenceID.Before(splitAt) {
+			exceptions = append(exceptions, e)
+		}
+	}
+	stored.Exceptions = exceptions
+	r.store.gatherings[current.ID] = stored
+
+	id = r.store.nextID("gatherings")
+	r.store.gatherings[id] = domain.Gathering{
+		ID:          id,
+		CreatorID:   following.Creator.ID,
+		Type:        following.Type,
+		ScheduledAt: following.ScheduledAt.UTC().Truncate(time.Second),
+		EndsAt:      utcTime(following.EndsAt),
+		TimeZone:    following.TimeZone,
+		Recurrence:  following.Recurrence,
+		Name:        following.Name,
+		Location:    following.Location,
+		Capacity:    following.Capacity,
+		CreatedAt:   now(),
+	}
+	for _, a := range r.store.attendees {
+		if a.gatheringID == current.ID {
+			r.store.attendees = append(r.store.attendees, attendee{memberID: a.memberID, gatheringID: id})
+		}
+	}
+	for _, inv := range r.store.invitations {
+		if inv.GatheringID == current.ID {
+			invitationID := r.store.nextID("invitations")
+			r.store.invitations[invitationID] = domain.Invitation{
+				ID:           invitationID,
+				MemberID:     inv.MemberID,
+				GatheringID:  id,
+				Status:       inv.Status,
+				WaitlistedAt: inv.WaitlistedAt,
+				Guests:       inv.Guests,
+				Note:         inv.Note,
+				CreatedAt:    now(),
+			}
+		}
+	}
+	for _, exception := range following.Exceptions {
+		exception.GatheringID = id
+		r.store.saveException(exception)
+	}
+	// notifications tell about the following series
+	for i := range notifications {
+		notifications[i].Data.GatheringID = id
+	}
+	r.store.saveNotifications(notifications)
+	return
+}
+
+func (r *gatheringAdapterRepository) Delete(ctx context.Context, args domain.GatheringArgs, notifications ...domain.Notification) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	gathering, ok := r.store.gatherings[args.ID]
+	if !ok {
+		return
+	}
+	gathering.DiscardedAt = now()
+	r.store.gatherings[args.ID] = gathering
+	r.store.saveNotifications(notifications)
+	return
+}
+
+// Join makes the member an attendee when the gathering has a seat for them
+func (r *gatheringAdapterRepository) Join(ctx context.Context, gatheringID int64, memberID int64) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if !r.store.hasSeats(gatheringID, 1) {
+		return domain.NewError(domain.ErrConflict, "the gathering is full")
+	}
+	if err = r.store.createAttendee(memberID, gatheringID); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			err = domain.WrapError(domain.ErrConflict, "the member already attends the gathering", err)
+		}
+		log.Println(err)
+	}
+	return
+}
+
+// Leave removes the member from the attendees, an accepted invitation of the member is rejected so its guests free their seats too
+func (r *gatheringAdapterRepository) Leave(ctx context.Context, gatheringID int64, memberID int64) (promotedIDs []int64, err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.removeAttendee(memberID, gatheringID)
+	for id, inv := range r.store.invitations {
+		if inv.GatheringID == gatheringID && inv.MemberID == memberID && inv.Status == valueobject.INVITATION_ACCEPT {
+			inv.Status = valueobject.INVITATION_REJECT
+			r.store.invitations[id] = inv
+		}
+	}
+	promotedIDs = r.store.promoteWaitlist(gatheringID)
+	return
+}
+
+// Purge hard deletes gatherings discarded before the given time together with their invitations, attendees, occurrence exceptions and group links
+func (r *gatheringAdapterRepository) Purge(ctx context.Context, discardedBefore string) (total int64, err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	purged := map[int64]bool{}
+	for id, g := range r.store.gatherings {
+		if g.DiscardedAt != "" && g.DiscardedAt < discardedBefore {
+			purged[id] = true
+			delete(r.store.gatherings, id)
+		}
+	}
+	r.store.purgeRelations(func(memberID int64, gatheringID int64) bool { return purged[gatheringID] })
+	return int64(len(purged)), nil
+}
+
+func (s *Store) filterGatherings(args domain.GatheringArgs) (gatherings []domain.Gathering) {
+	gatherings = []domain.Gathering{}
+	for _, g := range s.gatherings {
+		if !args.IsIncludeDiscard && g.DiscardedAt != "" {
+			continue
+		}
+		if len(args.IDs) > 0 && !containsID(args.IDs, g.ID) {
+			continue
+		}
+		if len(args.MemberIDs) > 0 && !s.hasAnyAttendee(g.ID, args.MemberIDs) {
+			continue
+		}
+		if args.CreatorID > 0 && g.CreatorID != args.CreatorID {
+			continue
+		}
+		if args.IsVisibleOnly && !s.isVisible(g, args.ViewerID) {
+			continue
+		}
+		if args.CalendarUID != "" && g.CalendarUID != args.CalendarUID {
+			continue
+		}
+		if len(args.Types) > 0 && !containsType(args.Types, g.Type) {
+			continue
+		}
+		if args.Name != "" && !containsFold(g.Name, args.Name) {
+			continue
+		}
+		if args.Location != "" && !containsFold(g.Location, args.Location) {
+			continue
+		}
+		if !args.ScheduledFrom.IsZero() && g.ScheduledAt.Before(args.ScheduledFrom) && !(args.IsIncludeSeries && g.IsRecurring()) {
+			continue
+		}
+		if !args.ScheduledTo.IsZero() && g.ScheduledAt.After(args.ScheduledTo) {
+			continue
+		}
+		gatherings = append(gatherings, g)
+	}
+	return
+}
+
+func (s *Store) hasAnyAttendee(gatheringID int64, memberIDs []int64) bool {
+	for _, a := range s.attendees {
+		if a.gatheringID == gatheringID && containsID(memberIDs, a.memberID) {
+			return true
+		}
+	}
+	return false
+}
+
+// isVisible reports whether a gathering is public or the viewer created, attends or is invited to it
+func (s *Store) isVisible(g domain.Gathering, viewerID int64) bool {
+	if g.Type == valueobject.PUBLIC || g.CreatorID == viewerID || s.hasAnyAttendee(g.ID, []int64{viewerID}) {
+		return true
+	}
+	for _, inv := range s.invitations {
+		if inv.GatheringID == g.ID && inv.MemberID == viewerID {
+			return true
+		}
+	}
+	return false
+}
+
+func containsType(types []valueobject.GatheringType, t valueobject.GatheringType) bool {
+	for _, v := range types {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+// saveException stores times in UTC like Create does and replaces an exception with the same recurrence id,
+// caller must hold the write lock
+func (s *Store) saveException(occurrence domain.Occurrence) {
+	occurrence.RecurrenceID = occurrence.RecurrenceID.UTC().Truncate(time.Second)
+	occurrence.ScheduledAt = occurrence.ScheduledAt.UTC().Truncate(time.Second)
+	occurrence.EndsAt = utcTime(occurrence.EndsAt)
+	g := s.gatherings[occurrence.GatheringID]
+	exceptions := []domain.Occurrence{}
+	for _, e := range g.Exceptions {
+		if !e.RecurrenceID.Equal(occurrence.RecurrenceID) {
+			exceptions = append(exceptions, e)
+		}
+	}
+	exceptions = append(exceptions, occurrence)
+	sort.Slice(exceptions, func(i, j int) bool { return exceptions[i].RecurrenceID.Before(exceptions[j].RecurrenceID) })
+	g.Exceptions = exceptions
+	s.gatherings[occurrence.GatheringID] = g
+}
+
+// utcTime stores an optional time the way a timestamp column returns it, in UTC to the second
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC().Truncate(time.Second)
+	return &utc
+}
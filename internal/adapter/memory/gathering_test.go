@@ -0,0 +1,371 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/memory"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_gatheringAdapterRepository_Create(t *testing.T) {
+	gathering := domain.Gathering{
+		Creator:     domain.Member{ID: 1},
+		Type:        valueobject.PUBLIC,
+		ScheduledAt: time.Date(2023, 10, 7, 10, 0, 0, 0, time.UTC),
+		Name:        "Public Meeting",
+		Location:    "pramuka street",
+		Attendees:   []domain.Member{{ID: 1}, {ID: 2}},
+	}
+	duplicateAttendee := gathering
+	duplicateAttendee.Attendees = []domain.Member{{ID: 1}, {ID: 1}}
+	unknownCreator := gathering
+	unknownCreator.Creator = domain.Member{ID: 99}
+	type args struct {
+		gathering domain.Gathering
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantId  int64
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			args:   args{gathering: gathering},
+			wantId: 2,
+		},
+		{
+			name:    "duplicate attendee",
+			args:    args{gathering: duplicateAttendee},
+			wantErr: true,
+		},
+		{
+			name:    "unknown creator",
+			args:    args{gathering: unknownCreator},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewGatheringRepository(memory.GatheringAdapterRepositoryArgs{
+				Store: seed(t),
+			})
+			gotId, err := repo.Create(context.Background(), tt.args.gathering)
+			if tt.wantErr {
+				require.Error(t, err)
+				total, err := repo.Count(context.Background(), domain.GatheringArgs{})
+				require.NoError(t, err)
+				require.Equal(t, int64(1), total)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantId, gotId)
+				gatherings, err := repo.Get(context.Background(), domain.GatheringArgs{IDs: []int64{gotId}})
+				require.NoError(t, err)
+				require.Equal(t, time.Date(2023, 10, 7, 10, 0, 0, 0, time.UTC), gatherings[0].ScheduledAt)
+				require.Equal(t, tt.args.gathering.Attendees, gatherings[0].Attendees)
+			}
+		})
+	}
+}
+
+func Test_gatheringAdapterRepository_Get(t *testing.T) {
+	type args struct {
+		args domain.GatheringArgs
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantIDs []int64
+	}{
+		{
+			name:    "success",
+			wantIDs: []int64{1, 2},
+		},
+		{
+			name: "success filter by attendee",
+			args: args{domain.GatheringArgs{
+				MemberIDs: []int64{2},
+			}},
+			wantIDs: []int64{2},
+		},
+		{
+			name: "success filter by type and schedule",
+			args: args{domain.GatheringArgs{
+				Types:         []valueobject.GatheringType{valueobject.PRIVATE},
+				ScheduledFrom: time.Date(2023, 10, 6, 0, 0, 0, 0, time.UTC),
+				ScheduledTo:   time.Date(2023, 10, 6, 23, 59, 0, 0, time.UTC),
+			}},
+			wantIDs: []int64{1},
+		},
+		{
+			name: "success sort by scheduled at descending",
+			args: args{domain.GatheringArgs{
+				Pagination: domain.Pagination{Sort: "-scheduled_at"},
+			}},
+			wantIDs: []int64{2, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := seed(t)
+			repo := memory.NewGatheringRepository(memory.GatheringAdapterRepositoryArgs{
+				Store: store,
+			})
+			_, err := repo.Create(context.Background(), domain.Gathering{
+				Creator:     domain.Member{ID: 2},
+				Type:        valueobject.PUBLIC,
+				ScheduledAt: time.Date(2023, 10, 7, 10, 0, 0, 0, time.UTC),
+				Name:        "Public Meeting",
+				Location:    "sudirman street",
+				Attendees:   []domain.Member{{ID: 2}},
+			})
+			require.NoError(t, err)
+			gotGatherings, err := repo.Get(context.Background(), tt.args.args)
+			require.NoError(t, err)
+			gotIDs := []int64{}
+			for _, g := range gotGatherings {
+				gotIDs = append(gotIDs, g.ID)
+				require.Equal(t, g.CreatorID, g.Creator.ID)
+			}
+			require.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
+
+func Test_gatheringAdapterRepository_Update(t *testing.T) {
+	repo := memory.NewGatheringRepository(memory.GatheringAdapterRepositoryArgs{
+		Store: seed(t),
+	})
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	endsAt := time.Date(2023, 10, 8, 18, 0, 0, 0, jakarta)
+	_, err = repo.Update(context.Background(), domain.Gathering{
+		ID:          1,
+		Type:        valueobject.PUBLIC,
+		ScheduledAt: time.Date(2023, 10, 8, 16, 30, 0, 0, jakarta),
+		EndsAt:      &endsAt,
+		TimeZone:    "Asia/Jakarta",
+		Name:        "Update Private Meeting",
+		Location:    "update pramuka street",
+	})
+	require.NoError(t, err)
+	// check data
+	gatherings, err := repo.Get(context.Background(), domain.GatheringArgs{IDs: []int64{1}})
+	require.NoError(t, err)
+	require.Equal(t, "Update Private Meeting", gatherings[0].Name)
+	require.Equal(t, "2023-10-08T16:30:00+07:00", gatherings[0].ScheduledAt.Format(time.RFC3339))
+	require.Equal(t, "2023-10-08T18:00:00+07:00", gatherings[0].EndsAt.Format(time.RFC3339))
+	require.Equal(t, 90, gatherings[0].DurationMinutes)
+	require.Equal(t, valueobject.PUBLIC, gatherings[0].Type)
+	require.Equal(t, []domain.Member{{ID: 1}}, gatherings[0].Attendees)
+}
+
+func Test_gatheringAdapterRepository_Delete(t *testing.T) {
+	repo := memory.NewGatheringRepository(memory.GatheringAdapterRepositoryArgs{
+		Store: seed(t),
+	})
+	err := repo.Delete(context.Background(), domain.GatheringArgs{ID: 1})
+	require.NoError(t, err)
+	// check data
+	gatherings, err := repo.Get(context.Background(), domain.GatheringArgs{IDs: []int64{1}})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(gatherings))
+	gatherings, err = repo.Get(context.Background(), domain.GatheringArgs{IDs: []int64{1}, IsIncludeDiscard: true})
+	require.NoError(t, err)
+	require.NotEmpty(t, gatherings[0].DiscardedAt)
+}
+
+func Test_gatheringAdapterRepository_Purge(t *testing.T) {
+	store := seed(t)
+	repo := memory.NewGatheringRepository(memory.GatheringAdapterRepositoryArgs{Store: store})
+	invitationRepo := memory.NewInvitationRepository(memory.InvitationAdapterRepositoryArgs{Store: store})
+	ctx := context.Background()
+	require.NoError(t, repo.Delete(ctx, domain.GatheringArgs{ID: 1}))
+
+	total, err := repo.Purge(ctx, "2000-01-01 00:00:00")
+	require.NoError(t, err)
+	require.Equal(t, int64(0), total)
+
+	total, err = repo.Purge(ctx, "9999-12-31 00:00:00")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	// check data
+	gatherings, err := repo.Get(ctx, domain.GatheringArgs{IsIncludeDiscard: true})
+	require.NoError(t, err)
+	require.Empty(t, gatherings)
+	invitations, err := invitationRepo.Get(ctx, domain.InvitationArgs{GatheringID: 1})
+	require.NoError(t, err)
+	require.Empty(t, invitations)
+}
+
+func Test_gatheringAdapterRepository_Split(t *testing.T) {
+	store := seed(t)
+	repo := memory.NewGatheringRepository(memory.GatheringAdapterRepositoryArgs{Store: store})
+	invitationRepo := memory.NewInvitationRepository(memory.InvitationAdapterRepositoryArgs{Store: store})
+	ctx := context.Background()
+	start := time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC)
+	week := func(n int) time.Time { return start.AddDate(0, 0, 7*n) }
+	id, err := repo.Create(ctx, domain.Gathering{
+		Creator:     domain.Member{ID: 1},
+		ScheduledAt: start,
+		TimeZone:    domain.DefaultTimeZone,
+		Recurrence:  "FREQ=WEEKLY;COUNT=4",
+		Name:        "standup",
+		Location:    "room 1",
+		Attendees:   []domain.Member{{ID: 1}},
+		Exceptions:  []domain.Occurrence{{RecurrenceID: week(1), ScheduledAt: week(1), Name: "standup", Location: "room 1", Canceled: true}},
+	})
+	require.NoError(t, err)
+	_, err = invitationRepo.Create(ctx, domain.Invitation{Member: domain.Member{ID: 2}, Gathering: domain.Gathering{ID: id}})
+	require.NoError(t, err)
+	moved := domain.Occurrence{GatheringID: id, RecurrenceID: week(3), ScheduledAt: week(3).Add(time.Hour), Name: "standup", Location: "room 2"}
+	require.NoError(t, repo.SaveOccurrence(ctx, moved))
+	// saving again replaces the exception
+	moved.Location = "room 3"
+	require.NoError(t, repo.SaveOccurrence(ctx, moved))
+
+	gatherings, err := repo.Get(ctx, domain.GatheringArgs{IDs: []int64{id}})
+	require.NoError(t, err)
+	current := gatherings[0]
+	require.Equal(t, "FREQ=WEEKLY;COUNT=4", current.Recurrence)
+	require.Len(t, current.Exceptions, 2)
+	require.Equal(t, "room 3", current.Exceptions[1].Location)
+
+	following, ok, err := current.Split(week(2))
+	require.NoError(t, err)
+	require.True(t, ok)
+	following.Name = "retro"
+	followingID, err := repo.Split(ctx, current, following, week(2))
+	require.NoError(t, err)
+	// check data
+	gatherings, err = repo.Get(ctx, domain.GatheringArgs{IDs: []int64{id, followingID}})
+	require.NoError(t, err)
+	require.Len(t, gatherings, 2)
+	require.Equal(t, "FREQ=WEEKLY;COUNT=2", gatherings[0].Recurrence)
+	require.Len(t, gatherings[0].Exceptions, 1)
+	require.Equal(t, "FREQ=WEEKLY;COUNT=2", gatherings[1].Recurrence)
+	require.Equal(t, "retro", gatherings[1].Name)
+	require.True(t, week(2).Equal(gatherings[1].ScheduledAt))
+	require.Equal(t, []domain.Member{{ID: 1}}, gatherings[1].Attendees)
+	require.Len(t, gatherings[1].Exceptions, 1)
+	require.Equal(t, followingID, gatherings[1].Exceptions[0].GatheringID)
+	invitations, err := invitationRepo.Get(ctx, domain.InvitationArgs{GatheringID: followingID})
+	require.NoError(t, err)
+	require.Len(t, invitations, 1)
+}
+
+func Test_gatheringAdapterRepository_CalendarUID(t *testing.T) {
+	store := seed(t)
+	repo := memory.NewGatheringRepository(memory.GatheringAdapterRepositoryArgs{Store: store})
+	ctx := context.Background()
+	gathering := domain.Gathering{
+		Creator:     domain.Member{ID: 1},
+		ScheduledAt: time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC),
+		TimeZone:    domain.DefaultTimeZone,
+		CalendarUID: "standup@example.com",
+		Name:        "standup",
+		Location:    "room 1",
+	}
+	id, err := repo.Create(ctx, gathering)
+	require.NoError(t, err)
+	// the UID is unique per creator
+	_, err = repo.Create(ctx, gathering)
+	require.ErrorIs(t, err, domain.ErrConflict)
+	gathering.Creator.ID = 2
+	_, err = repo.Create(ctx, gathering)
+	require.NoError(t, err)
+	// check data
+	gatherings, err := repo.Get(ctx, domain.GatheringArgs{CreatorID: 1, CalendarUID: "standup@example.com"})
+	require.NoError(t, err)
+	require.Len(t, gatherings, 1)
+	require.Equal(t, id, gatherings[0].ID)
+	require.Equal(t, "standup@example.com", gatherings[0].CalendarUID)
+	gatherings, err = repo.Get(ctx, domain.GatheringArgs{IDs: []int64{1}})
+	require.NoError(t, err)
+	require.Empty(t, gatherings[0].CalendarUID)
+}
+
+func Test_gatheringAdapterRepository_IsVisibleOnly(t *testing.T) {
+	store := seed(t)
+	memberRepo := memory.NewMemberRepository(memory.MemberAdapterRepositoryArgs{Store: store})
+	repo := memory.NewGatheringRepository(memory.GatheringAdapterRepositoryArgs{Store: store})
+	ctx := context.Background()
+	_, err := memberRepo.Create(ctx, domain.Member{FirstName: "ken", Email: "ken@mail.com"})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, domain.Gathering{
+		Creator:     domain.Member{ID: 2},
+		Type:        valueobject.PUBLIC,
+		ScheduledAt: time.Date(2023, 10, 7, 10, 0, 0, 0, time.UTC),
+		Name:        "Public Meeting",
+		Location:    "sudirman street",
+	})
+	require.NoError(t, err)
+	// the seeded gathering 1 is private, created by member 1 who attends it, member 2 is invited
+	for viewerID, wantIDs := range map[int64][]int64{0: {2}, 1: {1, 2}, 2: {1, 2}, 3: {2}} {
+		args := domain.GatheringArgs{IsVisibleOnly: true, ViewerID: viewerID}
+		gatherings, err := repo.Get(ctx, args)
+		require.NoError(t, err)
+		gotIDs := []int64{}
+		for _, g := range gatherings {
+			gotIDs = append(gotIDs, g.ID)
+		}
+		require.Equal(t, wantIDs, gotIDs, "viewer %d", viewerID)
+		total, err := repo.Count(ctx, args)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(wantIDs)), total)
+	}
+}
+
+func Test_gatheringAdapterRepository_JoinLeave(t *testing.T) {
+	store := seed(t)
+	memberRepo := memory.NewMemberRepository(memory.MemberAdapterRepositoryArgs{Store: store})
+	invitationRepo := memory.NewInvitationRepository(memory.InvitationAdapterRepositoryArgs{Store: store})
+	repo := memory.NewGatheringRepository(memory.GatheringAdapterRepositoryArgs{Store: store})
+	ctx := context.Background()
+	_, err := memberRepo.Create(ctx, domain.Member{FirstName: "ken", Email: "ken@mail.com"})
+	require.NoError(t, err)
+	gathering := domain.Gathering{
+		Creator:     domain.Member{ID: 1},
+		Type:        valueobject.PUBLIC,
+		ScheduledAt: time.Date(2030, 10, 7, 10, 0, 0, 0, time.UTC),
+		Name:        "Public Meeting",
+		Location:    "sudirman street",
+		Capacity:    2,
+		Attendees:   []domain.Member{{ID: 1}},
+	}
+	gathering.ID, err = repo.Create(ctx, gathering)
+	require.NoError(t, err)
+	checkAttendees := func(want []domain.Member) {
+		gatherings, err := repo.Get(ctx, domain.GatheringArgs{IDs: []int64{gathering.ID}})
+		require.NoError(t, err)
+		require.ElementsMatch(t, want, gatherings[0].Attendees)
+	}
+
+	require.NoError(t, repo.Join(ctx, gathering.ID, 2))
+	require.ErrorIs(t, repo.Join(ctx, gathering.ID, 2), domain.ErrConflict)
+	// the gathering is full, an accepted invitation is waitlisted
+	require.ErrorIs(t, repo.Join(ctx, gathering.ID, 3), domain.ErrConflict)
+	invitationID, err := invitationRepo.Create(ctx, domain.Invitation{Member: domain.Member{ID: 3}, Gathering: gathering})
+	require.NoError(t, err)
+	_, err = invitationRepo.UpdateStatus(ctx, domain.InvitationArgs{ID: invitationID, MemberID: 3, GatheringID: gathering.ID, Status: valueobject.INVITATION_ACCEPT})
+	require.NoError(t, err)
+	checkAttendees([]domain.Member{{ID: 1}, {ID: 2}})
+
+	// the freed seat goes to the waitlist
+	promotedIDs, err := repo.Leave(ctx, gathering.ID, 2)
+	require.NoError(t, err)
+	require.Equal(t, []int64{invitationID}, promotedIDs)
+	checkAttendees([]domain.Member{{ID: 1}, {ID: 3}})
+	// leaving rejects the accepted invitation
+	promotedIDs, err = repo.Leave(ctx, gathering.ID, 3)
+	require.NoError(t, err)
+	require.Empty(t, promotedIDs)
+	checkAttendees([]domain.Member{{ID: 1}})
+	invitations, err := invitationRepo.Get(ctx, domain.InvitationArgs{IDs: []int64{invitationID}})
+	require.NoError(t, err)
+	require.Equal(t, valueobject.INVITATION_REJECT, invitations[0].Status)
+}
@@ -0,0 +1,214 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+)
+
+type (
+	groupAdapterRepository struct {
+		store *Store
+	}
+
+	GroupAdapterRepositoryArgs struct {
+		Store *Store
+	}
+)
+
+func NewGroupRepository(args GroupAdapterRepositoryArgs) repository.IGroup {
+	return &groupAdapterRepository{
+		store: args.Store,
+	}
+}
+
+func (r *groupAdapterRepository) Create(ctx context.Context, group domain.Group) (id int64, err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	// check members first so a failure leaves nothing behind, like a rolled back transaction
+	seen := map[int64]bool{}
+	for _, m := range group.Members {
+		if err = r.store.checkGroupMember(m.MemberID); err != nil {
+			log.Println(err)
+			return
+		}
+		if seen[m.MemberID] {
+			err = duplicateEntryError("a member is listed more than once", fmt.Sprintf("%d", m.MemberID), "group_members.PRIMARY")
+			log.Println(err)
+			return
+		}
+		seen[m.MemberID] = true
+	}
+	id = r.store.nextID("member_groups")
+	created := domain.Group{
+		ID:           id,
+		Name:         group.Name,
+		Members:      []domain.GroupMember{},
+		GatheringIDs: []int64{},
+		CreatedAt:    now(),
+	}
+	for _, m := range group.Members {
+		created.Members = append(created.Members, domain.GroupMember{GroupID: id, MemberID: m.MemberID, Role: m.Role, CreatedAt: created.CreatedAt})
+	}
+	r.store.groups[id] = created
+	return
+}
+
+func (r *groupAdapterRepository) Get(ctx context.Context, args domain.GroupArgs) (groups []domain.Group, err error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	groups = r.store.filterGroups(args)
+	groups, err = paginate(groups, args.Pagination, func(g domain.Group) int64 { return g.ID })
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+func (r *groupAdapterRepository) Count(ctx context.Context, args domain.GroupArgs) (total int64, err error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	total = int64(len(r.store.filterGroups(args)))
+	return
+}
+
+func (r *groupAdapterRepository) Update(ctx context.Context, group domain.Group) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	current, ok := r.store.groups[group.ID]
+	if !ok {
+		return
+	}
+	current.Name = group.Name
+	r.store.groups[group.ID] = current
+	return
+}
+
+// Delete removes the group with its members and gathering links, invitations already sent to its members are kept
+func (r *groupAdapterRepository) Delete(ctx context.Context, args domain.GroupArgs) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	delete(r.store.groups, args.ID)
+	return
+}
+
+func (r *groupAdapterRepository) AddMember(ctx context.Context, member domain.GroupMember) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	group, ok := r.store.groups[member.GroupID]
+	if !ok {
+		err = foreignKeyError("group_members", "group_id")
+		log.Println(err)
+		return
+	}
+	if err = r.store.checkGroupMember(member.MemberID); err != nil {
+		log.Println(err)
+		return
+	}
+	if _, ok := group.Member(member.MemberID); ok {
+		err = duplicateEntryError("the member is already in the group", fmt.Sprintf("%d-%d", member.GroupID, member.MemberID), "group_members.PRIMARY")
+		log.Println(err)
+		return
+	}
+	member.CreatedAt = now()
+	group.Members = append(copyGroupMembers(group.Members), member)
+	r.store.groups[group.ID] = group
+	return
+}
+
+// UpdateMember changes the role of a member of the group
+func (r *groupAdapterRepository) UpdateMember(ctx context.Context, member domain.GroupMember) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	group, ok := r.store.groups[member.GroupID]
+	if !ok {
+		return
+	}
+	group.Members = copyGroupMembers(group.Members)
+	for i, m := range group.Members {
+		if m.MemberID == member.MemberID {
+			group.Members[i].Role = member.Role
+		}
+	}
+	r.store.groups[group.ID] = group
+	return
+}
+
+func (r *groupAdapterRepository) RemoveMember(ctx context.Context, member domain.GroupMember) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	group, ok := r.store.groups[member.GroupID]
+	if !ok {
+		return
+	}
+	members := []domain.GroupMember{}
+	for _, m := range group.Members {
+		if m.MemberID != member.MemberID {
+			members = append(members, m)
+		}
+	}
+	group.Members = members
+	r.store.groups[group.ID] = group
+	return
+}
+
+// AddGathering links a gathering to the group for auto invites, a gathering linked before is left as is
+func (r *groupAdapterRepository) AddGathering(ctx context.Context, groupID int64, gatheringID int64) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	group, ok := r.store.groups[groupID]
+	if !ok {
+		err = foreignKeyError("group_gatherings", "group_id")
+		log.Println(err)
+		return
+	}
+	if _, ok := r.store.gatherings[gatheringID]; !ok {
+		err = foreignKeyError("group_gatherings", "gathering_id")
+		log.Println(err)
+		return
+	}
+	if containsID(group.GatheringIDs, gatheringID) {
+		return
+	}
+	group.GatheringIDs = append(append([]int64{}, group.GatheringIDs...), gatheringID)
+	r.store.groups[groupID] = group
+	return
+}
+
+// checkGroupMember applies the member foreign key of group members, caller must hold the lock
+func (s *Store) checkGroupMember(memberID int64) (err error) {
+	if _, ok := s.members[memberID]; !ok {
+		return foreignKeyError("group_members", "member_id")
+	}
+	return
+}
+
+func (s *Store) filterGroups(args domain.GroupArgs) (groups []domain.Group) {
+	groups = []domain.Group{}
+	for _, g := range s.groups {
+		if len(args.IDs) > 0 && !containsID(args.IDs, g.ID) {
+			continue
+		}
+		if args.ID > 0 && g.ID != args.ID {
+			continue
+		}
+		if _, ok := g.Member(args.MemberID); args.MemberID > 0 && !ok {
+			continue
+		}
+		if args.Name != "" && !containsFold(g.Name, args.Name) {
+			continue
+		}
+		// callers get their own copy of the slices
+		g.Members = copyGroupMembers(g.Members)
+		g.GatheringIDs = append([]int64{}, g.GatheringIDs...)
+		groups = append(groups, g)
+	}
+	return
+}
+
+func copyGroupMembers(members []domain.GroupMember) []domain.GroupMember {
+	return append([]domain.GroupMember{}, members...)
+}
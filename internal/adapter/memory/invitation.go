@@ -0,0 +1,192 @@
+package memory
+
+import (
+	"context"
+	"log"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+type (
+	invitationAdapterRepository struct {
+		store *Store
+	}
+
+	InvitationAdapterRepositoryArgs struct {
+		Store *Store
+	}
+)
+
+func NewInvitationRepository(args InvitationAdapterRepositoryArgs) repository.IInvitation {
+	return &invitationAdapterRepository{
+		store: args.Store,
+	}
+}
+
+func (r *invitationAdapterRepository) Create(ctx context.Context, invitation domain.Invitation, notifications ...domain.Notification) (id int64, err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err = r.store.checkInvitation(invitation); err != nil {
+		log.Println(err)
+		return
+	}
+	id = r.store.createInvitation(invitation)
+	for i := range notifications {
+		notifications[i].Data.InvitationID = id
+	}
+	r.store.saveNotifications(notifications)
+	return
+}
+
+// CreateBatch checks every invitation before creating any, so a failed batch leaves the store unchanged.
+// The invitation ID of each notification is set to the invitation created for its recipient and gathering.
+func (r *invitationAdapterRepository) CreateBatch(ctx context.Context, invitations []domain.Invitation, notifications ...domain.Notification) (ids []int64, err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, invitation := range invitations {
+		if err = r.store.checkInvitation(invitation); err != nil {
+			log.Println(err)
+			return
+		}
+	}
+	for _, invitation := range invitations {
+		ids = append(ids, r.store.createInvitation(invitation))
+	}
+	for i, n := range notifications {
+		for j, invitation := range invitations {
+			if invitation.Member.ID == n.MemberID && invitation.Gathering.ID == n.Data.GatheringID {
+				notifications[i].Data.InvitationID = ids[j]
+			}
+		}
+	}
+	r.store.saveNotifications(notifications)
+	return
+}
+
+// checkInvitation applies the foreign keys of invitations, caller must hold the lock
+func (s *Store) checkInvitation(invitation domain.Invitation) (err error) {
+	if _, ok := s.members[invitation.Member.ID]; !ok {
+		return foreignKeyError("invitations", "member_id")
+	}
+	if _, ok := s.gatherings[invitation.Gathering.ID]; !ok {
+		return foreignKeyError("invitations", "gathering_id")
+	}
+	return
+}
+
+// createInvitation stores a checked invitation, caller must hold the lock
+func (s *Store) createInvitation(invitation domain.Invitation) (id int64) {
+	id = s.nextID("invitations")
+	s.invitations[id] = domain.Invitation{
+		ID:           id,
+		MemberID:     invitation.Member.ID,
+		GatheringID:  invitation.Gathering.ID,
+		Status:       invitation.Status,
+		WaitlistedAt: invitation.WaitlistedAt,
+		Guests:       invitation.Guests,
+		Note:         invitation.Note,
+		CreatedAt:    now(),
+	}
+	return
+}
+
+func (r *invitationAdapterRepository) Get(ctx context.Context, args domain.InvitationArgs) (invitations []domain.Invitation, err error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	invitations = r.store.filterInvitations(args)
+	invitations, err = paginate(invitations, args.Pagination, func(inv domain.Invitation) int64 { return inv.ID })
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for i, inv := range invitations {
+		inv.Member.ID = inv.MemberID
+		inv.Gathering.ID = inv.GatheringID
+		invitations[i] = inv
+	}
+	return
+}
+
+func (r *invitationAdapterRepository) Count(ctx context.Context, args domain.InvitationArgs) (total int64, err error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	total = int64(len(r.store.filterInvitations(args)))
+	return
+}
+
+// UpdateStatus holds the store lock for the whole change, so status, response and attendees change together. An accept the
+// gathering has no seats for, guests included, is stored as waitlisted. A reject, cancel or tentative answer promotes waitlisted invitations.
+func (r *invitationAdapterRepository) UpdateStatus(ctx context.Context, args domain.InvitationArgs, notifications ...domain.Notification) (promotedIDs []int64, err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	invitation, ok := r.store.invitations[args.ID]
+	if !ok {
+		return
+	}
+	status := args.Status
+	invitation.WaitlistedAt = ""
+	if status == valueobject.INVITATION_ACCEPT && !r.store.hasSeats(args.GatheringID, 1+args.Response.Guests) {
+		status, invitation.WaitlistedAt = valueobject.INVITATION_WAITLISTED, now()
+	}
+	frees := status == valueobject.INVITATION_REJECT || status == valueobject.INVITATION_CANCELED || status == valueobject.INVITATION_TENTATIVE
+	if status == valueobject.INVITATION_ACCEPT {
+		err = r.store.createAttendee(args.MemberID, args.GatheringID)
+		if err != nil {
+			for _, a := range r.store.attendees {
+				if a.memberID == args.MemberID && a.gatheringID == args.GatheringID {
+					err = domain.NewError(domain.ErrConflict, "the member has accepted the invitation")
+				}
+			}
+			log.Println(err)
+			return
+		}
+	} else if frees {
+		r.store.removeAttendee(args.MemberID, args.GatheringID)
+	}
+	invitation.Status = status
+	invitation.Guests = args.Response.Guests
+	invitation.Note = args.Response.Note
+	r.store.invitations[args.ID] = invitation
+	if frees {
+		promotedIDs = r.store.promoteWaitlist(args.GatheringID)
+	}
+	r.store.saveNotifications(notifications)
+	return
+}
+
+func (s *Store) filterInvitations(args domain.InvitationArgs) (invitations []domain.Invitation) {
+	invitations = []domain.Invitation{}
+	for _, inv := range s.invitations {
+		if len(args.IDs) > 0 && !containsID(args.IDs, inv.ID) {
+			continue
+		}
+		if args.MemberID > 0 && inv.MemberID != args.MemberID {
+			continue
+		}
+		if args.GatheringID > 0 && inv.GatheringID != args.GatheringID {
+			continue
+		}
+		if len(args.GatheringIDs) > 0 && !containsID(args.GatheringIDs, inv.GatheringID) {
+			continue
+		}
+		if len(args.Statuses) > 0 && !containsStatus(args.Statuses, inv.Status) {
+			continue
+		}
+		if g, ok := s.gatherings[inv.GatheringID]; args.IsVisibleOnly && (!ok || !s.isVisible(g, args.ViewerID)) {
+			continue
+		}
+		invitations = append(invitations, inv)
+	}
+	return
+}
+
+func containsStatus(statuses []valueobject.InvitationStatus, status valueobject.InvitationStatus) bool {
+	for _, v := range statuses {
+		if v == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+)
+
+type (
+	leaseAdapterRepository struct {
+		store *Store
+	}
+
+	LeaseAdapterRepositoryArgs struct {
+		Store *Store
+	}
+)
+
+func NewLeaseRepository(args LeaseAdapterRepositoryArgs) repository.ILease {
+	return &leaseAdapterRepository{
+		store: args.Store,
+	}
+}
+
+func (r *leaseAdapterRepository) Acquire(ctx context.Context, name string, holder string, ttl time.Duration) (acquired bool, err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	now := time.Now()
+	if current, ok := r.store.leases[name]; ok && current.holder != holder && current.expiresAt.After(now) {
+		return
+	}
+	r.store.leases[name] = lease{holder: holder, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+func (r *leaseAdapterRepository) Release(ctx context.Context, name string, holder string) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if current, ok := r.store.leases[name]; ok && current.holder == holder {
+		delete(r.store.leases, name)
+	}
+	return
+}
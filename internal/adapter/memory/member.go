@@ -0,0 +1,170 @@
+package memory
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+type (
+	memberAdapterRepository struct {
+		store *Store
+	}
+
+	MemberAdapterRepositoryArgs struct {
+		Store *Store
+	}
+)
+
+func NewMemberRepository(args MemberAdapterRepositoryArgs) repository.IMember {
+	return &memberAdapterRepository{
+		store: args.Store,
+	}
+}
+
+func (r *memberAdapterRepository) Create(ctx context.Context, member domain.Member) (id int64, err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err = r.store.checkUniqueEmail(member); err != nil {
+		log.Println(err)
+		return
+	}
+	id = r.store.nextID("members")
+	r.store.members[id] = domain.Member{
+		ID:        id,
+		FirstName: member.FirstName,
+		LastName:  member.LastName,
+		Email:     member.Email,
+		Role:      valueobject.ROLE_MEMBER,
+		CreatedAt: now(),
+	}
+	return
+}
+
+func (r *memberAdapterRepository) Get(ctx context.Context, args domain.MemberArgs) (members []domain.Member, err error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	members = r.store.filterMembers(args)
+	members, err = paginate(members, args.Pagination, func(m domain.Member) int64 { return m.ID })
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+func (r *memberAdapterRepository) Count(ctx context.Context, args domain.MemberArgs) (total int64, err error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	total = int64(len(r.store.filterMembers(args)))
+	return
+}
+
+func (r *memberAdapterRepository) Update(ctx context.Context, member domain.Member) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	current, ok := r.store.members[member.ID]
+	if !ok {
+		return
+	}
+	if err = r.store.checkUniqueEmail(member); err != nil {
+		log.Println(err)
+		return
+	}
+	current.FirstName = member.FirstName
+	current.LastName = member.LastName
+	current.Email = member.Email
+	r.store.members[member.ID] = current
+	return
+}
+
+func (r *memberAdapterRepository) Delete(ctx context.Context, args domain.MemberArgs) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	member, ok := r.store.members[args.ID]
+	if !ok {
+		return
+	}
+	member.DiscardedAt = now()
+	r.store.members[args.ID] = member
+	return
+}
+
+// UpdateRole changes the role of a member, it is kept apart from Update so a member cannot change their own role
+func (r *memberAdapterRepository) UpdateRole(ctx context.Context, member domain.Member) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	current, ok := r.store.members[member.ID]
+	if !ok {
+		return
+	}
+	current.Role = member.Role
+	r.store.members[member.ID] = current
+	return
+}
+
+// Purge hard deletes members discarded before the given time together with their invitations, attendances, credentials and group memberships,
+// a member who still is creator of a gathering is kept
+func (r *memberAdapterRepository) Purge(ctx context.Context, discardedBefore string) (total int64, err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	creators := map[int64]bool{}
+	for _, g := range r.store.gatherings {
+		creators[g.CreatorID] = true
+	}
+	purged := map[int64]bool{}
+	for id, m := range r.store.members {
+		if m.DiscardedAt != "" && m.DiscardedAt < discardedBefore && !creators[id] {
+			purged[id] = true
+			delete(r.store.members, id)
+			delete(r.store.credentials, id)
+		}
+	}
+	r.store.purgeRelations(func(memberID int64, gatheringID int64) bool { return purged[memberID] })
+	return int64(len(purged)), nil
+}
+
+// checkUniqueEmail mimics unique_email index, it also applies to discarded members
+func (s *Store) checkUniqueEmail(member domain.Member) (err error) {
+	for _, m := range s.members {
+		if m.ID != member.ID && strings.EqualFold(m.Email, member.Email) {
+			return duplicateEntryError("email is already used", member.Email, "members.unique_email")
+		}
+	}
+	return
+}
+
+func (s *Store) filterMembers(args domain.MemberArgs) (members []domain.Member) {
+	members = []domain.Member{}
+	for _, m := range s.members {
+		if !args.IsIncludeDiscard && m.DiscardedAt != "" {
+			continue
+		}
+		if len(args.IDs) > 0 && !containsID(args.IDs, m.ID) {
+			continue
+		}
+		if args.Email != "" && !strings.EqualFold(m.Email, args.Email) {
+			continue
+		}
+		if (len(args.AnyIDs) > 0 || len(args.AnyEmails) > 0) && !containsID(args.AnyIDs, m.ID) && !containsEmail(args.AnyEmails, m.Email) {
+			continue
+		}
+		if args.Name != "" && !containsFold(m.FirstName, args.Name) && !containsFold(m.LastName, args.Name) {
+			continue
+		}
+		members = append(members, m)
+	}
+	return
+}
+
+func containsEmail(emails []string, email string) bool {
+	for _, e := range emails {
+		if strings.EqualFold(e, email) {
+			return true
+		}
+	}
+	return false
+}
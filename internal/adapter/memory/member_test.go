@@ -0,0 +1,258 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/memory"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/require"
+)
+
+// seed creates the same rows as test data.sql
+func seed(t *testing.T) *memory.Store {
+	store := memory.NewStore()
+	ctx := context.Background()
+	memberRepo := memory.NewMemberRepository(memory.MemberAdapterRepositoryArgs{Store: store})
+	gatheringRepo := memory.NewGatheringRepository(memory.GatheringAdapterRepositoryArgs{Store: store})
+	invitationRepo := memory.NewInvitationRepository(memory.InvitationAdapterRepositoryArgs{Store: store})
+	_, err := memberRepo.Create(ctx, domain.Member{FirstName: "linus", LastName: "torvalds", Email: "linus@mail.com"})
+	require.NoError(t, err)
+	_, err = memberRepo.Create(ctx, domain.Member{FirstName: "ron", LastName: "west", Email: "ron@mail.com"})
+	require.NoError(t, err)
+	_, err = gatheringRepo.Create(ctx, domain.Gathering{
+		Creator:     domain.Member{ID: 1},
+		Type:        valueobject.PRIVATE,
+		ScheduledAt: time.Date(2023, 10, 6, 5, 0, 0, 0, time.UTC),
+		Name:        "Private Meeting",
+		Location:    "pramuka street",
+		Attendees:   []domain.Member{{ID: 1}},
+	})
+	require.NoError(t, err)
+	_, err = invitationRepo.Create(ctx, domain.Invitation{
+		Member:    domain.Member{ID: 2},
+		Gathering: domain.Gathering{ID: 1},
+		Status:    valueobject.INVITATION_CREATED,
+	})
+	require.NoError(t, err)
+	return store
+}
+
+func Test_memberAdapterRepository_Create(t *testing.T) {
+	type args struct {
+		member domain.Member
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantId  int64
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				member: domain.Member{FirstName: "john", LastName: "doe", Email: "john@mail.com"},
+			},
+			wantId: 3,
+		},
+		{
+			name: "duplicate email",
+			args: args{
+				member: domain.Member{FirstName: "ron", Email: "ron@mail.com"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewMemberRepository(memory.MemberAdapterRepositoryArgs{
+				Store: seed(t),
+			})
+			gotId, err := repo.Create(context.Background(), tt.args.member)
+			if tt.wantErr {
+				require.ErrorIs(t, err, domain.ErrConflict)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantId, gotId)
+			}
+		})
+	}
+}
+
+func Test_memberAdapterRepository_Get(t *testing.T) {
+	type args struct {
+		args domain.MemberArgs
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantIDs []int64
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			wantIDs: []int64{1, 2},
+		},
+		{
+			name: "success with cursor",
+			args: args{domain.MemberArgs{
+				Pagination: domain.Pagination{Limit: 1, Cursor: domain.EncodeCursor("", 1)},
+			}},
+			wantIDs: []int64{2},
+		},
+		{
+			name: "success sort by name descending",
+			args: args{domain.MemberArgs{
+				Pagination: domain.Pagination{Sort: "-name"},
+			}},
+			wantIDs: []int64{2, 1},
+		},
+		{
+			name: "success filter by name",
+			args: args{domain.MemberArgs{
+				Name: "WES",
+			}},
+			wantIDs: []int64{2},
+		},
+		{
+			name: "success filter by any of ids or emails",
+			args: args{domain.MemberArgs{
+				AnyIDs:    []int64{1, 99},
+				AnyEmails: []string{"RON@mail.com", "nobody@mail.com"},
+			}},
+			wantIDs: []int64{1, 2},
+		},
+		{
+			name: "invalid cursor",
+			args: args{domain.MemberArgs{
+				Pagination: domain.Pagination{Cursor: "invalid"},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewMemberRepository(memory.MemberAdapterRepositoryArgs{
+				Store: seed(t),
+			})
+			gotMembers, err := repo.Get(context.Background(), tt.args.args)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				gotIDs := []int64{}
+				for _, m := range gotMembers {
+					gotIDs = append(gotIDs, m.ID)
+				}
+				require.Equal(t, tt.wantIDs, gotIDs)
+			}
+		})
+	}
+}
+
+func Test_memberAdapterRepository_Update(t *testing.T) {
+	type args struct {
+		member domain.Member
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				member: domain.Member{ID: 1, FirstName: "linus updated", LastName: "torvalds updated", Email: "updatedlinus@mail.com"},
+			},
+		},
+		{
+			name: "duplicate email",
+			args: args{
+				member: domain.Member{ID: 1, FirstName: "linus", Email: "ron@mail.com"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewMemberRepository(memory.MemberAdapterRepositoryArgs{
+				Store: seed(t),
+			})
+			err := repo.Update(context.Background(), tt.args.member)
+			if tt.wantErr {
+				require.ErrorIs(t, err, domain.ErrConflict)
+			} else {
+				require.NoError(t, err)
+				// check data
+				members, err := repo.Get(context.Background(), domain.MemberArgs{
+					IDs: []int64{tt.args.member.ID},
+				})
+				require.NoError(t, err)
+				require.Equal(t, tt.args.member.Email, members[0].Email)
+				require.Equal(t, tt.args.member.FirstName, members[0].FirstName)
+			}
+		})
+	}
+}
+
+func Test_memberAdapterRepository_UpdateRole(t *testing.T) {
+	repo := memory.NewMemberRepository(memory.MemberAdapterRepositoryArgs{
+		Store: seed(t),
+	})
+	// update keeps the role, only UpdateRole changes it
+	err := repo.Update(context.Background(), domain.Member{ID: 2, FirstName: "ron", Email: "ron@mail.com", Role: valueobject.ROLE_ADMIN})
+	require.NoError(t, err)
+	members, err := repo.Get(context.Background(), domain.MemberArgs{IDs: []int64{2}})
+	require.NoError(t, err)
+	require.Equal(t, valueobject.ROLE_MEMBER, members[0].Role)
+
+	err = repo.UpdateRole(context.Background(), domain.Member{ID: 2, Role: valueobject.ROLE_ADMIN})
+	require.NoError(t, err)
+	members, err = repo.Get(context.Background(), domain.MemberArgs{IDs: []int64{2}})
+	require.NoError(t, err)
+	require.Equal(t, valueobject.ROLE_ADMIN, members[0].Role)
+	require.Equal(t, "ron", members[0].FirstName)
+}
+
+func Test_memberAdapterRepository_Delete(t *testing.T) {
+	repo := memory.NewMemberRepository(memory.MemberAdapterRepositoryArgs{
+		Store: seed(t),
+	})
+	err := repo.Delete(context.Background(), domain.MemberArgs{ID: 1})
+	require.NoError(t, err)
+	// check data
+	members, err := repo.Get(context.Background(), domain.MemberArgs{IDs: []int64{1}})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(members))
+	members, err = repo.Get(context.Background(), domain.MemberArgs{IDs: []int64{1}, IsIncludeDiscard: true})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(members))
+	require.NotEmpty(t, members[0].DiscardedAt)
+	total, err := repo.Count(context.Background(), domain.MemberArgs{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+}
+
+func Test_memberAdapterRepository_Purge(t *testing.T) {
+	store := seed(t)
+	repo := memory.NewMemberRepository(memory.MemberAdapterRepositoryArgs{Store: store})
+	invitationRepo := memory.NewInvitationRepository(memory.InvitationAdapterRepositoryArgs{Store: store})
+	ctx := context.Background()
+	require.NoError(t, repo.Delete(ctx, domain.MemberArgs{ID: 1}))
+	require.NoError(t, repo.Delete(ctx, domain.MemberArgs{ID: 2}))
+
+	// member 1 is kept because it still creates gathering 1
+	total, err := repo.Purge(ctx, "9999-12-31 00:00:00")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	// check data
+	members, err := repo.Get(ctx, domain.MemberArgs{IsIncludeDiscard: true})
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	require.Equal(t, int64(1), members[0].ID)
+	invitations, err := invitationRepo.Get(ctx, domain.InvitationArgs{MemberID: 2})
+	require.NoError(t, err)
+	require.Empty(t, invitations)
+}
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+type (
+	notificationAdapterRepository struct {
+		store *Store
+	}
+
+	NotificationAdapterRepositoryArgs struct {
+		Store *Store
+	}
+)
+
+func NewNotificationRepository(args NotificationAdapterRepositoryArgs) repository.INotification {
+	return &notificationAdapterRepository{
+		store: args.Store,
+	}
+}
+
+func (r *notificationAdapterRepository) Get(ctx context.Context, args domain.NotificationArgs) (notifications []domain.Notification, err error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	notifications = []domain.Notification{}
+	for _, n := range r.store.notifications {
+		if len(args.IDs) > 0 && !containsID(args.IDs, n.ID) {
+			continue
+		}
+		if len(args.Statuses) > 0 && !containsNotificationStatus(args.Statuses, n.Status) {
+			continue
+		}
+		notifications = append(notifications, n)
+	}
+	sort.Slice(notifications, func(i, j int) bool { return notifications[i].ID < notifications[j].ID })
+	if args.Limit > 0 && len(notifications) > args.Limit {
+		notifications = notifications[:args.Limit]
+	}
+	return
+}
+
+func (r *notificationAdapterRepository) Update(ctx context.Context, notification domain.Notification) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	stored, ok := r.store.notifications[notification.ID]
+	if !ok {
+		return
+	}
+	stored.Status = notification.Status
+	stored.Attempts = notification.Attempts
+	stored.LastError = notification.LastError
+	stored.SentAt = notification.SentAt
+	r.store.notifications[notification.ID] = stored
+	return
+}
+
+// saveNotifications adds notifications to the outbox along with the change they tell about, caller must hold the write lock
+func (s *Store) saveNotifications(notifications []domain.Notification) {
+	for _, n := range notifications {
+		n.ID = s.nextID("notifications")
+		n.Attempts = 0
+		n.CreatedAt = now()
+		s.notifications[n.ID] = n
+	}
+}
+
+func containsNotificationStatus(statuses []valueobject.NotificationStatus, status valueobject.NotificationStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/memory"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_notificationAdapterRepository_outbox(t *testing.T) {
+	store := seed(t)
+	invitationRepo := memory.NewInvitationRepository(memory.InvitationAdapterRepositoryArgs{Store: store})
+	repo := memory.NewNotificationRepository(memory.NotificationAdapterRepositoryArgs{Store: store})
+	ctx := context.Background()
+	data := domain.NotificationData{GatheringID: 1, GatheringName: "standup", ActorID: 1}
+	pending := domain.NotificationArgs{Statuses: []valueobject.NotificationStatus{valueobject.NOTIFICATION_PENDING}}
+
+	// a failed invitation writes no notification
+	_, err := invitationRepo.Create(ctx, domain.Invitation{Member: domain.Member{ID: 2}, Gathering: domain.Gathering{ID: 99}},
+		domain.NewNotifications(domain.NotificationInvitationCreated, []int64{2}, data)...)
+	require.Error(t, err)
+	notifications, err := repo.Get(ctx, pending)
+	require.NoError(t, err)
+	require.Empty(t, notifications)
+
+	id, err := invitationRepo.Create(ctx, domain.Invitation{Member: domain.Member{ID: 2}, Gathering: domain.Gathering{ID: 1}},
+		domain.NewNotifications(domain.NotificationInvitationCreated, []int64{2}, data)...)
+	require.NoError(t, err)
+	notifications, err = repo.Get(ctx, pending)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	got := notifications[0]
+	require.Equal(t, int64(2), got.MemberID)
+	require.Equal(t, id, got.Data.InvitationID)
+
+	got.Sent(time.Now())
+	require.NoError(t, repo.Update(ctx, got))
+	notifications, err = repo.Get(ctx, pending)
+	require.NoError(t, err)
+	require.Empty(t, notifications)
+	notifications, err = repo.Get(ctx, domain.NotificationArgs{IDs: []int64{got.ID}})
+	require.NoError(t, err)
+	require.Equal(t, valueobject.NOTIFICATION_SENT, notifications[0].Status)
+}
@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+)
+
+type (
+	reminderAdapterRepository struct {
+		store *Store
+	}
+
+	ReminderAdapterRepositoryArgs struct {
+		Store *Store
+	}
+)
+
+func NewReminderRepository(args ReminderAdapterRepositoryArgs) repository.IReminder {
+	return &reminderAdapterRepository{
+		store: args.Store,
+	}
+}
+
+func (r *reminderAdapterRepository) Create(ctx context.Context, reminder domain.Reminder, notifications ...domain.Notification) (created bool, err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	key := reminderKey(reminder)
+	if _, ok := r.store.reminders[key]; ok {
+		return
+	}
+	reminder.CreatedAt = now()
+	r.store.reminders[key] = reminder
+	r.store.saveNotifications(notifications)
+	return true, nil
+}
+
+// reminderKey works like the primary key of the SQL adapters: gathering, start in UTC and offset
+func reminderKey(reminder domain.Reminder) string {
+	return fmt.Sprintf("%d/%s/%d", reminder.Occurrence.GatheringID, reminder.Occurrence.ScheduledAt.UTC().Format(timeFormat), int64(reminder.Offset.Seconds()))
+}
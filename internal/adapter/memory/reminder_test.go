@@ -0,0 +1,50 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/memory"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_reminderAdapterRepository_Create(t *testing.T) {
+	store := memory.NewStore()
+	repo := memory.NewReminderRepository(memory.ReminderAdapterRepositoryArgs{Store: store})
+	notificationRepo := memory.NewNotificationRepository(memory.NotificationAdapterRepositoryArgs{Store: store})
+	ctx := context.Background()
+
+	start := time.Date(2031, 5, 1, 10, 0, 0, 0, time.FixedZone("WIB", 7*60*60))
+	reminder := domain.Reminder{Occurrence: domain.Occurrence{GatheringID: 1, ScheduledAt: start, Name: "retro"}, Offset: time.Hour}
+	notifications := reminder.Notifications([]int64{1, 2}, nil)
+	created, err := repo.Create(ctx, reminder, notifications...)
+	require.NoError(t, err)
+	require.True(t, created)
+
+	// the same start in another zone is the same occurrence
+	reminder.Occurrence.ScheduledAt = start.UTC()
+	created, err = repo.Create(ctx, reminder, notifications...)
+	require.NoError(t, err)
+	require.False(t, created)
+	got, err := notificationRepo.Get(ctx, domain.NotificationArgs{})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+}
+
+func Test_leaseAdapterRepository(t *testing.T) {
+	repo := memory.NewLeaseRepository(memory.LeaseAdapterRepositoryArgs{Store: memory.NewStore()})
+	ctx := context.Background()
+
+	acquired, err := repo.Acquire(ctx, "reminders", "replica-a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	acquired, err = repo.Acquire(ctx, "reminders", "replica-b", time.Minute)
+	require.NoError(t, err)
+	require.False(t, acquired)
+	require.NoError(t, repo.Release(ctx, "reminders", "replica-a"))
+	acquired, err = repo.Acquire(ctx, "reminders", "replica-b", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+}
@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+const timeFormat = "2006-01-02 15:04:05"
+
+type (
+	// Store keeps every table in memory, it is shared by all repositories of this adapter
+	Store struct {
+		mu          sync.RWMutex
+		members     map[int64]domain.Member
+		gatherings  map[int64]domain.Gathering
+		invitations map[int64]domain.Invitation
+		credentials map[int64]domain.Credential
+		groups      map[int64]domain.Group
+		// notifications is the outbox
+		notifications map[int64]domain.Notification
+		webhooks      map[int64]domain.Webhook
+		// webhookDeliveries is the delivery log of every webhook
+		webhookDeliveries map[int64]domain.WebhookDelivery
+		// reminders are keyed by occurrence and offset, see reminderKey
+		reminders map[string]domain.Reminder
+		leases    map[string]lease
+		attendees []attendee
+		sequences map[string]int64
+	}
+
+	attendee struct {
+		memberID    int64
+		gatheringID int64
+	}
+
+	lease struct {
+		holder    string
+		expiresAt time.Time
+	}
+)
+
+func NewStore() *Store {
+	return &Store{
+		members:           map[int64]domain.Member{},
+		gatherings:        map[int64]domain.Gathering{},
+		invitations:       map[int64]domain.Invitation{},
+		credentials:       map[int64]domain.Credential{},
+		groups:            map[int64]domain.Group{},
+		notifications:     map[int64]domain.Notification{},
+		webhooks:          map[int64]domain.Webhook{},
+		webhookDeliveries: map[int64]domain.WebhookDelivery{},
+		reminders:         map[string]domain.Reminder{},
+		leases:            map[string]lease{},
+		attendees:         []attendee{},
+		sequences:         map[string]int64{},
+	}
+}
+
+// nextID works like AUTO_INCREMENT, caller must hold the write lock
+func (s *Store) nextID(table string) int64 {
+	s.sequences[table]++
+	return s.sequences[table]
+}
+
+func now() string {
+	return time.Now().UTC().Format(timeFormat)
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold works like LIKE '%substr%' on a case insensitive collation
+func containsFold(s string, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// paginate sorts rows, then applies cursor, offset and limit the same way the SQL adapters do
+func paginate[T interface{ CursorValue(field string) string }](rows []T, p domain.Pagination, id func(T) int64) (result []T, err error) {
+	var cursor domain.Cursor
+	if p.Cursor != "" {
+		if cursor, err = domain.DecodeCursor(p.Cursor); err != nil {
+			return
+		}
+	}
+	field, desc := p.SortField()
+	compare := func(value string, rowID int64, otherValue string, otherID int64) int {
+		if c := strings.Compare(strings.ToLower(value), strings.ToLower(otherValue)); c != 0 {
+			return c
+		}
+		switch {
+		case rowID < otherID:
+			return -1
+		case rowID > otherID:
+			return 1
+		}
+		return 0
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		c := compare(rows[i].CursorValue(field), id(rows[i]), rows[j].CursorValue(field), id(rows[j]))
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+	result = []T{}
+	for _, row := range rows {
+		if p.Cursor != "" {
+			c := compare(row.CursorValue(field), id(row), cursor.Value, cursor.ID)
+			if (!desc && c <= 0) || (desc && c >= 0) {
+				continue
+			}
+		}
+		result = append(result, row)
+	}
+	if p.Limit <= 0 {
+		return
+	}
+	if p.Offset >= len(result) {
+		return []T{}, nil
+	}
+	result = result[p.Offset:]
+	if len(result) > p.Limit {
+		result = result[:p.Limit]
+	}
+	return
+}
+
+// duplicateEntryError is a unique index violation classified like SQL adapters do, message is shown to clients
+func duplicateEntryError(message string, value string, key string) error {
+	return domain.WrapError(domain.ErrConflict, message, fmt.Errorf("duplicate entry '%s' for key '%s'", value, key))
+}
+
+// foreignKeyError is a foreign key violation classified like SQL adapters do
+func foreignKeyError(table string, column string) error {
+	return domain.WrapError(domain.ErrValidation, "referenced member or gathering does not exist",
+		fmt.Errorf("cannot add or update a child row: foreign key constraint fails on %s.%s", table, column))
+}
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+// hasSeats reports whether seats more people fit the gathering capacity, a capacity of 0 is unlimited.
+// Attendees and the guests of accepted invitations take seats, caller must hold the lock.
+func (s *Store) hasSeats(gatheringID int64, seats int) bool {
+	g, ok := s.gatherings[gatheringID]
+	if !ok || g.Capacity == 0 {
+		return true
+	}
+	headcount := 0
+	for _, a := range s.attendees {
+		if a.gatheringID == gatheringID {
+			headcount++
+		}
+	}
+	for _, inv := range s.invitations {
+		if inv.GatheringID == gatheringID && inv.Status == valueobject.INVITATION_ACCEPT {
+			headcount += inv.Guests
+		}
+	}
+	return headcount+seats <= g.Capacity
+}
+
+// promoteWaitlist accepts waitlisted invitations in waitlist order while the gathering has seats for the first one and its guests,
+// each promoted member is notified and the IDs of the promoted invitations are returned. Caller must hold the lock
+func (s *Store) promoteWaitlist(gatheringID int64) (promotedIDs []int64) {
+	promoted := []domain.Invitation{}
+	waitlist := []domain.Invitation{}
+	for _, inv := range s.invitations {
+		if inv.GatheringID == gatheringID && inv.Status == valueobject.INVITATION_WAITLISTED {
+			waitlist = append(waitlist, inv)
+		}
+	}
+	sort.Slice(waitlist, func(i, j int) bool {
+		if waitlist[i].WaitlistedAt != waitlist[j].WaitlistedAt {
+			return waitlist[i].WaitlistedAt < waitlist[j].WaitlistedAt
+		}
+		return waitlist[i].ID < waitlist[j].ID
+	})
+	for _, inv := range waitlist {
+		if !s.hasSeats(gatheringID, 1+inv.Guests) || s.createAttendee(inv.MemberID, gatheringID) != nil {
+			break
+		}
+		inv.Status = valueobject.INVITATION_ACCEPT
+		inv.WaitlistedAt = ""
+		s.invitations[inv.ID] = inv
+		promoted = append(promoted, inv)
+		promotedIDs = append(promotedIDs, inv.ID)
+	}
+	s.saveNotifications(s.gatherings[gatheringID].PromotedNotifications(promoted))
+	return
+}
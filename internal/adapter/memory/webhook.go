@@ -0,0 +1,176 @@
+package memory
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+type (
+	webhookAdapterRepository struct {
+		store *Store
+	}
+
+	WebhookAdapterRepositoryArgs struct {
+		Store *Store
+	}
+)
+
+func NewWebhookRepository(args WebhookAdapterRepositoryArgs) repository.IWebhook {
+	return &webhookAdapterRepository{
+		store: args.Store,
+	}
+}
+
+func (r *webhookAdapterRepository) Create(ctx context.Context, webhook domain.Webhook) (id int64, err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	id = r.store.nextID("webhooks")
+	webhook.ID = id
+	webhook.Events = append([]string{}, webhook.Events...)
+	webhook.CreatedAt = now()
+	r.store.webhooks[id] = webhook
+	return
+}
+
+func (r *webhookAdapterRepository) Get(ctx context.Context, args domain.WebhookArgs) (webhooks []domain.Webhook, err error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	webhooks = []domain.Webhook{}
+	for _, w := range r.store.webhooks {
+		if len(args.IDs) > 0 && !containsID(args.IDs, w.ID) {
+			continue
+		}
+		if args.IsEnabledOnly && w.IsDisabled {
+			continue
+		}
+		w.Events = append([]string{}, w.Events...)
+		webhooks = append(webhooks, w)
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+	return
+}
+
+func (r *webhookAdapterRepository) Update(ctx context.Context, webhook domain.Webhook) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	current, ok := r.store.webhooks[webhook.ID]
+	if !ok {
+		return
+	}
+	current.URL = webhook.URL
+	if webhook.Secret != "" {
+		current.Secret = webhook.Secret
+	}
+	current.Events = append([]string{}, webhook.Events...)
+	current.IsDisabled = webhook.IsDisabled
+	r.store.webhooks[webhook.ID] = current
+	return
+}
+
+func (r *webhookAdapterRepository) Delete(ctx context.Context, id int64) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for deliveryID, d := range r.store.webhookDeliveries {
+		if d.WebhookID == id {
+			delete(r.store.webhookDeliveries, deliveryID)
+		}
+	}
+	delete(r.store.webhooks, id)
+	return
+}
+
+func (r *webhookAdapterRepository) CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) (ids []int64, err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	// check webhooks first so a failure leaves nothing behind, like a rolled back transaction
+	for _, d := range deliveries {
+		if _, ok := r.store.webhooks[d.WebhookID]; !ok {
+			err = foreignKeyError("webhook_deliveries", "webhook_id")
+			log.Println(err)
+			return
+		}
+	}
+	for _, d := range deliveries {
+		d.ID = r.store.nextID("webhook_deliveries")
+		d.Attempts = 0
+		d.CreatedAt = now()
+		d.NextAttemptAt = d.CreatedAt
+		r.store.webhookDeliveries[d.ID] = d
+		ids = append(ids, d.ID)
+	}
+	return
+}
+
+func (r *webhookAdapterRepository) GetDeliveries(ctx context.Context, args domain.WebhookDeliveryArgs) (deliveries []domain.WebhookDelivery, err error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	deliveries, err = paginate(r.store.filterWebhookDeliveries(args), args.Pagination, func(d domain.WebhookDelivery) int64 { return d.ID })
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+func (r *webhookAdapterRepository) CountDeliveries(ctx context.Context, args domain.WebhookDeliveryArgs) (total int64, err error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	total = int64(len(r.store.filterWebhookDeliveries(args)))
+	return
+}
+
+func (r *webhookAdapterRepository) UpdateDelivery(ctx context.Context, delivery domain.WebhookDelivery) (err error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	stored, ok := r.store.webhookDeliveries[delivery.ID]
+	if !ok {
+		return
+	}
+	stored.Status = delivery.Status
+	stored.Attempts = delivery.Attempts
+	stored.NextAttemptAt = delivery.NextAttemptAt
+	stored.ResponseStatus = delivery.ResponseStatus
+	stored.LastError = delivery.LastError
+	stored.DeliveredAt = delivery.DeliveredAt
+	r.store.webhookDeliveries[delivery.ID] = stored
+	return
+}
+
+// filterWebhookDeliveries applies the conditions of args, caller must hold the lock
+func (s *Store) filterWebhookDeliveries(args domain.WebhookDeliveryArgs) (deliveries []domain.WebhookDelivery) {
+	deliveries = []domain.WebhookDelivery{}
+	due := ""
+	if !args.DueBefore.IsZero() {
+		due = args.DueBefore.UTC().Format(timeFormat)
+	}
+	for _, d := range s.webhookDeliveries {
+		if len(args.IDs) > 0 && !containsID(args.IDs, d.ID) {
+			continue
+		}
+		if args.WebhookID > 0 && d.WebhookID != args.WebhookID {
+			continue
+		}
+		if len(args.Statuses) > 0 && !containsWebhookDeliveryStatus(args.Statuses, d.Status) {
+			continue
+		}
+		// times share one format, so they compare as strings like the SQL adapters do
+		if due != "" && (d.NextAttemptAt == "" || d.NextAttemptAt > due) {
+			continue
+		}
+		deliveries = append(deliveries, d)
+	}
+	return
+}
+
+func containsWebhookDeliveryStatus(statuses []valueobject.WebhookDeliveryStatus, status valueobject.WebhookDeliveryStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package adapter
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+// Authenticate requires "Authorization: Bearer <token>" header and attaches the member to the request context
+func (ctr *Controller) Authenticate(c *gin.Context) {
+	header := c.GetHeader("Authorization")
+	accessToken, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || accessToken == "" {
+		errorResponse(c, domain.NewError(domain.ErrUnauthorized, "authorization bearer token is required"))
+		c.Abort()
+		return
+	}
+	member, err := ctr.AuthUsecase.Authenticate(c.Request.Context(), accessToken)
+	if err != nil {
+		errorResponse(c, err)
+		c.Abort()
+		return
+	}
+	c.Request = c.Request.WithContext(domain.ContextWithMember(c.Request.Context(), member))
+	c.Next()
+}
+
+// Identify attaches the member of an optional bearer token to the request context, requests without one go on anonymously.
+// A token that is sent must be valid.
+func (ctr *Controller) Identify(c *gin.Context) {
+	if c.GetHeader("Authorization") == "" {
+		c.Next()
+		return
+	}
+	ctr.Authenticate(c)
+}
+
+func parseTTL(value string) (ttl time.Duration, err error) {
+	if value == "" {
+		return usecase.DefaultTokenTTL, nil
+	}
+	return time.ParseDuration(value)
+}
@@ -0,0 +1,101 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/migration"
+	"github.com/hieronimusbudi/simple-go-api/internal/config"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	MigrateOff   = "off"
+	MigrateCheck = "check"
+	MigrateAuto  = "auto"
+)
+
+// Migrate runs "up", "down" or "status" migration command against the database of DBDRIVER config
+func Migrate(command string, w io.Writer) (err error) {
+	db, driver := Connection()
+	if db == nil {
+		return fmt.Errorf("%s driver has no schema to migrate", driver)
+	}
+	defer db.Close()
+	migrator, err := migration.NewMigrator(migration.MigratorArgs{DB: db, Dialect: driver})
+	if err != nil {
+		return
+	}
+	ctx := context.Background()
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintln(w, "schema is up to date")
+		}
+		for _, m := range applied {
+			fmt.Fprintf(w, "applied %04d_%s\n", m.Version, m.Name)
+		}
+	case "down":
+		reverted, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "reverted %04d_%s\n", reverted.Version, reverted.Name)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.AppliedAt != "" {
+				appliedAt = "applied at " + s.AppliedAt
+			}
+			fmt.Fprintf(w, "%04d_%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+	default:
+		return errors.New("usage: migrate up|down|status")
+	}
+	return
+}
+
+// prepareSchema applies or checks pending migrations on start according to DBMIGRATE config
+func prepareSchema(db *sqlx.DB, driver string) {
+	mode := strings.ToLower(config.Get().DBMIGRATE)
+	if mode == "" || mode == MigrateOff {
+		return
+	}
+	migrator, err := migration.NewMigrator(migration.MigratorArgs{DB: db, Dialect: driver})
+	if err != nil {
+		log.Fatalln(err)
+	}
+	ctx := context.Background()
+	switch mode {
+	case MigrateAuto:
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		for _, m := range applied {
+			log.Printf("applied migration %04d_%s", m.Version, m.Name)
+		}
+	case MigrateCheck:
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if len(pending) > 0 {
+			log.Fatalf("schema is behind by %d migration(s), run \"migrate up\" first", len(pending))
+		}
+	default:
+		log.Fatalf("unknown DBMIGRATE: %s", config.Get().DBMIGRATE)
+	}
+}
@@ -0,0 +1,301 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed mysql/*.sql sqlite/*.sql
+var files embed.FS
+
+const (
+	DialectMySQL  = "mysql"
+	DialectSQLite = "sqlite"
+
+	// lockName is mysql named lock held while migrating, so replicas starting together do not race
+	lockName    = "schema_migrations"
+	lockTimeout = 60
+)
+
+var (
+	// file name is <version>_<name>.<up|down>.sql, e.g. 0001_create_tables.up.sql
+	fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+	trackingTables = map[string]string{
+		DialectMySQL: "CREATE TABLE IF NOT EXISTS `schema_migrations` (" +
+			"`version` bigint NOT NULL, `name` varchar(255) NOT NULL, `applied_at` timestamp NOT NULL, PRIMARY KEY (`version`))",
+		DialectSQLite: "CREATE TABLE IF NOT EXISTS `schema_migrations` (" +
+			"`version` INTEGER PRIMARY KEY, `name` TEXT NOT NULL, `applied_at` TEXT NOT NULL)",
+	}
+
+	ErrNoMigration = errors.New("no migration has been applied")
+)
+
+type (
+	Migration struct {
+		Version int64
+		Name    string
+		Up      string
+		Down    string
+	}
+
+	// Status is a known migration and when it was applied, AppliedAt is empty for a pending migration
+	Status struct {
+		Migration
+		AppliedAt string
+	}
+
+	Migrator struct {
+		db         *sqlx.DB
+		dialect    string
+		migrations []Migration
+	}
+
+	MigratorArgs struct {
+		DB      *sqlx.DB
+		Dialect string
+	}
+)
+
+func NewMigrator(args MigratorArgs) (migrator *Migrator, err error) {
+	if _, ok := trackingTables[args.Dialect]; !ok {
+		return nil, fmt.Errorf("migrations are not available for %s", args.Dialect)
+	}
+	migrations, err := Load(args.Dialect)
+	if err != nil {
+		return
+	}
+	return &Migrator{db: args.DB, dialect: args.Dialect, migrations: migrations}, nil
+}
+
+// Load reads embedded migrations of a dialect ordered by version
+func Load(dialect string) (migrations []Migration, err error) {
+	entries, err := fs.ReadDir(files, dialect)
+	if err != nil {
+		return
+	}
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %s", entry.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := files.ReadFile(path.Join(dialect, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has different names %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return
+}
+
+// Up applies every pending migration in version order
+func (m *Migrator) Up(ctx context.Context) (applied []Migration, err error) {
+	err = m.withLock(ctx, func(conn *sqlx.Conn) error {
+		versions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration.Up, func(tx execer) error {
+				_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, CURRENT_TIMESTAMP)",
+					migration.Version, migration.Name)
+				return err
+			}); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return
+}
+
+// Down reverts the latest applied migration
+func (m *Migrator) Down(ctx context.Context) (reverted Migration, err error) {
+	err = m.withLock(ctx, func(conn *sqlx.Conn) error {
+		versions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				reverted, found = migration, true
+			}
+		}
+		if !found {
+			return ErrNoMigration
+		}
+		if err := m.apply(ctx, conn, reverted.Down, func(tx execer) error {
+			_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", reverted.Version)
+			return err
+		}); err != nil {
+			return fmt.Errorf("migration %d_%s: %w", reverted.Version, reverted.Name, err)
+		}
+		return nil
+	})
+	return
+}
+
+// Status lists every known migration, applied or pending
+func (m *Migrator) Status(ctx context.Context) (statuses []Status, err error) {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	versions, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return
+	}
+	for _, migration := range m.migrations {
+		statuses = append(statuses, Status{Migration: migration, AppliedAt: versions[migration.Version]})
+	}
+	return
+}
+
+// Pending lists migrations not applied yet, the schema is behind when it is not empty
+func (m *Migrator) Pending(ctx context.Context) (pending []Migration, err error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return
+	}
+	for _, s := range statuses {
+		if s.AppliedAt == "" {
+			pending = append(pending, s.Migration)
+		}
+	}
+	return
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// apply runs migration statements then records it. sqlite runs both in one transaction,
+// mysql commits DDL implicitly so statements run one by one before the record is written.
+func (m *Migrator) apply(ctx context.Context, conn *sqlx.Conn, script string, record func(tx execer) error) (err error) {
+	var target execer = conn
+	var tx *sqlx.Tx
+	if m.dialect == DialectSQLite {
+		if tx, err = conn.BeginTxx(ctx, nil); err != nil {
+			return
+		}
+		defer func() {
+			if err != nil {
+				tx.Rollback()
+			}
+		}()
+		target = tx
+	}
+	for _, statement := range splitStatements(script) {
+		if _, err = target.ExecContext(ctx, statement); err != nil {
+			return
+		}
+	}
+	if err = record(target); err != nil {
+		return
+	}
+	if tx != nil {
+		err = tx.Commit()
+	}
+	return
+}
+
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sqlx.Conn) (versions map[int64]string, err error) {
+	if _, err = conn.ExecContext(ctx, trackingTables[m.dialect]); err != nil {
+		return
+	}
+	rows, err := conn.QueryxContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+	versions = map[int64]string{}
+	for rows.Next() {
+		var version int64
+		var appliedAt string
+		if err = rows.Scan(&version, &appliedAt); err != nil {
+			return
+		}
+		versions[version] = appliedAt
+	}
+	err = rows.Err()
+	return
+}
+
+// withLock runs fn on a single connection, holding mysql named lock for the whole run
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) (err error) {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	if m.dialect == DialectMySQL {
+		var locked sql.NullInt64
+		if err = conn.QueryRowxContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, lockTimeout).Scan(&locked); err != nil {
+			return
+		}
+		if locked.Int64 != 1 {
+			return errors.New("timeout waiting for another migration to finish")
+		}
+		defer conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName)
+	}
+	return fn(conn)
+}
+
+// splitStatements splits a script on semicolons ending a line, so mysql does not need multiStatements
+func splitStatements(script string) (statements []string) {
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+	if s := strings.TrimSpace(current.String()); s != "" {
+		statements = append(statements, s)
+	}
+	return
+}
@@ -0,0 +1,101 @@
+package migration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/migration"
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/sqlite"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect string
+		wantErr bool
+	}{
+		{
+			name:    "mysql",
+			dialect: migration.DialectMySQL,
+		},
+		{
+			name:    "sqlite",
+			dialect: migration.DialectSQLite,
+		},
+		{
+			name:    "unknown dialect",
+			dialect: "postgres",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := migration.Load(tt.dialect)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, got)
+			for i, m := range got {
+				require.NotEmpty(t, m.Up)
+				require.NotEmpty(t, m.Down)
+				if i > 0 {
+					require.Greater(t, m.Version, got[i-1].Version)
+				}
+			}
+		})
+	}
+}
+
+// Every migration must revert cleanly, so the schema can go all the way down and up again
+func TestMigrator_UpDown(t *testing.T) {
+	ctx := context.Background()
+	db, err := sqlite.Open(":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	migrator, err := migration.NewMigrator(migration.MigratorArgs{DB: db, Dialect: migration.DialectSQLite})
+	require.NoError(t, err)
+	all, err := migration.Load(migration.DialectSQLite)
+	require.NoError(t, err)
+
+	pending, err := migrator.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, len(all))
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	require.Equal(t, all, applied)
+
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, s := range statuses {
+		require.NotEmpty(t, s.AppliedAt)
+	}
+
+	for i := len(all) - 1; i >= 0; i-- {
+		reverted, err := migrator.Down(ctx)
+		require.NoError(t, err)
+		require.Equal(t, all[i].Version, reverted.Version)
+	}
+	_, err = migrator.Down(ctx)
+	require.ErrorIs(t, err, migration.ErrNoMigration)
+
+	var tables int
+	require.NoError(t, db.Get(&tables, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name NOT IN ('schema_migrations', 'sqlite_sequence')"))
+	require.Zero(t, tables)
+
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	require.Len(t, applied, len(all))
+}
+
+func TestNewMigrator(t *testing.T) {
+	_, err := migration.NewMigrator(migration.MigratorArgs{Dialect: "memory"})
+	require.Error(t, err)
+}
@@ -0,0 +1,49 @@
+-- IF NOT EXISTS lets databases created from the old data.sql dump adopt migrations
+CREATE TABLE IF NOT EXISTS `members` (
+  `id` mediumint NOT NULL AUTO_INCREMENT,
+  `first_name` varchar(255) NOT NULL,
+  `last_name` varchar(255) DEFAULT NULL,
+  `email` varchar(255) NOT NULL,
+  `created_at` timestamp NOT NULL,
+  `updated_at` timestamp NULL DEFAULT NULL,
+  `discarded_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `unique_email` (`email`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `gatherings` (
+  `id` mediumint NOT NULL AUTO_INCREMENT,
+  `creator` mediumint NOT NULL,
+  `type` int NOT NULL,
+  `scheduled_at` timestamp NOT NULL,
+  `name` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NOT NULL,
+  `location` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NOT NULL,
+  `created_at` timestamp NOT NULL,
+  `updated_at` timestamp NULL DEFAULT NULL,
+  `discarded_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `creator` (`creator`),
+  CONSTRAINT `gatherings_ibfk_1` FOREIGN KEY (`creator`) REFERENCES `members` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `invitations` (
+  `id` mediumint NOT NULL AUTO_INCREMENT,
+  `member_id` mediumint NOT NULL,
+  `gathering_id` mediumint NOT NULL,
+  `status` int DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `member_id` (`member_id`),
+  KEY `gathering_id` (`gathering_id`),
+  CONSTRAINT `invitations_ibfk_1` FOREIGN KEY (`member_id`) REFERENCES `members` (`id`),
+  CONSTRAINT `invitations_ibfk_2` FOREIGN KEY (`gathering_id`) REFERENCES `gatherings` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `attendees` (
+  `member_id` mediumint NOT NULL,
+  `gathering_id` mediumint NOT NULL,
+  UNIQUE KEY `unique_index` (`member_id`,`gathering_id`),
+  KEY `gathering_id` (`gathering_id`),
+  CONSTRAINT `attendees_ibfk_1` FOREIGN KEY (`member_id`) REFERENCES `members` (`id`),
+  CONSTRAINT `attendees_ibfk_2` FOREIGN KEY (`gathering_id`) REFERENCES `gatherings` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
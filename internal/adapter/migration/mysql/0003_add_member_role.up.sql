@@ -0,0 +1 @@
+ALTER TABLE `members` ADD COLUMN `role` varchar(16) NOT NULL DEFAULT 'member';
@@ -0,0 +1,4 @@
+ALTER TABLE `gatherings`
+  DROP COLUMN `time_zone`,
+  DROP COLUMN `ends_at`,
+  MODIFY COLUMN `scheduled_at` timestamp NOT NULL;
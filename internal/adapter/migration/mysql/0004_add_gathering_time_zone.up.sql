@@ -0,0 +1,5 @@
+-- scheduled times are stored in UTC, datetime keeps them independent of the session time zone
+ALTER TABLE `gatherings`
+  MODIFY COLUMN `scheduled_at` datetime NOT NULL,
+  ADD COLUMN `ends_at` datetime NULL DEFAULT NULL AFTER `scheduled_at`,
+  ADD COLUMN `time_zone` varchar(64) NOT NULL DEFAULT 'UTC' AFTER `ends_at`;
@@ -0,0 +1,15 @@
+ALTER TABLE `gatherings`
+  ADD COLUMN `recurrence` varchar(255) NOT NULL DEFAULT '' AFTER `time_zone`;
+
+-- an edited or canceled occurrence of a recurring gathering, recurrence_id is the start generated by the rule
+CREATE TABLE IF NOT EXISTS `gathering_exceptions` (
+  `gathering_id` mediumint NOT NULL,
+  `recurrence_id` datetime NOT NULL,
+  `scheduled_at` datetime NOT NULL,
+  `ends_at` datetime NULL DEFAULT NULL,
+  `name` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NOT NULL,
+  `location` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NOT NULL,
+  `canceled` tinyint(1) NOT NULL DEFAULT 0,
+  PRIMARY KEY (`gathering_id`, `recurrence_id`),
+  CONSTRAINT `gathering_exceptions_ibfk_1` FOREIGN KEY (`gathering_id`) REFERENCES `gatherings` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,3 @@
+ALTER TABLE `gatherings`
+  DROP INDEX `unique_creator_calendar_uid`,
+  DROP COLUMN `calendar_uid`;
@@ -0,0 +1,4 @@
+-- UID of the iCalendar event a gathering was imported from, re-importing the same file finds it
+ALTER TABLE `gatherings`
+  ADD COLUMN `calendar_uid` varchar(255) NULL DEFAULT NULL AFTER `recurrence`,
+  ADD UNIQUE KEY `unique_creator_calendar_uid` (`creator`, `calendar_uid`);
@@ -0,0 +1,2 @@
+ALTER TABLE `invitations` DROP COLUMN `waitlisted_at`;
+ALTER TABLE `gatherings` DROP COLUMN `capacity`;
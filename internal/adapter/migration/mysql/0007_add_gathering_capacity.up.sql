@@ -0,0 +1,6 @@
+-- capacity 0 means unlimited, accepts beyond capacity wait in waitlisted_at order
+ALTER TABLE `gatherings`
+  ADD COLUMN `capacity` int NOT NULL DEFAULT 0 AFTER `location`;
+
+ALTER TABLE `invitations`
+  ADD COLUMN `waitlisted_at` datetime NULL DEFAULT NULL AFTER `status`;
@@ -0,0 +1 @@
+ALTER TABLE `invitations` DROP COLUMN `note`, DROP COLUMN `guests`;
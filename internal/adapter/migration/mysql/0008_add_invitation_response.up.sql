@@ -0,0 +1,4 @@
+-- guests are plus-ones of an accepted or tentative invitation, note is the member's answer
+ALTER TABLE `invitations`
+  ADD COLUMN `guests` int NOT NULL DEFAULT 0 AFTER `waitlisted_at`,
+  ADD COLUMN `note` varchar(500) NOT NULL DEFAULT '' AFTER `guests`;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS `group_gatherings`;
+DROP TABLE IF EXISTS `group_members`;
+DROP TABLE IF EXISTS `member_groups`;
@@ -0,0 +1,29 @@
+-- groups is a reserved word in mysql 8
+CREATE TABLE IF NOT EXISTS `member_groups` (
+  `id` mediumint NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NOT NULL,
+  `created_at` timestamp NOT NULL,
+  `updated_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `group_members` (
+  `group_id` mediumint NOT NULL,
+  `member_id` mediumint NOT NULL,
+  `role` varchar(32) NOT NULL DEFAULT 'member',
+  `created_at` timestamp NOT NULL,
+  PRIMARY KEY (`group_id`, `member_id`),
+  KEY `member_id` (`member_id`),
+  CONSTRAINT `group_members_ibfk_1` FOREIGN KEY (`group_id`) REFERENCES `member_groups` (`id`),
+  CONSTRAINT `group_members_ibfk_2` FOREIGN KEY (`member_id`) REFERENCES `members` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- gatherings the group was invited to with auto invite, members joining later are invited to the upcoming ones
+CREATE TABLE IF NOT EXISTS `group_gatherings` (
+  `group_id` mediumint NOT NULL,
+  `gathering_id` mediumint NOT NULL,
+  PRIMARY KEY (`group_id`, `gathering_id`),
+  KEY `gathering_id` (`gathering_id`),
+  CONSTRAINT `group_gatherings_ibfk_1` FOREIGN KEY (`group_id`) REFERENCES `member_groups` (`id`),
+  CONSTRAINT `group_gatherings_ibfk_2` FOREIGN KEY (`gathering_id`) REFERENCES `gatherings` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,16 @@
+-- outbox of notifications to members, written in the transaction of the change they tell about.
+-- member_id has no foreign key, purging a member keeps what was sent to them. sent_at is stored in UTC, datetime keeps it
+-- independent of the session time zone
+CREATE TABLE IF NOT EXISTS `notifications` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `event` varchar(64) NOT NULL,
+  `member_id` mediumint NOT NULL,
+  `data` text NOT NULL,
+  `status` varchar(16) NOT NULL DEFAULT 'pending',
+  `attempts` int NOT NULL DEFAULT 0,
+  `last_error` text DEFAULT NULL,
+  `created_at` timestamp NOT NULL,
+  `sent_at` datetime NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `status` (`status`, `id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
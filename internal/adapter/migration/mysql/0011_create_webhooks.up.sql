@@ -0,0 +1,32 @@
+-- webhook subscriptions of other tools, events is a comma separated list
+CREATE TABLE IF NOT EXISTS `webhooks` (
+  `id` mediumint NOT NULL AUTO_INCREMENT,
+  `url` varchar(2048) NOT NULL,
+  `secret` varchar(255) NOT NULL,
+  `events` text NOT NULL,
+  `is_disabled` tinyint(1) NOT NULL DEFAULT 0,
+  `created_at` timestamp NOT NULL,
+  `updated_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- delivery log, pending deliveries are tried when next_attempt_at is due. next_attempt_at and delivered_at are stored in UTC,
+-- datetime keeps them independent of the session time zone
+CREATE TABLE IF NOT EXISTS `webhook_deliveries` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `webhook_id` mediumint NOT NULL,
+  `event` varchar(64) NOT NULL,
+  `payload` mediumtext NOT NULL,
+  `status` varchar(16) NOT NULL DEFAULT 'pending',
+  `attempts` int NOT NULL DEFAULT 0,
+  `next_attempt_at` datetime NULL DEFAULT NULL,
+  `response_status` int NOT NULL DEFAULT 0,
+  `last_error` text DEFAULT NULL,
+  `replay_of` bigint DEFAULT NULL,
+  `created_at` timestamp NOT NULL,
+  `delivered_at` datetime NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `webhook_id` (`webhook_id`),
+  KEY `due` (`status`, `next_attempt_at`),
+  CONSTRAINT `webhook_deliveries_ibfk_1` FOREIGN KEY (`webhook_id`) REFERENCES `webhooks` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
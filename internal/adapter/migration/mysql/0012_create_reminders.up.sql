@@ -0,0 +1,19 @@
+-- reminders sent, one per occurrence and offset so none is sent twice. gathering_id has no foreign key,
+-- purging a gathering keeps what was sent. scheduled_at is stored in UTC like gatherings, datetime keeps it independent
+-- of the session time zone
+CREATE TABLE IF NOT EXISTS `reminders` (
+  `gathering_id` mediumint NOT NULL,
+  `scheduled_at` datetime NOT NULL,
+  `offset_seconds` int NOT NULL,
+  `created_at` timestamp NOT NULL,
+  PRIMARY KEY (`gathering_id`, `scheduled_at`, `offset_seconds`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- a job runs in the process holding its lease until expires_at, another process takes it over once it expired.
+-- expires_at is stored in UTC and compared with the UTC time of the caller
+CREATE TABLE IF NOT EXISTS `leases` (
+  `name` varchar(64) NOT NULL,
+  `holder` varchar(255) NOT NULL,
+  `expires_at` datetime NOT NULL,
+  PRIMARY KEY (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `attendees`;
+DROP TABLE IF EXISTS `invitations`;
+DROP TABLE IF EXISTS `gatherings`;
+DROP TABLE IF EXISTS `members`;
@@ -0,0 +1,40 @@
+CREATE TABLE `members` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `first_name` TEXT NOT NULL,
+  `last_name` TEXT DEFAULT NULL,
+  `email` TEXT NOT NULL COLLATE NOCASE,
+  `created_at` TEXT NOT NULL,
+  `updated_at` TEXT DEFAULT NULL,
+  `discarded_at` TEXT DEFAULT NULL,
+  CONSTRAINT `unique_email` UNIQUE (`email`)
+);
+
+CREATE TABLE `gatherings` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `creator` INTEGER NOT NULL REFERENCES `members` (`id`),
+  `type` INTEGER NOT NULL,
+  `scheduled_at` TEXT NOT NULL,
+  `name` TEXT NOT NULL,
+  `location` TEXT NOT NULL,
+  `created_at` TEXT NOT NULL,
+  `updated_at` TEXT DEFAULT NULL,
+  `discarded_at` TEXT DEFAULT NULL
+);
+CREATE INDEX `gatherings_creator` ON `gatherings` (`creator`);
+
+CREATE TABLE `invitations` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `member_id` INTEGER NOT NULL REFERENCES `members` (`id`),
+  `gathering_id` INTEGER NOT NULL REFERENCES `gatherings` (`id`),
+  `status` INTEGER DEFAULT NULL,
+  `created_at` TEXT DEFAULT NULL
+);
+CREATE INDEX `invitations_member_id` ON `invitations` (`member_id`);
+CREATE INDEX `invitations_gathering_id` ON `invitations` (`gathering_id`);
+
+CREATE TABLE `attendees` (
+  `member_id` INTEGER NOT NULL REFERENCES `members` (`id`),
+  `gathering_id` INTEGER NOT NULL REFERENCES `gatherings` (`id`),
+  CONSTRAINT `unique_index` UNIQUE (`member_id`, `gathering_id`)
+);
+CREATE INDEX `attendees_gathering_id` ON `attendees` (`gathering_id`);
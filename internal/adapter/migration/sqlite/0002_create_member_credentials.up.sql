@@ -0,0 +1,6 @@
+CREATE TABLE `member_credentials` (
+  `member_id` INTEGER PRIMARY KEY REFERENCES `members` (`id`),
+  `password_hash` TEXT NOT NULL,
+  `created_at` TEXT NOT NULL,
+  `updated_at` TEXT DEFAULT NULL
+);
@@ -0,0 +1,2 @@
+ALTER TABLE `gatherings` DROP COLUMN `time_zone`;
+ALTER TABLE `gatherings` DROP COLUMN `ends_at`;
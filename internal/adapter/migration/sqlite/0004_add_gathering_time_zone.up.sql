@@ -0,0 +1,2 @@
+ALTER TABLE `gatherings` ADD COLUMN `ends_at` TEXT;
+ALTER TABLE `gatherings` ADD COLUMN `time_zone` TEXT NOT NULL DEFAULT 'UTC';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `gathering_exceptions`;
+ALTER TABLE `gatherings` DROP COLUMN `recurrence`;
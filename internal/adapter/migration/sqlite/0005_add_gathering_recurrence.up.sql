@@ -0,0 +1,13 @@
+ALTER TABLE `gatherings` ADD COLUMN `recurrence` TEXT NOT NULL DEFAULT '';
+
+-- an edited or canceled occurrence of a recurring gathering, recurrence_id is the start generated by the rule
+CREATE TABLE `gathering_exceptions` (
+  `gathering_id` INTEGER NOT NULL REFERENCES `gatherings` (`id`),
+  `recurrence_id` TEXT NOT NULL,
+  `scheduled_at` TEXT NOT NULL,
+  `ends_at` TEXT DEFAULT NULL,
+  `name` TEXT NOT NULL,
+  `location` TEXT NOT NULL,
+  `canceled` INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY (`gathering_id`, `recurrence_id`)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS `unique_creator_calendar_uid`;
+ALTER TABLE `gatherings` DROP COLUMN `calendar_uid`;
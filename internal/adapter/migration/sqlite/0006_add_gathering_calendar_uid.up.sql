@@ -0,0 +1,3 @@
+-- UID of the iCalendar event a gathering was imported from, re-importing the same file finds it
+ALTER TABLE `gatherings` ADD COLUMN `calendar_uid` TEXT DEFAULT NULL;
+CREATE UNIQUE INDEX `unique_creator_calendar_uid` ON `gatherings` (`creator`, `calendar_uid`);
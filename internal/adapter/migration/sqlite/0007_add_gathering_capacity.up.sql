@@ -0,0 +1,3 @@
+-- capacity 0 means unlimited, accepts beyond capacity wait in waitlisted_at order
+ALTER TABLE `gatherings` ADD COLUMN `capacity` INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE `invitations` ADD COLUMN `waitlisted_at` TEXT DEFAULT NULL;
@@ -0,0 +1,3 @@
+-- guests are plus-ones of an accepted or tentative invitation, note is the member's answer
+ALTER TABLE `invitations` ADD COLUMN `guests` INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE `invitations` ADD COLUMN `note` TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,23 @@
+CREATE TABLE `member_groups` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `name` TEXT NOT NULL,
+  `created_at` TEXT NOT NULL,
+  `updated_at` TEXT DEFAULT NULL
+);
+
+CREATE TABLE `group_members` (
+  `group_id` INTEGER NOT NULL REFERENCES `member_groups` (`id`),
+  `member_id` INTEGER NOT NULL REFERENCES `members` (`id`),
+  `role` TEXT NOT NULL DEFAULT 'member',
+  `created_at` TEXT NOT NULL,
+  PRIMARY KEY (`group_id`, `member_id`)
+);
+CREATE INDEX `group_members_member_id` ON `group_members` (`member_id`);
+
+-- gatherings the group was invited to with auto invite, members joining later are invited to the upcoming ones
+CREATE TABLE `group_gatherings` (
+  `group_id` INTEGER NOT NULL REFERENCES `member_groups` (`id`),
+  `gathering_id` INTEGER NOT NULL REFERENCES `gatherings` (`id`),
+  PRIMARY KEY (`group_id`, `gathering_id`)
+);
+CREATE INDEX `group_gatherings_gathering_id` ON `group_gatherings` (`gathering_id`);
@@ -0,0 +1,14 @@
+-- outbox of notifications to members, written in the transaction of the change they tell about.
+-- member_id has no foreign key, purging a member keeps what was sent to them
+CREATE TABLE `notifications` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `event` TEXT NOT NULL,
+  `member_id` INTEGER NOT NULL,
+  `data` TEXT NOT NULL,
+  `status` TEXT NOT NULL DEFAULT 'pending',
+  `attempts` INTEGER NOT NULL DEFAULT 0,
+  `last_error` TEXT DEFAULT NULL,
+  `created_at` TEXT NOT NULL,
+  `sent_at` TEXT DEFAULT NULL
+);
+CREATE INDEX `notifications_status` ON `notifications` (`status`, `id`);
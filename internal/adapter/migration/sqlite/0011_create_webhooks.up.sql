@@ -0,0 +1,28 @@
+-- webhook subscriptions of other tools, events is a comma separated list
+CREATE TABLE `webhooks` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `url` TEXT NOT NULL,
+  `secret` TEXT NOT NULL,
+  `events` TEXT NOT NULL,
+  `is_disabled` INTEGER NOT NULL DEFAULT 0,
+  `created_at` TEXT NOT NULL,
+  `updated_at` TEXT DEFAULT NULL
+);
+
+-- delivery log, pending deliveries are tried when next_attempt_at is due
+CREATE TABLE `webhook_deliveries` (
+  `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+  `webhook_id` INTEGER NOT NULL REFERENCES `webhooks` (`id`),
+  `event` TEXT NOT NULL,
+  `payload` TEXT NOT NULL,
+  `status` TEXT NOT NULL DEFAULT 'pending',
+  `attempts` INTEGER NOT NULL DEFAULT 0,
+  `next_attempt_at` TEXT DEFAULT NULL,
+  `response_status` INTEGER NOT NULL DEFAULT 0,
+  `last_error` TEXT DEFAULT NULL,
+  `replay_of` INTEGER DEFAULT NULL,
+  `created_at` TEXT NOT NULL,
+  `delivered_at` TEXT DEFAULT NULL
+);
+CREATE INDEX `webhook_deliveries_webhook_id` ON `webhook_deliveries` (`webhook_id`);
+CREATE INDEX `webhook_deliveries_due` ON `webhook_deliveries` (`status`, `next_attempt_at`);
@@ -0,0 +1,16 @@
+-- reminders sent, one per occurrence and offset so none is sent twice. gathering_id has no foreign key,
+-- purging a gathering keeps what was sent
+CREATE TABLE `reminders` (
+  `gathering_id` INTEGER NOT NULL,
+  `scheduled_at` TEXT NOT NULL,
+  `offset_seconds` INTEGER NOT NULL,
+  `created_at` TEXT NOT NULL,
+  PRIMARY KEY (`gathering_id`, `scheduled_at`, `offset_seconds`)
+);
+
+-- a job runs in the process holding its lease until expires_at, another process takes it over once it expired
+CREATE TABLE `leases` (
+  `name` TEXT NOT NULL PRIMARY KEY,
+  `holder` TEXT NOT NULL,
+  `expires_at` TEXT NOT NULL
+);
@@ -0,0 +1,41 @@
+package adapter
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/notifier"
+	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
+	"github.com/hieronimusbudi/simple-go-api/internal/config"
+)
+
+// newNotifier mails notifications through SMTPHOST, or logs them when no SMTP server is configured
+func newNotifier() usecase.INotifier {
+	cfg := config.Get()
+	if cfg.SMTPHOST == "" {
+		log.Println("SMTPHOST is empty, notifications are logged instead of mailed")
+		return notifier.NewLogNotifier()
+	}
+	n, err := notifier.NewSMTPNotifier(notifier.SMTPNotifierArgs{
+		Addr:     cfg.SMTPHOST,
+		Username: cfg.SMTPUSERNAME,
+		Password: cfg.SMTPPASSWORD,
+		From:     cfg.SMTPFROM,
+	})
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return n
+}
+
+// parseInterval reads a dispatcher interval config, fallback is used when it is empty and key names it in errors
+func parseInterval(key string, value string, fallback time.Duration) (interval time.Duration, err error) {
+	if value == "" {
+		return fallback, nil
+	}
+	if interval, err = time.ParseDuration(value); err == nil && interval <= 0 {
+		err = fmt.Errorf("%s %q must be a positive duration", key, value)
+	}
+	return
+}
@@ -0,0 +1,26 @@
+package notifier
+
+import (
+	"context"
+	"log"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+type logNotifier struct{}
+
+// NewLogNotifier writes notifications to the log instead of mailing them, e.g. when no SMTP server is configured
+func NewLogNotifier() usecase.INotifier {
+	return &logNotifier{}
+}
+
+func (n *logNotifier) Notify(ctx context.Context, recipient domain.Member, actor domain.Member, notification domain.Notification) (err error) {
+	subject, body, err := Render(recipient, actor, notification)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	log.Printf("notification %d to %s: %s\n%s", notification.ID, recipient.Email, subject, body)
+	return
+}
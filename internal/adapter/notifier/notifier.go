@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// templates has one template per event, each defines "subject" and "body"
+var templates = parseTemplates()
+
+// message is what templates read
+type message struct {
+	Recipient    domain.Member
+	Actor        domain.Member
+	Notification domain.Notification
+	Data         domain.NotificationData
+}
+
+func parseTemplates() map[string]*template.Template {
+	funcs := template.FuncMap{
+		"date":     func(t time.Time) string { return t.Format("Mon, 02 Jan 2006") },
+		"datetime": func(t time.Time) string { return t.Format("Mon, 02 Jan 2006 15:04 -07:00") },
+	}
+	layout := template.Must(template.New("layout.tmpl").Funcs(funcs).ParseFS(templateFS, "templates/layout.tmpl"))
+	parsed := map[string]*template.Template{}
+	for _, event := range []string{
+		domain.NotificationInvitationCreated,
+		domain.NotificationInvitationAccepted,
+		domain.NotificationInvitationTentative,
+		domain.NotificationInvitationRejected,
+		domain.NotificationInvitationCanceled,
+		domain.NotificationWaitlistPromoted,
+		domain.NotificationGatheringUpdated,
+		domain.NotificationGatheringCanceled,
+		domain.NotificationOccurrenceCanceled,
+		domain.NotificationGatheringReminder,
+	} {
+		parsed[event] = template.Must(template.Must(layout.Clone()).ParseFS(templateFS, "templates/"+event+".tmpl"))
+	}
+	return parsed
+}
+
+// Render fills the subject and body template of the notification event
+func Render(recipient domain.Member, actor domain.Member, notification domain.Notification) (subject string, body string, err error) {
+	t, ok := templates[notification.Event]
+	if !ok {
+		return "", "", fmt.Errorf("no template for notification event %q", notification.Event)
+	}
+	m := message{Recipient: recipient, Actor: actor, Notification: notification, Data: notification.Data}
+	var b bytes.Buffer
+	if err = t.ExecuteTemplate(&b, "subject", m); err != nil {
+		return
+	}
+	subject = strings.TrimSpace(b.String())
+	b.Reset()
+	if err = t.ExecuteTemplate(&b, "body", m); err != nil {
+		return
+	}
+	body = strings.TrimSpace(b.String()) + "\n"
+	return
+}
@@ -0,0 +1,216 @@
+package notifier_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/notifier"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	linus = domain.Member{ID: 1, FirstName: "linus", LastName: "torvalds", Email: "linus@mail.com"}
+	ron   = domain.Member{ID: 2, FirstName: "ron", LastName: "weasley", Email: "ron@mail.com"}
+	data  = domain.NotificationData{
+		GatheringID:   1,
+		GatheringName: "kernel standup",
+		Location:      "room 1",
+		ScheduledAt:   time.Date(2023, 10, 2, 9, 0, 0, 0, time.FixedZone("WIB", 7*60*60)),
+		InvitationID:  3,
+		ActorID:       1,
+	}
+)
+
+// mail is what the fake SMTP server received
+type mail struct {
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTP accepts one connection at a time and hands each mail to the returned channel, rejected recipients get 550
+func fakeSMTP(t *testing.T, rejected string) (addr string, mails <-chan mail) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	received := make(chan mail, 10)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			serveSMTP(conn, rejected, received)
+		}
+	}()
+	return l.Addr().String(), received
+}
+
+func serveSMTP(conn net.Conn, rejected string, received chan<- mail) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { fmt.Fprintf(conn, "%s\r\n", line) }
+	reply("220 localhost fake SMTP")
+	m := mail{}
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			m.from = strings.Trim(strings.TrimSpace(line)[len("MAIL FROM:"):], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			to := strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>")
+			if to == rejected {
+				reply("550 no such user")
+				continue
+			}
+			m.to = append(m.to, to)
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 end with .")
+			var b strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				b.WriteString(l)
+			}
+			m.data = b.String()
+			received <- m
+			m = mail{}
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTPNotifier_Notify(t *testing.T) {
+	addr, mails := fakeSMTP(t, "nobody@mail.com")
+	n, err := notifier.NewSMTPNotifier(notifier.SMTPNotifierArgs{Addr: addr, From: "Gathering App <no-reply@example.com>"})
+	require.NoError(t, err)
+	notification := domain.Notification{ID: 7, Event: domain.NotificationInvitationCreated, MemberID: 2, Data: data}
+
+	require.NoError(t, n.Notify(context.Background(), ron, linus, notification))
+	got := <-mails
+	require.Equal(t, "no-reply@example.com", got.from)
+	require.Equal(t, []string{"ron@mail.com"}, got.to)
+	require.Contains(t, got.data, "To: \"ron weasley\" <ron@mail.com>\r\n")
+	require.Contains(t, got.data, "Subject: You are invited to kernel standup\r\n")
+	require.Contains(t, got.data, "Message-ID: <notification-7@example.com>\r\n")
+	require.Contains(t, got.data, "linus torvalds invited you to kernel standup.\r\n")
+	require.Contains(t, got.data, "When:  Mon, 02 Oct 2023 09:00 +07:00\r\n")
+
+	nobody := domain.Member{ID: 9, FirstName: "nobody", Email: "nobody@mail.com"}
+	require.Error(t, n.Notify(context.Background(), nobody, linus, notification))
+}
+
+func TestNewSMTPNotifier(t *testing.T) {
+	_, err := notifier.NewSMTPNotifier(notifier.SMTPNotifierArgs{Addr: "localhost:25", From: "not an address"})
+	require.Error(t, err)
+	_, err = notifier.NewSMTPNotifier(notifier.SMTPNotifierArgs{Addr: "localhost", Username: "app", From: "no-reply@example.com"})
+	require.Error(t, err)
+}
+
+func TestRender(t *testing.T) {
+	ends := data.ScheduledAt.Add(time.Hour)
+	tests := []struct {
+		name        string
+		event       string
+		data        func(d domain.NotificationData) domain.NotificationData
+		wantSubject string
+		wantBody    string
+	}{
+		{
+			name:        "accepted with note",
+			event:       domain.NotificationInvitationAccepted,
+			data:        func(d domain.NotificationData) domain.NotificationData { d.Note = "with my kids"; return d },
+			wantSubject: "linus torvalds accepted your invitation to kernel standup",
+			wantBody:    "They wrote: with my kids",
+		},
+		{
+			name:        "tentative",
+			event:       domain.NotificationInvitationTentative,
+			wantSubject: "linus torvalds might come to kernel standup",
+		},
+		{
+			name:        "rejected",
+			event:       domain.NotificationInvitationRejected,
+			wantSubject: "linus torvalds cannot come to kernel standup",
+		},
+		{
+			name:        "canceled invitation",
+			event:       domain.NotificationInvitationCanceled,
+			wantSubject: "Your invitation to kernel standup was canceled",
+		},
+		{
+			name:        "promoted from waitlist",
+			event:       domain.NotificationWaitlistPromoted,
+			wantSubject: "A seat freed up at kernel standup",
+			wantBody:    "your waitlisted invitation is now accepted",
+		},
+		{
+			name:        "updated with end",
+			event:       domain.NotificationGatheringUpdated,
+			data:        func(d domain.NotificationData) domain.NotificationData { d.EndsAt = &ends; return d },
+			wantSubject: "kernel standup was changed",
+			wantBody:    "Mon, 02 Oct 2023 09:00 +07:00 until Mon, 02 Oct 2023 10:00 +07:00",
+		},
+		{
+			name:        "canceled gathering",
+			event:       domain.NotificationGatheringCanceled,
+			wantSubject: "kernel standup was canceled",
+		},
+		{
+			name:  "canceled following occurrences",
+			event: domain.NotificationOccurrenceCanceled,
+			data: func(d domain.NotificationData) domain.NotificationData {
+				d.Scope = domain.OccurrenceScopeFollowing
+				return d
+			},
+			wantSubject: "kernel standup on Mon, 02 Oct 2023 was canceled",
+			wantBody:    "earlier occurrences still take place",
+		},
+		{
+			name:        "reminder of an unanswered invitation",
+			event:       domain.NotificationGatheringReminder,
+			wantSubject: "Reminder: kernel standup on Mon, 02 Oct 2023 09:00 +07:00",
+			wantBody:    "You have not answered invitation 3 yet",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := data
+			if tt.data != nil {
+				d = tt.data(d)
+			}
+			subject, body, err := notifier.Render(ron, linus, domain.Notification{Event: tt.event, Data: d})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSubject, subject)
+			require.True(t, strings.HasPrefix(body, "Hi ron,\n\n"), body)
+			require.Contains(t, body, tt.wantBody)
+		})
+	}
+
+	_, _, err := notifier.Render(ron, linus, domain.Notification{Event: "unknown"})
+	require.Error(t, err)
+}
@@ -0,0 +1,84 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+type (
+	smtpNotifier struct {
+		addr string
+		auth smtp.Auth
+		from mail.Address
+	}
+
+	SMTPNotifierArgs struct {
+		// Addr is host:port of the SMTP server
+		Addr string
+		// Username and Password log in with PLAIN auth, no auth when Username is empty
+		Username string
+		Password string
+		// From is the sender address, e.g. "Gathering App <no-reply@example.com>"
+		From string
+	}
+)
+
+func NewSMTPNotifier(args SMTPNotifierArgs) (notifier usecase.INotifier, err error) {
+	from, err := mail.ParseAddress(args.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender %q: %w", args.From, err)
+	}
+	n := &smtpNotifier{addr: args.Addr, from: *from}
+	if args.Username != "" {
+		host, _, err := net.SplitHostPort(args.Addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SMTP address %q: %w", args.Addr, err)
+		}
+		n.auth = smtp.PlainAuth("", args.Username, args.Password, host)
+	}
+	return n, nil
+}
+
+// Notify mails the rendered notification to the recipient
+func (n *smtpNotifier) Notify(ctx context.Context, recipient domain.Member, actor domain.Member, notification domain.Notification) (err error) {
+	subject, body, err := Render(recipient, actor, notification)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	to := mail.Address{Name: recipient.FirstName + " " + recipient.LastName, Address: recipient.Email}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", to.String())
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Message-ID: <notification-%d@%s>\r\n", notification.ID, n.hostname())
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	msg.WriteString("\r\n")
+	msg.Write(bytes.ReplaceAll([]byte(body), []byte("\n"), []byte("\r\n")))
+	if err = smtp.SendMail(n.addr, n.auth, n.from.Address, []string{recipient.Email}, msg.Bytes()); err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// hostname is the domain of the sender, retries of a notification keep its Message-ID
+func (n *smtpNotifier) hostname() string {
+	if i := strings.LastIndex(n.from.Address, "@"); i >= 0 {
+		return n.from.Address[i+1:]
+	}
+	return "localhost"
+}
@@ -0,0 +1,293 @@
+package adapter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+// bindJSON binds request body, a field of the wrong type or a malformed body is a validation error
+func bindJSON(c *gin.Context, obj interface{}) (err error) {
+	if err = c.ShouldBindJSON(obj); err != nil {
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			return validationErr
+		}
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return domain.NewFieldError(typeErr.Field, domain.CodeInvalidType, fmt.Sprintf("%s must be %s", typeErr.Field, jsonType(typeErr.Type)))
+		}
+		return domain.WrapError(domain.ErrValidation, "invalid request body", err)
+	}
+	return
+}
+
+// bindInvitationResponse binds the optional guests and note of an answer to an invitation, an empty body answers without them
+func bindInvitationResponse(c *gin.Context) (response domain.InvitationResponse, err error) {
+	if c.Request.Body == nil || c.Request.ContentLength == 0 {
+		return
+	}
+	err = bindJSON(c, &response)
+	return
+}
+
+// jsonType names a Go type the way clients know it
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	}
+	return "an object"
+}
+
+// paramID reads the id path param
+func paramID(c *gin.Context) (id int64, err error) {
+	id, err = strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return id, domain.NewFieldError("id", domain.CodeInvalid, "invalid id")
+	}
+	return
+}
+
+// paramMemberID reads the member_id path param of a group member path
+func paramMemberID(c *gin.Context) (id int64, err error) {
+	id, err = strconv.ParseInt(c.Param("member_id"), 10, 64)
+	if err != nil {
+		return id, domain.NewFieldError("member_id", domain.CodeInvalid, "invalid member id")
+	}
+	return
+}
+
+// paramDeliveryID reads the delivery_id path param of a webhook delivery path
+func paramDeliveryID(c *gin.Context) (id int64, err error) {
+	id, err = strconv.ParseInt(c.Param("delivery_id"), 10, 64)
+	if err != nil {
+		return id, domain.NewFieldError("delivery_id", domain.CodeInvalid, "invalid delivery id")
+	}
+	return
+}
+
+// paramCalendarID reads the id path param of an iCalendar path such as /gatherings/1.ics
+func paramCalendarID(c *gin.Context) (id int64, err error) {
+	id, err = strconv.ParseInt(strings.TrimSuffix(c.Param("id"), ".ics"), 10, 64)
+	if err != nil {
+		return id, domain.NewFieldError("id", domain.CodeInvalid, "invalid id")
+	}
+	return
+}
+
+// bindPagination reads limit, offset, cursor and sort query params
+func bindPagination(c *gin.Context) (p domain.Pagination, err error) {
+	if p.Limit, err = queryInt(c, "limit", domain.DefaultLimit); err != nil {
+		return
+	}
+	if p.Limit <= 0 {
+		return p, domain.NewFieldError("limit", domain.CodeOutOfRange, fmt.Sprintf("limit must be between 1 and %d", domain.MaxLimit))
+	}
+	if p.Offset, err = queryInt(c, "offset", 0); err != nil {
+		return
+	}
+	p.Cursor = c.Query("cursor")
+	p.Sort = c.Query("sort")
+	return
+}
+
+func bindMemberArgs(c *gin.Context) (args domain.MemberArgs, err error) {
+	if args.Pagination, err = bindPagination(c); err != nil {
+		return
+	}
+	args.Email = c.Query("email")
+	args.Name = c.Query("name")
+	err = args.Validate()
+	return
+}
+
+func bindGroupArgs(c *gin.Context) (args domain.GroupArgs, err error) {
+	if args.Pagination, err = bindPagination(c); err != nil {
+		return
+	}
+	if args.MemberID, err = queryInt64(c, "member_id"); err != nil {
+		return
+	}
+	args.Name = c.Query("name")
+	err = args.Validate()
+	return
+}
+
+func bindGatheringArgs(c *gin.Context) (args domain.GatheringArgs, err error) {
+	if args.Pagination, err = bindPagination(c); err != nil {
+		return
+	}
+	if args.CreatorID, err = queryInt64(c, "creator_id"); err != nil {
+		return
+	}
+	memberID, err := queryInt64(c, "member_id")
+	if err != nil {
+		return
+	}
+	if memberID > 0 {
+		args.MemberIDs = []int64{memberID}
+	}
+	for _, v := range queryList(c, "type") {
+		t, err := strconv.Atoi(v)
+		if err != nil {
+			return args, domain.NewFieldError("type", domain.CodeInvalid, fmt.Sprintf("invalid type %s", v))
+		}
+		args.Types = append(args.Types, valueobject.GatheringType(t))
+	}
+	args.Name = c.Query("name")
+	args.Location = c.Query("location")
+	if args.ScheduledFrom, err = queryTime(c, "scheduled_from"); err != nil {
+		return
+	}
+	if args.ScheduledTo, err = queryTime(c, "scheduled_to"); err != nil {
+		return
+	}
+	err = args.Validate()
+	return
+}
+
+// bindOccurrenceArgs reads the gathering id and recurrence id path params, scope, from and to
+func bindOccurrenceArgs(c *gin.Context) (args domain.OccurrenceArgs, err error) {
+	if args.GatheringID, err = paramID(c); err != nil {
+		return
+	}
+	if v := c.Param("recurrence_id"); v != "" {
+		if args.RecurrenceID, err = time.Parse(time.RFC3339, v); err != nil {
+			return args, domain.NewFieldError("recurrence_id", domain.CodeInvalid, "invalid recurrence_id, please use RFC 3339 format")
+		}
+	}
+	args.Scope = c.Query("scope")
+	if args.From, err = queryTime(c, "from"); err != nil {
+		return
+	}
+	if args.To, err = queryTime(c, "to"); err != nil {
+		return
+	}
+	err = args.Validate()
+	return
+}
+
+func bindScheduleArgs(c *gin.Context) (args domain.ScheduleArgs, err error) {
+	if args.MemberID, err = paramID(c); err != nil {
+		return
+	}
+	if args.From, err = queryTime(c, "from"); err != nil {
+		return
+	}
+	args.To, err = queryTime(c, "to")
+	return
+}
+
+func bindInvitationArgs(c *gin.Context) (args domain.InvitationArgs, err error) {
+	if args.Pagination, err = bindPagination(c); err != nil {
+		return
+	}
+	if args.MemberID, err = queryInt64(c, "member_id"); err != nil {
+		return
+	}
+	if args.GatheringID, err = queryInt64(c, "gathering_id"); err != nil {
+		return
+	}
+	for _, v := range queryList(c, "status") {
+		s, err := strconv.Atoi(v)
+		if err != nil {
+			return args, domain.NewFieldError("status", domain.CodeInvalid, fmt.Sprintf("invalid status %s", v))
+		}
+		args.Statuses = append(args.Statuses, valueobject.InvitationStatus(s))
+	}
+	err = args.Validate()
+	return
+}
+
+func bindWebhookDeliveryArgs(c *gin.Context) (args domain.WebhookDeliveryArgs, err error) {
+	if args.WebhookID, err = paramID(c); err != nil {
+		return
+	}
+	if args.Pagination, err = bindPagination(c); err != nil {
+		return
+	}
+	for _, v := range queryList(c, "status") {
+		args.Statuses = append(args.Statuses, valueobject.WebhookDeliveryStatus(v))
+	}
+	err = args.Validate()
+	return
+}
+
+func queryInt(c *gin.Context, key string, defaultValue int) (value int, err error) {
+	v := c.Query(key)
+	if v == "" {
+		return defaultValue, nil
+	}
+	value, err = strconv.Atoi(v)
+	if err != nil {
+		return value, domain.NewFieldError(key, domain.CodeInvalid, fmt.Sprintf("invalid %s", key))
+	}
+	return
+}
+
+func queryInt64(c *gin.Context, key string) (value int64, err error) {
+	v := c.Query(key)
+	if v == "" {
+		return
+	}
+	value, err = strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return value, domain.NewFieldError(key, domain.CodeInvalid, fmt.Sprintf("invalid %s", key))
+	}
+	return
+}
+
+// queryBool reads true or false, e.g. force=true, a missing value is false
+func queryBool(c *gin.Context, key string) (value bool, err error) {
+	v := c.Query(key)
+	if v == "" {
+		return
+	}
+	value, err = strconv.ParseBool(v)
+	if err != nil {
+		return value, domain.NewFieldError(key, domain.CodeInvalid, fmt.Sprintf("invalid %s, please use true or false", key))
+	}
+	return
+}
+
+// queryTime reads a time in RFC 3339 format, e.g. 2023-10-06T19:00:00+07:00
+func queryTime(c *gin.Context, key string) (value time.Time, err error) {
+	v := c.Query(key)
+	if v == "" {
+		return
+	}
+	value, err = time.Parse(time.RFC3339, v)
+	if err != nil {
+		return value, domain.NewFieldError(key, domain.CodeInvalid, fmt.Sprintf("invalid %s, please use RFC 3339 format", key))
+	}
+	return
+}
+
+// queryList accepts both repeated (?status=1&status=2) and comma separated (?status=1,2) values
+func queryList(c *gin.Context, key string) (values []string) {
+	for _, v := range c.QueryArray(key) {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+	return
+}
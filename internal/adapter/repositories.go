@@ -0,0 +1,82 @@
+package adapter
+
+import (
+	"log"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/memory"
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/mysql"
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/sqlite"
+	"github.com/hieronimusbudi/simple-go-api/internal/config"
+	domainRepository "github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	DriverMySQL  = "mysql"
+	DriverSQLite = "sqlite"
+	DriverMemory = "memory"
+)
+
+// Repositories groups repository implementations of one persistence adapter
+type Repositories struct {
+	Member     domainRepository.IMember
+	Gathering  domainRepository.IGathering
+	Invitation domainRepository.IInvitation
+	Credential domainRepository.ICredential
+	Group      domainRepository.IGroup
+	// Notification is the outbox other repositories write notifications to
+	Notification domainRepository.INotification
+	Webhook      domainRepository.IWebhook
+	Reminder     domainRepository.IReminder
+	// Lease lets one replica at a time run a background job
+	Lease domainRepository.ILease
+}
+
+// Connection opens the database selected by DBDRIVER config, db is nil for memory driver
+func Connection() (db *sqlx.DB, driver string) {
+	driver = config.Get().DBDRIVER
+	switch driver {
+	case DriverMemory:
+		return nil, driver
+	case DriverSQLite:
+		return sqlite.Connection(), driver
+	case DriverMySQL, "":
+		return mysql.Connection(), DriverMySQL
+	}
+	log.Fatalf("unknown DBDRIVER: %s", driver)
+	return
+}
+
+// NewRepositories builds repositories of the adapter selected by DBDRIVER config, MySQL and SQLite share the SQL repositories
+func NewRepositories() Repositories {
+	db, driver := Connection()
+	switch driver {
+	case DriverMemory:
+		store := memory.NewStore()
+		return Repositories{
+			Member:       memory.NewMemberRepository(memory.MemberAdapterRepositoryArgs{Store: store}),
+			Gathering:    memory.NewGatheringRepository(memory.GatheringAdapterRepositoryArgs{Store: store}),
+			Invitation:   memory.NewInvitationRepository(memory.InvitationAdapterRepositoryArgs{Store: store}),
+			Credential:   memory.NewCredentialRepository(memory.CredentialAdapterRepositoryArgs{Store: store}),
+			Group:        memory.NewGroupRepository(memory.GroupAdapterRepositoryArgs{Store: store}),
+			Notification: memory.NewNotificationRepository(memory.NotificationAdapterRepositoryArgs{Store: store}),
+			Webhook:      memory.NewWebhookRepository(memory.WebhookAdapterRepositoryArgs{Store: store}),
+			Reminder:     memory.NewReminderRepository(memory.ReminderAdapterRepositoryArgs{Store: store}),
+			Lease:        memory.NewLeaseRepository(memory.LeaseAdapterRepositoryArgs{Store: store}),
+		}
+	default:
+		prepareSchema(db, driver)
+		return Repositories{
+			Member:       repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{DB: db}),
+			Gathering:    repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{DB: db}),
+			Invitation:   repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{DB: db}),
+			Credential:   repository.NewCredentialRepository(repository.CredentialAdapterRepositoryArgs{DB: db}),
+			Group:        repository.NewGroupRepository(repository.GroupAdapterRepositoryArgs{DB: db}),
+			Notification: repository.NewNotificationRepository(repository.NotificationAdapterRepositoryArgs{DB: db}),
+			Webhook:      repository.NewWebhookRepository(repository.WebhookAdapterRepositoryArgs{DB: db}),
+			Reminder:     repository.NewReminderRepository(repository.ReminderAdapterRepositoryArgs{DB: db}),
+			Lease:        repository.NewLeaseRepository(repository.LeaseAdapterRepositoryArgs{DB: db}),
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	credentialAdapterRepository struct {
+		db      *sqlx.DB
+		dialect Dialect
+	}
+
+	CredentialAdapterRepositoryArgs struct {
+		DB *sqlx.DB
+	}
+)
+
+func NewCredentialRepository(args CredentialAdapterRepositoryArgs) repository.ICredential {
+	return &credentialAdapterRepository{
+		db:      args.DB,
+		dialect: dialectOf(args.DB),
+	}
+}
+
+// Save creates the member credential or replaces its password hash
+func (r *credentialAdapterRepository) Save(ctx context.Context, credential domain.Credential) (err error) {
+	query := `INSERT INTO member_credentials (
+		member_id
+		, password_hash
+		, created_at
+	) VALUES (?, ?, CURRENT_TIMESTAMP)
+	` + r.dialect.upsert([]string{"member_id"}, "password_hash") + `, updated_at = CURRENT_TIMESTAMP`
+	_, err = r.db.ExecContext(
+		ctx,
+		query,
+		credential.MemberID,
+		credential.PasswordHash,
+	)
+	if err != nil {
+		log.Println(err)
+		err = constraintError(err, "credential already exists")
+	}
+	return
+}
+
+func (r *credentialAdapterRepository) Get(ctx context.Context, args domain.CredentialArgs) (credentials []domain.Credential, err error) {
+	credentials = []domain.Credential{}
+	query := `
+		SELECT
+			member_id
+			, password_hash
+			, created_at
+		FROM member_credentials
+		WHERE member_id = ?
+	`
+	err = r.db.SelectContext(ctx, &credentials, query, args.MemberID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println(err)
+	}
+	return
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Dialect is the SQL flavor of the database, repositories spell with it the few clauses MySQL and SQLite write differently.
+// Both read CURRENT_TIMESTAMP as the current time.
+type Dialect string
+
+const (
+	DialectMySQL  Dialect = "mysql"
+	DialectSQLite Dialect = "sqlite"
+)
+
+// dialectOf tells the dialect by the driver db was opened with, MySQL unless it is SQLite
+func dialectOf(db *sqlx.DB) Dialect {
+	if db != nil && db.DriverName() == string(DialectSQLite) {
+		return DialectSQLite
+	}
+	return DialectMySQL
+}
+
+// timeParam is the placeholder of a time, SQLite normalizes it with datetime so it compares with stored times as text
+func (d Dialect) timeParam() string {
+	if d == DialectSQLite {
+		return "datetime(?)"
+	}
+	return "?"
+}
+
+// insertIgnore starts an insert that skips rows breaking a unique key
+func (d Dialect) insertIgnore() string {
+	if d == DialectSQLite {
+		return "INSERT OR IGNORE"
+	}
+	return "INSERT IGNORE"
+}
+
+// forUpdate locks the selected rows until the transaction ends, SQLite has a single writer and needs no lock
+func (d Dialect) forUpdate() string {
+	if d == DialectSQLite {
+		return ""
+	}
+	return "FOR UPDATE"
+}
+
+// upsert ends an insert so a row whose keys already exist gets the inserted columns instead
+func (d Dialect) upsert(keys []string, columns ...string) string {
+	set := []string{}
+	for _, c := range columns {
+		if d == DialectSQLite {
+			set = append(set, fmt.Sprintf("%s = excluded.%s", c, c))
+		} else {
+			set = append(set, fmt.Sprintf("%s = VALUES(%s)", c, c))
+		}
+	}
+	if d == DialectSQLite {
+		return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(keys, ", "), strings.Join(set, ", "))
+	}
+	return fmt.Sprintf("ON DUPLICATE KEY UPDATE %s", strings.Join(set, ", "))
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// mysql server error numbers of constraint violations
+const (
+	errDuplicateEntry     = 1062
+	errRowIsReferenced    = 1451
+	errNoReferencedRow    = 1452
+	errNoReferencedRowOld = 1216
+)
+
+// constraintError turns a constraint violation into a domain error, a duplicate entry gets conflictMessage.
+// Other errors are returned as is.
+func constraintError(err error, conflictMessage string) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case errDuplicateEntry:
+			return domain.WrapError(domain.ErrConflict, conflictMessage, err)
+		case errNoReferencedRow, errNoReferencedRowOld:
+			return domain.WrapError(domain.ErrValidation, "referenced member or gathering does not exist", err)
+		case errRowIsReferenced:
+			return domain.WrapError(domain.ErrConflict, "the row is still referenced", err)
+		}
+		return err
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+			return domain.WrapError(domain.ErrConflict, conflictMessage, err)
+		case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+			return domain.WrapError(domain.ErrValidation, "referenced member or gathering does not exist", err)
+		}
+	}
+	return err
+}
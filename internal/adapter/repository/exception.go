@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+)
+
+// exceptionRow is an occurrence exception as stored, times are UTC
+type exceptionRow struct {
+	domain.Occurrence
+	RecurrenceID string         `db:"recurrence_id"`
+	ScheduledAt  string         `db:"scheduled_at"`
+	EndsAt       sql.NullString `db:"ends_at"`
+}
+
+// saveException inserts the exception of an occurrence or replaces the one already stored
+func saveException(ctx context.Context, tx *sql.Tx, dialect Dialect, occurrence domain.Occurrence) (err error) {
+	t := dialect.timeParam()
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`
+	INSERT INTO gathering_exceptions (
+		gathering_id
+		, recurrence_id
+		, scheduled_at
+		, ends_at
+		, name
+		, location
+		, canceled
+	) VALUES (?, %s, %s, %s, ?, ?, ?)
+	%s`, t, t, t, dialect.upsert([]string{"gathering_id", "recurrence_id"}, "scheduled_at", "ends_at", "name", "location", "canceled")),
+		occurrence.GatheringID,
+		helpers.FormatDBTime(occurrence.RecurrenceID),
+		helpers.FormatDBTime(occurrence.ScheduledAt),
+		helpers.NullDBTime(occurrence.EndsAt),
+		occurrence.Name,
+		occurrence.Location,
+		occurrence.Canceled,
+	)
+	if err != nil {
+		tx.Rollback()
+		log.Println(err)
+	}
+	return
+}
+
+// occurrence converts stored UTC times, the gathering localizes them
+func (row exceptionRow) occurrence() (occurrence domain.Occurrence, err error) {
+	occurrence = row.Occurrence
+	if occurrence.RecurrenceID, err = helpers.ParseDBTime(row.RecurrenceID); err != nil {
+		return
+	}
+	if occurrence.ScheduledAt, err = helpers.ParseDBTime(row.ScheduledAt); err != nil {
+		return
+	}
+	if row.EndsAt.Valid {
+		endsAt, err := helpers.ParseDBTime(row.EndsAt.String)
+		if err != nil {
+			return occurrence, err
+		}
+		occurrence.EndsAt = &endsAt
+	}
+	return
+}
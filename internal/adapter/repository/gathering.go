@@ -6,38 +6,55 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hieronimusbudi/simple-go-api/internal/domain"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
 	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
 	"github.com/jmoiron/sqlx"
 )
 
 type (
 	gatheringAdapterRepository struct {
-		db *sqlx.DB
+		db      *sqlx.DB
+		dialect Dialect
 	}
 
 	GatheringAdapterRepositoryArgs struct {
 		DB *sqlx.DB
 	}
+
+	// gatheringRow is a gathering as stored, scheduled_at and ends_at are UTC
+	gatheringRow struct {
+		domain.Gathering
+		ScheduledAt string         `db:"scheduled_at"`
+		EndsAt      sql.NullString `db:"ends_at"`
+	}
 )
 
 func NewGatheringRepository(args GatheringAdapterRepositoryArgs) repository.IGathering {
 	return &gatheringAdapterRepository{
-		db: args.DB,
+		db:      args.DB,
+		dialect: dialectOf(args.DB),
 	}
 }
 
 func (r *gatheringAdapterRepository) Create(ctx context.Context, gathering domain.Gathering) (id int64, err error) {
-	query := `INSERT INTO gatherings (
+	t := r.dialect.timeParam()
+	query := fmt.Sprintf(`INSERT INTO gatherings (
 		creator
 		, type
 		, scheduled_at
+		, ends_at
+		, time_zone
+		, recurrence
+		, calendar_uid
 		, name
 		, location
+		, capacity
 		, created_at
-	) VALUES (?, ?, ?, ?, ?, NOW())`
+	) VALUES (?, ?, %s, %s, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)`, t, t)
 	tx, err := r.db.Begin()
 	if err != nil {
 		log.Println(err)
@@ -48,13 +65,19 @@ func (r *gatheringAdapterRepository) Create(ctx context.Context, gathering domai
 		query,
 		gathering.Creator.ID,
 		gathering.Type,
-		gathering.ScheduledAt,
+		helpers.FormatDBTime(gathering.ScheduledAt),
+		helpers.NullDBTime(gathering.EndsAt),
+		gathering.TimeZone,
+		gathering.Recurrence,
+		helpers.NullString(gathering.CalendarUID),
 		gathering.Name,
 		gathering.Location,
+		gathering.Capacity,
 	)
 	if err != nil {
 		tx.Rollback()
 		log.Println(err)
+		err = constraintError(err, "gathering already exists")
 		return
 	}
 	id, err = insertResult.LastInsertId()
@@ -67,64 +90,89 @@ func (r *gatheringAdapterRepository) Create(ctx context.Context, gathering domai
 			err = createAttendee(ctx, tx, member.ID, id)
 			if err != nil {
 				log.Println(err)
+				err = constraintError(err, "an attendee is listed more than once")
 				return
 			}
 		}
 	}
+	for _, exception := range gathering.Exceptions {
+		exception.GatheringID = id
+		if err = saveException(ctx, tx, r.dialect, exception); err != nil {
+			return
+		}
+	}
 	tx.Commit()
 	return
 }
 
 func (r *gatheringAdapterRepository) Get(ctx context.Context, args domain.GatheringArgs) (gatherings []domain.Gathering, err error) {
 	gatherings = []domain.Gathering{}
-	conditions := []string{}
+	conditions, params := gatheringConditions(r.dialect, args)
 	query := `
 		SELECT
 			id
 			, creator
 			, type
 			, scheduled_at
+			, ends_at
+			, time_zone
+			, recurrence
+			, COALESCE(calendar_uid, '') AS calendar_uid
 			, name
 			, location
+			, capacity
 			, created_at
 			, COALESCE(discarded_at, '') AS discarded_at
 		FROM gatherings
 	`
-	if !args.IsIncludeDiscard {
-		conditions = append(conditions, `discarded_at IS NULL`)
+	condition, cursorParams, err := cursorCondition(args.Pagination, gatheringSortColumns)
+	if err != nil {
+		return
 	}
-	if len(args.IDs) > 0 {
-		conditions = append(conditions, fmt.Sprintf(`id IN (%s)`, helpers.IntSliceToString(args.IDs)))
+	if condition != "" {
+		conditions = append(conditions, condition)
+		params = append(params, cursorParams...)
 	}
 	if len(conditions) > 0 {
 		query += fmt.Sprintf(` WHERE %s`, strings.Join(conditions, " AND "))
 	}
-	err = r.db.SelectContext(ctx, &gatherings, query)
+	query += orderAndLimit(args.Pagination, gatheringSortColumns)
+	rows := []gatheringRow{}
+	err = r.db.SelectContext(ctx, &rows, query, params...)
 	if err != nil && err != sql.ErrNoRows {
 		log.Println(err)
 		return
 	}
-
-	attendeesQuery := `SELECT member_id, gathering_id FROM attendees`
-	conditions = []string{}
-	if len(args.IDs) > 0 {
-		conditions = append(conditions, fmt.Sprintf(`gathering_id IN (%s)`, helpers.IntSliceToString(args.IDs)))
+	if len(rows) == 0 {
+		return
 	}
-	if len(conditions) > 0 {
-		attendeesQuery += fmt.Sprintf(` WHERE %s`, strings.Join(conditions, " AND "))
+	for _, row := range rows {
+		gathering, err := row.gathering()
+		if err != nil {
+			log.Println(err)
+			return []domain.Gathering{}, err
+		}
+		gatherings = append(gatherings, gathering)
+	}
+
+	gatheringIDs := []int64{}
+	for _, g := range gatherings {
+		gatheringIDs = append(gatheringIDs, g.ID)
 	}
-	rows, err := r.db.Query(
+	attendeesQuery := fmt.Sprintf(`SELECT member_id, gathering_id FROM attendees WHERE gathering_id IN (%s)`, helpers.IntSliceToString(gatheringIDs))
+	attendeeRows, err := r.db.QueryContext(
+		ctx,
 		attendeesQuery,
 	)
 	if err != nil && err != sql.ErrNoRows {
 		log.Println(err)
 		return
 	}
-	defer rows.Close()
+	defer attendeeRows.Close()
 	mapAttendeesByGatheringID := map[int64][]int64{}
-	for rows.Next() {
+	for attendeeRows.Next() {
 		var gID, aID int64
-		if err = rows.Scan(
+		if err = attendeeRows.Scan(
 			&aID,
 			&gID,
 		); err != nil {
@@ -139,6 +187,11 @@ func (r *gatheringAdapterRepository) Get(ctx context.Context, args domain.Gather
 		mapAttendeesByGatheringID[gID] = attendees
 	}
 
+	mapExceptionsByGatheringID, err := r.exceptions(ctx, gatheringIDs)
+	if err != nil {
+		return
+	}
+
 	for i, g := range gatherings {
 		g.Creator.ID = g.CreatorID
 		attendees, ok := mapAttendeesByGatheringID[g.ID]
@@ -148,32 +201,143 @@ func (r *gatheringAdapterRepository) Get(ctx context.Context, args domain.Gather
 				g.Attendees = append(g.Attendees, domain.Member{ID: a})
 			}
 		}
+		g.Exceptions = mapExceptionsByGatheringID[g.ID]
+		g.Localize()
 		gatherings[i] = g
 	}
 	return
 }
 
-func (r *gatheringAdapterRepository) Update(ctx context.Context, gathering domain.Gathering) (err error) {
+// exceptions loads occurrence exceptions of the gatherings ordered by recurrence id
+func (r *gatheringAdapterRepository) exceptions(ctx context.Context, gatheringIDs []int64) (exceptions map[int64][]domain.Occurrence, err error) {
+	exceptions = map[int64][]domain.Occurrence{}
+	query := fmt.Sprintf(`
+		SELECT
+			gathering_id
+			, recurrence_id
+			, scheduled_at
+			, ends_at
+			, name
+			, location
+			, canceled
+		FROM gathering_exceptions
+		WHERE gathering_id IN (%s)
+		ORDER BY gathering_id, recurrence_id`, helpers.IntSliceToString(gatheringIDs))
+	rows := []exceptionRow{}
+	if err = r.db.SelectContext(ctx, &rows, query); err != nil {
+		log.Println(err)
+		return
+	}
+	for _, row := range rows {
+		occurrence, err := row.occurrence()
+		if err != nil {
+			log.Println(err)
+			return exceptions, err
+		}
+		exceptions[occurrence.GatheringID] = append(exceptions[occurrence.GatheringID], occurrence)
+	}
+	return
+}
+
+func (r *gatheringAdapterRepository) Count(ctx context.Context, args domain.GatheringArgs) (total int64, err error) {
+	conditions, params := gatheringConditions(r.dialect, args)
+	query := `SELECT COUNT(id) FROM gatherings`
+	if len(conditions) > 0 {
+		query += fmt.Sprintf(` WHERE %s`, strings.Join(conditions, " AND "))
+	}
+	err = r.db.GetContext(ctx, &total, query, params...)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+func gatheringConditions(dialect Dialect, args domain.GatheringArgs) (conditions []string, params []interface{}) {
+	conditions = []string{}
+	if !args.IsIncludeDiscard {
+		conditions = append(conditions, `discarded_at IS NULL`)
+	}
+	if len(args.IDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`id IN (%s)`, helpers.IntSliceToString(args.IDs)))
+	}
+	if len(args.MemberIDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`id IN (SELECT gathering_id FROM attendees WHERE member_id IN (%s))`, helpers.IntSliceToString(args.MemberIDs)))
+	}
+	if args.CreatorID > 0 {
+		conditions = append(conditions, `creator = ?`)
+		params = append(params, args.CreatorID)
+	}
+	if args.IsVisibleOnly {
+		conditions = append(conditions, `(type = ?
+			OR creator = ?
+			OR id IN (SELECT gathering_id FROM attendees WHERE member_id = ?)
+			OR id IN (SELECT gathering_id FROM invitations WHERE member_id = ?))`)
+		params = append(params, valueobject.PUBLIC, args.ViewerID, args.ViewerID, args.ViewerID)
+	}
+	if args.CalendarUID != "" {
+		conditions = append(conditions, `calendar_uid = ?`)
+		params = append(params, args.CalendarUID)
+	}
+	if len(args.Types) > 0 {
+		placeholders := []string{}
+		for _, t := range args.Types {
+			placeholders = append(placeholders, "?")
+			params = append(params, t)
+		}
+		conditions = append(conditions, fmt.Sprintf(`type IN (%s)`, strings.Join(placeholders, ", ")))
+	}
+	if args.Name != "" {
+		conditions = append(conditions, `name LIKE ?`)
+		params = append(params, "%"+args.Name+"%")
+	}
+	if args.Location != "" {
+		conditions = append(conditions, `location LIKE ?`)
+		params = append(params, "%"+args.Location+"%")
+	}
+	if !args.ScheduledFrom.IsZero() && args.IsIncludeSeries {
+		conditions = append(conditions, fmt.Sprintf(`(scheduled_at >= %s OR recurrence != '')`, dialect.timeParam()))
+		params = append(params, helpers.FormatDBTime(args.ScheduledFrom))
+	} else if !args.ScheduledFrom.IsZero() {
+		conditions = append(conditions, fmt.Sprintf(`scheduled_at >= %s`, dialect.timeParam()))
+		params = append(params, helpers.FormatDBTime(args.ScheduledFrom))
+	}
+	if !args.ScheduledTo.IsZero() {
+		conditions = append(conditions, fmt.Sprintf(`scheduled_at <= %s`, dialect.timeParam()))
+		params = append(params, helpers.FormatDBTime(args.ScheduledTo))
+	}
+	return
+}
+
+func (r *gatheringAdapterRepository) Update(ctx context.Context, gathering domain.Gathering, notifications ...domain.Notification) (promotedIDs []int64, err error) {
 	tx, err := r.db.Begin()
 	if err != nil {
 		log.Println(err)
 		return
 	}
 
-	query := `UPDATE gatherings SET
+	t := r.dialect.timeParam()
+	query := fmt.Sprintf(`UPDATE gatherings SET
 		type = ?
-		, scheduled_at = ?
+		, scheduled_at = %s
+		, ends_at = %s
+		, time_zone = ?
+		, recurrence = ?
 		, name = ?
 		, location = ?
-		, updated_at = NOW()
-		WHERE id = ?`
+		, capacity = ?
+		, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`, t, t)
 	_, err = tx.ExecContext(
 		ctx,
 		query,
 		gathering.Type,
-		gathering.ScheduledAt,
+		helpers.FormatDBTime(gathering.ScheduledAt),
+		helpers.NullDBTime(gathering.EndsAt),
+		gathering.TimeZone,
+		gathering.Recurrence,
 		gathering.Name,
 		gathering.Location,
+		gathering.Capacity,
 		gathering.ID,
 	)
 	if err != nil {
@@ -181,21 +345,235 @@ func (r *gatheringAdapterRepository) Update(ctx context.Context, gathering domai
 		log.Println(err)
 		return
 	}
-	tx.Commit()
+	// a raised capacity frees seats for the waitlist
+	if promotedIDs, err = promoteWaitlist(ctx, tx, r.dialect, gathering.ID); err != nil {
+		return
+	}
+	if err = saveNotifications(ctx, tx, notifications); err != nil {
+		return
+	}
+	err = tx.Commit()
+	return
+}
+
+// SaveOccurrence stores an edited or canceled occurrence of a series, replacing an earlier edit of it
+func (r *gatheringAdapterRepository) SaveOccurrence(ctx context.Context, occurrence domain.Occurrence, notifications ...domain.Notification) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if err = saveException(ctx, tx, r.dialect, occurrence); err != nil {
+		err = constraintError(err, "occurrence already exists")
+		return
+	}
+	if err = saveNotifications(ctx, tx, notifications); err != nil {
+		return
+	}
+	err = tx.Commit()
 	return
 }
 
-func (r *gatheringAdapterRepository) Delete(ctx context.Context, args domain.GatheringArgs) (err error) {
+// Split ends the current series and creates the following one starting at splitAt in one transaction.
+// Attendees and invitations of the current series are copied to the following one.
+func (r *gatheringAdapterRepository) Split(ctx context.Context, current domain.Gathering, following domain.Gathering, splitAt time.Time, notifications ...domain.Notification) (id int64, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE gatherings SET recurrence = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`, current.Recurrence, current.ID)
+	if err != nil {
+		tx.Rollback()
+		log.Println(err)
+		return
+	}
+	t := r.dialect.timeParam()
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM gathering_exceptions WHERE gathering_id = ? AND recurrence_id >= %s`, t), current.ID, helpers.FormatDBTime(splitAt))
+	if err != nil {
+		tx.Rollback()
+		log.Println(err)
+		return
+	}
+	result, err := tx.ExecContext(ctx, fmt.Sprintf(`INSERT INTO gatherings (
+		creator
+		, type
+		, scheduled_at
+		, ends_at
+		, time_zone
+		, recurrence
+		, name
+		, location
+		, capacity
+		, created_at
+	) VALUES (?, ?, %s, %s, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)`, t, t),
+		following.Creator.ID,
+		following.Type,
+		helpers.FormatDBTime(following.ScheduledAt),
+		helpers.NullDBTime(following.EndsAt),
+		following.TimeZone,
+		following.Recurrence,
+		following.Name,
+		following.Location,
+		following.Capacity,
+	)
+	if err != nil {
+		tx.Rollback()
+		log.Println(err)
+		return
+	}
+	if id, err = result.LastInsertId(); err != nil {
+		tx.Rollback()
+		log.Println(err)
+		return
+	}
+	for _, query := range []string{
+		`INSERT INTO attendees (member_id, gathering_id) SELECT member_id, ? FROM attendees WHERE gathering_id = ?`,
+		`INSERT INTO invitations (member_id, gathering_id, status, waitlisted_at, guests, note, created_at) SELECT member_id, ?, status, waitlisted_at, guests, note, CURRENT_TIMESTAMP FROM invitations WHERE gathering_id = ?`,
+	} {
+		if _, err = tx.ExecContext(ctx, query, id, current.ID); err != nil {
+			tx.Rollback()
+			log.Println(err)
+			return
+		}
+	}
+	for _, exception := range following.Exceptions {
+		exception.GatheringID = id
+		if err = saveException(ctx, tx, r.dialect, exception); err != nil {
+			return
+		}
+	}
+	// notifications tell about the following series
+	for i := range notifications {
+		notifications[i].Data.GatheringID = id
+	}
+	if err = saveNotifications(ctx, tx, notifications); err != nil {
+		return
+	}
+	err = tx.Commit()
+	return
+}
+
+func (r *gatheringAdapterRepository) Delete(ctx context.Context, args domain.GatheringArgs, notifications ...domain.Notification) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Println(err)
+		return
+	}
 	query := `UPDATE gatherings SET
-		discarded_at = NOW()
+		discarded_at = CURRENT_TIMESTAMP
 		WHERE id = ?`
-	_, err = r.db.ExecContext(
+	_, err = tx.ExecContext(
 		ctx,
 		query,
 		args.ID,
 	)
 	if err != nil {
+		tx.Rollback()
 		log.Println(err)
+		return
+	}
+	if err = saveNotifications(ctx, tx, notifications); err != nil {
+		return
+	}
+	err = tx.Commit()
+	return
+}
+
+// Join makes the member an attendee when the gathering has a seat for them
+func (r *gatheringAdapterRepository) Join(ctx context.Context, gatheringID int64, memberID int64) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	ok, err := hasSeats(ctx, tx, r.dialect, gatheringID, 1)
+	if err != nil {
+		return
+	}
+	if !ok {
+		tx.Rollback()
+		return domain.NewError(domain.ErrConflict, "the gathering is full")
+	}
+	if err = createAttendee(ctx, tx, memberID, gatheringID); err != nil {
+		return constraintError(err, "the member already attends the gathering")
+	}
+	err = tx.Commit()
+	return
+}
+
+// Leave removes the member from the attendees, an accepted invitation of the member is rejected so its guests free their seats too
+func (r *gatheringAdapterRepository) Leave(ctx context.Context, gatheringID int64, memberID int64) (promotedIDs []int64, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if err = removeAttendee(ctx, tx, memberID, gatheringID); err != nil {
+		return
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE invitations SET status = ? WHERE gathering_id = ? AND member_id = ? AND status = ?`,
+		valueobject.INVITATION_REJECT, gatheringID, memberID, valueobject.INVITATION_ACCEPT)
+	if err != nil {
+		tx.Rollback()
+		log.Println(err)
+		return
+	}
+	if promotedIDs, err = promoteWaitlist(ctx, tx, r.dialect, gatheringID); err != nil {
+		return
+	}
+	err = tx.Commit()
+	return
+}
+
+// Purge hard deletes gatherings discarded before the given time together with their invitations, attendees, occurrence exceptions and group links
+func (r *gatheringAdapterRepository) Purge(ctx context.Context, discardedBefore string) (total int64, err error) {
+	purgeable := `SELECT id FROM gatherings WHERE discarded_at < ?`
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for _, query := range []string{
+		fmt.Sprintf(`DELETE FROM attendees WHERE gathering_id IN (%s)`, purgeable),
+		fmt.Sprintf(`DELETE FROM invitations WHERE gathering_id IN (%s)`, purgeable),
+		fmt.Sprintf(`DELETE FROM gathering_exceptions WHERE gathering_id IN (%s)`, purgeable),
+		fmt.Sprintf(`DELETE FROM group_gatherings WHERE gathering_id IN (%s)`, purgeable),
+	} {
+		if _, err = tx.ExecContext(ctx, query, discardedBefore); err != nil {
+			tx.Rollback()
+			log.Println(err)
+			return
+		}
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM gatherings WHERE discarded_at < ?`, discardedBefore)
+	if err != nil {
+		tx.Rollback()
+		log.Println(err)
+		return
+	}
+	if total, err = result.RowsAffected(); err != nil {
+		tx.Rollback()
+		log.Println(err)
+		return
+	}
+	err = tx.Commit()
+	return
+}
+
+// gathering converts stored UTC times to the gathering time zone
+func (row gatheringRow) gathering() (gathering domain.Gathering, err error) {
+	gathering = row.Gathering
+	if gathering.ScheduledAt, err = helpers.ParseDBTime(row.ScheduledAt); err != nil {
+		return
+	}
+	if row.EndsAt.Valid {
+		endsAt, err := helpers.ParseDBTime(row.EndsAt.String)
+		if err != nil {
+			return gathering, err
+		}
+		gathering.EndsAt = &endsAt
 	}
+	gathering.Localize()
 	return
 }
@@ -2,10 +2,13 @@ package repository_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/hieronimusbudi/simple-go-api/internal/adapter/repository"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/test"
 	"github.com/stretchr/testify/require"
 )
 
@@ -16,7 +19,7 @@ func Test_gatheringAdapterRepository_Create(t *testing.T) {
 			ID: 1,
 		},
 		Type:        0,
-		ScheduledAt: "2020-10-06 11:53",
+		ScheduledAt: time.Date(2020, 10, 6, 11, 53, 0, 0, time.UTC),
 		Name:        "Private Gathering",
 		Location:    "Local Street",
 		Attendees: []domain.Member{
@@ -67,7 +70,8 @@ func Test_gatheringAdapterRepository_Get(t *testing.T) {
 				ID: 1,
 			},
 			Type:        0,
-			ScheduledAt: "2023-10-06T05:00:00Z",
+			ScheduledAt: time.Date(2023, 10, 6, 5, 0, 0, 0, time.UTC),
+			TimeZone:    domain.DefaultTimeZone,
 			CreatedAt:   "2023-10-02T11:06:52Z",
 			Name:        "Private Meeting",
 			Location:    "pramuka street",
@@ -114,6 +118,9 @@ func Test_gatheringAdapterRepository_Get(t *testing.T) {
 }
 
 func Test_gatheringAdapterRepository_Update(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	endsAt := time.Date(2023, 10, 6, 13, 53, 0, 0, jakarta)
 	gathering := domain.Gathering{
 		ID:        1,
 		CreatorID: 1,
@@ -121,7 +128,9 @@ func Test_gatheringAdapterRepository_Update(t *testing.T) {
 			ID: 1,
 		},
 		Type:        0,
-		ScheduledAt: "2023-10-06 04:53",
+		ScheduledAt: time.Date(2023, 10, 6, 11, 53, 0, 0, jakarta),
+		EndsAt:      &endsAt,
+		TimeZone:    "Asia/Jakarta",
 		CreatedAt:   "2023-10-02T11:06:52Z",
 		Name:        "Update Private Meeting",
 		Location:    "update pramuka street",
@@ -132,7 +141,7 @@ func Test_gatheringAdapterRepository_Update(t *testing.T) {
 		},
 	}
 	wantGathering := gathering
-	wantGathering.ScheduledAt = "2023-10-06T04:53:00Z"
+	wantGathering.DurationMinutes = 120
 	type args struct {
 		gathering domain.Gathering
 	}
@@ -155,7 +164,7 @@ func Test_gatheringAdapterRepository_Update(t *testing.T) {
 			repo := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{
 				DB: db,
 			})
-			err := repo.Update(context.Background(), tt.args.gathering)
+			_, err := repo.Update(context.Background(), tt.args.gathering)
 			if tt.wantErr {
 				require.Error(t, err)
 			} else {
@@ -165,8 +174,12 @@ func Test_gatheringAdapterRepository_Update(t *testing.T) {
 					IDs: []int64{tt.args.gathering.ID},
 				})
 				require.NoError(t, err)
-				gotGathering := gatherings[0]
-				require.Equal(t, tt.wantGathering, gotGathering)
+				// times are compared in their JSON form, with the offset of the time zone
+				want, err := json.Marshal(tt.wantGathering)
+				require.NoError(t, err)
+				got, err := json.Marshal(gatherings[0])
+				require.NoError(t, err)
+				require.JSONEq(t, string(want), string(got))
 			}
 		})
 	}
@@ -210,3 +223,45 @@ func Test_gatheringAdapterRepository_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_gatheringAdapterRepository_Purge(t *testing.T) {
+	// purge removes rows other tests rely on, so it runs on its own database
+	db, err := test.SetupMySQLDatabase("purge_gatherings")
+	require.NoError(t, err)
+	defer db.Close()
+	repo := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{DB: db})
+	invitationRepo := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+	newerID, err := repo.Create(ctx, domain.Gathering{
+		Creator:     domain.Member{ID: 2},
+		ScheduledAt: time.Date(2020, 10, 6, 11, 53, 0, 0, time.UTC),
+		Name:        "Newer Gathering",
+		Location:    "Local Street",
+		Attendees:   []domain.Member{{ID: 2}},
+	})
+	require.NoError(t, err)
+	_, err = invitationRepo.Create(ctx, domain.Invitation{Member: domain.Member{ID: 1}, Gathering: domain.Gathering{ID: newerID}})
+	require.NoError(t, err)
+	// gathering 1 of data.sql, attended by member 1 and with an invitation of member 2, was discarded before the cutoff
+	_, err = db.ExecContext(ctx, `UPDATE gatherings SET discarded_at = ? WHERE id = ?`, "2020-01-01 00:00:00", 1)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, `UPDATE gatherings SET discarded_at = ? WHERE id = ?`, "2022-01-01 00:00:00", newerID)
+	require.NoError(t, err)
+
+	total, err := repo.Purge(ctx, "2021-01-01 00:00:00")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	// check data
+	gatherings, err := repo.Get(ctx, domain.GatheringArgs{IsIncludeDiscard: true})
+	require.NoError(t, err)
+	require.Len(t, gatherings, 1)
+	require.Equal(t, newerID, gatherings[0].ID)
+	for gatheringID, want := range map[int64]int{1: 0, newerID: 1} {
+		invitations, err := invitationRepo.Get(ctx, domain.InvitationArgs{GatheringID: gatheringID})
+		require.NoError(t, err)
+		require.Len(t, invitations, want, "invitations of gathering %d", gatheringID)
+		var attendees int
+		require.NoError(t, db.GetContext(ctx, &attendees, `SELECT COUNT(*) FROM attendees WHERE gathering_id = ?`, gatheringID))
+		require.Equal(t, want, attendees, "attendees of gathering %d", gatheringID)
+	}
+}
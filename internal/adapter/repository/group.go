@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	groupAdapterRepository struct {
+		db *sqlx.DB
+	}
+
+	GroupAdapterRepositoryArgs struct {
+		DB *sqlx.DB
+	}
+)
+
+func NewGroupRepository(args GroupAdapterRepositoryArgs) repository.IGroup {
+	return &groupAdapterRepository{
+		db: args.DB,
+	}
+}
+
+func (r *groupAdapterRepository) Create(ctx context.Context, group domain.Group) (id int64, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	insertResult, err := tx.ExecContext(ctx, `INSERT INTO member_groups (
+		name
+		, created_at
+	) VALUES (?, CURRENT_TIMESTAMP)`, group.Name)
+	if err != nil {
+		tx.Rollback()
+		log.Println(err)
+		return
+	}
+	id, err = insertResult.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		log.Println(err)
+		return
+	}
+	for _, member := range group.Members {
+		member.GroupID = id
+		if err = addGroupMember(ctx, tx, member); err != nil {
+			err = constraintError(err, "a member is listed more than once")
+			return
+		}
+	}
+	err = tx.Commit()
+	return
+}
+
+func (r *groupAdapterRepository) Get(ctx context.Context, args domain.GroupArgs) (groups []domain.Group, err error) {
+	groups = []domain.Group{}
+	conditions, params := groupConditions(args)
+	query := `
+		SELECT
+			id
+			, name
+			, created_at
+		FROM member_groups
+	`
+	condition, cursorParams, err := cursorCondition(args.Pagination, groupSortColumns)
+	if err != nil {
+		return
+	}
+	if condition != "" {
+		conditions = append(conditions, condition)
+		params = append(params, cursorParams...)
+	}
+	if len(conditions) > 0 {
+		query += fmt.Sprintf(` WHERE %s`, strings.Join(conditions, " AND "))
+	}
+	query += orderAndLimit(args.Pagination, groupSortColumns)
+	err = r.db.SelectContext(ctx, &groups, query, params...)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println(err)
+		return
+	}
+	if len(groups) == 0 {
+		return
+	}
+
+	groupIDs := []int64{}
+	for _, g := range groups {
+		groupIDs = append(groupIDs, g.ID)
+	}
+	members := []domain.GroupMember{}
+	err = r.db.SelectContext(ctx, &members, fmt.Sprintf(`
+		SELECT
+			group_id
+			, member_id
+			, role
+			, created_at
+		FROM group_members
+		WHERE group_id IN (%s)
+		ORDER BY created_at, member_id`, helpers.IntSliceToString(groupIDs)))
+	if err != nil && err != sql.ErrNoRows {
+		log.Println(err)
+		return
+	}
+	links := []struct {
+		GroupID     int64 `db:"group_id"`
+		GatheringID int64 `db:"gathering_id"`
+	}{}
+	err = r.db.SelectContext(ctx, &links, fmt.Sprintf(`
+		SELECT group_id, gathering_id
+		FROM group_gatherings
+		WHERE group_id IN (%s)
+		ORDER BY gathering_id`, helpers.IntSliceToString(groupIDs)))
+	if err != nil && err != sql.ErrNoRows {
+		log.Println(err)
+		return
+	}
+	for i, g := range groups {
+		g.Members = []domain.GroupMember{}
+		for _, m := range members {
+			if m.GroupID == g.ID {
+				g.Members = append(g.Members, m)
+			}
+		}
+		g.GatheringIDs = []int64{}
+		for _, l := range links {
+			if l.GroupID == g.ID {
+				g.GatheringIDs = append(g.GatheringIDs, l.GatheringID)
+			}
+		}
+		groups[i] = g
+	}
+	return
+}
+
+func (r *groupAdapterRepository) Count(ctx context.Context, args domain.GroupArgs) (total int64, err error) {
+	conditions, params := groupConditions(args)
+	query := `SELECT COUNT(id) FROM member_groups`
+	if len(conditions) > 0 {
+		query += fmt.Sprintf(` WHERE %s`, strings.Join(conditions, " AND "))
+	}
+	err = r.db.GetContext(ctx, &total, query, params...)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+func groupConditions(args domain.GroupArgs) (conditions []string, params []interface{}) {
+	conditions = []string{}
+	if len(args.IDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`id IN (%s)`, helpers.IntSliceToString(args.IDs)))
+	}
+	if args.ID > 0 {
+		conditions = append(conditions, `id = ?`)
+		params = append(params, args.ID)
+	}
+	if args.MemberID > 0 {
+		conditions = append(conditions, `id IN (SELECT group_id FROM group_members WHERE member_id = ?)`)
+		params = append(params, args.MemberID)
+	}
+	if args.Name != "" {
+		conditions = append(conditions, `name LIKE ?`)
+		params = append(params, "%"+args.Name+"%")
+	}
+	return
+}
+
+func (r *groupAdapterRepository) Update(ctx context.Context, group domain.Group) (err error) {
+	query := `UPDATE member_groups SET
+		name = ?
+		, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`
+	_, err = r.db.ExecContext(ctx, query, group.Name, group.ID)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// Delete removes the group with its members and gathering links, invitations already sent to its members are kept
+func (r *groupAdapterRepository) Delete(ctx context.Context, args domain.GroupArgs) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for _, query := range []string{
+		`DELETE FROM group_gatherings WHERE group_id = ?`,
+		`DELETE FROM group_members WHERE group_id = ?`,
+		`DELETE FROM member_groups WHERE id = ?`,
+	} {
+		if _, err = tx.ExecContext(ctx, query, args.ID); err != nil {
+			tx.Rollback()
+			log.Println(err)
+			return
+		}
+	}
+	err = tx.Commit()
+	return
+}
+
+func (r *groupAdapterRepository) AddMember(ctx context.Context, member domain.GroupMember) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if err = addGroupMember(ctx, tx, member); err != nil {
+		return constraintError(err, "the member is already in the group")
+	}
+	err = tx.Commit()
+	return
+}
+
+// UpdateMember changes the role of a member of the group
+func (r *groupAdapterRepository) UpdateMember(ctx context.Context, member domain.GroupMember) (err error) {
+	query := `UPDATE group_members SET role = ? WHERE group_id = ? AND member_id = ?`
+	_, err = r.db.ExecContext(ctx, query, member.Role, member.GroupID, member.MemberID)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+func (r *groupAdapterRepository) RemoveMember(ctx context.Context, member domain.GroupMember) (err error) {
+	query := `DELETE FROM group_members WHERE group_id = ? AND member_id = ?`
+	_, err = r.db.ExecContext(ctx, query, member.GroupID, member.MemberID)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// AddGathering links a gathering to the group for auto invites, a gathering linked before is left as is
+func (r *groupAdapterRepository) AddGathering(ctx context.Context, groupID int64, gatheringID int64) (err error) {
+	query := `INSERT INTO group_gatherings (group_id, gathering_id) VALUES (?, ?)`
+	_, err = r.db.ExecContext(ctx, query, groupID, gatheringID)
+	if err != nil {
+		err = constraintError(err, "the gathering is already linked")
+		if errors.Is(err, domain.ErrConflict) {
+			return nil
+		}
+		log.Println(err)
+	}
+	return
+}
+
+func addGroupMember(ctx context.Context, tx *sql.Tx, member domain.GroupMember) (err error) {
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO group_members (
+		group_id
+		, member_id
+		, role
+		, created_at
+	) VALUES (?, ?, ?, CURRENT_TIMESTAMP)`, member.GroupID, member.MemberID, member.Role)
+	if err != nil {
+		tx.Rollback()
+		log.Println(err)
+	}
+	return
+}
@@ -0,0 +1,56 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/hieronimusbudi/simple-go-api/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// This test is integration test
+func Test_groupAdapterRepository(t *testing.T) {
+	// groups are created and deleted along the way, so it runs on its own database
+	db, err := test.SetupMySQLDatabase("member_groups")
+	require.NoError(t, err)
+	defer db.Close()
+	repo := repository.NewGroupRepository(repository.GroupAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+
+	id, err := repo.Create(ctx, domain.Group{Name: "book club", Members: []domain.GroupMember{{MemberID: 1, Role: valueobject.GROUP_OWNER}}})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, domain.Group{Name: "broken", Members: []domain.GroupMember{{MemberID: 99, Role: valueobject.GROUP_OWNER}}})
+	require.ErrorIs(t, err, domain.ErrValidation, "member 99 does not exist")
+	total, err := repo.Count(ctx, domain.GroupArgs{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total, "a failed create leaves nothing behind")
+
+	require.NoError(t, repo.AddMember(ctx, domain.GroupMember{GroupID: id, MemberID: 2, Role: valueobject.GROUP_MEMBER}))
+	require.ErrorIs(t, repo.AddMember(ctx, domain.GroupMember{GroupID: id, MemberID: 2, Role: valueobject.GROUP_MEMBER}), domain.ErrConflict)
+	require.ErrorIs(t, repo.AddMember(ctx, domain.GroupMember{GroupID: id, MemberID: 99, Role: valueobject.GROUP_MEMBER}), domain.ErrValidation)
+	require.NoError(t, repo.UpdateMember(ctx, domain.GroupMember{GroupID: id, MemberID: 2, Role: valueobject.GROUP_OWNER}))
+	require.NoError(t, repo.AddGathering(ctx, id, 1))
+	require.NoError(t, repo.AddGathering(ctx, id, 1), "linking again is a no-op")
+	require.NoError(t, repo.Update(ctx, domain.Group{ID: id, Name: "reading club"}))
+
+	groups, err := repo.Get(ctx, domain.GroupArgs{MemberID: 2})
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Equal(t, "reading club", groups[0].Name)
+	require.Equal(t, []int64{1, 2}, groups[0].MemberIDs())
+	require.Equal(t, 2, groups[0].Owners())
+	require.Equal(t, []int64{1}, groups[0].GatheringIDs)
+
+	require.NoError(t, repo.RemoveMember(ctx, domain.GroupMember{GroupID: id, MemberID: 2}))
+	groups, err = repo.Get(ctx, domain.GroupArgs{MemberID: 2})
+	require.NoError(t, err)
+	require.Empty(t, groups)
+
+	require.NoError(t, repo.Delete(ctx, domain.GroupArgs{ID: id}))
+	groups, err = repo.Get(ctx, domain.GroupArgs{IDs: []int64{id}})
+	require.NoError(t, err)
+	require.Empty(t, groups)
+}
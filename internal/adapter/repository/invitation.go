@@ -3,10 +3,10 @@ package repository
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hieronimusbudi/simple-go-api/internal/domain"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
@@ -17,7 +17,8 @@ import (
 
 type (
 	invitationAdapterRepository struct {
-		db *sqlx.DB
+		db      *sqlx.DB
+		dialect Dialect
 	}
 
 	InvitationAdapterRepositoryArgs struct {
@@ -27,54 +28,141 @@ type (
 
 func NewInvitationRepository(args InvitationAdapterRepositoryArgs) repository.IInvitation {
 	return &invitationAdapterRepository{
-		db: args.DB,
+		db:      args.DB,
+		dialect: dialectOf(args.DB),
 	}
 }
 
-func (r *invitationAdapterRepository) Create(ctx context.Context, invitation domain.Invitation) (id int64, err error) {
-	query := `INSERT INTO invitations (
+func (r *invitationAdapterRepository) Create(ctx context.Context, invitation domain.Invitation, notifications ...domain.Notification) (id int64, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	query := fmt.Sprintf(`INSERT INTO invitations (
 		member_id
 		, gathering_id
 		, status
+		, waitlisted_at
+		, guests
+		, note
 		, created_at
-	) VALUES (?, ?, ?, NOW())`
-	insertResult, err := r.db.ExecContext(
+	) VALUES (?, ?, ?, %s, ?, ?, CURRENT_TIMESTAMP)`, r.dialect.timeParam())
+	insertResult, err := tx.ExecContext(
 		ctx,
 		query,
 		invitation.Member.ID,
 		invitation.Gathering.ID,
 		invitation.Status,
+		helpers.NullString(invitation.WaitlistedAt),
+		invitation.Guests,
+		invitation.Note,
 	)
 	if err != nil {
+		tx.Rollback()
 		log.Println(err)
+		err = constraintError(err, "the member is already invited")
 		return
 	}
 	id, err = insertResult.LastInsertId()
 	if err != nil {
+		tx.Rollback()
 		log.Println(err)
+		return
+	}
+	for i := range notifications {
+		notifications[i].Data.InvitationID = id
+	}
+	if err = saveNotifications(ctx, tx, notifications); err != nil {
+		return
+	}
+	err = tx.Commit()
+	return
+}
+
+// CreateBatch sets the invitation ID of each notification to the invitation created for its recipient and gathering
+func (r *invitationAdapterRepository) CreateBatch(ctx context.Context, invitations []domain.Invitation, notifications ...domain.Notification) (ids []int64, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	query := `INSERT INTO invitations (
+		member_id
+		, gathering_id
+		, status
+		, guests
+		, note
+		, created_at
+	) VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)`
+	for _, invitation := range invitations {
+		insertResult, err := tx.ExecContext(
+			ctx,
+			query,
+			invitation.Member.ID,
+			invitation.Gathering.ID,
+			invitation.Status,
+			invitation.Guests,
+			invitation.Note,
+		)
+		if err != nil {
+			tx.Rollback()
+			log.Println(err)
+			return nil, constraintError(err, "the member is already invited")
+		}
+		id, err := insertResult.LastInsertId()
+		if err != nil {
+			tx.Rollback()
+			log.Println(err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	for i, n := range notifications {
+		for j, invitation := range invitations {
+			if invitation.Member.ID == n.MemberID && invitation.Gathering.ID == n.Data.GatheringID {
+				notifications[i].Data.InvitationID = ids[j]
+			}
+		}
+	}
+	if err = saveNotifications(ctx, tx, notifications); err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		log.Println(err)
+		return nil, err
 	}
 	return
 }
 
 func (r *invitationAdapterRepository) Get(ctx context.Context, args domain.InvitationArgs) (invitations []domain.Invitation, err error) {
 	invitations = []domain.Invitation{}
-	conditions := []string{}
+	conditions, params := invitationConditions(args)
 	query := `
 		SELECT
 			id
 			, member_id
 			, gathering_id
 			, status
+			, COALESCE(waitlisted_at, '') AS waitlisted_at
+			, guests
+			, note
 			, created_at
 		FROM invitations
 	`
-	if len(args.IDs) > 0 {
-		conditions = append(conditions, fmt.Sprintf(`id IN (%s)`, helpers.IntSliceToString(args.IDs)))
+	condition, cursorParams, err := cursorCondition(args.Pagination, invitationSortColumns)
+	if err != nil {
+		return
+	}
+	if condition != "" {
+		conditions = append(conditions, condition)
+		params = append(params, cursorParams...)
 	}
 	if len(conditions) > 0 {
 		query += fmt.Sprintf(` WHERE %s`, strings.Join(conditions, " AND "))
 	}
-	err = r.db.SelectContext(ctx, &invitations, query)
+	query += orderAndLimit(args.Pagination, invitationSortColumns)
+	err = r.db.SelectContext(ctx, &invitations, query, params...)
 	if err != nil && err != sql.ErrNoRows {
 		log.Println(err)
 	}
@@ -86,19 +174,86 @@ func (r *invitationAdapterRepository) Get(ctx context.Context, args domain.Invit
 	return
 }
 
-func (r *invitationAdapterRepository) UpdateStatus(ctx context.Context, args domain.InvitationArgs) (err error) {
+func (r *invitationAdapterRepository) Count(ctx context.Context, args domain.InvitationArgs) (total int64, err error) {
+	conditions, params := invitationConditions(args)
+	query := `SELECT COUNT(id) FROM invitations`
+	if len(conditions) > 0 {
+		query += fmt.Sprintf(` WHERE %s`, strings.Join(conditions, " AND "))
+	}
+	err = r.db.GetContext(ctx, &total, query, params...)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+func invitationConditions(args domain.InvitationArgs) (conditions []string, params []interface{}) {
+	conditions = []string{}
+	if len(args.IDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`id IN (%s)`, helpers.IntSliceToString(args.IDs)))
+	}
+	if args.MemberID > 0 {
+		conditions = append(conditions, `member_id = ?`)
+		params = append(params, args.MemberID)
+	}
+	if args.GatheringID > 0 {
+		conditions = append(conditions, `gathering_id = ?`)
+		params = append(params, args.GatheringID)
+	}
+	if len(args.GatheringIDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`gathering_id IN (%s)`, helpers.IntSliceToString(args.GatheringIDs)))
+	}
+	if len(args.Statuses) > 0 {
+		placeholders := []string{}
+		for _, status := range args.Statuses {
+			placeholders = append(placeholders, "?")
+			params = append(params, status)
+		}
+		conditions = append(conditions, fmt.Sprintf(`status IN (%s)`, strings.Join(placeholders, ", ")))
+	}
+	if args.IsVisibleOnly {
+		conditions = append(conditions, `gathering_id IN (SELECT id FROM gatherings WHERE type = ?
+			OR creator = ?
+			OR id IN (SELECT gathering_id FROM attendees WHERE member_id = ?)
+			OR id IN (SELECT gathering_id FROM invitations WHERE member_id = ?))`)
+		params = append(params, valueobject.PUBLIC, args.ViewerID, args.ViewerID, args.ViewerID)
+	}
+	return
+}
+
+// UpdateStatus changes attendees with the status and stores the response in one transaction. An accept the gathering has
+// no seats for, guests included, is stored as waitlisted. A reject, cancel or tentative answer frees the seats for waitlisted invitations.
+func (r *invitationAdapterRepository) UpdateStatus(ctx context.Context, args domain.InvitationArgs, notifications ...domain.Notification) (promotedIDs []int64, err error) {
 	tx, err := r.db.Begin()
 	if err != nil {
 		log.Println(err)
 		return
 	}
-	query := `UPDATE invitations SET
+	status := args.Status
+	var waitlistedAt *time.Time
+	if status == valueobject.INVITATION_ACCEPT {
+		ok, err := hasSeats(ctx, tx, r.dialect, args.GatheringID, 1+args.Response.Guests)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			now := time.Now()
+			status, waitlistedAt = valueobject.INVITATION_WAITLISTED, &now
+		}
+	}
+	query := fmt.Sprintf(`UPDATE invitations SET
 		status = ?
-		WHERE id = ?`
+		, waitlisted_at = %s
+		, guests = ?
+		, note = ?
+		WHERE id = ?`, r.dialect.timeParam())
 	_, err = tx.ExecContext(
 		ctx,
 		query,
-		args.Status,
+		status,
+		helpers.NullDBTime(waitlistedAt),
+		args.Response.Guests,
+		args.Response.Note,
 		args.ID,
 	)
 	if err != nil {
@@ -106,24 +261,28 @@ func (r *invitationAdapterRepository) UpdateStatus(ctx context.Context, args dom
 		log.Println(err)
 		return
 	}
-	if args.Status == valueobject.INVITATION_ACCEPT {
+	if status == valueobject.INVITATION_ACCEPT {
 		err = createAttendee(ctx, tx, args.MemberID, args.GatheringID)
 		if err != nil {
 			tx.Rollback()
-			if strings.Contains(err.Error(), "Error 1062: Duplicate entry") {
-				err = errors.New("the member has accepted the invitation")
-			}
 			log.Println(err)
+			err = constraintError(err, "the member has accepted the invitation")
 			return
 		}
-	} else if args.Status == valueobject.INVITATION_REJECT || args.Status == valueobject.INVITATION_CANCELED {
+	} else if status == valueobject.INVITATION_REJECT || status == valueobject.INVITATION_CANCELED || status == valueobject.INVITATION_TENTATIVE {
 		err = removeAttendee(ctx, tx, args.MemberID, args.GatheringID)
 		if err != nil {
 			tx.Rollback()
 			log.Println(err)
 			return
 		}
+		if promotedIDs, err = promoteWaitlist(ctx, tx, r.dialect, args.GatheringID); err != nil {
+			return
+		}
+	}
+	if err = saveNotifications(ctx, tx, notifications); err != nil {
+		return
 	}
-	tx.Commit()
+	err = tx.Commit()
 	return
 }
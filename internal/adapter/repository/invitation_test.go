@@ -3,10 +3,12 @@ package repository_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/hieronimusbudi/simple-go-api/internal/adapter/repository"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/hieronimusbudi/simple-go-api/internal/test"
 	"github.com/stretchr/testify/require"
 )
 
@@ -170,7 +172,7 @@ func Test_invitationAdapterRepository_UpdateStatus(t *testing.T) {
 			repo := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{
 				DB: db,
 			})
-			err := repo.UpdateStatus(context.Background(), tt.args.args)
+			_, err := repo.UpdateStatus(context.Background(), tt.args.args)
 			if tt.wantErr {
 				require.Error(t, err)
 			} else {
@@ -186,3 +188,106 @@ func Test_invitationAdapterRepository_UpdateStatus(t *testing.T) {
 		})
 	}
 }
+
+func Test_invitationAdapterRepository_UpdateStatus_waitlist(t *testing.T) {
+	// promotions change statuses other tests read, so it runs on its own database
+	db, err := test.SetupMySQLDatabase("waitlist")
+	require.NoError(t, err)
+	defer db.Close()
+	memberRepo := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{DB: db})
+	gatheringRepo := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{DB: db})
+	repo := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{DB: db})
+	notificationRepo := repository.NewNotificationRepository(repository.NotificationAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+	for _, email := range []string{"ken@mail.com", "dennis@mail.com", "rob@mail.com"} {
+		_, err = memberRepo.Create(ctx, domain.Member{FirstName: "member", Email: email})
+		require.NoError(t, err)
+	}
+	gathering := domain.Gathering{
+		Creator:     domain.Member{ID: 1},
+		ScheduledAt: time.Date(2023, 10, 6, 5, 0, 0, 0, time.UTC),
+		TimeZone:    domain.DefaultTimeZone,
+		Name:        "dinner",
+		Location:    "home",
+		Capacity:    2,
+		Attendees:   []domain.Member{{ID: 1}},
+	}
+	gathering.ID, err = gatheringRepo.Create(ctx, gathering)
+	require.NoError(t, err)
+	invitationIDs := map[int64]int64{}
+	for _, memberID := range []int64{2, 3, 4, 5} {
+		invitationIDs[memberID], err = repo.Create(ctx, domain.Invitation{Member: domain.Member{ID: memberID}, Gathering: gathering})
+		require.NoError(t, err)
+	}
+	updateStatus := func(memberID int64, status valueobject.InvitationStatus) (promotedIDs []int64) {
+		promotedIDs, err := repo.UpdateStatus(ctx, domain.InvitationArgs{ID: invitationIDs[memberID], MemberID: memberID, GatheringID: gathering.ID, Status: status})
+		require.NoError(t, err)
+		return
+	}
+	check := func(wantStatuses map[int64]valueobject.InvitationStatus, wantAttendees []domain.Member) {
+		invitations, err := repo.Get(ctx, domain.InvitationArgs{GatheringID: gathering.ID})
+		require.NoError(t, err)
+		for _, inv := range invitations {
+			require.Equal(t, wantStatuses[inv.MemberID], inv.Status, "member %d", inv.MemberID)
+			require.Equal(t, inv.Status == valueobject.INVITATION_WAITLISTED, inv.WaitlistedAt != "")
+		}
+		gatherings, err := gatheringRepo.Get(ctx, domain.GatheringArgs{IDs: []int64{gathering.ID}})
+		require.NoError(t, err)
+		require.ElementsMatch(t, wantAttendees, gatherings[0].Attendees)
+	}
+	// promoted lists who was told their waitlisted invitation is accepted, in the order they were promoted
+	promoted := func() (memberIDs []int64) {
+		notifications, err := notificationRepo.Get(ctx, domain.NotificationArgs{})
+		require.NoError(t, err)
+		for _, n := range notifications {
+			if n.Event == domain.NotificationWaitlistPromoted {
+				require.Equal(t, invitationIDs[n.MemberID], n.Data.InvitationID)
+				require.Equal(t, "dinner", n.Data.GatheringName)
+				require.True(t, gathering.ScheduledAt.Equal(n.Data.ScheduledAt))
+				memberIDs = append(memberIDs, n.MemberID)
+			}
+		}
+		return
+	}
+
+	require.Empty(t, updateStatus(2, valueobject.INVITATION_ACCEPT))
+	require.Empty(t, updateStatus(3, valueobject.INVITATION_ACCEPT))
+	require.Empty(t, updateStatus(4, valueobject.INVITATION_ACCEPT))
+	check(map[int64]valueobject.InvitationStatus{
+		2: valueobject.INVITATION_ACCEPT,
+		3: valueobject.INVITATION_WAITLISTED,
+		4: valueobject.INVITATION_WAITLISTED,
+	}, []domain.Member{{ID: 1}, {ID: 2}})
+	require.Empty(t, promoted())
+	// the seat of a rejected attendee goes to the first waitlisted member
+	require.Equal(t, []int64{invitationIDs[3]}, updateStatus(2, valueobject.INVITATION_REJECT))
+	check(map[int64]valueobject.InvitationStatus{
+		2: valueobject.INVITATION_REJECT,
+		3: valueobject.INVITATION_ACCEPT,
+		4: valueobject.INVITATION_WAITLISTED,
+	}, []domain.Member{{ID: 1}, {ID: 3}})
+	require.Equal(t, []int64{3}, promoted())
+	// a raised capacity promotes the waitlist
+	gathering.Capacity = 3
+	promotedIDs, err := gatheringRepo.Update(ctx, gathering)
+	require.NoError(t, err)
+	require.Equal(t, []int64{invitationIDs[4]}, promotedIDs)
+	check(map[int64]valueobject.InvitationStatus{
+		2: valueobject.INVITATION_REJECT,
+		3: valueobject.INVITATION_ACCEPT,
+		4: valueobject.INVITATION_ACCEPT,
+	}, []domain.Member{{ID: 1}, {ID: 3}, {ID: 4}})
+	require.Equal(t, []int64{3, 4}, promoted())
+	// the seat of an attendee who leaves goes to the waitlist too
+	require.Empty(t, updateStatus(5, valueobject.INVITATION_ACCEPT))
+	promotedIDs, err = gatheringRepo.Leave(ctx, gathering.ID, 3)
+	require.NoError(t, err)
+	require.Equal(t, []int64{invitationIDs[5]}, promotedIDs)
+	check(map[int64]valueobject.InvitationStatus{
+		2: valueobject.INVITATION_REJECT,
+		3: valueobject.INVITATION_REJECT,
+		4: valueobject.INVITATION_ACCEPT,
+		5: valueobject.INVITATION_ACCEPT,
+	}, []domain.Member{{ID: 1}, {ID: 4}, {ID: 5}})
+	require.Equal(t, []int64{3, 4, 5}, promoted())
+}
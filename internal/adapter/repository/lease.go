@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	leaseAdapterRepository struct {
+		db      *sqlx.DB
+		dialect Dialect
+	}
+
+	LeaseAdapterRepositoryArgs struct {
+		DB *sqlx.DB
+	}
+)
+
+func NewLeaseRepository(args LeaseAdapterRepositoryArgs) repository.ILease {
+	return &leaseAdapterRepository{
+		db:      args.DB,
+		dialect: dialectOf(args.DB),
+	}
+}
+
+func (r *leaseAdapterRepository) Acquire(ctx context.Context, name string, holder string, ttl time.Duration) (acquired bool, err error) {
+	now := time.Now()
+	// the lease is taken over only from its holder or once it expired, the holder read back tells who has it.
+	// On MySQL holder is assigned first, expires_at then moves only when the holder is now the caller
+	query := `
+	INSERT INTO leases (name, holder, expires_at) VALUES (?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		holder = IF(holder = VALUES(holder) OR expires_at <= ?, VALUES(holder), holder)
+		, expires_at = IF(holder = VALUES(holder), VALUES(expires_at), expires_at)`
+	if r.dialect == DialectSQLite {
+		query = `
+		INSERT INTO leases (name, holder, expires_at) VALUES (?, ?, datetime(?))
+		ON CONFLICT (name) DO UPDATE SET
+			holder = excluded.holder
+			, expires_at = excluded.expires_at
+		WHERE leases.holder = excluded.holder OR leases.expires_at <= datetime(?)`
+	}
+	_, err = r.db.ExecContext(ctx, query, name, holder, helpers.FormatDBTime(now.Add(ttl)), helpers.FormatDBTime(now))
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	current := ""
+	if err = r.db.GetContext(ctx, &current, `SELECT holder FROM leases WHERE name = ?`, name); err != nil {
+		log.Println(err)
+		return
+	}
+	return current == holder, nil
+}
+
+func (r *leaseAdapterRepository) Release(ctx context.Context, name string, holder string) (err error) {
+	_, err = r.db.ExecContext(ctx, `DELETE FROM leases WHERE name = ? AND holder = ?`, name, holder)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
@@ -0,0 +1,48 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_leaseAdapterRepository(t *testing.T) {
+	repo := repository.NewLeaseRepository(repository.LeaseAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+	expiresAt := func() (expiresAt time.Time) {
+		require.NoError(t, db.GetContext(ctx, &expiresAt, `SELECT expires_at FROM leases WHERE name = ?`, "test-lease"))
+		return
+	}
+
+	acquired, err := repo.Acquire(ctx, "test-lease", "replica-a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	held := expiresAt()
+	acquired, err = repo.Acquire(ctx, "test-lease", "replica-b", time.Hour)
+	require.NoError(t, err)
+	require.False(t, acquired, "held by replica-a")
+	require.Equal(t, held, expiresAt(), "a refused caller does not move the expiry")
+	acquired, err = repo.Acquire(ctx, "test-lease", "replica-a", time.Hour)
+	require.NoError(t, err)
+	require.True(t, acquired, "renewed by its holder")
+	require.True(t, expiresAt().After(held.Add(30*time.Minute)), "renewal moves the expiry")
+
+	// releasing a lease of another holder does nothing
+	require.NoError(t, repo.Release(ctx, "test-lease", "replica-b"))
+	acquired, err = repo.Acquire(ctx, "test-lease", "replica-b", time.Minute)
+	require.NoError(t, err)
+	require.False(t, acquired)
+	require.NoError(t, repo.Release(ctx, "test-lease", "replica-a"))
+	acquired, err = repo.Acquire(ctx, "test-lease", "replica-b", -time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired, "free once released, this time it expired already")
+
+	// an expired lease is taken over along with its expiry
+	acquired, err = repo.Acquire(ctx, "test-lease", "replica-a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	require.True(t, expiresAt().After(time.Now()))
+}
@@ -35,7 +35,7 @@ func (r *memberAdapterRepository) Create(ctx context.Context, member domain.Memb
 		, last_name
 		, email
 		, created_at
-	) VALUES (?, ?, ?, NOW())`
+	) VALUES (?, ?, ?, CURRENT_TIMESTAMP)`
 	insertResult, err := r.db.ExecContext(
 		ctx,
 		query,
@@ -45,6 +45,7 @@ func (r *memberAdapterRepository) Create(ctx context.Context, member domain.Memb
 	)
 	if err != nil {
 		log.Println(err)
+		err = constraintError(err, "email is already used")
 		return
 	}
 	id, err = insertResult.LastInsertId()
@@ -56,33 +57,84 @@ func (r *memberAdapterRepository) Create(ctx context.Context, member domain.Memb
 
 func (r *memberAdapterRepository) Get(ctx context.Context, args domain.MemberArgs) (members []domain.Member, err error) {
 	members = []domain.Member{}
-	conditions := []string{}
+	conditions, params := memberConditions(args)
 	query := `
 		SELECT
 			id
 			, first_name
 			, last_name
 			, email
+			, role
 			, created_at
 			, COALESCE(discarded_at, '') AS discarded_at
 		FROM members
 	`
-	if !args.IsIncludeDiscard {
-		conditions = append(conditions, `discarded_at IS NULL`)
+	condition, cursorParams, err := cursorCondition(args.Pagination, memberSortColumns)
+	if err != nil {
+		return
 	}
-	if len(args.IDs) > 0 {
-		conditions = append(conditions, fmt.Sprintf(`id IN (%s)`, helpers.IntSliceToString(args.IDs)))
+	if condition != "" {
+		conditions = append(conditions, condition)
+		params = append(params, cursorParams...)
 	}
 	if len(conditions) > 0 {
 		query += fmt.Sprintf(` WHERE %s`, strings.Join(conditions, " AND "))
 	}
-	err = r.db.SelectContext(ctx, &members, query)
+	query += orderAndLimit(args.Pagination, memberSortColumns)
+	err = r.db.SelectContext(ctx, &members, query, params...)
 	if err != nil && err != sql.ErrNoRows {
 		log.Println(err)
 	}
 	return
 }
 
+func (r *memberAdapterRepository) Count(ctx context.Context, args domain.MemberArgs) (total int64, err error) {
+	conditions, params := memberConditions(args)
+	query := `SELECT COUNT(id) FROM members`
+	if len(conditions) > 0 {
+		query += fmt.Sprintf(` WHERE %s`, strings.Join(conditions, " AND "))
+	}
+	err = r.db.GetContext(ctx, &total, query, params...)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+func memberConditions(args domain.MemberArgs) (conditions []string, params []interface{}) {
+	conditions = []string{}
+	if !args.IsIncludeDiscard {
+		conditions = append(conditions, `discarded_at IS NULL`)
+	}
+	if len(args.IDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`id IN (%s)`, helpers.IntSliceToString(args.IDs)))
+	}
+	if args.Email != "" {
+		conditions = append(conditions, `email = ?`)
+		params = append(params, args.Email)
+	}
+	if len(args.AnyIDs) > 0 || len(args.AnyEmails) > 0 {
+		matches := []string{}
+		if len(args.AnyIDs) > 0 {
+			matches = append(matches, fmt.Sprintf(`id IN (%s)`, helpers.IntSliceToString(args.AnyIDs)))
+		}
+		if len(args.AnyEmails) > 0 {
+			placeholders := []string{}
+			for _, email := range args.AnyEmails {
+				placeholders = append(placeholders, "?")
+				params = append(params, email)
+			}
+			matches = append(matches, fmt.Sprintf(`email IN (%s)`, strings.Join(placeholders, ", ")))
+		}
+		conditions = append(conditions, fmt.Sprintf(`(%s)`, strings.Join(matches, " OR ")))
+	}
+	if args.Name != "" {
+		conditions = append(conditions, `(first_name LIKE ? OR last_name LIKE ?)`)
+		params = append(params, "%"+args.Name+"%", "%"+args.Name+"%")
+	}
+	return
+}
+
 func (r *memberAdapterRepository) Update(ctx context.Context, member domain.Member) (err error) {
 	tx, err := r.db.Begin()
 	if err != nil {
@@ -93,7 +145,7 @@ func (r *memberAdapterRepository) Update(ctx context.Context, member domain.Memb
 		first_name = ?
 		, last_name = ?
 		, email = ?
-		, updated_at = NOW()
+		, updated_at = CURRENT_TIMESTAMP
 		WHERE id = ?`
 	_, err = tx.ExecContext(
 		ctx,
@@ -106,6 +158,7 @@ func (r *memberAdapterRepository) Update(ctx context.Context, member domain.Memb
 	if err != nil {
 		tx.Rollback()
 		log.Println(err)
+		err = constraintError(err, "email is already used")
 		return
 	}
 	tx.Commit()
@@ -114,7 +167,7 @@ func (r *memberAdapterRepository) Update(ctx context.Context, member domain.Memb
 
 func (r *memberAdapterRepository) Delete(ctx context.Context, args domain.MemberArgs) (err error) {
 	query := `UPDATE members SET
-		discarded_at = NOW()
+		discarded_at = CURRENT_TIMESTAMP
 		WHERE id = ?`
 	_, err = r.db.ExecContext(
 		ctx,
@@ -126,3 +179,57 @@ func (r *memberAdapterRepository) Delete(ctx context.Context, args domain.Member
 	}
 	return
 }
+
+// UpdateRole changes the role of a member, it is kept apart from Update so a member cannot change their own role
+func (r *memberAdapterRepository) UpdateRole(ctx context.Context, member domain.Member) (err error) {
+	query := `UPDATE members SET
+		role = ?
+		, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`
+	_, err = r.db.ExecContext(
+		ctx,
+		query,
+		member.Role,
+		member.ID,
+	)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// Purge hard deletes members discarded before the given time together with their invitations, attendances, credentials and group memberships,
+// a member who still is creator of a gathering is kept
+func (r *memberAdapterRepository) Purge(ctx context.Context, discardedBefore string) (total int64, err error) {
+	purgeable := `SELECT id FROM members WHERE discarded_at < ? AND id NOT IN (SELECT creator FROM gatherings)`
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for _, query := range []string{
+		fmt.Sprintf(`DELETE FROM attendees WHERE member_id IN (%s)`, purgeable),
+		fmt.Sprintf(`DELETE FROM invitations WHERE member_id IN (%s)`, purgeable),
+		fmt.Sprintf(`DELETE FROM member_credentials WHERE member_id IN (%s)`, purgeable),
+		fmt.Sprintf(`DELETE FROM group_members WHERE member_id IN (%s)`, purgeable),
+	} {
+		if _, err = tx.ExecContext(ctx, query, discardedBefore); err != nil {
+			tx.Rollback()
+			log.Println(err)
+			return
+		}
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM members WHERE discarded_at < ? AND id NOT IN (SELECT creator FROM gatherings)`, discardedBefore)
+	if err != nil {
+		tx.Rollback()
+		log.Println(err)
+		return
+	}
+	if total, err = result.RowsAffected(); err != nil {
+		tx.Rollback()
+		log.Println(err)
+		return
+	}
+	err = tx.Commit()
+	return
+}
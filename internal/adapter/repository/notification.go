@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	notificationAdapterRepository struct {
+		db      *sqlx.DB
+		dialect Dialect
+	}
+
+	NotificationAdapterRepositoryArgs struct {
+		DB *sqlx.DB
+	}
+
+	// notificationRow is a notification as stored, data is JSON
+	notificationRow struct {
+		domain.Notification
+		Data string `db:"data"`
+	}
+)
+
+func NewNotificationRepository(args NotificationAdapterRepositoryArgs) repository.INotification {
+	return &notificationAdapterRepository{
+		db:      args.DB,
+		dialect: dialectOf(args.DB),
+	}
+}
+
+func (r *notificationAdapterRepository) Get(ctx context.Context, args domain.NotificationArgs) (notifications []domain.Notification, err error) {
+	notifications = []domain.Notification{}
+	conditions, params := []string{}, []interface{}{}
+	if len(args.IDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`id IN (%s)`, helpers.IntSliceToString(args.IDs)))
+	}
+	if len(args.Statuses) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`status IN (?%s)`, strings.Repeat(", ?", len(args.Statuses)-1)))
+		for _, s := range args.Statuses {
+			params = append(params, s)
+		}
+	}
+	query := `
+		SELECT
+			id
+			, event
+			, member_id
+			, data
+			, status
+			, attempts
+			, COALESCE(last_error, '') AS last_error
+			, created_at
+			, COALESCE(sent_at, '') AS sent_at
+		FROM notifications
+	`
+	if len(conditions) > 0 {
+		query += fmt.Sprintf(` WHERE %s`, strings.Join(conditions, " AND "))
+	}
+	query += ` ORDER BY id`
+	if args.Limit > 0 {
+		query += ` LIMIT ?`
+		params = append(params, args.Limit)
+	}
+	rows := []notificationRow{}
+	err = r.db.SelectContext(ctx, &rows, query, params...)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println(err)
+		return
+	}
+	for _, row := range rows {
+		notification := row.Notification
+		if err = json.Unmarshal([]byte(row.Data), &notification.Data); err != nil {
+			log.Println(err)
+			return []domain.Notification{}, err
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, nil
+}
+
+func (r *notificationAdapterRepository) Update(ctx context.Context, notification domain.Notification) (err error) {
+	query := fmt.Sprintf(`UPDATE notifications SET
+		status = ?
+		, attempts = ?
+		, last_error = ?
+		, sent_at = %s
+		WHERE id = ?`, r.dialect.timeParam())
+	_, err = r.db.ExecContext(
+		ctx,
+		query,
+		notification.Status,
+		notification.Attempts,
+		helpers.NullString(notification.LastError),
+		helpers.NullString(notification.SentAt),
+		notification.ID,
+	)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// saveNotifications adds notifications to the outbox within the transaction of the change they tell about
+func saveNotifications(ctx context.Context, tx *sql.Tx, notifications []domain.Notification) (err error) {
+	for _, notification := range notifications {
+		data, err := json.Marshal(notification.Data)
+		if err != nil {
+			tx.Rollback()
+			log.Println(err)
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `
+		INSERT INTO notifications (
+			event
+			, member_id
+			, data
+			, status
+			, attempts
+			, created_at
+		) VALUES (?, ?, ?, ?, 0, CURRENT_TIMESTAMP)`, notification.Event, notification.MemberID, string(data), notification.Status)
+		if err != nil {
+			tx.Rollback()
+			log.Println(err)
+			return err
+		}
+	}
+	return
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+var (
+	memberSortColumns = map[string]string{
+		domain.SortByID:        "id",
+		domain.SortByCreatedAt: "created_at",
+		domain.SortByName:      "first_name",
+	}
+	gatheringSortColumns = map[string]string{
+		domain.SortByID:          "id",
+		domain.SortByCreatedAt:   "created_at",
+		domain.SortByScheduledAt: "scheduled_at",
+		domain.SortByName:        "name",
+	}
+	invitationSortColumns = map[string]string{
+		domain.SortByID:        "id",
+		domain.SortByCreatedAt: "created_at",
+	}
+	groupSortColumns = map[string]string{
+		domain.SortByID:        "id",
+		domain.SortByCreatedAt: "created_at",
+		domain.SortByName:      "name",
+	}
+	webhookDeliverySortColumns = map[string]string{
+		domain.SortByID:        "id",
+		domain.SortByCreatedAt: "created_at",
+	}
+)
+
+func sortColumn(p domain.Pagination, sortColumns map[string]string) (column string, desc bool) {
+	field, desc := p.SortField()
+	column, ok := sortColumns[field]
+	if !ok {
+		column = "id"
+	}
+	return
+}
+
+// cursorCondition returns keyset condition to fetch rows after the cursor, tie broken by ID
+func cursorCondition(p domain.Pagination, sortColumns map[string]string) (condition string, params []interface{}, err error) {
+	if p.Cursor == "" {
+		return
+	}
+	cursor, err := domain.DecodeCursor(p.Cursor)
+	if err != nil {
+		return
+	}
+	column, desc := sortColumn(p, sortColumns)
+	operator := ">"
+	if desc {
+		operator = "<"
+	}
+	if column == "id" {
+		return fmt.Sprintf(`id %s ?`, operator), []interface{}{cursor.ID}, nil
+	}
+	condition = fmt.Sprintf(`(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))`, column, operator)
+	params = []interface{}{cursor.Value, cursor.Value, cursor.ID}
+	return
+}
+
+func orderAndLimit(p domain.Pagination, sortColumns map[string]string) (clause string) {
+	column, desc := sortColumn(p, sortColumns)
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
+	}
+	clause = fmt.Sprintf(` ORDER BY %s %s`, column, direction)
+	if column != "id" {
+		clause += fmt.Sprintf(`, id %s`, direction)
+	}
+	if p.Limit > 0 {
+		clause += fmt.Sprintf(` LIMIT %d`, p.Limit)
+		if p.Offset > 0 {
+			clause += fmt.Sprintf(` OFFSET %d`, p.Offset)
+		}
+	}
+	return
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	reminderAdapterRepository struct {
+		db      *sqlx.DB
+		dialect Dialect
+	}
+
+	ReminderAdapterRepositoryArgs struct {
+		DB *sqlx.DB
+	}
+)
+
+func NewReminderRepository(args ReminderAdapterRepositoryArgs) repository.IReminder {
+	return &reminderAdapterRepository{
+		db:      args.DB,
+		dialect: dialectOf(args.DB),
+	}
+}
+
+func (r *reminderAdapterRepository) Create(ctx context.Context, reminder domain.Reminder, notifications ...domain.Notification) (created bool, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	// the primary key of occurrence and offset ignores a reminder stored before, e.g. by another replica
+	insertResult, err := tx.ExecContext(ctx, fmt.Sprintf(`
+	%s INTO reminders (
+		gathering_id
+		, scheduled_at
+		, offset_seconds
+		, created_at
+	) VALUES (?, %s, ?, CURRENT_TIMESTAMP)`, r.dialect.insertIgnore(), r.dialect.timeParam()),
+		reminder.Occurrence.GatheringID,
+		helpers.FormatDBTime(reminder.Occurrence.ScheduledAt),
+		int64(reminder.Offset.Seconds()),
+	)
+	if err != nil {
+		tx.Rollback()
+		log.Println(err)
+		return
+	}
+	inserted, err := insertResult.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		log.Println(err)
+		return
+	}
+	if inserted == 0 {
+		err = tx.Rollback()
+		return
+	}
+	if err = saveNotifications(ctx, tx, notifications); err != nil {
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		log.Println(err)
+		return
+	}
+	return true, nil
+}
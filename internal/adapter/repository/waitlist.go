@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+// hasSeats reports whether seats more people fit the gathering capacity, a capacity of 0 is unlimited. Attendees and
+// the guests of accepted invitations take seats. On MySQL it locks the gathering row, so concurrent accepts of the same gathering wait for each other.
+func hasSeats(ctx context.Context, tx *sql.Tx, dialect Dialect, gatheringID int64, seats int) (ok bool, err error) {
+	var capacity, headcount int
+	err = tx.QueryRowContext(ctx, `
+	SELECT
+		capacity
+		, (SELECT COUNT(*) FROM attendees WHERE gathering_id = gatherings.id)
+			+ (SELECT COALESCE(SUM(guests), 0) FROM invitations WHERE gathering_id = gatherings.id AND status = ?)
+	FROM gatherings
+	WHERE id = ?
+	`+dialect.forUpdate(), valueobject.INVITATION_ACCEPT, gatheringID).Scan(&capacity, &headcount)
+	if err == sql.ErrNoRows {
+		return true, nil
+	}
+	if err != nil {
+		tx.Rollback()
+		log.Println(err)
+		return
+	}
+	return capacity == 0 || headcount+seats <= capacity, nil
+}
+
+// promoteWaitlist accepts waitlisted invitations in waitlist order while the gathering has seats for the first one and its guests.
+// Each promoted member is notified in the same transaction, the IDs of the promoted invitations are returned.
+func promoteWaitlist(ctx context.Context, tx *sql.Tx, dialect Dialect, gatheringID int64) (promotedIDs []int64, err error) {
+	promoted := []domain.Invitation{}
+	for {
+		invitation := domain.Invitation{GatheringID: gatheringID, Status: valueobject.INVITATION_ACCEPT}
+		err = tx.QueryRowContext(ctx, `
+		SELECT id, member_id, guests
+		FROM invitations
+		WHERE gathering_id = ? AND status = ?
+		ORDER BY waitlisted_at, id
+		LIMIT 1`, gatheringID, valueobject.INVITATION_WAITLISTED).Scan(&invitation.ID, &invitation.MemberID, &invitation.Guests)
+		if err == sql.ErrNoRows {
+			break
+		}
+		if err != nil {
+			tx.Rollback()
+			log.Println(err)
+			return nil, err
+		}
+		ok, err := hasSeats(ctx, tx, dialect, gatheringID, 1+invitation.Guests)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE invitations SET status = ?, waitlisted_at = NULL WHERE id = ?`, valueobject.INVITATION_ACCEPT, invitation.ID)
+		if err != nil {
+			tx.Rollback()
+			log.Println(err)
+			return nil, err
+		}
+		if err = createAttendee(ctx, tx, invitation.MemberID, gatheringID); err != nil {
+			return nil, err
+		}
+		promoted = append(promoted, invitation)
+		promotedIDs = append(promotedIDs, invitation.ID)
+	}
+	if len(promoted) == 0 {
+		return nil, nil
+	}
+	gathering, err := notificationGathering(ctx, tx, gatheringID)
+	if err != nil {
+		return nil, err
+	}
+	if err = saveNotifications(ctx, tx, gathering.PromotedNotifications(promoted)); err != nil {
+		return nil, err
+	}
+	return promotedIDs, nil
+}
+
+// notificationGathering reads what notifications tell about the gathering
+func notificationGathering(ctx context.Context, tx *sql.Tx, gatheringID int64) (gathering domain.Gathering, err error) {
+	row := gatheringRow{}
+	row.ID = gatheringID
+	err = tx.QueryRowContext(ctx, `SELECT name, location, scheduled_at, ends_at, time_zone FROM gatherings WHERE id = ?`, gatheringID).
+		Scan(&row.Name, &row.Location, &row.ScheduledAt, &row.EndsAt, &row.TimeZone)
+	if err == nil {
+		gathering, err = row.gathering()
+	}
+	if err != nil {
+		tx.Rollback()
+		log.Println(err)
+	}
+	return
+}
@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+	"github.com/jmoiron/sqlx"
+)
+
+type (
+	webhookAdapterRepository struct {
+		db      *sqlx.DB
+		dialect Dialect
+	}
+
+	WebhookAdapterRepositoryArgs struct {
+		DB *sqlx.DB
+	}
+
+	// webhookRow is a webhook as stored, events are comma separated
+	webhookRow struct {
+		domain.Webhook
+		Events string `db:"events"`
+	}
+)
+
+func NewWebhookRepository(args WebhookAdapterRepositoryArgs) repository.IWebhook {
+	return &webhookAdapterRepository{
+		db:      args.DB,
+		dialect: dialectOf(args.DB),
+	}
+}
+
+func (r *webhookAdapterRepository) Create(ctx context.Context, webhook domain.Webhook) (id int64, err error) {
+	insertResult, err := r.db.ExecContext(ctx, `INSERT INTO webhooks (
+		url
+		, secret
+		, events
+		, is_disabled
+		, created_at
+	) VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP)`, webhook.URL, webhook.Secret, strings.Join(webhook.Events, ","), webhook.IsDisabled)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	id, err = insertResult.LastInsertId()
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+func (r *webhookAdapterRepository) Get(ctx context.Context, args domain.WebhookArgs) (webhooks []domain.Webhook, err error) {
+	webhooks = []domain.Webhook{}
+	conditions := []string{}
+	if len(args.IDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`id IN (%s)`, helpers.IntSliceToString(args.IDs)))
+	}
+	if args.IsEnabledOnly {
+		conditions = append(conditions, `is_disabled = 0`)
+	}
+	query := `
+		SELECT
+			id
+			, url
+			, secret
+			, events
+			, is_disabled
+			, created_at
+		FROM webhooks
+	`
+	if len(conditions) > 0 {
+		query += fmt.Sprintf(` WHERE %s`, strings.Join(conditions, " AND "))
+	}
+	query += ` ORDER BY id`
+	rows := []webhookRow{}
+	err = r.db.SelectContext(ctx, &rows, query)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println(err)
+		return
+	}
+	for _, row := range rows {
+		webhook := row.Webhook
+		webhook.Events = strings.Split(row.Events, ",")
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, nil
+}
+
+func (r *webhookAdapterRepository) Update(ctx context.Context, webhook domain.Webhook) (err error) {
+	query := `UPDATE webhooks SET
+		url = ?
+		, secret = COALESCE(NULLIF(?, ''), secret)
+		, events = ?
+		, is_disabled = ?
+		, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`
+	_, err = r.db.ExecContext(ctx, query, webhook.URL, webhook.Secret, strings.Join(webhook.Events, ","), webhook.IsDisabled, webhook.ID)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+func (r *webhookAdapterRepository) Delete(ctx context.Context, id int64) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for _, query := range []string{
+		`DELETE FROM webhook_deliveries WHERE webhook_id = ?`,
+		`DELETE FROM webhooks WHERE id = ?`,
+	} {
+		if _, err = tx.ExecContext(ctx, query, id); err != nil {
+			tx.Rollback()
+			log.Println(err)
+			return
+		}
+	}
+	err = tx.Commit()
+	return
+}
+
+func (r *webhookAdapterRepository) CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) (ids []int64, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	// a delivery is due at once, next_attempt_at holds UTC like every time compared with a param
+	now := helpers.FormatDBTime(time.Now())
+	for _, delivery := range deliveries {
+		insertResult, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO webhook_deliveries (
+			webhook_id
+			, event
+			, payload
+			, status
+			, attempts
+			, next_attempt_at
+			, replay_of
+			, created_at
+		) VALUES (?, ?, ?, ?, 0, %s, ?, CURRENT_TIMESTAMP)`, r.dialect.timeParam()),
+			delivery.WebhookID,
+			delivery.Event,
+			delivery.Payload,
+			delivery.Status,
+			now,
+			sql.NullInt64{Int64: delivery.ReplayOf, Valid: delivery.ReplayOf > 0},
+		)
+		if err != nil {
+			tx.Rollback()
+			log.Println(err)
+			return nil, err
+		}
+		id, err := insertResult.LastInsertId()
+		if err != nil {
+			tx.Rollback()
+			log.Println(err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	err = tx.Commit()
+	return
+}
+
+func (r *webhookAdapterRepository) GetDeliveries(ctx context.Context, args domain.WebhookDeliveryArgs) (deliveries []domain.WebhookDelivery, err error) {
+	deliveries = []domain.WebhookDelivery{}
+	conditions, params := webhookDeliveryConditions(r.dialect, args)
+	query := `
+		SELECT
+			id
+			, webhook_id
+			, event
+			, payload
+			, status
+			, attempts
+			, COALESCE(next_attempt_at, '') AS next_attempt_at
+			, response_status
+			, COALESCE(last_error, '') AS last_error
+			, COALESCE(replay_of, 0) AS replay_of
+			, created_at
+			, COALESCE(delivered_at, '') AS delivered_at
+		FROM webhook_deliveries
+	`
+	condition, cursorParams, err := cursorCondition(args.Pagination, webhookDeliverySortColumns)
+	if err != nil {
+		return
+	}
+	if condition != "" {
+		conditions = append(conditions, condition)
+		params = append(params, cursorParams...)
+	}
+	if len(conditions) > 0 {
+		query += fmt.Sprintf(` WHERE %s`, strings.Join(conditions, " AND "))
+	}
+	query += orderAndLimit(args.Pagination, webhookDeliverySortColumns)
+	err = r.db.SelectContext(ctx, &deliveries, query, params...)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println(err)
+		return
+	}
+	return deliveries, nil
+}
+
+func (r *webhookAdapterRepository) CountDeliveries(ctx context.Context, args domain.WebhookDeliveryArgs) (total int64, err error) {
+	conditions, params := webhookDeliveryConditions(r.dialect, args)
+	query := `SELECT COUNT(id) FROM webhook_deliveries`
+	if len(conditions) > 0 {
+		query += fmt.Sprintf(` WHERE %s`, strings.Join(conditions, " AND "))
+	}
+	err = r.db.GetContext(ctx, &total, query, params...)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+func webhookDeliveryConditions(dialect Dialect, args domain.WebhookDeliveryArgs) (conditions []string, params []interface{}) {
+	conditions = []string{}
+	if len(args.IDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`id IN (%s)`, helpers.IntSliceToString(args.IDs)))
+	}
+	if args.WebhookID > 0 {
+		conditions = append(conditions, `webhook_id = ?`)
+		params = append(params, args.WebhookID)
+	}
+	if len(args.Statuses) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`status IN (?%s)`, strings.Repeat(", ?", len(args.Statuses)-1)))
+		for _, s := range args.Statuses {
+			params = append(params, s)
+		}
+	}
+	if !args.DueBefore.IsZero() {
+		conditions = append(conditions, fmt.Sprintf(`next_attempt_at <= %s`, dialect.timeParam()))
+		params = append(params, helpers.FormatDBTime(args.DueBefore))
+	}
+	return
+}
+
+func (r *webhookAdapterRepository) UpdateDelivery(ctx context.Context, delivery domain.WebhookDelivery) (err error) {
+	t := r.dialect.timeParam()
+	query := fmt.Sprintf(`UPDATE webhook_deliveries SET
+		status = ?
+		, attempts = ?
+		, next_attempt_at = %s
+		, response_status = ?
+		, last_error = ?
+		, delivered_at = %s
+		WHERE id = ?`, t, t)
+	_, err = r.db.ExecContext(
+		ctx,
+		query,
+		delivery.Status,
+		delivery.Attempts,
+		helpers.NullString(delivery.NextAttemptAt),
+		delivery.ResponseStatus,
+		helpers.NullString(delivery.LastError),
+		helpers.NullString(delivery.DeliveredAt),
+		delivery.ID,
+	)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
@@ -0,0 +1,99 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+	"github.com/hieronimusbudi/simple-go-api/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_webhookAdapterRepository(t *testing.T) {
+	// deliveries are counted, so it runs on its own database
+	db, err := test.SetupMySQLDatabase("webhooks")
+	require.NoError(t, err)
+	defer db.Close()
+	repo := repository.NewWebhookRepository(repository.WebhookAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+
+	id, err := repo.Create(ctx, domain.Webhook{URL: "https://hooks.example.com", Secret: "0123456789abcdef", Events: []string{domain.WebhookGatheringCreated, domain.WebhookMemberCreated}})
+	require.NoError(t, err)
+	disabledID, err := repo.Create(ctx, domain.Webhook{URL: "https://other.example.com", Secret: "0123456789abcdef", Events: []string{domain.WebhookEventAll}, IsDisabled: true})
+	require.NoError(t, err)
+	webhooks, err := repo.Get(ctx, domain.WebhookArgs{IsEnabledOnly: true})
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	require.Equal(t, []string{domain.WebhookGatheringCreated, domain.WebhookMemberCreated}, webhooks[0].Events)
+	require.Equal(t, "0123456789abcdef", webhooks[0].Secret)
+
+	// an update without secret keeps it
+	require.NoError(t, repo.Update(ctx, domain.Webhook{ID: id, URL: "https://hooks.example.com/v2", Events: []string{domain.WebhookEventAll}}))
+	webhooks, err = repo.Get(ctx, domain.WebhookArgs{IDs: []int64{id}})
+	require.NoError(t, err)
+	require.Equal(t, "https://hooks.example.com/v2", webhooks[0].URL)
+	require.Equal(t, "0123456789abcdef", webhooks[0].Secret)
+	require.Equal(t, []string{domain.WebhookEventAll}, webhooks[0].Events)
+
+	ids, err := repo.CreateDeliveries(ctx, []domain.WebhookDelivery{
+		{WebhookID: id, Event: domain.WebhookMemberCreated, Payload: `{"id":"a"}`, Status: valueobject.WEBHOOK_DELIVERY_PENDING},
+		{WebhookID: id, Event: domain.WebhookMemberUpdated, Payload: `{"id":"b"}`, Status: valueobject.WEBHOOK_DELIVERY_PENDING},
+		{WebhookID: disabledID, Event: domain.WebhookMemberUpdated, Payload: `{"id":"b"}`, Status: valueobject.WEBHOOK_DELIVERY_PENDING},
+	})
+	require.NoError(t, err)
+	require.Len(t, ids, 3)
+	due := domain.WebhookDeliveryArgs{Statuses: []valueobject.WebhookDeliveryStatus{valueobject.WEBHOOK_DELIVERY_PENDING}, DueBefore: time.Now().Add(time.Second)}
+	deliveries, err := repo.GetDeliveries(ctx, due)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 3)
+
+	// a failed delivery waits for its next attempt
+	failed := deliveries[0]
+	failed.Fail(time.Now(), 500, context.DeadlineExceeded, false)
+	require.NoError(t, repo.UpdateDelivery(ctx, failed))
+	delivered := deliveries[1]
+	delivered.Delivered(time.Now(), 204)
+	require.NoError(t, repo.UpdateDelivery(ctx, delivered))
+	deliveries, err = repo.GetDeliveries(ctx, due)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, ids[2], deliveries[0].ID)
+
+	deliveries, err = repo.GetDeliveries(ctx, domain.WebhookDeliveryArgs{WebhookID: id, Pagination: domain.Pagination{Sort: "-id"}})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	require.Equal(t, valueobject.WEBHOOK_DELIVERY_DELIVERED, deliveries[0].Status)
+	require.Equal(t, 204, deliveries[0].ResponseStatus)
+	require.NotEmpty(t, deliveries[0].DeliveredAt)
+	require.Equal(t, valueobject.WEBHOOK_DELIVERY_PENDING, deliveries[1].Status)
+	require.Equal(t, 1, deliveries[1].Attempts)
+	require.Equal(t, context.DeadlineExceeded.Error(), deliveries[1].LastError)
+	// parseTime of the test connection reads times back as RFC3339
+	wantNextAttemptAt, err := helpers.ParseDBTime(failed.NextAttemptAt)
+	require.NoError(t, err)
+	gotNextAttemptAt, err := helpers.ParseDBTime(deliveries[1].NextAttemptAt)
+	require.NoError(t, err)
+	require.True(t, wantNextAttemptAt.Equal(gotNextAttemptAt))
+
+	replayIDs, err := repo.CreateDeliveries(ctx, []domain.WebhookDelivery{deliveries[0].Replay()})
+	require.NoError(t, err)
+	deliveries, err = repo.GetDeliveries(ctx, domain.WebhookDeliveryArgs{IDs: replayIDs})
+	require.NoError(t, err)
+	require.Equal(t, ids[1], deliveries[0].ReplayOf)
+	require.Equal(t, `{"id":"b"}`, deliveries[0].Payload)
+	total, err := repo.CountDeliveries(ctx, domain.WebhookDeliveryArgs{WebhookID: id})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), total)
+
+	require.NoError(t, repo.Delete(ctx, id))
+	total, err = repo.CountDeliveries(ctx, domain.WebhookDeliveryArgs{WebhookID: id})
+	require.NoError(t, err)
+	require.Zero(t, total)
+	webhooks, err = repo.Get(ctx, domain.WebhookArgs{})
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+}
@@ -0,0 +1,34 @@
+package sqlite
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/config"
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+)
+
+func Connection() (db *sqlx.DB) {
+	path := config.Get().DBPATH
+	if path == "" {
+		path = "gathering.db"
+	}
+	db, err := Open(path)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return db
+}
+
+// Open connects to a database file, use ":memory:" path for a temporary database. Tables are created by migrations.
+func Open(path string) (db *sqlx.DB, err error) {
+	descriptor := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)", path)
+	db, err = sqlx.Connect("sqlite", descriptor)
+	if err != nil {
+		return
+	}
+	// sqlite allows one writer at a time, a single connection avoids "database is locked" errors
+	db.SetMaxOpenConns(1)
+	return
+}
@@ -0,0 +1,447 @@
+package sqlite_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/hieronimusbudi/simple-go-api/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// This test is integration test
+func Test_gatheringAdapterRepository_Create(t *testing.T) {
+	gathering := domain.Gathering{
+		Creator: domain.Member{
+			ID: 1,
+		},
+		Type:        0,
+		ScheduledAt: time.Date(2020, 10, 6, 11, 53, 0, 0, time.UTC),
+		Name:        "Private Gathering",
+		Location:    "Local Street",
+		Attendees: []domain.Member{
+			{
+				ID: 1,
+			},
+		},
+	}
+	type args struct {
+		gathering domain.Gathering
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantId  int64 // based on last ID from test data.sql
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				gathering: gathering,
+			},
+			wantId: 2, // based on last ID from test data.sql
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{
+				DB: db,
+			})
+			gotId, err := repo.Create(context.Background(), tt.args.gathering)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantId, gotId)
+			}
+		})
+	}
+}
+
+func Test_gatheringAdapterRepository_Get(t *testing.T) {
+	gatherings := []domain.Gathering{
+		{
+			ID:        1,
+			CreatorID: 1,
+			Creator: domain.Member{
+				ID: 1,
+			},
+			Type:        0,
+			ScheduledAt: time.Date(2023, 10, 6, 5, 0, 0, 0, time.UTC),
+			TimeZone:    domain.DefaultTimeZone,
+			CreatedAt:   "2023-10-02 11:06:52",
+			Name:        "Private Meeting",
+			Location:    "pramuka street",
+			Attendees: []domain.Member{
+				{
+					ID: 1,
+				},
+			},
+		},
+	}
+	type args struct {
+		args domain.GatheringArgs
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantGatherings []domain.Gathering
+		wantErr        bool
+	}{
+		{
+			name: "success",
+			args: args{
+				domain.GatheringArgs{
+					IDs: []int64{1},
+				},
+			},
+			wantGatherings: gatherings,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{
+				DB: db,
+			})
+			gotGatherings, err := repo.Get(context.Background(), tt.args.args)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantGatherings, gotGatherings)
+			}
+		})
+	}
+}
+
+func Test_gatheringAdapterRepository_Update(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	endsAt := time.Date(2023, 10, 6, 13, 53, 0, 0, jakarta)
+	gathering := domain.Gathering{
+		ID:        1,
+		CreatorID: 1,
+		Creator: domain.Member{
+			ID: 1,
+		},
+		Type:        0,
+		ScheduledAt: time.Date(2023, 10, 6, 11, 53, 0, 0, jakarta),
+		EndsAt:      &endsAt,
+		TimeZone:    "Asia/Jakarta",
+		CreatedAt:   "2023-10-02 11:06:52",
+		Name:        "Update Private Meeting",
+		Location:    "update pramuka street",
+		Attendees: []domain.Member{
+			{
+				ID: 1,
+			},
+		},
+	}
+	wantGathering := gathering
+	wantGathering.DurationMinutes = 120
+	type args struct {
+		gathering domain.Gathering
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantGathering domain.Gathering
+		wantErr       bool
+	}{
+		{
+			name: "success",
+			args: args{
+				gathering: gathering,
+			},
+			wantGathering: wantGathering,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{
+				DB: db,
+			})
+			_, err := repo.Update(context.Background(), tt.args.gathering)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				// check data
+				gatherings, err := repo.Get(context.Background(), domain.GatheringArgs{
+					IDs: []int64{tt.args.gathering.ID},
+				})
+				require.NoError(t, err)
+				// times are compared in their JSON form, with the offset of the time zone
+				want, err := json.Marshal(tt.wantGathering)
+				require.NoError(t, err)
+				got, err := json.Marshal(gatherings[0])
+				require.NoError(t, err)
+				require.JSONEq(t, string(want), string(got))
+			}
+		})
+	}
+}
+
+func Test_gatheringAdapterRepository_Delete(t *testing.T) {
+	type args struct {
+		args domain.GatheringArgs
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				args: domain.GatheringArgs{
+					ID: 1,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{
+				DB: db,
+			})
+			err := repo.Delete(context.Background(), tt.args.args)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				// check data
+				gatherings, err := repo.Get(context.Background(), domain.GatheringArgs{
+					IDs: []int64{tt.args.args.ID},
+				})
+				require.NoError(t, err)
+				require.Equal(t, 0, len(gatherings))
+			}
+		})
+	}
+}
+
+func Test_gatheringAdapterRepository_Purge(t *testing.T) {
+	// purge removes rows other tests rely on, so it runs on its own database
+	db, err := test.SetupSQLite()
+	require.NoError(t, err)
+	defer db.Close()
+	repo := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{DB: db})
+	invitationRepo := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+	newerID, err := repo.Create(ctx, domain.Gathering{
+		Creator:     domain.Member{ID: 2},
+		ScheduledAt: time.Date(2020, 10, 6, 11, 53, 0, 0, time.UTC),
+		Name:        "Newer Gathering",
+		Location:    "Local Street",
+		Attendees:   []domain.Member{{ID: 2}},
+	})
+	require.NoError(t, err)
+	_, err = invitationRepo.Create(ctx, domain.Invitation{Member: domain.Member{ID: 1}, Gathering: domain.Gathering{ID: newerID}})
+	require.NoError(t, err)
+	// gathering 1 of data.sql, attended by member 1 and with an invitation of member 2, was discarded before the cutoff
+	_, err = db.ExecContext(ctx, `UPDATE gatherings SET discarded_at = ? WHERE id = ?`, "2020-01-01 00:00:00", 1)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, `UPDATE gatherings SET discarded_at = ? WHERE id = ?`, "2022-01-01 00:00:00", newerID)
+	require.NoError(t, err)
+
+	total, err := repo.Purge(ctx, "2021-01-01 00:00:00")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	// check data
+	gatherings, err := repo.Get(ctx, domain.GatheringArgs{IsIncludeDiscard: true})
+	require.NoError(t, err)
+	require.Len(t, gatherings, 1)
+	require.Equal(t, newerID, gatherings[0].ID)
+	for gatheringID, want := range map[int64]int{1: 0, newerID: 1} {
+		invitations, err := invitationRepo.Get(ctx, domain.InvitationArgs{GatheringID: gatheringID})
+		require.NoError(t, err)
+		require.Len(t, invitations, want, "invitations of gathering %d", gatheringID)
+		var attendees int
+		require.NoError(t, db.GetContext(ctx, &attendees, `SELECT COUNT(*) FROM attendees WHERE gathering_id = ?`, gatheringID))
+		require.Equal(t, want, attendees, "attendees of gathering %d", gatheringID)
+	}
+}
+
+func Test_gatheringAdapterRepository_Split(t *testing.T) {
+	// the split series adds gatherings other tests count, so it runs on its own database
+	db, err := test.SetupSQLite()
+	require.NoError(t, err)
+	defer db.Close()
+	repo := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{DB: db})
+	invitationRepo := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+	start := time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC)
+	week := func(n int) time.Time { return start.AddDate(0, 0, 7*n) }
+	id, err := repo.Create(ctx, domain.Gathering{
+		Creator:     domain.Member{ID: 1},
+		ScheduledAt: start,
+		TimeZone:    domain.DefaultTimeZone,
+		Recurrence:  "FREQ=WEEKLY;COUNT=4",
+		Name:        "standup",
+		Location:    "room 1",
+		Attendees:   []domain.Member{{ID: 1}},
+		Exceptions:  []domain.Occurrence{{RecurrenceID: week(1), ScheduledAt: week(1), Name: "standup", Location: "room 1", Canceled: true}},
+	})
+	require.NoError(t, err)
+	_, err = invitationRepo.Create(ctx, domain.Invitation{Member: domain.Member{ID: 2}, Gathering: domain.Gathering{ID: id}})
+	require.NoError(t, err)
+	moved := domain.Occurrence{GatheringID: id, RecurrenceID: week(3), ScheduledAt: week(3).Add(time.Hour), Name: "standup", Location: "room 2"}
+	require.NoError(t, repo.SaveOccurrence(ctx, moved))
+	// saving again replaces the exception
+	moved.Location = "room 3"
+	require.NoError(t, repo.SaveOccurrence(ctx, moved))
+
+	gatherings, err := repo.Get(ctx, domain.GatheringArgs{IDs: []int64{id}})
+	require.NoError(t, err)
+	current := gatherings[0]
+	require.Equal(t, "FREQ=WEEKLY;COUNT=4", current.Recurrence)
+	require.Len(t, current.Exceptions, 2)
+	require.Equal(t, "room 3", current.Exceptions[1].Location)
+
+	following, ok, err := current.Split(week(2))
+	require.NoError(t, err)
+	require.True(t, ok)
+	following.Name = "retro"
+	followingID, err := repo.Split(ctx, current, following, week(2))
+	require.NoError(t, err)
+	// check data
+	gatherings, err = repo.Get(ctx, domain.GatheringArgs{IDs: []int64{id, followingID}})
+	require.NoError(t, err)
+	require.Len(t, gatherings, 2)
+	require.Equal(t, "FREQ=WEEKLY;COUNT=2", gatherings[0].Recurrence)
+	require.Len(t, gatherings[0].Exceptions, 1)
+	require.Equal(t, "FREQ=WEEKLY;COUNT=2", gatherings[1].Recurrence)
+	require.Equal(t, "retro", gatherings[1].Name)
+	require.True(t, week(2).Equal(gatherings[1].ScheduledAt))
+	require.Equal(t, []domain.Member{{ID: 1}}, gatherings[1].Attendees)
+	require.Len(t, gatherings[1].Exceptions, 1)
+	require.Equal(t, followingID, gatherings[1].Exceptions[0].GatheringID)
+	invitations, err := invitationRepo.Get(ctx, domain.InvitationArgs{GatheringID: followingID})
+	require.NoError(t, err)
+	require.Len(t, invitations, 1)
+}
+
+func Test_gatheringAdapterRepository_CalendarUID(t *testing.T) {
+	db, err := test.SetupSQLite()
+	require.NoError(t, err)
+	defer db.Close()
+	repo := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+	gathering := domain.Gathering{
+		Creator:     domain.Member{ID: 1},
+		ScheduledAt: time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC),
+		TimeZone:    domain.DefaultTimeZone,
+		CalendarUID: "standup@example.com",
+		Name:        "standup",
+		Location:    "room 1",
+	}
+	id, err := repo.Create(ctx, gathering)
+	require.NoError(t, err)
+	// the UID is unique per creator
+	_, err = repo.Create(ctx, gathering)
+	require.ErrorIs(t, err, domain.ErrConflict)
+	gathering.Creator.ID = 2
+	_, err = repo.Create(ctx, gathering)
+	require.NoError(t, err)
+	// check data
+	gatherings, err := repo.Get(ctx, domain.GatheringArgs{CreatorID: 1, CalendarUID: "standup@example.com"})
+	require.NoError(t, err)
+	require.Len(t, gatherings, 1)
+	require.Equal(t, id, gatherings[0].ID)
+	require.Equal(t, "standup@example.com", gatherings[0].CalendarUID)
+	gatherings, err = repo.Get(ctx, domain.GatheringArgs{IDs: []int64{1}})
+	require.NoError(t, err)
+	require.Empty(t, gatherings[0].CalendarUID)
+}
+
+func Test_gatheringAdapterRepository_IsVisibleOnly(t *testing.T) {
+	db, err := test.SetupSQLite()
+	require.NoError(t, err)
+	defer db.Close()
+	memberRepo := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{DB: db})
+	repo := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+	_, err = memberRepo.Create(ctx, domain.Member{FirstName: "ken", Email: "ken@mail.com"})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, domain.Gathering{
+		Creator:     domain.Member{ID: 2},
+		Type:        valueobject.PUBLIC,
+		ScheduledAt: time.Date(2023, 10, 7, 10, 0, 0, 0, time.UTC),
+		TimeZone:    domain.DefaultTimeZone,
+		Name:        "Public Meeting",
+		Location:    "sudirman street",
+	})
+	require.NoError(t, err)
+	// gathering 1 of data.sql is private, created by member 1 who attends it, member 2 is invited
+	for viewerID, wantIDs := range map[int64][]int64{0: {2}, 1: {1, 2}, 2: {1, 2}, 3: {2}} {
+		args := domain.GatheringArgs{IsVisibleOnly: true, ViewerID: viewerID}
+		gatherings, err := repo.Get(ctx, args)
+		require.NoError(t, err)
+		gotIDs := []int64{}
+		for _, g := range gatherings {
+			gotIDs = append(gotIDs, g.ID)
+		}
+		require.Equal(t, wantIDs, gotIDs, "viewer %d", viewerID)
+		total, err := repo.Count(ctx, args)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(wantIDs)), total)
+	}
+}
+
+func Test_gatheringAdapterRepository_JoinLeave(t *testing.T) {
+	db, err := test.SetupSQLite()
+	require.NoError(t, err)
+	defer db.Close()
+	memberRepo := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{DB: db})
+	invitationRepo := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{DB: db})
+	repo := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+	_, err = memberRepo.Create(ctx, domain.Member{FirstName: "ken", Email: "ken@mail.com"})
+	require.NoError(t, err)
+	gathering := domain.Gathering{
+		Creator:     domain.Member{ID: 1},
+		Type:        valueobject.PUBLIC,
+		ScheduledAt: time.Date(2030, 10, 7, 10, 0, 0, 0, time.UTC),
+		TimeZone:    domain.DefaultTimeZone,
+		Name:        "Public Meeting",
+		Location:    "sudirman street",
+		Capacity:    2,
+		Attendees:   []domain.Member{{ID: 1}},
+	}
+	gathering.ID, err = repo.Create(ctx, gathering)
+	require.NoError(t, err)
+	checkAttendees := func(want []domain.Member) {
+		gatherings, err := repo.Get(ctx, domain.GatheringArgs{IDs: []int64{gathering.ID}})
+		require.NoError(t, err)
+		require.ElementsMatch(t, want, gatherings[0].Attendees)
+	}
+
+	require.NoError(t, repo.Join(ctx, gathering.ID, 2))
+	require.ErrorIs(t, repo.Join(ctx, gathering.ID, 2), domain.ErrConflict)
+	// the gathering is full, an accepted invitation is waitlisted
+	require.ErrorIs(t, repo.Join(ctx, gathering.ID, 3), domain.ErrConflict)
+	invitationID, err := invitationRepo.Create(ctx, domain.Invitation{Member: domain.Member{ID: 3}, Gathering: gathering})
+	require.NoError(t, err)
+	_, err = invitationRepo.UpdateStatus(ctx, domain.InvitationArgs{ID: invitationID, MemberID: 3, GatheringID: gathering.ID, Status: valueobject.INVITATION_ACCEPT})
+	require.NoError(t, err)
+	checkAttendees([]domain.Member{{ID: 1}, {ID: 2}})
+
+	// the freed seat goes to the waitlist
+	promotedIDs, err := repo.Leave(ctx, gathering.ID, 2)
+	require.NoError(t, err)
+	require.Equal(t, []int64{invitationID}, promotedIDs)
+	checkAttendees([]domain.Member{{ID: 1}, {ID: 3}})
+	// leaving rejects the accepted invitation
+	promotedIDs, err = repo.Leave(ctx, gathering.ID, 3)
+	require.NoError(t, err)
+	require.Empty(t, promotedIDs)
+	checkAttendees([]domain.Member{{ID: 1}})
+	invitations, err := invitationRepo.Get(ctx, domain.InvitationArgs{IDs: []int64{invitationID}})
+	require.NoError(t, err)
+	require.Equal(t, valueobject.INVITATION_REJECT, invitations[0].Status)
+}
@@ -0,0 +1,434 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/hieronimusbudi/simple-go-api/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+// This test is integration test
+func Test_invitationAdapterRepository_Create(t *testing.T) {
+	invitation := domain.Invitation{
+		Member: domain.Member{
+			ID: 2,
+		},
+		Gathering: domain.Gathering{
+			ID: 2,
+		},
+	}
+	type args struct {
+		invitation domain.Invitation
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantId  int64
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				invitation: invitation,
+			},
+			wantId: 2, // based on last ID from test data.sql
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{
+				DB: db,
+			})
+			gotId, err := repo.Create(context.Background(), tt.args.invitation)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantId, gotId)
+			}
+		})
+	}
+}
+
+func Test_invitationAdapterRepository_CreateBatch(t *testing.T) {
+	// a failed batch must leave no rows behind, so it runs on its own database
+	db, err := test.SetupSQLite()
+	require.NoError(t, err)
+	defer db.Close()
+	memberRepo := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{DB: db})
+	repo := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+	for _, email := range []string{"ken@mail.com", "dennis@mail.com"} {
+		_, err = memberRepo.Create(ctx, domain.Member{FirstName: "member", Email: email})
+		require.NoError(t, err)
+	}
+	invitation := func(memberID int64) domain.Invitation {
+		return domain.Invitation{Member: domain.Member{ID: memberID}, Gathering: domain.Gathering{ID: 1}}
+	}
+
+	ids, err := repo.CreateBatch(ctx, []domain.Invitation{invitation(3), invitation(4)})
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	invitations, err := repo.Get(ctx, domain.InvitationArgs{IDs: ids})
+	require.NoError(t, err)
+	require.Len(t, invitations, 2)
+
+	// member 99 does not exist, nobody of the batch is invited
+	_, err = repo.CreateBatch(ctx, []domain.Invitation{invitation(1), invitation(99)})
+	require.ErrorIs(t, err, domain.ErrValidation)
+	invitations, err = repo.Get(ctx, domain.InvitationArgs{GatheringID: 1, MemberID: 1})
+	require.NoError(t, err)
+	require.Empty(t, invitations)
+}
+
+func Test_invitationAdapterRepository_Get(t *testing.T) {
+	invitations := []domain.Invitation{
+		{
+			ID:       1,
+			MemberID: 2,
+			Member: domain.Member{
+				ID: 2,
+			},
+			GatheringID: 1,
+			Gathering: domain.Gathering{
+				ID: 1,
+			},
+			Status:    valueobject.INVITATION_CREATED,
+			CreatedAt: "2023-10-02 11:09:22",
+		},
+	}
+	type args struct {
+		args domain.InvitationArgs
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantInvitations []domain.Invitation
+		wantErr         bool
+	}{
+		{
+			name: "success",
+			args: args{
+				domain.InvitationArgs{
+					IDs: []int64{1},
+				},
+			},
+			wantInvitations: invitations,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{
+				DB: db,
+			})
+			gotInvitations, err := repo.Get(context.Background(), tt.args.args)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantInvitations, gotInvitations)
+			}
+		})
+	}
+}
+
+func Test_invitationAdapterRepository_UpdateStatus(t *testing.T) {
+	invitationAccept := domain.Invitation{
+		ID:       1,
+		MemberID: 2,
+		Member: domain.Member{
+			ID: 2,
+		},
+		GatheringID: 1,
+		Gathering: domain.Gathering{
+			ID: 1,
+		},
+		Status:    valueobject.INVITATION_ACCEPT,
+		CreatedAt: "2023-10-02 11:09:22",
+	}
+	invitationReject := domain.Invitation{
+		ID:       1,
+		MemberID: 2,
+		Member: domain.Member{
+			ID: 2,
+		},
+		GatheringID: 1,
+		Gathering: domain.Gathering{
+			ID: 1,
+		},
+		Status:    valueobject.INVITATION_REJECT,
+		CreatedAt: "2023-10-02 11:09:22",
+	}
+	type args struct {
+		args domain.InvitationArgs
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantInvitation domain.Invitation
+		wantErr        bool
+	}{
+		{
+			name: "success accept",
+			args: args{
+				domain.InvitationArgs{
+					ID:          1,
+					MemberID:    invitationAccept.MemberID,
+					GatheringID: invitationAccept.GatheringID,
+					Status:      valueobject.INVITATION_ACCEPT,
+				},
+			},
+			wantInvitation: invitationAccept,
+		},
+		{
+			name: "success reject",
+			args: args{
+				domain.InvitationArgs{
+					ID:          1,
+					MemberID:    invitationReject.MemberID,
+					GatheringID: invitationReject.GatheringID,
+					Status:      valueobject.INVITATION_REJECT,
+				},
+			},
+			wantInvitation: invitationReject,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{
+				DB: db,
+			})
+			_, err := repo.UpdateStatus(context.Background(), tt.args.args)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				// check data
+				invitations, err := repo.Get(context.Background(), domain.InvitationArgs{
+					IDs: []int64{tt.args.args.ID},
+				})
+				require.NoError(t, err)
+				gotInvitation := invitations[0]
+				require.Equal(t, tt.wantInvitation, gotInvitation)
+			}
+		})
+	}
+}
+
+func Test_invitationAdapterRepository_UpdateStatus_acceptTwice(t *testing.T) {
+	repo := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{
+		DB: db,
+	})
+	args := domain.InvitationArgs{
+		ID:          1,
+		MemberID:    2,
+		GatheringID: 1,
+		Status:      valueobject.INVITATION_ACCEPT,
+	}
+	_, err := repo.UpdateStatus(context.Background(), args)
+	require.NoError(t, err)
+	_, err = repo.UpdateStatus(context.Background(), args)
+	require.EqualError(t, err, "the member has accepted the invitation")
+}
+
+func Test_invitationAdapterRepository_UpdateStatus_waitlist(t *testing.T) {
+	// promotions change statuses other tests read, so it runs on its own database
+	db, err := test.SetupSQLite()
+	require.NoError(t, err)
+	defer db.Close()
+	memberRepo := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{DB: db})
+	gatheringRepo := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{DB: db})
+	repo := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{DB: db})
+	notificationRepo := repository.NewNotificationRepository(repository.NotificationAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+	for _, email := range []string{"ken@mail.com", "dennis@mail.com", "rob@mail.com"} {
+		_, err = memberRepo.Create(ctx, domain.Member{FirstName: "member", Email: email})
+		require.NoError(t, err)
+	}
+	gathering := domain.Gathering{
+		Creator:     domain.Member{ID: 1},
+		ScheduledAt: time.Date(2023, 10, 6, 5, 0, 0, 0, time.UTC),
+		TimeZone:    domain.DefaultTimeZone,
+		Name:        "dinner",
+		Location:    "home",
+		Capacity:    2,
+		Attendees:   []domain.Member{{ID: 1}},
+	}
+	gathering.ID, err = gatheringRepo.Create(ctx, gathering)
+	require.NoError(t, err)
+	invitationIDs := map[int64]int64{}
+	for _, memberID := range []int64{2, 3, 4, 5} {
+		invitationIDs[memberID], err = repo.Create(ctx, domain.Invitation{Member: domain.Member{ID: memberID}, Gathering: gathering})
+		require.NoError(t, err)
+	}
+	updateStatus := func(memberID int64, status valueobject.InvitationStatus) (promotedIDs []int64) {
+		promotedIDs, err := repo.UpdateStatus(ctx, domain.InvitationArgs{ID: invitationIDs[memberID], MemberID: memberID, GatheringID: gathering.ID, Status: status})
+		require.NoError(t, err)
+		return
+	}
+	check := func(wantStatuses map[int64]valueobject.InvitationStatus, wantAttendees []domain.Member) {
+		invitations, err := repo.Get(ctx, domain.InvitationArgs{GatheringID: gathering.ID})
+		require.NoError(t, err)
+		for _, inv := range invitations {
+			require.Equal(t, wantStatuses[inv.MemberID], inv.Status, "member %d", inv.MemberID)
+			require.Equal(t, inv.Status == valueobject.INVITATION_WAITLISTED, inv.WaitlistedAt != "")
+		}
+		gatherings, err := gatheringRepo.Get(ctx, domain.GatheringArgs{IDs: []int64{gathering.ID}})
+		require.NoError(t, err)
+		require.ElementsMatch(t, wantAttendees, gatherings[0].Attendees)
+	}
+	// promoted lists who was told their waitlisted invitation is accepted, in the order they were promoted
+	promoted := func() (memberIDs []int64) {
+		notifications, err := notificationRepo.Get(ctx, domain.NotificationArgs{})
+		require.NoError(t, err)
+		for _, n := range notifications {
+			if n.Event == domain.NotificationWaitlistPromoted {
+				require.Equal(t, invitationIDs[n.MemberID], n.Data.InvitationID)
+				require.Equal(t, "dinner", n.Data.GatheringName)
+				require.True(t, gathering.ScheduledAt.Equal(n.Data.ScheduledAt))
+				memberIDs = append(memberIDs, n.MemberID)
+			}
+		}
+		return
+	}
+
+	require.Empty(t, updateStatus(2, valueobject.INVITATION_ACCEPT))
+	require.Empty(t, updateStatus(3, valueobject.INVITATION_ACCEPT))
+	require.Empty(t, updateStatus(4, valueobject.INVITATION_ACCEPT))
+	check(map[int64]valueobject.InvitationStatus{
+		2: valueobject.INVITATION_ACCEPT,
+		3: valueobject.INVITATION_WAITLISTED,
+		4: valueobject.INVITATION_WAITLISTED,
+	}, []domain.Member{{ID: 1}, {ID: 2}})
+	require.Empty(t, promoted())
+	// the seat of a rejected attendee goes to the first waitlisted member
+	require.Equal(t, []int64{invitationIDs[3]}, updateStatus(2, valueobject.INVITATION_REJECT))
+	check(map[int64]valueobject.InvitationStatus{
+		2: valueobject.INVITATION_REJECT,
+		3: valueobject.INVITATION_ACCEPT,
+		4: valueobject.INVITATION_WAITLISTED,
+	}, []domain.Member{{ID: 1}, {ID: 3}})
+	require.Equal(t, []int64{3}, promoted())
+	// a raised capacity promotes the waitlist
+	gathering.Capacity = 3
+	promotedIDs, err := gatheringRepo.Update(ctx, gathering)
+	require.NoError(t, err)
+	require.Equal(t, []int64{invitationIDs[4]}, promotedIDs)
+	check(map[int64]valueobject.InvitationStatus{
+		2: valueobject.INVITATION_REJECT,
+		3: valueobject.INVITATION_ACCEPT,
+		4: valueobject.INVITATION_ACCEPT,
+	}, []domain.Member{{ID: 1}, {ID: 3}, {ID: 4}})
+	require.Equal(t, []int64{3, 4}, promoted())
+	// the seat of an attendee who leaves goes to the waitlist too
+	require.Empty(t, updateStatus(5, valueobject.INVITATION_ACCEPT))
+	promotedIDs, err = gatheringRepo.Leave(ctx, gathering.ID, 3)
+	require.NoError(t, err)
+	require.Equal(t, []int64{invitationIDs[5]}, promotedIDs)
+	check(map[int64]valueobject.InvitationStatus{
+		2: valueobject.INVITATION_REJECT,
+		3: valueobject.INVITATION_REJECT,
+		4: valueobject.INVITATION_ACCEPT,
+		5: valueobject.INVITATION_ACCEPT,
+	}, []domain.Member{{ID: 1}, {ID: 4}, {ID: 5}})
+	require.Equal(t, []int64{3, 4, 5}, promoted())
+}
+
+func Test_invitationAdapterRepository_UpdateStatus_guests(t *testing.T) {
+	db, err := test.SetupSQLite()
+	require.NoError(t, err)
+	defer db.Close()
+	memberRepo := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{DB: db})
+	gatheringRepo := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{DB: db})
+	repo := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+	_, err = memberRepo.Create(ctx, domain.Member{FirstName: "ken", Email: "ken@mail.com"})
+	require.NoError(t, err)
+	gathering := domain.Gathering{
+		Creator:     domain.Member{ID: 1},
+		ScheduledAt: time.Date(2023, 10, 6, 5, 0, 0, 0, time.UTC),
+		TimeZone:    domain.DefaultTimeZone,
+		Name:        "dinner",
+		Location:    "home",
+		Capacity:    3,
+		Attendees:   []domain.Member{{ID: 1}},
+	}
+	gathering.ID, err = gatheringRepo.Create(ctx, gathering)
+	require.NoError(t, err)
+	invitationIDs := map[int64]int64{}
+	for _, memberID := range []int64{2, 3} {
+		invitationIDs[memberID], err = repo.Create(ctx, domain.Invitation{Member: domain.Member{ID: memberID}, Gathering: gathering})
+		require.NoError(t, err)
+	}
+	respond := func(memberID int64, status valueobject.InvitationStatus, response domain.InvitationResponse) domain.Invitation {
+		_, err := repo.UpdateStatus(ctx, domain.InvitationArgs{ID: invitationIDs[memberID], MemberID: memberID, GatheringID: gathering.ID, Status: status, Response: response})
+		require.NoError(t, err)
+		invitations, err := repo.Get(ctx, domain.InvitationArgs{IDs: []int64{invitationIDs[memberID]}})
+		require.NoError(t, err)
+		return invitations[0]
+	}
+
+	// the creator and ron with a guest fill the gathering
+	ron := respond(2, valueobject.INVITATION_ACCEPT, domain.InvitationResponse{Guests: 1, Note: "bringing a friend"})
+	require.Equal(t, valueobject.INVITATION_ACCEPT, ron.Status)
+	require.Equal(t, 1, ron.Guests)
+	require.Equal(t, "bringing a friend", ron.Note)
+	ken := respond(3, valueobject.INVITATION_ACCEPT, domain.InvitationResponse{})
+	require.Equal(t, valueobject.INVITATION_WAITLISTED, ken.Status)
+	// a maybe frees the seats of ron and the guest
+	ron = respond(2, valueobject.INVITATION_TENTATIVE, domain.InvitationResponse{Guests: 1, Note: "maybe"})
+	require.Equal(t, valueobject.INVITATION_TENTATIVE, ron.Status)
+	require.Equal(t, "maybe", ron.Note)
+	invitations, err := repo.Get(ctx, domain.InvitationArgs{GatheringID: gathering.ID, Statuses: []valueobject.InvitationStatus{valueobject.INVITATION_ACCEPT}})
+	require.NoError(t, err)
+	require.Len(t, invitations, 1)
+	require.Equal(t, int64(3), invitations[0].MemberID)
+	// one seat is left, ron and the guest need two
+	ron = respond(2, valueobject.INVITATION_ACCEPT, domain.InvitationResponse{Guests: 1})
+	require.Equal(t, valueobject.INVITATION_WAITLISTED, ron.Status)
+	gatherings, err := gatheringRepo.Get(ctx, domain.GatheringArgs{IDs: []int64{gathering.ID}})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []domain.Member{{ID: 1}, {ID: 3}}, gatherings[0].Attendees)
+}
+
+func Test_invitationAdapterRepository_IsVisibleOnly(t *testing.T) {
+	db, err := test.SetupSQLite()
+	require.NoError(t, err)
+	defer db.Close()
+	memberRepo := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{DB: db})
+	gatheringRepo := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{DB: db})
+	repo := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+	_, err = memberRepo.Create(ctx, domain.Member{FirstName: "ken", Email: "ken@mail.com"})
+	require.NoError(t, err)
+	gatheringID, err := gatheringRepo.Create(ctx, domain.Gathering{
+		Creator:     domain.Member{ID: 2},
+		Type:        valueobject.PUBLIC,
+		ScheduledAt: time.Date(2023, 10, 7, 10, 0, 0, 0, time.UTC),
+		TimeZone:    domain.DefaultTimeZone,
+		Name:        "Public Meeting",
+		Location:    "sudirman street",
+	})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, domain.Invitation{Member: domain.Member{ID: 3}, Gathering: domain.Gathering{ID: gatheringID}})
+	require.NoError(t, err)
+	// invitation 1 of data.sql is of member 2 to the private gathering 1 of member 1
+	for viewerID, wantIDs := range map[int64][]int64{0: {2}, 1: {1, 2}, 2: {1, 2}, 3: {2}} {
+		args := domain.InvitationArgs{IsVisibleOnly: true, ViewerID: viewerID}
+		invitations, err := repo.Get(ctx, args)
+		require.NoError(t, err)
+		gotIDs := []int64{}
+		for _, inv := range invitations {
+			gotIDs = append(gotIDs, inv.ID)
+		}
+		require.Equal(t, wantIDs, gotIDs, "viewer %d", viewerID)
+		total, err := repo.Count(ctx, args)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(wantIDs)), total)
+	}
+}
@@ -0,0 +1,40 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_leaseAdapterRepository(t *testing.T) {
+	repo := repository.NewLeaseRepository(repository.LeaseAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+
+	acquired, err := repo.Acquire(ctx, "test-lease", "replica-a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	acquired, err = repo.Acquire(ctx, "test-lease", "replica-b", time.Minute)
+	require.NoError(t, err)
+	require.False(t, acquired, "held by replica-a")
+	acquired, err = repo.Acquire(ctx, "test-lease", "replica-a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired, "renewed by its holder")
+
+	// releasing a lease of another holder does nothing
+	require.NoError(t, repo.Release(ctx, "test-lease", "replica-b"))
+	acquired, err = repo.Acquire(ctx, "test-lease", "replica-b", time.Minute)
+	require.NoError(t, err)
+	require.False(t, acquired)
+	require.NoError(t, repo.Release(ctx, "test-lease", "replica-a"))
+	acquired, err = repo.Acquire(ctx, "test-lease", "replica-b", -time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired, "free once released, this time it expired already")
+
+	// an expired lease is taken over
+	acquired, err = repo.Acquire(ctx, "test-lease", "replica-a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+}
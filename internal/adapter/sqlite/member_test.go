@@ -0,0 +1,364 @@
+package sqlite_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/hieronimusbudi/simple-go-api/internal/test"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	db *sqlx.DB
+)
+
+// These tests run the shared SQL repositories against in-memory sqlite database, no docker needed
+func TestMain(m *testing.M) {
+	var err error
+	db, err = test.SetupSQLite()
+	if err != nil {
+		log.Println("failed to setup SQLite database")
+		panic(err)
+	}
+	code := m.Run()
+	db.Close()
+	os.Exit(code)
+}
+
+func Test_memberAdapterRepository_Create(t *testing.T) {
+	member := domain.Member{
+		FirstName: "john",
+		LastName:  "doe",
+		Email:     "john@mail.com",
+	}
+	type args struct {
+		member domain.Member
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantId  int64 // based on last ID from test data.sql
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				member: member,
+			},
+			wantId: 3, // based on last ID from test data.sql
+		},
+		{
+			name: "duplicate email",
+			args: args{
+				member: member,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{
+				DB: db,
+			})
+			gotId, err := repo.Create(context.Background(), tt.args.member)
+			if tt.wantErr {
+				require.ErrorIs(t, err, domain.ErrConflict)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantId, gotId)
+			}
+		})
+	}
+}
+
+func Test_memberAdapterRepository_Get(t *testing.T) {
+	members := []domain.Member{
+		{
+			ID:        1,
+			FirstName: "linus",
+			LastName:  "torvalds",
+			Email:     "linus@mail.com",
+			CreatedAt: "2023-10-02 11:05:01",
+			Role:      valueobject.ROLE_MEMBER,
+		},
+		{
+			ID:        2,
+			FirstName: "ron",
+			LastName:  "west",
+			Email:     "ron@mail.com",
+			CreatedAt: "2023-10-02 11:05:43",
+			Role:      valueobject.ROLE_MEMBER,
+		},
+	}
+	type args struct {
+		args domain.MemberArgs
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantMembers []domain.Member
+		wantErr     bool
+	}{
+		{
+			name:        "success",
+			wantMembers: members,
+			args: args{
+				domain.MemberArgs{
+					IDs: []int64{1, 2},
+				},
+			},
+		},
+		{
+			name:        "any of ids or emails",
+			wantMembers: members,
+			args: args{
+				domain.MemberArgs{
+					AnyIDs:    []int64{1, 99},
+					AnyEmails: []string{"ron@mail.com", "nobody@mail.com"},
+				},
+			},
+		},
+		{
+			name:        "success with cursor",
+			wantMembers: members[1:],
+			args: args{
+				domain.MemberArgs{
+					IDs: []int64{1, 2},
+					Pagination: domain.Pagination{
+						Limit:  1,
+						Cursor: domain.EncodeCursor("", 1),
+					},
+				},
+			},
+		},
+		{
+			name:        "success sort by name descending",
+			wantMembers: []domain.Member{members[1], members[0]},
+			args: args{
+				domain.MemberArgs{
+					IDs: []int64{1, 2},
+					Pagination: domain.Pagination{
+						Sort: "-name",
+					},
+				},
+			},
+		},
+		{
+			name:        "success filter by name",
+			wantMembers: members[1:],
+			args: args{
+				domain.MemberArgs{
+					Name: "wes",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{
+				DB: db,
+			})
+			gotMembers, err := repo.Get(context.Background(), tt.args.args)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantMembers, gotMembers)
+			}
+		})
+	}
+}
+
+func Test_memberAdapterRepository_Count(t *testing.T) {
+	type args struct {
+		args domain.MemberArgs
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantTotal int64
+		wantErr   bool
+	}{
+		{
+			name:      "success",
+			wantTotal: 3, // 2 from test data.sql and 1 from create test
+		},
+		{
+			name: "success ignore pagination",
+			args: args{
+				domain.MemberArgs{
+					Email: "ron@mail.com",
+					Pagination: domain.Pagination{
+						Limit:  1,
+						Offset: 1,
+					},
+				},
+			},
+			wantTotal: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{
+				DB: db,
+			})
+			gotTotal, err := repo.Count(context.Background(), tt.args.args)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantTotal, gotTotal)
+			}
+		})
+	}
+}
+
+func Test_memberAdapterRepository_Update(t *testing.T) {
+	member := domain.Member{
+		ID:        1,
+		FirstName: "linus updated",
+		LastName:  "torvalds updated",
+		Email:     "updatedlinus@mail.com",
+		CreatedAt: "2023-10-02 11:05:01",
+		Role:      valueobject.ROLE_MEMBER,
+	}
+	type args struct {
+		member domain.Member
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				member: member,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{
+				DB: db,
+			})
+			err := repo.Update(context.Background(), tt.args.member)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				// check data
+				members, err := repo.Get(context.Background(), domain.MemberArgs{
+					IDs: []int64{tt.args.member.ID},
+				})
+				require.NoError(t, err)
+				gotMember := members[0]
+				require.Equal(t, tt.args.member, gotMember)
+			}
+		})
+	}
+}
+
+func Test_memberAdapterRepository_UpdateRole(t *testing.T) {
+	repo := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{
+		DB: db,
+	})
+	err := repo.UpdateRole(context.Background(), domain.Member{ID: 2, Role: valueobject.ROLE_ADMIN})
+	require.NoError(t, err)
+	members, err := repo.Get(context.Background(), domain.MemberArgs{IDs: []int64{2}})
+	require.NoError(t, err)
+	require.Equal(t, valueobject.ROLE_ADMIN, members[0].Role)
+	require.Equal(t, "ron", members[0].FirstName)
+	// reset, later tests expect seeded members
+	err = repo.UpdateRole(context.Background(), domain.Member{ID: 2, Role: valueobject.ROLE_MEMBER})
+	require.NoError(t, err)
+}
+
+func Test_memberAdapterRepository_Delete(t *testing.T) {
+	type args struct {
+		args domain.MemberArgs
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				args: domain.MemberArgs{
+					ID: 1,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{
+				DB: db,
+			})
+			err := repo.Delete(context.Background(), tt.args.args)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				// check data
+				members, err := repo.Get(context.Background(), domain.MemberArgs{
+					IDs: []int64{tt.args.args.ID},
+				})
+				require.NoError(t, err)
+				require.Equal(t, 0, len(members))
+			}
+		})
+	}
+}
+
+func Test_memberAdapterRepository_Purge(t *testing.T) {
+	// purge removes rows other tests rely on, so it runs on its own database
+	db, err := test.SetupSQLite()
+	require.NoError(t, err)
+	defer db.Close()
+	repo := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{DB: db})
+	gatheringRepo := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{DB: db})
+	invitationRepo := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+	newerID, err := repo.Create(ctx, domain.Member{FirstName: "ken", LastName: "thompson", Email: "ken@mail.com"})
+	require.NoError(t, err)
+	// member 2 of data.sql is invited to gathering 1, both attend it
+	for _, memberID := range []int64{2, newerID} {
+		require.NoError(t, gatheringRepo.Join(ctx, 1, memberID))
+	}
+	_, err = invitationRepo.Create(ctx, domain.Invitation{Member: domain.Member{ID: newerID}, Gathering: domain.Gathering{ID: 1}})
+	require.NoError(t, err)
+	for id, discardedAt := range map[int64]string{1: "2020-01-01 00:00:00", 2: "2020-01-01 00:00:00", newerID: "2022-01-01 00:00:00"} {
+		_, err = db.ExecContext(ctx, `UPDATE members SET discarded_at = ? WHERE id = ?`, discardedAt, id)
+		require.NoError(t, err)
+	}
+
+	// member 1 is kept because it still creates gathering 1
+	total, err := repo.Purge(ctx, "2021-01-01 00:00:00")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	// check data
+	members, err := repo.Get(ctx, domain.MemberArgs{IsIncludeDiscard: true})
+	require.NoError(t, err)
+	gotIDs := []int64{}
+	for _, m := range members {
+		gotIDs = append(gotIDs, m.ID)
+	}
+	require.ElementsMatch(t, []int64{1, newerID}, gotIDs)
+	for memberID, want := range map[int64]int{2: 0, newerID: 1} {
+		invitations, err := invitationRepo.Get(ctx, domain.InvitationArgs{MemberID: memberID})
+		require.NoError(t, err)
+		require.Len(t, invitations, want, "invitations of member %d", memberID)
+		var attendances int
+		require.NoError(t, db.GetContext(ctx, &attendances, `SELECT COUNT(*) FROM attendees WHERE member_id = ?`, memberID))
+		require.Equal(t, want, attendances, "attendances of member %d", memberID)
+	}
+}
@@ -0,0 +1,62 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/hieronimusbudi/simple-go-api/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_notificationAdapterRepository_outbox(t *testing.T) {
+	// notifications are counted, so it runs on its own database
+	db, err := test.SetupSQLite()
+	require.NoError(t, err)
+	defer db.Close()
+	memberRepo := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{DB: db})
+	invitationRepo := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{DB: db})
+	repo := repository.NewNotificationRepository(repository.NotificationAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+	memberID, err := memberRepo.Create(ctx, domain.Member{FirstName: "ken", Email: "ken@mail.com"})
+	require.NoError(t, err)
+	data := domain.NotificationData{GatheringID: 1, GatheringName: "standup", ScheduledAt: time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC), ActorID: 1}
+	pending := domain.NotificationArgs{Statuses: []valueobject.NotificationStatus{valueobject.NOTIFICATION_PENDING}}
+
+	// a failed batch writes no notification
+	_, err = invitationRepo.CreateBatch(ctx,
+		[]domain.Invitation{{Member: domain.Member{ID: memberID}, Gathering: domain.Gathering{ID: 1}}, {Member: domain.Member{ID: 99}, Gathering: domain.Gathering{ID: 1}}},
+		domain.NewNotifications(domain.NotificationInvitationCreated, []int64{memberID, 99}, data)...)
+	require.ErrorIs(t, err, domain.ErrValidation)
+	notifications, err := repo.Get(ctx, pending)
+	require.NoError(t, err)
+	require.Empty(t, notifications)
+
+	ids, err := invitationRepo.CreateBatch(ctx,
+		[]domain.Invitation{{Member: domain.Member{ID: memberID}, Gathering: domain.Gathering{ID: 1}}},
+		domain.NewNotifications(domain.NotificationInvitationCreated, []int64{memberID}, data)...)
+	require.NoError(t, err)
+	notifications, err = repo.Get(ctx, pending)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	got := notifications[0]
+	require.Equal(t, memberID, got.MemberID)
+	require.Equal(t, domain.NotificationInvitationCreated, got.Event)
+	require.Equal(t, ids[0], got.Data.InvitationID)
+	require.Equal(t, "standup", got.Data.GatheringName)
+	require.True(t, data.ScheduledAt.Equal(got.Data.ScheduledAt))
+
+	got.Sent(time.Now())
+	require.NoError(t, repo.Update(ctx, got))
+	notifications, err = repo.Get(ctx, pending)
+	require.NoError(t, err)
+	require.Empty(t, notifications)
+	notifications, err = repo.Get(ctx, domain.NotificationArgs{IDs: []int64{got.ID}})
+	require.NoError(t, err)
+	require.Equal(t, valueobject.NOTIFICATION_SENT, notifications[0].Status)
+	require.Equal(t, 1, notifications[0].Attempts)
+	require.NotEmpty(t, notifications[0].SentAt)
+}
@@ -0,0 +1,63 @@
+package sqlite_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_reminderAdapterRepository_Create(t *testing.T) {
+	// notifications are counted, so it runs on its own database
+	db, err := test.SetupSQLite()
+	require.NoError(t, err)
+	defer db.Close()
+	repo := repository.NewReminderRepository(repository.ReminderAdapterRepositoryArgs{DB: db})
+	notificationRepo := repository.NewNotificationRepository(repository.NotificationAdapterRepositoryArgs{DB: db})
+	ctx := context.Background()
+
+	start := time.Date(2031, 5, 1, 10, 0, 0, 0, time.FixedZone("WIB", 7*60*60))
+	reminder := domain.Reminder{Occurrence: domain.Occurrence{GatheringID: 1, ScheduledAt: start, Name: "retro", Location: "room 1"}, Offset: time.Hour}
+	notifications := reminder.Notifications([]int64{1, 2}, nil)
+
+	// replicas racing for the same reminder store it once
+	created := make(chan bool, 3)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := repo.Create(ctx, reminder, notifications...)
+			require.NoError(t, err)
+			created <- ok
+		}()
+	}
+	wg.Wait()
+	close(created)
+	total := 0
+	for ok := range created {
+		if ok {
+			total++
+		}
+	}
+	require.Equal(t, 1, total)
+	got, err := notificationRepo.Get(ctx, domain.NotificationArgs{})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, domain.NotificationGatheringReminder, got[0].Event)
+
+	// another offset or a moved occurrence is reminded of again
+	reminder.Offset = 24 * time.Hour
+	ok, err := repo.Create(ctx, reminder, notifications...)
+	require.NoError(t, err)
+	require.True(t, ok)
+	reminder.Occurrence.ScheduledAt = start.Add(time.Hour)
+	ok, err = repo.Create(ctx, reminder, notifications...)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
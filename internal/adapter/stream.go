@@ -0,0 +1,93 @@
+package adapter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+const (
+	// streamKeepAlive is how often an idle stream sends a comment so proxies do not close it
+	streamKeepAlive = 15 * time.Second
+	// streamRetry is how many milliseconds a client waits before it reconnects to an ended stream
+	streamRetry = 1000
+)
+
+// @Tags			Gathering
+// @Summary		Get Gathering Events
+// @Description	Stream changes of a gathering as Server-Sent Events: attendee.added, attendee.removed, invitation status changes
+// @Description	such as invitation.accepted, gathering.updated, gathering.rescheduled, occurrence changes and gathering.deleted.
+// @Description	Each event has an id, a client reconnecting with it in the Last-Event-ID header or the last_event_id query gets the
+// @Description	events it missed, or stream.reset when they are no longer kept and the gathering should be reloaded.
+// @Description	A private gathering is not found unless the bearer token is of its creator, an attendee or an invitee.
+// @Produce		text/event-stream
+// @Param			id				path		int		true	"Gathering ID"
+// @Param			Last-Event-ID	header		string	false	"ID of the last event received"
+// @Param			last_event_id	query		string	false	"ID of the last event received, for clients that cannot set headers"
+// @Success		200				{string}	string	"Event stream"
+// @Router			/gatherings/{id}/events [get]
+func (ctr *Controller) GetGatheringEvents(c *gin.Context) {
+	id, err := paramID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	subscription, err := ctr.GatheringStreamUsecase.Subscribe(c.Request.Context(), id, lastEventID)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	defer subscription.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// nginx buffers responses unless told otherwise
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry)
+	for _, e := range subscription.Replay {
+		writeStreamEvent(c.Writer, e)
+	}
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case e, ok := <-subscription.Events:
+			if !ok {
+				return
+			}
+			writeStreamEvent(c.Writer, e)
+		case <-keepAlive.C:
+			io.WriteString(c.Writer, ": keep-alive\n\n")
+		}
+		c.Writer.Flush()
+	}
+}
+
+// writeStreamEvent writes an event in the text/event-stream format, its data is the domain event as JSON
+func writeStreamEvent(w io.Writer, e domain.StreamEvent) {
+	data := []byte("{}")
+	if e.Event != nil {
+		var err error
+		if data, err = json.Marshal(e.Event); err != nil {
+			log.Println(err)
+			return
+		}
+	}
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Name, data)
+}
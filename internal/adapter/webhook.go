@@ -0,0 +1,197 @@
+package adapter
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+)
+
+// @Tags			Webhook
+// @Summary		Create Webhook
+// @Description	Subscribe a URL to events, only an admin. Deliveries are signed with the secret, it is only returned here.
+// @Accept			json
+// @Produce		json
+// @Param			payload	body		swaggermodel.WebhookPayload							true	"Payload"
+// @Success		201		{object}	helpers.ResponsePayload{data=swaggermodel.Webhook}	"Webhook"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}	"Invalid fields"
+// @Security		BearerAuth
+// @Router			/webhooks [post]
+func (ctr *Controller) CreateWebhook(c *gin.Context) {
+	webhook := domain.Webhook{}
+	if err := bindJSON(c, &webhook); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	if err := webhook.Validate(); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	webhook, err := ctr.WebhookUsecase.Create(c.Request.Context(), webhook)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusCreated, "success", webhook)
+}
+
+// @Tags			Webhook
+// @Summary		Get Webhooks
+// @Description	Get Webhooks without their secrets, only an admin
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	helpers.ResponsePayload{data=[]swaggermodel.Webhook}	"Webhook"
+// @Security		BearerAuth
+// @Router			/webhooks [get]
+func (ctr *Controller) GetWebhooks(c *gin.Context) {
+	webhooks, err := ctr.WebhookUsecase.List(c.Request.Context())
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusOK, "success", webhooks)
+}
+
+// @Tags			Webhook
+// @Summary		Get Webhook By ID
+// @Description	Get Webhook By ID without its secret, only an admin
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int													true	"Webhook ID"
+// @Success		200	{object}	helpers.ResponsePayload{data=swaggermodel.Webhook}	"Webhook"
+// @Security		BearerAuth
+// @Router			/webhooks/{id} [get]
+func (ctr *Controller) GetWebhook(c *gin.Context) {
+	id, err := paramID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	webhook, err := ctr.WebhookUsecase.GetByID(c.Request.Context(), id)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusOK, "success", webhook)
+}
+
+// @Tags			Webhook
+// @Summary		Update Webhook
+// @Description	Change the URL, events or disabled flag of a webhook, only an admin. The secret is kept unless a new one is given.
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int													true	"Webhook ID"
+// @Param			payload	body		swaggermodel.WebhookPayload							true	"Payload"
+// @Success		200		{object}	helpers.ResponsePayload{data=swaggermodel.Webhook}	"Webhook"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}	"Invalid fields"
+// @Security		BearerAuth
+// @Router			/webhooks/{id} [put]
+func (ctr *Controller) UpdateWebhook(c *gin.Context) {
+	webhook := domain.Webhook{}
+	if err := bindJSON(c, &webhook); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	if err := webhook.Validate(); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	id, err := paramID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	webhook.ID = id
+	if err = ctr.WebhookUsecase.Update(c.Request.Context(), webhook); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	webhook, err = ctr.WebhookUsecase.GetByID(c.Request.Context(), id)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusOK, "success", webhook)
+}
+
+// @Tags			Webhook
+// @Summary		Delete Webhook
+// @Description	Delete Webhook and its delivery log, only an admin
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int							true	"Webhook ID"
+// @Success		200	{object}	helpers.ResponsePayload{}	"Webhook"
+// @Security		BearerAuth
+// @Router			/webhooks/{id} [delete]
+func (ctr *Controller) DeleteWebhook(c *gin.Context) {
+	id, err := paramID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	if err = ctr.WebhookUsecase.Delete(c.Request.Context(), id); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusOK, "success", nil)
+}
+
+// @Tags			Webhook
+// @Summary		Get Webhook Deliveries
+// @Description	Get the delivery log of a webhook, only an admin.
+// @Description	A failed delivery is retried with exponential backoff and is dead after 8 attempts.
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int																				true	"Webhook ID"
+// @Param			limit	query		int																				false	"Page size, default 20, max 100"
+// @Param			offset	query		int																				false	"Rows to skip, cannot be combined with cursor"
+// @Param			cursor	query		string																			false	"Next cursor from previous page meta"
+// @Param			sort	query		string																			false	"Sort by id or created_at, prefix with - for descending"
+// @Param			status	query		[]string																		false	"Filter by status: pending, delivered or dead"	collectionFormat(csv)
+// @Success		200		{object}	helpers.ResponsePayload{data=[]swaggermodel.WebhookDelivery,meta=domain.Page}	"Webhook Delivery"
+// @Failure		400		{object}	helpers.ResponsePayload{errors=[]domain.FieldError}								"Invalid fields"
+// @Security		BearerAuth
+// @Router			/webhooks/{id}/deliveries [get]
+func (ctr *Controller) GetWebhookDeliveries(c *gin.Context) {
+	args, err := bindWebhookDeliveryArgs(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	deliveries, page, err := ctr.WebhookUsecase.Deliveries(c.Request.Context(), args)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponseWithMeta(c, http.StatusOK, "success", deliveries, page)
+}
+
+// @Tags			Webhook
+// @Summary		Replay Webhook Delivery
+// @Description	Queue the payload of a delivery again as a new delivery, only an admin. Usually a dead delivery once the receiver is fixed.
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int															true	"Webhook ID"
+// @Param			delivery_id	path		int															true	"Delivery ID"
+// @Success		201			{object}	helpers.ResponsePayload{data=swaggermodel.WebhookDelivery}	"Webhook Delivery"
+// @Security		BearerAuth
+// @Router			/webhooks/{id}/deliveries/{delivery_id}/replay [post]
+func (ctr *Controller) ReplayWebhookDelivery(c *gin.Context) {
+	id, err := paramID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	deliveryID, err := paramDeliveryID(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	delivery, err := ctr.WebhookUsecase.Replay(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	helpers.NewResponse(c, http.StatusCreated, "success", delivery)
+}
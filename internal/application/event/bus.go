@@ -0,0 +1,156 @@
+// Package event hands the domain events usecases publish to subscribers in the same process, such as webhooks,
+// so a new reaction to a change hooks in without touching the usecases.
+package event
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+// DefaultBusBuffer is how many events an asynchronous subscriber may fall behind before publishing waits for it
+const DefaultBusBuffer = 256
+
+type (
+	// Handler reacts to an event, an error is logged and does not reach the publisher or the other subscribers
+	Handler func(ctx context.Context, event domain.Event) (err error)
+
+	subscription struct {
+		handler Handler
+		// names are the events handled, every event when empty
+		names map[string]bool
+		// queue feeds the worker of an asynchronous subscriber, nil for a synchronous one
+		queue chan queued
+	}
+
+	queued struct {
+		ctx   context.Context
+		event domain.Event
+	}
+
+	bus struct {
+		mu            sync.RWMutex
+		subscriptions []*subscription
+		buffer        int
+		closed        bool
+		workers       sync.WaitGroup
+	}
+
+	BusArgs struct {
+		// Buffer is the queue size of each asynchronous subscriber, DefaultBusBuffer when zero
+		Buffer int
+	}
+
+	IBus interface {
+		Publish(ctx context.Context, events ...domain.Event)
+		Subscribe(handler Handler, names ...string)
+		SubscribeAsync(handler Handler, names ...string)
+		Close()
+	}
+)
+
+func NewBus(args BusArgs) IBus {
+	buffer := args.Buffer
+	if buffer <= 0 {
+		buffer = DefaultBusBuffer
+	}
+	return &bus{buffer: buffer}
+}
+
+// Subscribe runs handler in the goroutine of the publisher for the named events or every event, before Publish returns.
+// Synchronous subscribers run in the order they subscribed, a slow one slows the request that published.
+func (b *bus) Subscribe(handler Handler, names ...string) {
+	b.subscribe(&subscription{handler: handler, names: nameSet(names)})
+}
+
+// SubscribeAsync runs handler in a goroutine of its own for the named events or every event, in the order they were
+// published. The context of the publisher is passed on without its cancellation, as the request is usually over by then.
+func (b *bus) SubscribeAsync(handler Handler, names ...string) {
+	s := &subscription{handler: handler, names: nameSet(names), queue: make(chan queued, b.buffer)}
+	b.workers.Add(1)
+	go func() {
+		defer b.workers.Done()
+		for q := range s.queue {
+			s.handle(q.ctx, q.event)
+		}
+	}()
+	b.subscribe(s)
+}
+
+func (b *bus) subscribe(s *subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		if s.queue != nil {
+			close(s.queue)
+		}
+		return
+	}
+	b.subscriptions = append(b.subscriptions, s)
+}
+
+// Publish hands the events to their subscribers in order. Synchronous subscribers are done when it returns,
+// asynchronous ones are queued, publishing waits while the queue of one is full. Nothing is published once closed.
+func (b *bus) Publish(ctx context.Context, events ...domain.Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return
+	}
+	for _, event := range events {
+		for _, s := range b.subscriptions {
+			if !s.matches(event) {
+				continue
+			}
+			if s.queue == nil {
+				s.handle(ctx, event)
+				continue
+			}
+			s.queue <- queued{ctx: context.WithoutCancel(ctx), event: event}
+		}
+	}
+}
+
+// Close stops publishing and waits for asynchronous subscribers to handle the events queued so far
+func (b *bus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	for _, s := range b.subscriptions {
+		if s.queue != nil {
+			close(s.queue)
+		}
+	}
+	b.mu.Unlock()
+	b.workers.Wait()
+}
+
+func (s *subscription) matches(event domain.Event) bool {
+	return event != nil && (len(s.names) == 0 || s.names[event.EventName()])
+}
+
+// handle runs the handler, a panic is logged like an error so one subscriber cannot take down the others
+func (s *subscription) handle(ctx context.Context, event domain.Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println(fmt.Errorf("event %s: subscriber panicked: %v", event.EventName(), r))
+		}
+	}()
+	if err := s.handler(ctx, event); err != nil {
+		log.Println(fmt.Errorf("event %s: %w", event.EventName(), err))
+	}
+}
+
+func nameSet(names []string) map[string]bool {
+	set := map[string]bool{}
+	for _, n := range names {
+		set[n] = true
+	}
+	return set
+}
@@ -0,0 +1,108 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/application/event"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder collects the names of the events a subscriber handled
+type recorder struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (r *recorder) handle(ctx context.Context, e domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.names = append(r.names, e.EventName())
+	return nil
+}
+
+func (r *recorder) got() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.names...)
+}
+
+func TestBus_Subscribe(t *testing.T) {
+	events := []domain.Event{
+		domain.MemberCreated{Member: domain.Member{ID: 1}},
+		domain.AttendeeRemoved{Gathering: domain.Gathering{ID: 2}, MemberID: 1},
+		domain.GatheringRescheduled{Gathering: domain.Gathering{ID: 2}},
+	}
+	tests := []struct {
+		name  string
+		names []string
+		want  []string
+	}{
+		{
+			name: "every event",
+			want: []string{domain.EventMemberCreated, domain.EventAttendeeRemoved, domain.EventGatheringRescheduled},
+		},
+		{
+			name:  "named events",
+			names: []string{domain.EventGatheringRescheduled, domain.EventAttendeeRemoved},
+			want:  []string{domain.EventAttendeeRemoved, domain.EventGatheringRescheduled},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := event.NewBus(event.BusArgs{Buffer: 1})
+			synchronous, asynchronous := &recorder{}, &recorder{}
+			bus.Subscribe(synchronous.handle, tt.names...)
+			bus.SubscribeAsync(asynchronous.handle, tt.names...)
+			bus.Publish(context.Background(), events...)
+			// synchronous subscribers are done once Publish returns
+			require.Equal(t, tt.want, synchronous.got())
+			bus.Close()
+			require.Equal(t, tt.want, asynchronous.got())
+		})
+	}
+}
+
+func TestBus_Publish_failingSubscriber(t *testing.T) {
+	bus := event.NewBus(event.BusArgs{})
+	after := &recorder{}
+	bus.Subscribe(func(ctx context.Context, e domain.Event) error {
+		return errors.New("cannot handle")
+	})
+	bus.Subscribe(func(ctx context.Context, e domain.Event) error {
+		panic("broken subscriber")
+	})
+	bus.Subscribe(after.handle)
+	require.NotPanics(t, func() {
+		bus.Publish(context.Background(), domain.MemberDeleted{MemberID: 1})
+	})
+	require.Equal(t, []string{domain.EventMemberDeleted}, after.got())
+}
+
+func TestBus_SubscribeAsync_context(t *testing.T) {
+	bus := event.NewBus(event.BusArgs{})
+	errs := make(chan error, 1)
+	bus.SubscribeAsync(func(ctx context.Context, e domain.Event) error {
+		errs <- ctx.Err()
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	bus.Publish(ctx, domain.MemberDeleted{MemberID: 1})
+	// the request is over before the subscriber runs
+	cancel()
+	bus.Close()
+	require.NoError(t, <-errs)
+}
+
+func TestBus_Close(t *testing.T) {
+	bus := event.NewBus(event.BusArgs{})
+	r := &recorder{}
+	bus.Subscribe(r.handle)
+	bus.Close()
+	bus.Close()
+	bus.Publish(context.Background(), domain.MemberDeleted{MemberID: 1})
+	require.Empty(t, r.got())
+}
@@ -0,0 +1,138 @@
+// Package policy decides whether the authenticated member of a context may act on a resource,
+// usecases consult it before changing data and return domain.ErrForbidden when denied.
+package policy
+
+import (
+	"context"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+// actor returns the authenticated member, a context without one is denied
+func actor(ctx context.Context) (member domain.Member, err error) {
+	member, ok := domain.MemberFromContext(ctx)
+	if !ok {
+		return member, domain.NewError(domain.ErrUnauthorized, "authentication required")
+	}
+	return
+}
+
+// CanManageMember allows the member themself or an admin to update or delete a member
+func CanManageMember(ctx context.Context, memberID int64) (err error) {
+	member, err := actor(ctx)
+	if err != nil {
+		return
+	}
+	if member.ID != memberID && !member.IsAdmin() {
+		return domain.NewError(domain.ErrForbidden, "only the member or an admin can change this member")
+	}
+	return
+}
+
+// CanViewSchedule allows the member themself or an admin to see which gatherings of a member overlap, private ones included
+func CanViewSchedule(ctx context.Context, memberID int64) (err error) {
+	member, err := actor(ctx)
+	if err != nil {
+		return
+	}
+	if member.ID != memberID && !member.IsAdmin() {
+		return domain.NewError(domain.ErrForbidden, "only the member or an admin can see this schedule")
+	}
+	return
+}
+
+// CanCreateGathering allows a member to create gatherings as creator, not on behalf of another member
+func CanCreateGathering(ctx context.Context, gathering domain.Gathering) (err error) {
+	member, err := actor(ctx)
+	if err != nil {
+		return
+	}
+	if member.ID != gathering.Creator.ID {
+		return domain.NewError(domain.ErrForbidden, "the creator must be the authenticated member")
+	}
+	return
+}
+
+// CanManageGathering allows only the creator to update or delete a gathering and cancel its invitations
+func CanManageGathering(ctx context.Context, gathering domain.Gathering) (err error) {
+	member, err := actor(ctx)
+	if err != nil {
+		return
+	}
+	if member.ID != gathering.CreatorID {
+		return domain.NewError(domain.ErrForbidden, "only the creator can change this gathering")
+	}
+	return
+}
+
+// CanJoinGathering allows a member to join a public gathering without an invitation
+func CanJoinGathering(ctx context.Context, gathering domain.Gathering) (err error) {
+	if _, err = actor(ctx); err != nil {
+		return
+	}
+	if gathering.Type != valueobject.PUBLIC {
+		return domain.NewError(domain.ErrForbidden, "only public gatherings can be joined, a private one needs an invitation")
+	}
+	return
+}
+
+// CanRespondInvitation allows only the invited member to accept or reject an invitation
+func CanRespondInvitation(ctx context.Context, invitation domain.Invitation) (err error) {
+	member, err := actor(ctx)
+	if err != nil {
+		return
+	}
+	if member.ID != invitation.MemberID {
+		return domain.NewError(domain.ErrForbidden, "only the invited member can respond to this invitation")
+	}
+	return
+}
+
+// CanManageGroup allows only an owner to rename or delete a group and manage its members
+func CanManageGroup(ctx context.Context, group domain.Group) (err error) {
+	member, err := actor(ctx)
+	if err != nil {
+		return
+	}
+	if !group.IsOwner(member.ID) {
+		return domain.NewError(domain.ErrForbidden, "only an owner can change this group")
+	}
+	return
+}
+
+// CanRemoveGroupMember allows an owner to remove a member and a member to leave the group
+func CanRemoveGroupMember(ctx context.Context, group domain.Group, memberID int64) (err error) {
+	member, err := actor(ctx)
+	if err != nil {
+		return
+	}
+	if member.ID != memberID && !group.IsOwner(member.ID) {
+		return domain.NewError(domain.ErrForbidden, "only an owner or the member can remove this member")
+	}
+	return
+}
+
+// CanInviteGroup allows only a member of a group to invite the whole group to a gathering
+func CanInviteGroup(ctx context.Context, group domain.Group) (err error) {
+	member, err := actor(ctx)
+	if err != nil {
+		return
+	}
+	if _, ok := group.Member(member.ID); !ok {
+		return domain.NewError(domain.ErrForbidden, "only a member of the group can invite it")
+	}
+	return
+}
+
+// CanManageWebhooks allows only an admin to manage webhooks and see their deliveries, payloads tell about every member
+func CanManageWebhooks(ctx context.Context) (err error) {
+	member, err := actor(ctx)
+	if err != nil {
+		return
+	}
+	if !member.IsAdmin() {
+		return domain.NewError(domain.ErrForbidden, "only an admin can manage webhooks")
+	}
+	return
+}
@@ -0,0 +1,217 @@
+package policy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/application/policy"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	anonymous = context.Background()
+	john      = domain.ContextWithMember(anonymous, domain.Member{ID: 1, Role: valueobject.ROLE_MEMBER})
+	ron       = domain.ContextWithMember(anonymous, domain.Member{ID: 2, Role: valueobject.ROLE_MEMBER})
+	admin     = domain.ContextWithMember(anonymous, domain.Member{ID: 3, Role: valueobject.ROLE_ADMIN})
+)
+
+func TestCanManageMember(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		memberID int64
+		wantErr  error
+	}{
+		{name: "the member themself", ctx: john, memberID: 1},
+		{name: "admin", ctx: admin, memberID: 1},
+		{name: "another member", ctx: ron, memberID: 1, wantErr: domain.ErrForbidden},
+		{name: "anonymous", ctx: anonymous, memberID: 1, wantErr: domain.ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.CanManageMember(tt.ctx, tt.memberID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCanCreateGathering(t *testing.T) {
+	gathering := domain.Gathering{Creator: domain.Member{ID: 1}}
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{name: "creator", ctx: john},
+		{name: "on behalf of another member", ctx: ron, wantErr: domain.ErrForbidden},
+		{name: "admin on behalf of another member", ctx: admin, wantErr: domain.ErrForbidden},
+		{name: "anonymous", ctx: anonymous, wantErr: domain.ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.CanCreateGathering(tt.ctx, gathering)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCanManageGathering(t *testing.T) {
+	gathering := domain.Gathering{ID: 1, CreatorID: 1, Creator: domain.Member{ID: 1}}
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{name: "creator", ctx: john},
+		{name: "another member", ctx: ron, wantErr: domain.ErrForbidden},
+		{name: "admin", ctx: admin, wantErr: domain.ErrForbidden},
+		{name: "anonymous", ctx: anonymous, wantErr: domain.ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.CanManageGathering(tt.ctx, gathering)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCanViewSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		memberID int64
+		wantErr  error
+	}{
+		{name: "the member themself", ctx: john, memberID: 1},
+		{name: "admin", ctx: admin, memberID: 1},
+		{name: "another member", ctx: ron, memberID: 1, wantErr: domain.ErrForbidden},
+		{name: "anonymous", ctx: anonymous, memberID: 1, wantErr: domain.ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.CanViewSchedule(tt.ctx, tt.memberID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCanJoinGathering(t *testing.T) {
+	public := domain.Gathering{ID: 1, CreatorID: 1, Type: valueobject.PUBLIC}
+	private := domain.Gathering{ID: 2, CreatorID: 1, Type: valueobject.PRIVATE}
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		gathering domain.Gathering
+		wantErr   error
+	}{
+		{name: "public", ctx: ron, gathering: public},
+		{name: "private", ctx: ron, gathering: private, wantErr: domain.ErrForbidden},
+		{name: "anonymous", ctx: anonymous, gathering: public, wantErr: domain.ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.CanJoinGathering(tt.ctx, tt.gathering)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCanRespondInvitation(t *testing.T) {
+	invitation := domain.Invitation{ID: 1, MemberID: 2, GatheringID: 1}
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{name: "invited member", ctx: ron},
+		{name: "gathering creator", ctx: john, wantErr: domain.ErrForbidden},
+		{name: "admin", ctx: admin, wantErr: domain.ErrForbidden},
+		{name: "anonymous", ctx: anonymous, wantErr: domain.ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.CanRespondInvitation(tt.ctx, invitation)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGroupPolicies(t *testing.T) {
+	group := domain.Group{ID: 1, Members: []domain.GroupMember{
+		{GroupID: 1, MemberID: 1, Role: valueobject.GROUP_OWNER},
+		{GroupID: 1, MemberID: 2, Role: valueobject.GROUP_MEMBER},
+	}}
+	tests := []struct {
+		name    string
+		check   func() error
+		wantErr error
+	}{
+		{name: "owner manages", check: func() error { return policy.CanManageGroup(john, group) }},
+		{name: "member cannot manage", check: func() error { return policy.CanManageGroup(ron, group) }, wantErr: domain.ErrForbidden},
+		{name: "admin outside the group cannot manage", check: func() error { return policy.CanManageGroup(admin, group) }, wantErr: domain.ErrForbidden},
+		{name: "anonymous cannot manage", check: func() error { return policy.CanManageGroup(anonymous, group) }, wantErr: domain.ErrUnauthorized},
+		{name: "owner removes a member", check: func() error { return policy.CanRemoveGroupMember(john, group, 2) }},
+		{name: "member leaves", check: func() error { return policy.CanRemoveGroupMember(ron, group, 2) }},
+		{name: "member cannot remove the owner", check: func() error { return policy.CanRemoveGroupMember(ron, group, 1) }, wantErr: domain.ErrForbidden},
+		{name: "member invites the group", check: func() error { return policy.CanInviteGroup(ron, group) }},
+		{name: "outsider cannot invite the group", check: func() error { return policy.CanInviteGroup(admin, group) }, wantErr: domain.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check()
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCanManageWebhooks(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{name: "admin", ctx: admin},
+		{name: "member", ctx: john, wantErr: domain.ErrForbidden},
+		{name: "anonymous", ctx: anonymous, wantErr: domain.ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.CanManageWebhooks(tt.ctx)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DefaultTokenTTL is used when AuthUsecaseArgs.TTL is not set
+const DefaultTokenTTL = 24 * time.Hour
+
+// MinSecretLength is the shortest secret accepted to sign access tokens, a shorter or example one could be guessed
+const MinSecretLength = 32
+
+// dummyHash is compared when the email is unknown, so login takes the same time for unknown and known members
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+type (
+	authUsecase struct {
+		memberRepository     repository.IMember
+		credentialRepository repository.ICredential
+		secret               []byte
+		ttl                  time.Duration
+	}
+
+	AuthUsecaseArgs struct {
+		MemberRepository     repository.IMember
+		CredentialRepository repository.ICredential
+		// Secret signs access tokens with HS256
+		Secret string
+		TTL    time.Duration
+	}
+
+	IAuthUsecase interface {
+		Login(ctx context.Context, login domain.Login) (token domain.Token, err error)
+		Authenticate(ctx context.Context, accessToken string) (member domain.Member, err error)
+		SetPassword(ctx context.Context, memberID int64, password string) (err error)
+	}
+)
+
+func NewAuthUsecase(args AuthUsecaseArgs) IAuthUsecase {
+	ttl := args.TTL
+	if ttl <= 0 {
+		ttl = DefaultTokenTTL
+	}
+	return &authUsecase{
+		memberRepository:     args.MemberRepository,
+		credentialRepository: args.CredentialRepository,
+		secret:               []byte(args.Secret),
+		ttl:                  ttl,
+	}
+}
+
+// ValidateSecret refuses a secret too short to sign access tokens safely
+func ValidateSecret(secret string) (err error) {
+	if secret == "" {
+		return errors.New("JWTSECRET is required to sign access tokens")
+	}
+	if len(secret) < MinSecretLength {
+		return fmt.Errorf("JWTSECRET must be at least %d bytes, generate one with e.g. openssl rand -hex 32", MinSecretLength)
+	}
+	return
+}
+
+// Login checks the member password and issues an access token with the member ID as subject
+func (u *authUsecase) Login(ctx context.Context, login domain.Login) (token domain.Token, err error) {
+	members, err := u.memberRepository.Get(ctx, domain.MemberArgs{Email: login.Email})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	hash := dummyHash
+	if len(members) > 0 {
+		credentials, err := u.credentialRepository.Get(ctx, domain.CredentialArgs{MemberID: members[0].ID})
+		if err != nil {
+			log.Println(err)
+			return token, err
+		}
+		if len(credentials) > 0 {
+			hash = []byte(credentials[0].PasswordHash)
+		}
+	}
+	if bcrypt.CompareHashAndPassword(hash, []byte(login.Password)) != nil || len(members) == 0 {
+		return token, domain.ErrInvalidCredentials
+	}
+
+	expiresAt := time.Now().Add(u.ttl)
+	claims := jwt.RegisteredClaims{
+		Subject:   strconv.FormatInt(members[0].ID, 10),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(u.secret)
+	if err != nil {
+		log.Println(err)
+		return token, domain.WrapError(domain.ErrInternal, "cannot issue access token", err)
+	}
+	token = domain.Token{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresAt:   expiresAt.UTC().Format(time.RFC3339),
+	}
+	return
+}
+
+// Authenticate verifies an access token and returns its member, a discarded member cannot authenticate
+func (u *authUsecase) Authenticate(ctx context.Context, accessToken string) (member domain.Member, err error) {
+	claims := jwt.RegisteredClaims{}
+	_, err = jwt.ParseWithClaims(accessToken, &claims, func(t *jwt.Token) (interface{}, error) {
+		return u.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return member, domain.ErrInvalidToken
+	}
+	id, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return member, domain.ErrInvalidToken
+	}
+	members, err := u.memberRepository.Get(ctx, domain.MemberArgs{IDs: []int64{id}})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(members) == 0 {
+		return member, domain.ErrInvalidToken
+	}
+	member = members[0]
+	return
+}
+
+// SetPassword stores a bcrypt hash of the password as the member credential
+func (u *authUsecase) SetPassword(ctx context.Context, memberID int64, password string) (err error) {
+	if err = domain.ValidatePassword(password); err != nil {
+		return
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Println(err)
+		return domain.WrapError(domain.ErrInternal, "cannot hash password", err)
+	}
+	err = u.credentialRepository.Save(ctx, domain.Credential{MemberID: memberID, PasswordHash: string(hash)})
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
@@ -0,0 +1,293 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+	"github.com/hieronimusbudi/simple-go-api/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func Test_authUsecase_Login(t *testing.T) {
+	member := domain.Member{ID: 1, FirstName: "john", Email: "john@mail.com"}
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	credential := domain.Credential{MemberID: 1, PasswordHash: string(hash)}
+	tests := []struct {
+		name              string
+		login             domain.Login
+		wantErr           error
+		funcGetMember     helpers.TestFuncCall
+		funcGetCredential helpers.TestFuncCall
+	}{
+		{
+			name:  "success",
+			login: domain.Login{Email: "john@mail.com", Password: "secret-password"},
+			funcGetMember: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.MemberArgs{Email: "john@mail.com"}},
+				Output: []interface{}{[]domain.Member{member}, nil},
+			},
+			funcGetCredential: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.CredentialArgs{MemberID: 1}},
+				Output: []interface{}{[]domain.Credential{credential}, nil},
+			},
+		},
+		{
+			name:    "wrong password",
+			login:   domain.Login{Email: "john@mail.com", Password: "wrong-password"},
+			wantErr: domain.ErrInvalidCredentials,
+			funcGetMember: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Member{member}, nil},
+			},
+			funcGetCredential: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Credential{credential}, nil},
+			},
+		},
+		{
+			name:    "member without credential",
+			login:   domain.Login{Email: "john@mail.com", Password: "secret-password"},
+			wantErr: domain.ErrInvalidCredentials,
+			funcGetMember: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Member{member}, nil},
+			},
+			funcGetCredential: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Credential{}, nil},
+			},
+		},
+		{
+			name:    "unknown email",
+			login:   domain.Login{Email: "nobody@mail.com", Password: "secret-password"},
+			wantErr: domain.ErrInvalidCredentials,
+			funcGetMember: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Member{}, nil},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMember := new(mocks.IMember)
+			mockCredential := new(mocks.ICredential)
+			u := usecase.NewAuthUsecase(usecase.AuthUsecaseArgs{
+				MemberRepository:     mockMember,
+				CredentialRepository: mockCredential,
+				Secret:               "secret",
+			})
+			if tt.funcGetMember.Called {
+				mockMember.On("Get", tt.funcGetMember.Input...).Return(tt.funcGetMember.Output...)
+			}
+			if tt.funcGetCredential.Called {
+				mockCredential.On("Get", tt.funcGetCredential.Input...).Return(tt.funcGetCredential.Output...)
+			}
+			token, err := u.Login(context.Background(), tt.login)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, token.AccessToken)
+			require.Equal(t, "Bearer", token.TokenType)
+
+			// the issued token authenticates the same member
+			mockMember.On("Get", mock.Anything, domain.MemberArgs{IDs: []int64{1}}).Return([]domain.Member{member}, nil)
+			got, err := u.Authenticate(context.Background(), token.AccessToken)
+			require.NoError(t, err)
+			require.Equal(t, member, got)
+		})
+	}
+}
+
+func Test_authUsecase_Authenticate(t *testing.T) {
+	member := domain.Member{ID: 1, FirstName: "john", Email: "john@mail.com"}
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	login := func(secret string) string {
+		mockMember := new(mocks.IMember)
+		mockCredential := new(mocks.ICredential)
+		mockMember.On("Get", mock.Anything, mock.Anything).Return([]domain.Member{member}, nil)
+		mockCredential.On("Get", mock.Anything, mock.Anything).Return([]domain.Credential{{MemberID: 1, PasswordHash: string(hash)}}, nil)
+		token, err := usecase.NewAuthUsecase(usecase.AuthUsecaseArgs{
+			MemberRepository:     mockMember,
+			CredentialRepository: mockCredential,
+			Secret:               secret,
+		}).Login(context.Background(), domain.Login{Email: member.Email, Password: "secret-password"})
+		require.NoError(t, err)
+		return token.AccessToken
+	}
+	expired, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   "1",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	tests := []struct {
+		name          string
+		accessToken   string
+		wantErr       bool
+		funcGetMember helpers.TestFuncCall
+	}{
+		{
+			name:        "success",
+			accessToken: login("secret"),
+			funcGetMember: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.MemberArgs{IDs: []int64{1}}},
+				Output: []interface{}{[]domain.Member{member}, nil},
+			},
+		},
+		{
+			name:        "discarded member",
+			accessToken: login("secret"),
+			wantErr:     true,
+			funcGetMember: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Member{}, nil},
+			},
+		},
+		{
+			name:        "member error",
+			accessToken: login("secret"),
+			wantErr:     true,
+			funcGetMember: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Member{}, errors.New("get error")},
+			},
+		},
+		{
+			name:        "signed by another secret",
+			accessToken: login("another secret"),
+			wantErr:     true,
+		},
+		{
+			name:        "expired",
+			accessToken: expired,
+			wantErr:     true,
+		},
+		{
+			name:        "malformed",
+			accessToken: "not a token",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMember := new(mocks.IMember)
+			u := usecase.NewAuthUsecase(usecase.AuthUsecaseArgs{
+				MemberRepository:     mockMember,
+				CredentialRepository: new(mocks.ICredential),
+				Secret:               "secret",
+			})
+			if tt.funcGetMember.Called {
+				mockMember.On("Get", tt.funcGetMember.Input...).Return(tt.funcGetMember.Output...)
+			}
+			got, err := u.Authenticate(context.Background(), tt.accessToken)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, member, got)
+		})
+	}
+}
+
+func Test_authUsecase_SetPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		wantErr  bool
+		funcSave helpers.TestFuncCall
+	}{
+		{
+			name:     "success",
+			password: "secret-password",
+			funcSave: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{nil},
+			},
+		},
+		{
+			name:     "too short",
+			password: "secret",
+			wantErr:  true,
+		},
+		{
+			name:     "save fail",
+			password: "secret-password",
+			wantErr:  true,
+			funcSave: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{errors.New("save error")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCredential := new(mocks.ICredential)
+			u := usecase.NewAuthUsecase(usecase.AuthUsecaseArgs{
+				MemberRepository:     new(mocks.IMember),
+				CredentialRepository: mockCredential,
+				Secret:               "secret",
+			})
+			if tt.funcSave.Called {
+				mockCredential.On("Save", tt.funcSave.Input...).Return(tt.funcSave.Output...)
+			}
+			err := u.SetPassword(context.Background(), 1, tt.password)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			// stored hash matches the password, never the plain password
+			credential := mockCredential.Calls[0].Arguments.Get(1).(domain.Credential)
+			require.Equal(t, int64(1), credential.MemberID)
+			require.NotEqual(t, tt.password, credential.PasswordHash)
+			require.NoError(t, bcrypt.CompareHashAndPassword([]byte(credential.PasswordHash), []byte(tt.password)))
+		})
+	}
+}
+
+func TestValidateSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr bool
+	}{
+		{name: "empty", secret: "", wantErr: true},
+		{name: "shipped placeholder", secret: "change-me", wantErr: true},
+		{name: "one byte short", secret: "0123456789abcdef0123456789abcde", wantErr: true},
+		{name: "random hex", secret: "0123456789abcdef0123456789abcdef"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := usecase.ValidateSecret(tt.secret)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
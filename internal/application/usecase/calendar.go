@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+type (
+	calendarUsecase struct {
+		gatheringUsecase  IGatheringUsecase
+		invitationUsecase IInvitationUsecase
+		memberUsecase     IMemberUsecase
+	}
+
+	CalendarUsecaseArgs struct {
+		GatheringUsecase  IGatheringUsecase
+		InvitationUsecase IInvitationUsecase
+		// MemberUsecase finds members by the emails of event attendees
+		MemberUsecase IMemberUsecase
+	}
+
+	ICalendarUsecase interface {
+		Import(ctx context.Context, events []domain.CalendarEvent) (results []domain.CalendarImportResult, err error)
+	}
+)
+
+func NewCalendarUsecase(args CalendarUsecaseArgs) ICalendarUsecase {
+	return &calendarUsecase{
+		gatheringUsecase:  args.GatheringUsecase,
+		invitationUsecase: args.InvitationUsecase,
+		memberUsecase:     args.MemberUsecase,
+	}
+}
+
+// Import creates a gathering created by the authenticated member for each event and invites attendees who are members.
+// An event imported before is matched by its UID and not created again, only attendees new to it are invited.
+// Invalid events are reported in their result, other errors stop the import.
+func (u *calendarUsecase) Import(ctx context.Context, events []domain.CalendarEvent) (results []domain.CalendarImportResult, err error) {
+	creator, ok := domain.MemberFromContext(ctx)
+	if !ok {
+		return nil, domain.NewError(domain.ErrUnauthorized, "authentication required")
+	}
+	results = []domain.CalendarImportResult{}
+	for _, event := range events {
+		result, err := u.importEvent(ctx, creator, event)
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			result.Status = domain.CalendarImportFailed
+			result.Errors = validationErr.Errors
+		} else if err != nil {
+			log.Println(err)
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return
+}
+
+func (u *calendarUsecase) importEvent(ctx context.Context, creator domain.Member, event domain.CalendarEvent) (result domain.CalendarImportResult, err error) {
+	result = domain.CalendarImportResult{UID: event.UID, Invited: []string{}, UnknownAttendees: []string{}}
+	if event.Err != nil {
+		return result, event.Err
+	}
+	existing, err := u.gatheringUsecase.Get(ctx, domain.GatheringArgs{
+		CreatorID:        creator.ID,
+		CalendarUID:      event.UID,
+		IsIncludeDiscard: true,
+	})
+	if err != nil {
+		return
+	}
+	gathering := event.Gathering
+	if len(existing) > 0 {
+		gathering = existing[0]
+		result.Status = domain.CalendarImportExisting
+	} else {
+		gathering.Creator = creator
+		gathering.Attendees = []domain.Member{{ID: creator.ID}}
+		if err = gathering.Validate(); err != nil {
+			return
+		}
+		// the events are already on a calendar, overlaps are reported rather than refused
+		if gathering, result.Conflicts, err = u.gatheringUsecase.Create(ctx, gathering, true); err != nil {
+			return
+		}
+		result.Status = domain.CalendarImportCreated
+	}
+	result.GatheringID = gathering.ID
+	if gathering.DiscardedAt != "" {
+		// a deleted gathering stays deleted, its attendees are not invited again
+		return
+	}
+	invitations, err := u.invitationUsecase.Get(ctx, domain.InvitationArgs{GatheringID: gathering.ID})
+	if err != nil {
+		return
+	}
+	// members attending or invited before, whatever the invitation status, are not invited again
+	skipped := map[int64]bool{creator.ID: true}
+	for _, m := range gathering.Attendees {
+		skipped[m.ID] = true
+	}
+	for _, inv := range invitations {
+		skipped[inv.Member.ID] = true
+	}
+	for _, email := range event.AttendeeEmails {
+		members, err := u.memberUsecase.Get(ctx, domain.MemberArgs{Email: email})
+		if err != nil {
+			return result, err
+		}
+		if len(members) == 0 {
+			result.UnknownAttendees = append(result.UnknownAttendees, email)
+			continue
+		}
+		member := members[0]
+		if skipped[member.ID] {
+			continue
+		}
+		_, err = u.invitationUsecase.Create(ctx, domain.Invitation{
+			Member:    member,
+			Gathering: gathering,
+			Status:    valueobject.INVITATION_CREATED,
+		})
+		if err != nil {
+			return result, err
+		}
+		skipped[member.ID] = true
+		result.Invited = append(result.Invited, member.Email)
+	}
+	return
+}
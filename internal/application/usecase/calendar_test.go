@@ -0,0 +1,193 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+	"github.com/hieronimusbudi/simple-go-api/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_calendarUsecase_Import(t *testing.T) {
+	linus := domain.Member{ID: 1, Email: "linus@mail.com"}
+	ron := domain.Member{ID: 2, Email: "ron@mail.com"}
+	event := domain.CalendarEvent{
+		UID: "standup@example.com",
+		Gathering: domain.Gathering{
+			ScheduledAt: time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC),
+			CalendarUID: "standup@example.com",
+			Name:        "standup",
+			Location:    "room 1",
+		},
+		AttendeeEmails: []string{"linus@mail.com", "ron@mail.com", "nobody@mail.com"},
+	}
+	gathering := event.Gathering
+	gathering.ID = 5
+	gathering.CreatorID = linus.ID
+	gathering.Attendees = []domain.Member{{ID: linus.ID}}
+	conflicts := []domain.ScheduleConflict{{
+		MemberID:      linus.ID,
+		Occurrence:    domain.Occurrence{GatheringID: gathering.ID, ScheduledAt: gathering.ScheduledAt},
+		ConflictsWith: domain.Occurrence{GatheringID: 1, ScheduledAt: gathering.ScheduledAt},
+	}}
+	invalid := domain.CalendarEvent{
+		UID: "broken@example.com",
+		Err: domain.NewFieldError("dtstart", domain.CodeRequired, "dtstart is required"),
+	}
+	findByUID := []interface{}{mock.Anything, domain.GatheringArgs{CreatorID: linus.ID, CalendarUID: event.UID, IsIncludeDiscard: true}}
+	type args struct {
+		ctx    context.Context
+		events []domain.CalendarEvent
+	}
+	tests := []struct {
+		name                 string
+		args                 args
+		wantResults          []domain.CalendarImportResult
+		wantErr              bool
+		funcGetGathering     helpers.TestFuncCall
+		funcCreateGathering  helpers.TestFuncCall
+		funcGetInvitation    helpers.TestFuncCall
+		funcCreateInvitation helpers.TestFuncCall
+	}{
+		{
+			name: "create gathering and invite members",
+			args: args{
+				ctx:    domain.ContextWithMember(context.Background(), linus),
+				events: []domain.CalendarEvent{event},
+			},
+			wantResults: []domain.CalendarImportResult{{
+				UID:              event.UID,
+				GatheringID:      gathering.ID,
+				Status:           domain.CalendarImportCreated,
+				Invited:          []string{ron.Email},
+				UnknownAttendees: []string{"nobody@mail.com"},
+				Conflicts:        conflicts,
+			}},
+			funcGetGathering: helpers.TestFuncCall{
+				Called: true,
+				Input:  findByUID,
+				Output: []interface{}{[]domain.Gathering{}, nil},
+			},
+			funcCreateGathering: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything, true},
+				Output: []interface{}{gathering, conflicts, nil},
+			},
+			funcGetInvitation: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{GatheringID: gathering.ID}},
+				Output: []interface{}{[]domain.Invitation{}, nil},
+			},
+			funcCreateInvitation: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{domain.Invitation{ID: 1}, nil},
+			},
+		},
+		{
+			name: "re-import does not create or invite again",
+			args: args{
+				ctx:    domain.ContextWithMember(context.Background(), linus),
+				events: []domain.CalendarEvent{event},
+			},
+			wantResults: []domain.CalendarImportResult{{
+				UID:              event.UID,
+				GatheringID:      gathering.ID,
+				Status:           domain.CalendarImportExisting,
+				Invited:          []string{},
+				UnknownAttendees: []string{"nobody@mail.com"},
+			}},
+			funcGetGathering: helpers.TestFuncCall{
+				Called: true,
+				Input:  findByUID,
+				Output: []interface{}{[]domain.Gathering{gathering}, nil},
+			},
+			funcGetInvitation: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{GatheringID: gathering.ID}},
+				Output: []interface{}{[]domain.Invitation{{ID: 1, Member: ron}}, nil},
+			},
+		},
+		{
+			name: "invalid event is reported",
+			args: args{
+				ctx:    domain.ContextWithMember(context.Background(), linus),
+				events: []domain.CalendarEvent{invalid},
+			},
+			wantResults: []domain.CalendarImportResult{{
+				UID:              invalid.UID,
+				Status:           domain.CalendarImportFailed,
+				Invited:          []string{},
+				UnknownAttendees: []string{},
+				Errors:           []domain.FieldError{{Field: "dtstart", Code: domain.CodeRequired, Message: "dtstart is required"}},
+			}},
+		},
+		{
+			name: "error stops import",
+			args: args{
+				ctx:    domain.ContextWithMember(context.Background(), linus),
+				events: []domain.CalendarEvent{event},
+			},
+			wantErr: true,
+			funcGetGathering: helpers.TestFuncCall{
+				Called: true,
+				Input:  findByUID,
+				Output: []interface{}{[]domain.Gathering{}, nil},
+			},
+			funcCreateGathering: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything, true},
+				Output: []interface{}{domain.Gathering{}, []domain.ScheduleConflict(nil), errors.New("error")},
+			},
+		},
+		{
+			name: "anonymous",
+			args: args{
+				ctx:    context.Background(),
+				events: []domain.CalendarEvent{event},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGathering := new(mocks.IGatheringUsecase)
+			mockInvitation := new(mocks.IInvitationUsecase)
+			mockMember := new(mocks.IMemberUsecase)
+			usecase := usecase.NewCalendarUsecase(usecase.CalendarUsecaseArgs{
+				GatheringUsecase:  mockGathering,
+				InvitationUsecase: mockInvitation,
+				MemberUsecase:     mockMember,
+			})
+			if tt.funcGetGathering.Called {
+				mockGathering.On("Get", tt.funcGetGathering.Input...).Return(tt.funcGetGathering.Output...)
+			}
+			if tt.funcCreateGathering.Called {
+				mockGathering.On("Create", tt.funcCreateGathering.Input...).Return(tt.funcCreateGathering.Output...)
+			}
+			if tt.funcGetInvitation.Called {
+				mockInvitation.On("Get", tt.funcGetInvitation.Input...).Return(tt.funcGetInvitation.Output...)
+			}
+			if tt.funcCreateInvitation.Called {
+				mockInvitation.On("Create", tt.funcCreateInvitation.Input...).Return(tt.funcCreateInvitation.Output...)
+			}
+			mockMember.On("Get", mock.Anything, domain.MemberArgs{Email: linus.Email}).Return([]domain.Member{linus}, nil)
+			mockMember.On("Get", mock.Anything, domain.MemberArgs{Email: ron.Email}).Return([]domain.Member{ron}, nil)
+			mockMember.On("Get", mock.Anything, domain.MemberArgs{Email: "nobody@mail.com"}).Return([]domain.Member{}, nil)
+			gotResults, err := usecase.Import(tt.args.ctx, tt.args.events)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantResults, gotResults)
+			}
+			mockInvitation.AssertExpectations(t)
+		})
+	}
+}
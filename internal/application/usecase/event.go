@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+// IEventPublisher hands domain events to their subscribers, usecases publish after a change is stored
+type IEventPublisher interface {
+	Publish(ctx context.Context, events ...domain.Event)
+}
+
+// publish tells subscribers about changes already stored, a failing subscriber does not fail the change.
+// publisher is optional, without one nothing is published.
+func publish(ctx context.Context, publisher IEventPublisher, events ...domain.Event) {
+	if publisher == nil || len(events) == 0 {
+		return
+	}
+	publisher.Publish(ctx, events...)
+}
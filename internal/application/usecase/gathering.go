@@ -2,82 +2,436 @@ package usecase
 
 import (
 	"context"
-	"errors"
 	"log"
+	"time"
 
+	"github.com/hieronimusbudi/simple-go-api/internal/application/policy"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
 )
 
 type (
 	gatheringUsecase struct {
-		gatheringRepository repository.IGathering
+		gatheringRepository  repository.IGathering
+		invitationRepository repository.IInvitation
+		events               IEventPublisher
 	}
 
 	GatheringUsecaseArgs struct {
 		GatheringRepository repository.IGathering
+		// InvitationRepository reads the invitations promoted from the waitlist to tell Events about them
+		InvitationRepository repository.IInvitation
+		// Events is told about changes of gatherings, their occurrences and attendees, optional
+		Events IEventPublisher
 	}
 
 	IGatheringUsecase interface {
-		Create(ctx context.Context, gathering domain.Gathering) (NewGathering domain.Gathering, err error)
+		Create(ctx context.Context, gathering domain.Gathering, isIgnoreConflicts bool) (NewGathering domain.Gathering, conflicts []domain.ScheduleConflict, err error)
 		Get(ctx context.Context, args domain.GatheringArgs) (gatherings []domain.Gathering, err error)
+		List(ctx context.Context, args domain.GatheringArgs) (gatherings []domain.Gathering, page domain.Page, err error)
 		GetByID(ctx context.Context, id int64) (gathering domain.Gathering, err error)
 		Update(ctx context.Context, gathering domain.Gathering) (err error)
 		Delete(ctx context.Context, args domain.GatheringArgs) (err error)
+		Occurrences(ctx context.Context, args domain.OccurrenceArgs) (occurrences []domain.Occurrence, err error)
+		UpdateOccurrence(ctx context.Context, args domain.OccurrenceArgs, edit domain.Occurrence) (occurrence domain.Occurrence, err error)
+		CancelOccurrence(ctx context.Context, args domain.OccurrenceArgs) (err error)
+		Join(ctx context.Context, id int64, isIgnoreConflicts bool) (conflicts []domain.ScheduleConflict, err error)
+		Leave(ctx context.Context, id int64) (err error)
+		ScheduleConflicts(ctx context.Context, args domain.ScheduleArgs) (conflicts []domain.ScheduleConflict, err error)
 	}
 )
 
 func NewGatheringUsecase(args GatheringUsecaseArgs) IGatheringUsecase {
 	return &gatheringUsecase{
-		gatheringRepository: args.GatheringRepository,
+		gatheringRepository:  args.GatheringRepository,
+		invitationRepository: args.InvitationRepository,
+		events:               args.Events,
 	}
 }
 
-func (u *gatheringUsecase) Create(ctx context.Context, gathering domain.Gathering) (NewGathering domain.Gathering, err error) {
+// Create stores a gathering of the authenticated member. A gathering overlapping one its attendees attend is refused with
+// a domain.ScheduleConflictError, or created and its conflicts returned when isIgnoreConflicts is set.
+func (u *gatheringUsecase) Create(ctx context.Context, gathering domain.Gathering, isIgnoreConflicts bool) (NewGathering domain.Gathering, conflicts []domain.ScheduleConflict, err error) {
+	if err = policy.CanCreateGathering(ctx, gathering); err != nil {
+		return
+	}
+	memberIDs := []int64{}
+	for _, m := range gathering.Attendees {
+		memberIDs = append(memberIDs, m.ID)
+	}
+	conflicts, err = checkSchedule(ctx, u.gatheringRepository, gathering, memberIDs, isIgnoreConflicts)
+	if err != nil {
+		return
+	}
 	id, err := u.gatheringRepository.Create(ctx, gathering)
 	if err != nil {
 		log.Println(err)
 		return
 	}
+	for i := range conflicts {
+		conflicts[i].Occurrence.GatheringID = id
+	}
 	NewGathering, err = u.GetByID(ctx, id)
 	if err != nil {
 		log.Println(err)
+		return
 	}
+	publish(ctx, u.events, domain.GatheringCreated{Gathering: NewGathering})
 	return
 }
 
+// Get returns the gatherings the authenticated member can see, see visible
 func (u *gatheringUsecase) Get(ctx context.Context, args domain.GatheringArgs) (gatherings []domain.Gathering, err error) {
+	gatherings, err = u.gatheringRepository.Get(ctx, visible(ctx, args))
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// List returns a page of gatherings, it fetches one extra row to know whether there is a next page
+func (u *gatheringUsecase) List(ctx context.Context, args domain.GatheringArgs) (gatherings []domain.Gathering, page domain.Page, err error) {
+	args = visible(ctx, args)
+	limit := args.Limit
+	if limit > 0 {
+		args.Limit = limit + 1
+	}
 	gatherings, err = u.gatheringRepository.Get(ctx, args)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	page = domain.Page{Limit: limit, Offset: args.Offset}
+	if limit > 0 && len(gatherings) > limit {
+		gatherings = gatherings[:limit]
+		field, _ := args.SortField()
+		last := gatherings[limit-1]
+		page.NextCursor = domain.EncodeCursor(last.CursorValue(field), last.ID)
+	}
+	page.Total, err = u.gatheringRepository.Count(ctx, args)
 	if err != nil {
 		log.Println(err)
 	}
 	return
 }
 
+// GetByID returns a gathering the authenticated member can see, a private one hidden from them is not found
 func (u *gatheringUsecase) GetByID(ctx context.Context, id int64) (gathering domain.Gathering, err error) {
-	gatherings, err := u.gatheringRepository.Get(ctx, domain.GatheringArgs{IDs: []int64{id}})
+	gatherings, err := u.gatheringRepository.Get(ctx, visible(ctx, domain.GatheringArgs{IDs: []int64{id}}))
 	if err != nil {
 		log.Println(err)
 		return
 	}
 	if len(gatherings) == 0 {
-		err = errors.New("cannot find gathering")
+		err = domain.NewError(domain.ErrNotFound, "cannot find gathering")
 		return
 	}
 	gathering = gatherings[0]
 	return
 }
 
+// visible limits args to public gatherings and the private ones the authenticated member created, attends or is invited to
+func visible(ctx context.Context, args domain.GatheringArgs) domain.GatheringArgs {
+	args.IsVisibleOnly = true
+	if member, ok := domain.MemberFromContext(ctx); ok {
+		args.ViewerID = member.ID
+	}
+	return args
+}
+
 func (u *gatheringUsecase) Update(ctx context.Context, gathering domain.Gathering) (err error) {
-	err = u.gatheringRepository.Update(ctx, gathering)
+	current, err := u.GetByID(ctx, gathering.ID)
+	if err != nil {
+		return
+	}
+	if err = policy.CanManageGathering(ctx, current); err != nil {
+		return
+	}
+	promotedIDs, err := u.gatheringRepository.Update(ctx, gathering, attendeeNotifications(ctx, current, domain.NotificationGatheringUpdated, gathering.NotificationData())...)
 	if err != nil {
 		log.Println(err)
+		return
 	}
+	u.publishGathering(ctx, gathering.ID, func(g domain.Gathering) domain.Event {
+		return domain.NewGatheringUpdatedEvent(current, g)
+	})
+	// a raised capacity may promote waitlisted invitations
+	publishInvitations(ctx, u.events, u.invitationRepository, promotedIDs)
 	return
 }
 
 func (u *gatheringUsecase) Delete(ctx context.Context, args domain.GatheringArgs) (err error) {
-	err = u.gatheringRepository.Delete(ctx, args)
+	current, err := u.GetByID(ctx, args.ID)
+	if err != nil {
+		return
+	}
+	if err = policy.CanManageGathering(ctx, current); err != nil {
+		return
+	}
+	err = u.gatheringRepository.Delete(ctx, args, attendeeNotifications(ctx, current, domain.NotificationGatheringCanceled, current.NotificationData())...)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	publish(ctx, u.events, domain.GatheringDeleted{Gathering: current})
+	return
+}
+
+// Occurrences expands a gathering into its occurrences within args.From and args.To
+func (u *gatheringUsecase) Occurrences(ctx context.Context, args domain.OccurrenceArgs) (occurrences []domain.Occurrence, err error) {
+	gathering, err := u.GetByID(ctx, args.GatheringID)
+	if err != nil {
+		return
+	}
+	occurrences, err = gathering.Occurrences(args.From, args.To)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// UpdateOccurrence edits one occurrence of a series, or with OccurrenceScopeFollowing it and every later one.
+// Editing the following occurrences splits the series, the returned occurrence belongs to the new series then.
+func (u *gatheringUsecase) UpdateOccurrence(ctx context.Context, args domain.OccurrenceArgs, edit domain.Occurrence) (occurrence domain.Occurrence, err error) {
+	current, existing, err := u.occurrence(ctx, args)
+	if err != nil {
+		return
+	}
+	occurrence = existing.Edit(edit)
+	if err = occurrence.Validate(); err != nil {
+		return
+	}
+	if args.Scope == domain.OccurrenceScopeThis {
+		notifications := attendeeNotifications(ctx, current, domain.NotificationGatheringUpdated, occurrence.NotificationData())
+		if err = u.gatheringRepository.SaveOccurrence(ctx, occurrence, notifications...); err != nil {
+			log.Println(err)
+			return
+		}
+		u.publishGathering(ctx, current.ID, func(g domain.Gathering) domain.Event {
+			return domain.OccurrenceUpdated{Gathering: g, Occurrence: occurrence, Scope: args.Scope}
+		})
+		return
+	}
+
+	following, split, err := current.Split(existing.RecurrenceID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if !split {
+		// the first occurrence, the whole series is edited
+		following = current
+	}
+	moved := !occurrence.ScheduledAt.Equal(existing.RecurrenceID)
+	following.ScheduledAt = occurrence.ScheduledAt
+	following.EndsAt = occurrence.EndsAt
+	following.DurationMinutes = 0
+	following.Name = occurrence.Name
+	following.Location = occurrence.Location
+	if moved {
+		// exceptions are made for starts the moved series no longer generates
+		following.Exceptions = nil
+	}
+	if err = following.Validate(); err != nil {
+		return
+	}
+	data := following.NotificationData()
+	data.Scope = args.Scope
+	notifications := attendeeNotifications(ctx, current, domain.NotificationGatheringUpdated, data)
+	var promotedIDs []int64
+	if !split {
+		promotedIDs, err = u.gatheringRepository.Update(ctx, following, notifications...)
+	} else {
+		following.ID, err = u.gatheringRepository.Split(ctx, current, following, existing.RecurrenceID, notifications...)
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	occurrence.GatheringID = following.ID
+	occurrence.RecurrenceID = following.ScheduledAt
+	u.publishGathering(ctx, current.ID, func(g domain.Gathering) domain.Event {
+		return domain.OccurrenceUpdated{Gathering: g, Occurrence: occurrence, Scope: args.Scope}
+	})
+	if split {
+		u.publishGathering(ctx, following.ID, func(g domain.Gathering) domain.Event {
+			return domain.GatheringCreated{Gathering: g}
+		})
+	}
+	publishInvitations(ctx, u.events, u.invitationRepository, promotedIDs)
+	return
+}
+
+// CancelOccurrence cancels one occurrence of a series, or with OccurrenceScopeFollowing ends the series before it
+func (u *gatheringUsecase) CancelOccurrence(ctx context.Context, args domain.OccurrenceArgs) (err error) {
+	current, existing, err := u.occurrence(ctx, args)
+	if err != nil {
+		return
+	}
+	if args.Scope == domain.OccurrenceScopeThis {
+		existing.Canceled = true
+		notifications := attendeeNotifications(ctx, current, domain.NotificationOccurrenceCanceled, existing.NotificationData())
+		if err = u.gatheringRepository.SaveOccurrence(ctx, existing, notifications...); err != nil {
+			log.Println(err)
+			return
+		}
+		u.publishGathering(ctx, current.ID, func(g domain.Gathering) domain.Event {
+			return domain.OccurrenceCanceled{Gathering: g, Occurrence: existing, Scope: args.Scope}
+		})
+		return
+	}
+	_, split, err := current.Split(existing.RecurrenceID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	var promotedIDs []int64
+	if !split {
+		// canceling from the first occurrence cancels the whole series
+		notifications := attendeeNotifications(ctx, current, domain.NotificationGatheringCanceled, current.NotificationData())
+		err = u.gatheringRepository.Delete(ctx, domain.GatheringArgs{ID: current.ID}, notifications...)
+	} else {
+		data := existing.NotificationData()
+		data.Scope = domain.OccurrenceScopeFollowing
+		notifications := attendeeNotifications(ctx, current, domain.NotificationOccurrenceCanceled, data)
+		promotedIDs, err = u.gatheringRepository.Update(ctx, current, notifications...)
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if !split {
+		publish(ctx, u.events, domain.GatheringDeleted{Gathering: current})
+	} else {
+		u.publishGathering(ctx, current.ID, func(g domain.Gathering) domain.Event {
+			return domain.OccurrenceCanceled{Gathering: g, Occurrence: existing, Scope: args.Scope}
+		})
+		publishInvitations(ctx, u.events, u.invitationRepository, promotedIDs)
+	}
+	return
+}
+
+// publishGathering tells subscribers about a change of the gathering, event is made of the gathering as it is stored
+// now, whoever may see it
+func (u *gatheringUsecase) publishGathering(ctx context.Context, id int64, event func(gathering domain.Gathering) domain.Event) {
+	if u.events == nil {
+		return
+	}
+	gatherings, err := u.gatheringRepository.Get(ctx, domain.GatheringArgs{IDs: []int64{id}})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(gatherings) == 0 {
+		return
+	}
+	publish(ctx, u.events, event(gatherings[0]))
+}
+
+// attendeeNotifications tells the attendees of the gathering, except the member who changed it, about the change
+func attendeeNotifications(ctx context.Context, gathering domain.Gathering, event string, data domain.NotificationData) []domain.Notification {
+	actorID := gathering.CreatorID
+	if actor, ok := domain.MemberFromContext(ctx); ok {
+		actorID = actor.ID
+	}
+	data.ActorID = actorID
+	return domain.NewNotifications(event, gathering.AttendeeIDs(actorID), data)
+}
+
+// occurrence loads a series the member may manage and its occurrence at args.RecurrenceID
+func (u *gatheringUsecase) occurrence(ctx context.Context, args domain.OccurrenceArgs) (gathering domain.Gathering, occurrence domain.Occurrence, err error) {
+	if gathering, err = u.GetByID(ctx, args.GatheringID); err != nil {
+		return
+	}
+	if err = policy.CanManageGathering(ctx, gathering); err != nil {
+		return
+	}
+	if !gathering.IsRecurring() {
+		err = domain.NewError(domain.ErrValidation, "gathering is not recurring, update the gathering instead")
+		return
+	}
+	occurrence, ok := gathering.Occurrence(args.RecurrenceID)
+	if !ok {
+		err = domain.NewError(domain.ErrNotFound, "cannot find occurrence")
+	}
+	return
+}
+
+// Join makes the authenticated member an attendee of an upcoming public gathering, schedule conflicts are handled like Create does
+func (u *gatheringUsecase) Join(ctx context.Context, id int64, isIgnoreConflicts bool) (conflicts []domain.ScheduleConflict, err error) {
+	gathering, err := u.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = policy.CanJoinGathering(ctx, gathering); err != nil {
+		return
+	}
+	if !gathering.IsUpcoming(time.Now()) {
+		return nil, domain.NewError(domain.ErrConflict, "the gathering is over")
+	}
+	member, _ := domain.MemberFromContext(ctx)
+	if isAttending(gathering, member.ID) {
+		return nil, domain.NewError(domain.ErrConflict, "the member already attends the gathering")
+	}
+	conflicts, err = checkSchedule(ctx, u.gatheringRepository, gathering, []int64{member.ID}, isIgnoreConflicts)
+	if err != nil {
+		return
+	}
+	err = u.gatheringRepository.Join(ctx, id, member.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	u.publishGathering(ctx, id, func(g domain.Gathering) domain.Event {
+		return domain.AttendeeAdded{Gathering: g, MemberID: member.ID}
+	})
+	return
+}
+
+// Leave removes the authenticated member from the attendees, the creator cannot leave their own gathering
+func (u *gatheringUsecase) Leave(ctx context.Context, id int64) (err error) {
+	member, ok := domain.MemberFromContext(ctx)
+	if !ok {
+		return domain.NewError(domain.ErrUnauthorized, "authentication required")
+	}
+	gathering, err := u.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if gathering.CreatorID == member.ID {
+		return domain.NewError(domain.ErrConflict, "the creator cannot leave the gathering")
+	}
+	if !isAttending(gathering, member.ID) {
+		return domain.NewError(domain.ErrNotFound, "the member does not attend the gathering")
+	}
+	promotedIDs, err := u.gatheringRepository.Leave(ctx, id, member.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	u.publishGathering(ctx, id, func(g domain.Gathering) domain.Event {
+		return domain.AttendeeRemoved{Gathering: g, MemberID: member.ID}
+	})
+	// the freed seats may promote waitlisted invitations
+	publishInvitations(ctx, u.events, u.invitationRepository, promotedIDs)
+	return
+}
+
+// ScheduleConflicts lists overlapping occurrences of the gatherings a member attends within args.From and args.To,
+// only the member themself or an admin
+func (u *gatheringUsecase) ScheduleConflicts(ctx context.Context, args domain.ScheduleArgs) (conflicts []domain.ScheduleConflict, err error) {
+	if err = policy.CanViewSchedule(ctx, args.MemberID); err != nil {
+		return
+	}
+	if err = args.Validate(time.Now()); err != nil {
+		return
+	}
+	gatherings, err := u.gatheringRepository.Get(ctx, domain.GatheringArgs{MemberIDs: []int64{args.MemberID}})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	conflicts, err = domain.ScheduleConflicts(args.MemberID, gatherings, args.From, args.To)
 	if err != nil {
 		log.Println(err)
 	}
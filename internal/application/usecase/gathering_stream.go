@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+const (
+	// DefaultStreamReplay is how many events of a gathering are kept for clients resuming with Last-Event-ID
+	DefaultStreamReplay = 100
+	// DefaultStreamRetention is how long the events of a gathering nobody subscribes to are kept
+	DefaultStreamRetention = 10 * time.Minute
+	// streamQueue is how many events a subscriber may fall behind before its stream ends, it resumes on reconnect
+	streamQueue = 32
+)
+
+type (
+	gatheringStreamUsecase struct {
+		gatheringUsecase IGatheringUsecase
+		replay           int
+		retention        time.Duration
+		// epoch tells event IDs of this process from those of an earlier one or another replica
+		epoch   string
+		mu      sync.Mutex
+		seq     int64
+		streams map[int64]*gatheringStream
+	}
+
+	// gatheringStream keeps the latest events of a gathering and its subscribers
+	gatheringStream struct {
+		events []domain.StreamEvent
+		// evicted is the sequence of the newest event no longer kept, a client who got an older one has missed events
+		evicted     int64
+		isPublic    bool
+		subscribers map[*GatheringSubscription]bool
+		updatedAt   time.Time
+	}
+
+	// GatheringSubscription receives the events of a gathering until it is closed
+	GatheringSubscription struct {
+		// Replay are the events after the Last-Event-ID given, or a single EventStreamReset when some were missed
+		Replay []domain.StreamEvent
+		// Events delivers later events. It is closed after the gathering is deleted, after an event that may hide the
+		// gathering from the subscriber, or when the subscriber falls behind; the client reconnects to resume.
+		Events <-chan domain.StreamEvent
+		events chan domain.StreamEvent
+		close  func()
+	}
+
+	GatheringStreamUsecaseArgs struct {
+		// GatheringUsecase checks that a subscriber may view the gathering
+		GatheringUsecase IGatheringUsecase
+		// Replay is how many events of a gathering are kept, DefaultStreamReplay when zero
+		Replay int
+		// Retention is how long the events of a gathering without subscribers are kept, DefaultStreamRetention when zero
+		Retention time.Duration
+	}
+
+	IGatheringStreamUsecase interface {
+		Handle(ctx context.Context, event domain.Event) (err error)
+		Subscribe(ctx context.Context, gatheringID int64, lastEventID string) (subscription *GatheringSubscription, err error)
+	}
+)
+
+func NewGatheringStreamUsecase(args GatheringStreamUsecaseArgs) IGatheringStreamUsecase {
+	replay := args.Replay
+	if replay <= 0 {
+		replay = DefaultStreamReplay
+	}
+	retention := args.Retention
+	if retention <= 0 {
+		retention = DefaultStreamRetention
+	}
+	epoch, err := randomHex(4)
+	if err != nil {
+		log.Println(err)
+		epoch = strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return &gatheringStreamUsecase{
+		gatheringUsecase: args.GatheringUsecase,
+		replay:           replay,
+		retention:        retention,
+		epoch:            epoch,
+		streams:          map[int64]*gatheringStream{},
+	}
+}
+
+// Subscribe streams the events of a gathering the authenticated member can view, see IGatheringUsecase.GetByID.
+// With the ID of the last event a client got, the events kept since are replayed first.
+func (u *gatheringStreamUsecase) Subscribe(ctx context.Context, gatheringID int64, lastEventID string) (subscription *GatheringSubscription, err error) {
+	gathering, err := u.gatheringUsecase.GetByID(ctx, gatheringID)
+	if err != nil {
+		return
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	s := u.stream(gathering.ID, time.Now())
+	s.isPublic = gathering.Type == valueobject.PUBLIC
+	events := make(chan domain.StreamEvent, streamQueue)
+	subscription = &GatheringSubscription{Events: events, events: events}
+	if lastEventID != "" {
+		subscription.Replay = u.since(s, lastEventID)
+	}
+	var once sync.Once
+	subscription.close = func() {
+		once.Do(func() {
+			u.mu.Lock()
+			defer u.mu.Unlock()
+			s.unsubscribe(subscription)
+			s.updatedAt = time.Now()
+		})
+	}
+	s.subscribers[subscription] = true
+	return
+}
+
+// Handle sends an event about a gathering to its subscribers and keeps it for replay, it is subscribed to the event bus
+func (u *gatheringStreamUsecase) Handle(ctx context.Context, event domain.Event) (err error) {
+	e, ok := event.(domain.GatheringEvent)
+	if !ok {
+		return
+	}
+	now := time.Now()
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.prune(now)
+	s := u.stream(e.EventGatheringID(), now)
+	u.seq++
+	streamEvent := domain.StreamEvent{ID: u.eventID(u.seq), Name: event.EventName(), Event: event}
+	s.events = append(s.events, streamEvent)
+	if len(s.events) > u.replay {
+		s.evicted, _ = u.parseEventID(s.events[0].ID)
+		copy(s.events, s.events[1:])
+		s.events = s.events[:len(s.events)-1]
+	}
+	if g, ok := eventGathering(event); ok {
+		s.isPublic = g.Type == valueobject.PUBLIC
+	}
+	_, deleted := event.(domain.GatheringDeleted)
+	ends := deleted || (!s.isPublic && mayHide(event))
+	for subscriber := range s.subscribers {
+		select {
+		case subscriber.events <- streamEvent:
+			if ends {
+				s.unsubscribe(subscriber)
+			}
+		default:
+			// the client reconnects and resumes from the replay instead of holding up the others
+			s.unsubscribe(subscriber)
+		}
+	}
+	if deleted {
+		delete(u.streams, e.EventGatheringID())
+	}
+	return
+}
+
+// Close ends the subscription, it is safe to call more than once
+func (s *GatheringSubscription) Close() {
+	if s.close != nil {
+		s.close()
+	}
+}
+
+// stream returns the stream of a gathering, a new one has missed every event so far
+func (u *gatheringStreamUsecase) stream(gatheringID int64, now time.Time) *gatheringStream {
+	s, ok := u.streams[gatheringID]
+	if !ok {
+		s = &gatheringStream{evicted: u.seq, subscribers: map[*GatheringSubscription]bool{}}
+		u.streams[gatheringID] = s
+	}
+	s.updatedAt = now
+	return s
+}
+
+// prune forgets the events of gatherings nobody has subscribed to within the retention
+func (u *gatheringStreamUsecase) prune(now time.Time) {
+	for id, s := range u.streams {
+		if len(s.subscribers) == 0 && now.Sub(s.updatedAt) > u.retention {
+			delete(u.streams, id)
+		}
+	}
+}
+
+// since returns the events kept after lastEventID, or a reset when events after it are no longer kept or it is
+// not an ID of this process
+func (u *gatheringStreamUsecase) since(s *gatheringStream, lastEventID string) (events []domain.StreamEvent) {
+	seq, ok := u.parseEventID(lastEventID)
+	if !ok || seq < s.evicted || seq > u.seq {
+		return []domain.StreamEvent{{ID: u.eventID(u.seq), Name: domain.EventStreamReset}}
+	}
+	for _, e := range s.events {
+		if eventSeq, _ := u.parseEventID(e.ID); eventSeq > seq {
+			events = append(events, e)
+		}
+	}
+	return
+}
+
+func (u *gatheringStreamUsecase) eventID(seq int64) string {
+	return fmt.Sprintf("%s-%d", u.epoch, seq)
+}
+
+func (u *gatheringStreamUsecase) parseEventID(id string) (seq int64, ok bool) {
+	epoch, value, found := strings.Cut(id, "-")
+	if !found || epoch != u.epoch {
+		return
+	}
+	seq, err := strconv.ParseInt(value, 10, 64)
+	return seq, err == nil
+}
+
+func (s *gatheringStream) unsubscribe(subscription *GatheringSubscription) {
+	if s.subscribers[subscription] {
+		delete(s.subscribers, subscription)
+		close(subscription.events)
+	}
+}
+
+// eventGathering returns the gathering an event carries as it is stored now
+func eventGathering(event domain.Event) (gathering domain.Gathering, ok bool) {
+	switch e := event.(type) {
+	case domain.GatheringCreated:
+		return e.Gathering, true
+	case domain.GatheringUpdated:
+		return e.Gathering, true
+	case domain.GatheringRescheduled:
+		return e.Gathering, true
+	case domain.OccurrenceUpdated:
+		return e.Gathering, true
+	case domain.OccurrenceCanceled:
+		return e.Gathering, true
+	case domain.AttendeeAdded:
+		return e.Gathering, true
+	case domain.AttendeeRemoved:
+		return e.Gathering, true
+	}
+	return
+}
+
+// mayHide reports whether an event may hide a private gathering from some of its viewers, their streams end so they
+// are checked again when they reconnect
+func mayHide(event domain.Event) bool {
+	switch event.(type) {
+	case domain.GatheringUpdated, domain.GatheringRescheduled, domain.AttendeeRemoved, domain.InvitationRejected, domain.InvitationCanceled:
+		return true
+	}
+	return false
+}
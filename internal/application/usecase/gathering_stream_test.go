@@ -0,0 +1,162 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/hieronimusbudi/simple-go-api/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// streamNames returns the names of the events, in order
+func streamNames(events []domain.StreamEvent) (names []string) {
+	for _, e := range events {
+		names = append(names, e.Name)
+	}
+	return
+}
+
+// received drains the events already delivered to a subscription, closed tells whether its stream ended
+func received(subscription *usecase.GatheringSubscription) (events []domain.StreamEvent, closed bool) {
+	for {
+		select {
+		case e, ok := <-subscription.Events:
+			if !ok {
+				return events, true
+			}
+			events = append(events, e)
+		default:
+			return events, false
+		}
+	}
+}
+
+func Test_gatheringStreamUsecase_Subscribe(t *testing.T) {
+	public := domain.Gathering{ID: 1, Type: valueobject.PUBLIC}
+	mockGathering := new(mocks.IGatheringUsecase)
+	mockGathering.On("GetByID", mock.Anything, int64(1)).Return(public, nil)
+	mockGathering.On("GetByID", mock.Anything, int64(2)).Return(domain.Gathering{}, domain.NewError(domain.ErrNotFound, "cannot find gathering"))
+	stream := usecase.NewGatheringStreamUsecase(usecase.GatheringStreamUsecaseArgs{
+		GatheringUsecase: mockGathering,
+		Replay:           2,
+	})
+	ctx := context.Background()
+
+	_, err := stream.Subscribe(ctx, 2, "")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	live, err := stream.Subscribe(ctx, 1, "")
+	require.NoError(t, err)
+	require.Empty(t, live.Replay)
+	stream.Handle(ctx, domain.AttendeeAdded{Gathering: public, MemberID: 3})
+	// events of other gatherings and of members are not streamed
+	stream.Handle(ctx, domain.AttendeeAdded{Gathering: domain.Gathering{ID: 9}, MemberID: 3})
+	stream.Handle(ctx, domain.MemberDeleted{MemberID: 3})
+	stream.Handle(ctx, domain.InvitationAccepted{Invitation: domain.Invitation{ID: 5, GatheringID: 1}})
+	stream.Handle(ctx, domain.AttendeeRemoved{Gathering: public, MemberID: 3})
+	stream.Handle(ctx, domain.GatheringUpdated{Gathering: public})
+	events, closed := received(live)
+	require.False(t, closed)
+	require.Equal(t, []string{domain.EventAttendeeAdded, domain.EventInvitationAccepted, domain.EventAttendeeRemoved, domain.EventGatheringUpdated}, streamNames(events))
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		want        []string
+	}{
+		{
+			name:        "resumes after the last event",
+			lastEventID: events[1].ID,
+			want:        []string{domain.EventAttendeeRemoved, domain.EventGatheringUpdated},
+		},
+		{
+			name:        "up to date",
+			lastEventID: events[3].ID,
+		},
+		{
+			name:        "missed events no longer kept",
+			lastEventID: events[0].ID,
+			want:        []string{domain.EventStreamReset},
+		},
+		{
+			name:        "another process",
+			lastEventID: "0000-1",
+			want:        []string{domain.EventStreamReset},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription, err := stream.Subscribe(ctx, 1, tt.lastEventID)
+			require.NoError(t, err)
+			defer subscription.Close()
+			require.Equal(t, tt.want, streamNames(subscription.Replay))
+		})
+	}
+
+	live.Close()
+	live.Close()
+	_, closed = received(live)
+	require.True(t, closed)
+}
+
+func Test_gatheringStreamUsecase_Handle_ends(t *testing.T) {
+	public := domain.Gathering{ID: 1, Type: valueobject.PUBLIC}
+	private := domain.Gathering{ID: 1, Type: valueobject.PRIVATE}
+	tests := []struct {
+		name      string
+		gathering domain.Gathering
+		event     domain.Event
+		wantEnded bool
+	}{
+		{
+			name:      "attendee left a public gathering",
+			gathering: public,
+			event:     domain.AttendeeRemoved{Gathering: public, MemberID: 3},
+		},
+		{
+			name:      "attendee left a private gathering",
+			gathering: private,
+			event:     domain.AttendeeRemoved{Gathering: private, MemberID: 3},
+			wantEnded: true,
+		},
+		{
+			name:      "invitation of a private gathering canceled",
+			gathering: private,
+			event:     domain.InvitationCanceled{Invitation: domain.Invitation{ID: 5, GatheringID: 1}},
+			wantEnded: true,
+		},
+		{
+			name:      "public gathering made private",
+			gathering: public,
+			event:     domain.GatheringUpdated{Gathering: private},
+			wantEnded: true,
+		},
+		{
+			name:      "deleted",
+			gathering: public,
+			event:     domain.GatheringDeleted{Gathering: public},
+			wantEnded: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGathering := new(mocks.IGatheringUsecase)
+			mockGathering.On("GetByID", mock.Anything, int64(1)).Return(tt.gathering, nil)
+			stream := usecase.NewGatheringStreamUsecase(usecase.GatheringStreamUsecaseArgs{
+				GatheringUsecase: mockGathering,
+			})
+			subscription, err := stream.Subscribe(context.Background(), 1, "")
+			require.NoError(t, err)
+			defer subscription.Close()
+			require.NoError(t, stream.Handle(context.Background(), tt.event))
+			events, closed := received(subscription)
+			// the event is delivered before the stream ends
+			require.Equal(t, []string{tt.event.EventName()}, streamNames(events))
+			require.Equal(t, tt.wantEnded, closed)
+		})
+	}
+}
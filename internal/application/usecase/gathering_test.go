@@ -3,9 +3,11 @@ package usecase_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
 	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
 	"github.com/hieronimusbudi/simple-go-api/internal/mocks"
 	"github.com/stretchr/testify/mock"
@@ -18,7 +20,7 @@ func Test_gatheringUsecase_Create(t *testing.T) {
 			ID: 1,
 		},
 		Type:        0,
-		ScheduledAt: "2020-10-06 11:53",
+		ScheduledAt: time.Date(2020, 10, 6, 11, 53, 0, 0, time.UTC),
 		Name:        "Private Gathering",
 		Location:    "Local Street",
 		Attendees: []domain.Member{
@@ -29,19 +31,31 @@ func Test_gatheringUsecase_Create(t *testing.T) {
 	}
 	wantNewGathering := gathering
 	wantNewGathering.ID = 1
+	upcoming := gathering
+	upcoming.ScheduledAt = time.Now().Add(24 * time.Hour)
+	overlapping := domain.Gathering{
+		ID:          2,
+		ScheduledAt: upcoming.ScheduledAt.Add(-30 * time.Minute),
+		Attendees:   []domain.Member{{ID: 1}},
+	}
 	type args struct {
-		gathering domain.Gathering
+		gathering         domain.Gathering
+		isIgnoreConflicts bool
 	}
 	tests := []struct {
 		name             string
+		ctx              context.Context
 		args             args
 		wantNewGathering domain.Gathering
-		wantErr          bool
+		wantConflicts    []domain.ScheduleConflict
+		wantErr          error
 		funcCreate       helpers.TestFuncCall
 		funcGet          helpers.TestFuncCall
+		funcGetAttended  helpers.TestFuncCall
 	}{
 		{
 			name: "success",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
 			args: args{
 				gathering: gathering,
 			},
@@ -52,10 +66,75 @@ func Test_gatheringUsecase_Create(t *testing.T) {
 				Output: []interface{}{wantNewGathering.ID, nil},
 			},
 			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 1}},
+				Output: []interface{}{[]domain.Gathering{wantNewGathering}, nil},
+			},
+			funcGetAttended: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{MemberIDs: []int64{1}}},
+				Output: []interface{}{[]domain.Gathering{}, nil},
+			},
+		},
+		{
+			name: "creator is not the authenticated member",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			args: args{
+				gathering: gathering,
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name: "schedule conflict",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			args: args{
+				gathering: upcoming,
+			},
+			wantErr: domain.ErrConflict,
+			funcGetAttended: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{MemberIDs: []int64{1}}},
+				Output: []interface{}{[]domain.Gathering{overlapping}, nil},
+			},
+		},
+		{
+			name: "schedule conflict with force",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			args: args{
+				gathering:         upcoming,
+				isIgnoreConflicts: true,
+			},
+			wantNewGathering: wantNewGathering,
+			wantConflicts: []domain.ScheduleConflict{{
+				MemberID: 1,
+				Occurrence: domain.Occurrence{
+					GatheringID:  1,
+					RecurrenceID: upcoming.ScheduledAt,
+					ScheduledAt:  upcoming.ScheduledAt,
+					Name:         upcoming.Name,
+					Location:     upcoming.Location,
+				},
+				ConflictsWith: domain.Occurrence{
+					GatheringID:  2,
+					RecurrenceID: overlapping.ScheduledAt,
+					ScheduledAt:  overlapping.ScheduledAt,
+				},
+			}},
+			funcCreate: helpers.TestFuncCall{
 				Called: true,
 				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{wantNewGathering.ID, nil},
+			},
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 1}},
 				Output: []interface{}{[]domain.Gathering{wantNewGathering}, nil},
 			},
+			funcGetAttended: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{MemberIDs: []int64{1}}},
+				Output: []interface{}{[]domain.Gathering{overlapping}, nil},
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -70,17 +149,19 @@ func Test_gatheringUsecase_Create(t *testing.T) {
 			if tt.funcGet.Called {
 				mockGathering.On("Get", tt.funcGet.Input...).Return(tt.funcGet.Output...)
 			}
-			gotNewGathering, err := usecase.Create(context.Background(), tt.args.gathering)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("gatheringUsecase.Create() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			if tt.funcGetAttended.Called {
+				mockGathering.On("Get", tt.funcGetAttended.Input...).Return(tt.funcGetAttended.Output...)
 			}
-			if tt.wantErr {
-				require.Error(t, err)
+			gotNewGathering, gotConflicts, err := usecase.Create(tt.ctx, tt.args.gathering, tt.args.isIgnoreConflicts)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				mockGathering.AssertNotCalled(t, "Create")
 			} else {
 				require.NoError(t, err)
 				require.Equal(t, tt.wantNewGathering, gotNewGathering)
+				require.Equal(t, tt.wantConflicts, gotConflicts)
 			}
+			mockGathering.AssertExpectations(t)
 		})
 	}
 }
@@ -94,7 +175,7 @@ func Test_gatheringUsecase_Get(t *testing.T) {
 				ID: 1,
 			},
 			Type:        0,
-			ScheduledAt: "2020-10-06T04:53:02Z",
+			ScheduledAt: time.Date(2020, 10, 6, 4, 53, 2, 0, time.UTC),
 			CreatedAt:   "2023-09-28T19:09:41Z",
 			Name:        "Private Meeting",
 			Location:    "pramuka street",
@@ -154,7 +235,7 @@ func Test_gatheringUsecase_GetByID(t *testing.T) {
 				ID: 1,
 			},
 			Type:        0,
-			ScheduledAt: "2020-10-06T04:53:02Z",
+			ScheduledAt: time.Date(2020, 10, 6, 4, 53, 2, 0, time.UTC),
 			CreatedAt:   "2023-09-28T19:09:41Z",
 			Name:        "Private Meeting",
 			Location:    "pramuka street",
@@ -187,6 +268,18 @@ func Test_gatheringUsecase_GetByID(t *testing.T) {
 				Output: []interface{}{gatherings, nil},
 			},
 		},
+		{
+			name: "private gathering hidden from an anonymous viewer",
+			args: args{
+				id: 1,
+			},
+			wantErr: true,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{IDs: []int64{1}, IsVisibleOnly: true}},
+				Output: []interface{}{[]domain.Gathering{}, nil},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -216,7 +309,7 @@ func Test_gatheringUsecase_Update(t *testing.T) {
 			ID: 1,
 		},
 		Type:        0,
-		ScheduledAt: "2023-10-06 04:53",
+		ScheduledAt: time.Date(2023, 10, 6, 4, 53, 0, 0, time.UTC),
 		CreatedAt:   "2023-09-28T19:09:41Z",
 		Name:        "Update Private Meeting",
 		Location:    "update pramuka street",
@@ -231,19 +324,40 @@ func Test_gatheringUsecase_Update(t *testing.T) {
 	}
 	tests := []struct {
 		name       string
+		ctx        context.Context
 		args       args
-		wantErr    bool
+		wantErr    error
+		funcGet    helpers.TestFuncCall
 		funcUpdate helpers.TestFuncCall
 	}{
 		{
 			name: "success",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
 			args: args{
 				gathering: gathering,
 			},
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 1}},
+				Output: []interface{}{[]domain.Gathering{gathering}, nil},
+			},
 			funcUpdate: helpers.TestFuncCall{
 				Called: true,
 				Input:  []interface{}{mock.Anything, mock.Anything},
-				Output: []interface{}{nil},
+				Output: []interface{}{nil, nil},
+			},
+		},
+		{
+			name: "not the creator",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			args: args{
+				gathering: gathering,
+			},
+			wantErr: domain.ErrForbidden,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Gathering{gathering}, nil},
 			},
 		},
 	}
@@ -253,54 +367,593 @@ func Test_gatheringUsecase_Update(t *testing.T) {
 			usecase := usecase.NewGatheringUsecase(usecase.GatheringUsecaseArgs{
 				GatheringRepository: mockGathering,
 			})
+			if tt.funcGet.Called {
+				mockGathering.On("Get", tt.funcGet.Input...).Return(tt.funcGet.Output...)
+			}
 			if tt.funcUpdate.Called {
 				mockGathering.On("Update", tt.funcUpdate.Input...).Return(tt.funcUpdate.Output...)
 			}
-			err := usecase.Update(context.Background(), tt.args.gathering)
-			if tt.wantErr {
-				require.Error(t, err)
+			err := usecase.Update(tt.ctx, tt.args.gathering)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
 			} else {
 				require.NoError(t, err)
 			}
+			mockGathering.AssertExpectations(t)
 		})
 	}
 }
 
 func Test_gatheringUsecase_Delete(t *testing.T) {
+	gathering := domain.Gathering{ID: 1, CreatorID: 1, Creator: domain.Member{ID: 1}}
 	type args struct {
 		args domain.GatheringArgs
 	}
 	tests := []struct {
 		name       string
+		ctx        context.Context
 		args       args
-		wantErr    bool
+		wantErr    error
+		funcGet    helpers.TestFuncCall
 		funcDelete helpers.TestFuncCall
 	}{
 		{
 			name: "success",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
 			args: args{domain.GatheringArgs{
 				ID: int64(1),
 			}},
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 1}},
+				Output: []interface{}{[]domain.Gathering{gathering}, nil},
+			},
 			funcDelete: helpers.TestFuncCall{
 				Called: true,
 				Input:  []interface{}{mock.Anything, mock.Anything},
 				Output: []interface{}{nil},
 			},
 		},
+		{
+			name: "not the creator",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			args: args{domain.GatheringArgs{
+				ID: int64(1),
+			}},
+			wantErr: domain.ErrForbidden,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Gathering{gathering}, nil},
+			},
+		},
 	}
 	for _, tt := range tests {
 		mockGathering := new(mocks.IGathering)
 		usecase := usecase.NewGatheringUsecase(usecase.GatheringUsecaseArgs{
 			GatheringRepository: mockGathering,
 		})
+		if tt.funcGet.Called {
+			mockGathering.On("Get", tt.funcGet.Input...).Return(tt.funcGet.Output...)
+		}
 		if tt.funcDelete.Called {
 			mockGathering.On("Delete", tt.funcDelete.Input...).Return(tt.funcDelete.Output...)
 		}
-		err := usecase.Delete(context.Background(), tt.args.args)
-		if tt.wantErr {
-			require.Error(t, err)
+		err := usecase.Delete(tt.ctx, tt.args.args)
+		if tt.wantErr != nil {
+			require.ErrorIs(t, err, tt.wantErr)
 		} else {
 			require.NoError(t, err)
 		}
+		mockGathering.AssertExpectations(t)
+	}
+}
+
+func Test_gatheringUsecase_UpdateOccurrence(t *testing.T) {
+	start := time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC)
+	series := domain.Gathering{
+		ID:          1,
+		CreatorID:   1,
+		Creator:     domain.Member{ID: 1},
+		ScheduledAt: start,
+		TimeZone:    domain.DefaultTimeZone,
+		Recurrence:  "FREQ=WEEKLY;COUNT=4",
+		Name:        "standup",
+		Location:    "room 1",
+	}
+	single := series
+	single.Recurrence = ""
+	owner := domain.ContextWithMember(context.Background(), domain.Member{ID: 1})
+	type args struct {
+		args domain.OccurrenceArgs
+		edit domain.Occurrence
+	}
+	tests := []struct {
+		name               string
+		ctx                context.Context
+		args               args
+		want               domain.Occurrence
+		wantErr            error
+		funcGet            helpers.TestFuncCall
+		funcSaveOccurrence helpers.TestFuncCall
+		funcSplit          helpers.TestFuncCall
+		funcUpdate         helpers.TestFuncCall
+	}{
+		{
+			name: "this occurrence",
+			ctx:  owner,
+			args: args{
+				args: domain.OccurrenceArgs{GatheringID: 1, RecurrenceID: start.AddDate(0, 0, 7), Scope: domain.OccurrenceScopeThis},
+				edit: domain.Occurrence{Location: "room 2"},
+			},
+			want: domain.Occurrence{GatheringID: 1, RecurrenceID: start.AddDate(0, 0, 7), ScheduledAt: start.AddDate(0, 0, 7), Name: "standup", Location: "room 2"},
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 1}},
+				Output: []interface{}{[]domain.Gathering{series}, nil},
+			},
+			funcSaveOccurrence: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.Occurrence{GatheringID: 1, RecurrenceID: start.AddDate(0, 0, 7), ScheduledAt: start.AddDate(0, 0, 7), Name: "standup", Location: "room 2"}},
+				Output: []interface{}{nil},
+			},
+		},
+		{
+			name: "this and following occurrences",
+			ctx:  owner,
+			args: args{
+				args: domain.OccurrenceArgs{GatheringID: 1, RecurrenceID: start.AddDate(0, 0, 14), Scope: domain.OccurrenceScopeFollowing},
+				edit: domain.Occurrence{Name: "retro"},
+			},
+			want: domain.Occurrence{GatheringID: 2, RecurrenceID: start.AddDate(0, 0, 14), ScheduledAt: start.AddDate(0, 0, 14), Name: "retro", Location: "room 1"},
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Gathering{series}, nil},
+			},
+			funcSplit: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{
+					mock.Anything,
+					mock.MatchedBy(func(g domain.Gathering) bool { return g.Recurrence == "FREQ=WEEKLY;COUNT=2" }),
+					mock.MatchedBy(func(g domain.Gathering) bool { return g.Recurrence == "FREQ=WEEKLY;COUNT=2" && g.Name == "retro" }),
+					start.AddDate(0, 0, 14),
+				},
+				Output: []interface{}{int64(2), nil},
+			},
+		},
+		{
+			name: "first and following occurrences update the series",
+			ctx:  owner,
+			args: args{
+				args: domain.OccurrenceArgs{GatheringID: 1, RecurrenceID: start, Scope: domain.OccurrenceScopeFollowing},
+				edit: domain.Occurrence{ScheduledAt: start.Add(time.Hour)},
+			},
+			want: domain.Occurrence{GatheringID: 1, RecurrenceID: start.Add(time.Hour), ScheduledAt: start.Add(time.Hour), Name: "standup", Location: "room 1"},
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Gathering{series}, nil},
+			},
+			funcUpdate: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.MatchedBy(func(g domain.Gathering) bool { return g.ID == 1 && g.ScheduledAt.Equal(start.Add(time.Hour)) })},
+				Output: []interface{}{nil, nil},
+			},
+		},
+		{
+			name: "not an occurrence",
+			ctx:  owner,
+			args: args{
+				args: domain.OccurrenceArgs{GatheringID: 1, RecurrenceID: start.Add(time.Hour), Scope: domain.OccurrenceScopeThis},
+			},
+			wantErr: domain.ErrNotFound,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Gathering{series}, nil},
+			},
+		},
+		{
+			name: "not recurring",
+			ctx:  owner,
+			args: args{
+				args: domain.OccurrenceArgs{GatheringID: 1, RecurrenceID: start, Scope: domain.OccurrenceScopeThis},
+			},
+			wantErr: domain.ErrValidation,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Gathering{single}, nil},
+			},
+		},
+		{
+			name: "not the creator",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			args: args{
+				args: domain.OccurrenceArgs{GatheringID: 1, RecurrenceID: start, Scope: domain.OccurrenceScopeThis},
+			},
+			wantErr: domain.ErrForbidden,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Gathering{series}, nil},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGathering := new(mocks.IGathering)
+			usecase := usecase.NewGatheringUsecase(usecase.GatheringUsecaseArgs{
+				GatheringRepository: mockGathering,
+			})
+			if tt.funcGet.Called {
+				mockGathering.On("Get", tt.funcGet.Input...).Return(tt.funcGet.Output...)
+			}
+			if tt.funcSaveOccurrence.Called {
+				mockGathering.On("SaveOccurrence", tt.funcSaveOccurrence.Input...).Return(tt.funcSaveOccurrence.Output...)
+			}
+			if tt.funcSplit.Called {
+				mockGathering.On("Split", tt.funcSplit.Input...).Return(tt.funcSplit.Output...)
+			}
+			if tt.funcUpdate.Called {
+				mockGathering.On("Update", tt.funcUpdate.Input...).Return(tt.funcUpdate.Output...)
+			}
+			got, err := usecase.UpdateOccurrence(tt.ctx, tt.args.args, tt.args.edit)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			}
+			mockGathering.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_gatheringUsecase_CancelOccurrence(t *testing.T) {
+	start := time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC)
+	series := domain.Gathering{
+		ID:          1,
+		CreatorID:   1,
+		Creator:     domain.Member{ID: 1},
+		ScheduledAt: start,
+		TimeZone:    domain.DefaultTimeZone,
+		Recurrence:  "FREQ=WEEKLY",
+		Name:        "standup",
+		Location:    "room 1",
+		Attendees:   []domain.Member{{ID: 1}, {ID: 2}},
+	}
+	owner := domain.ContextWithMember(context.Background(), domain.Member{ID: 1})
+	// the attendee is told, the creator who canceled is not
+	notified := func(event string, scope string) interface{} {
+		return mock.MatchedBy(func(n domain.Notification) bool {
+			return n.Event == event && n.MemberID == 2 && n.Data.ActorID == 1 && n.Data.Scope == scope
+		})
 	}
+	tests := []struct {
+		name               string
+		args               domain.OccurrenceArgs
+		funcSaveOccurrence helpers.TestFuncCall
+		funcUpdate         helpers.TestFuncCall
+		funcDelete         helpers.TestFuncCall
+	}{
+		{
+			name: "this occurrence",
+			args: domain.OccurrenceArgs{GatheringID: 1, RecurrenceID: start.AddDate(0, 0, 7), Scope: domain.OccurrenceScopeThis},
+			funcSaveOccurrence: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{
+					mock.Anything,
+					mock.MatchedBy(func(o domain.Occurrence) bool { return o.Canceled && o.RecurrenceID.Equal(start.AddDate(0, 0, 7)) }),
+					notified(domain.NotificationOccurrenceCanceled, ""),
+				},
+				Output: []interface{}{nil},
+			},
+		},
+		{
+			name: "this and following occurrences end the series",
+			args: domain.OccurrenceArgs{GatheringID: 1, RecurrenceID: start.AddDate(0, 0, 7), Scope: domain.OccurrenceScopeFollowing},
+			funcUpdate: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{
+					mock.Anything,
+					mock.MatchedBy(func(g domain.Gathering) bool { return g.Recurrence == "FREQ=WEEKLY;UNTIL=20231009T085959Z" }),
+					notified(domain.NotificationOccurrenceCanceled, domain.OccurrenceScopeFollowing),
+				},
+				Output: []interface{}{nil, nil},
+			},
+		},
+		{
+			name: "first and following occurrences delete the series",
+			args: domain.OccurrenceArgs{GatheringID: 1, RecurrenceID: start, Scope: domain.OccurrenceScopeFollowing},
+			funcDelete: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{ID: 1}, notified(domain.NotificationGatheringCanceled, "")},
+				Output: []interface{}{nil},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGathering := new(mocks.IGathering)
+			usecase := usecase.NewGatheringUsecase(usecase.GatheringUsecaseArgs{
+				GatheringRepository: mockGathering,
+			})
+			mockGathering.On("Get", mock.Anything, mock.Anything).Return([]domain.Gathering{series}, nil)
+			if tt.funcSaveOccurrence.Called {
+				mockGathering.On("SaveOccurrence", tt.funcSaveOccurrence.Input...).Return(tt.funcSaveOccurrence.Output...)
+			}
+			if tt.funcUpdate.Called {
+				mockGathering.On("Update", tt.funcUpdate.Input...).Return(tt.funcUpdate.Output...)
+			}
+			if tt.funcDelete.Called {
+				mockGathering.On("Delete", tt.funcDelete.Input...).Return(tt.funcDelete.Output...)
+			}
+			err := usecase.CancelOccurrence(owner, tt.args)
+			require.NoError(t, err)
+			mockGathering.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_gatheringUsecase_Join(t *testing.T) {
+	public := domain.Gathering{
+		ID:          1,
+		CreatorID:   1,
+		Type:        valueobject.PUBLIC,
+		ScheduledAt: time.Now().Add(24 * time.Hour),
+		Attendees:   []domain.Member{{ID: 1}},
+	}
+	private := public
+	private.Type = valueobject.PRIVATE
+	past := public
+	past.ScheduledAt = time.Date(2023, 10, 6, 5, 0, 0, 0, time.UTC)
+	overlapping := domain.Gathering{
+		ID:          2,
+		ScheduledAt: public.ScheduledAt.Add(30 * time.Minute),
+		Attendees:   []domain.Member{{ID: 2}},
+	}
+	ron := domain.ContextWithMember(context.Background(), domain.Member{ID: 2})
+	tests := []struct {
+		name              string
+		ctx               context.Context
+		isIgnoreConflicts bool
+		wantConflicts     int
+		wantErr           error
+		funcGet           helpers.TestFuncCall
+		funcGetAttended   helpers.TestFuncCall
+		funcJoin          helpers.TestFuncCall
+	}{
+		{
+			name: "success",
+			ctx:  ron,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 2}},
+				Output: []interface{}{[]domain.Gathering{public}, nil},
+			},
+			funcGetAttended: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{MemberIDs: []int64{2}}},
+				Output: []interface{}{[]domain.Gathering{}, nil},
+			},
+			funcJoin: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(1), int64(2)},
+				Output: []interface{}{nil},
+			},
+		},
+		{
+			name:    "schedule conflict",
+			ctx:     ron,
+			wantErr: domain.ErrConflict,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 2}},
+				Output: []interface{}{[]domain.Gathering{public}, nil},
+			},
+			funcGetAttended: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{MemberIDs: []int64{2}}},
+				Output: []interface{}{[]domain.Gathering{overlapping}, nil},
+			},
+		},
+		{
+			name:              "schedule conflict with force",
+			ctx:               ron,
+			isIgnoreConflicts: true,
+			wantConflicts:     1,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 2}},
+				Output: []interface{}{[]domain.Gathering{public}, nil},
+			},
+			funcGetAttended: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{MemberIDs: []int64{2}}},
+				Output: []interface{}{[]domain.Gathering{overlapping}, nil},
+			},
+			funcJoin: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(1), int64(2)},
+				Output: []interface{}{nil},
+			},
+		},
+		{
+			name:    "private gathering",
+			ctx:     ron,
+			wantErr: domain.ErrForbidden,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Gathering{private}, nil},
+			},
+		},
+		{
+			name:    "past gathering",
+			ctx:     ron,
+			wantErr: domain.ErrConflict,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Gathering{past}, nil},
+			},
+		},
+		{
+			name:    "already attending",
+			ctx:     domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			wantErr: domain.ErrConflict,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Gathering{public}, nil},
+			},
+		},
+		{
+			name:    "hidden or missing gathering",
+			ctx:     ron,
+			wantErr: domain.ErrNotFound,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Gathering{}, nil},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGathering := new(mocks.IGathering)
+			usecase := usecase.NewGatheringUsecase(usecase.GatheringUsecaseArgs{
+				GatheringRepository: mockGathering,
+			})
+			if tt.funcGet.Called {
+				mockGathering.On("Get", tt.funcGet.Input...).Return(tt.funcGet.Output...)
+			}
+			if tt.funcGetAttended.Called {
+				mockGathering.On("Get", tt.funcGetAttended.Input...).Return(tt.funcGetAttended.Output...)
+			}
+			if tt.funcJoin.Called {
+				mockGathering.On("Join", tt.funcJoin.Input...).Return(tt.funcJoin.Output...)
+			}
+			conflicts, err := usecase.Join(tt.ctx, 1, tt.isIgnoreConflicts)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				mockGathering.AssertNotCalled(t, "Join")
+			} else {
+				require.NoError(t, err)
+				require.Len(t, conflicts, tt.wantConflicts)
+			}
+			mockGathering.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_gatheringUsecase_Leave(t *testing.T) {
+	gathering := domain.Gathering{
+		ID:          1,
+		CreatorID:   1,
+		Type:        valueobject.PUBLIC,
+		ScheduledAt: time.Now().Add(24 * time.Hour),
+		Attendees:   []domain.Member{{ID: 1}, {ID: 2}},
+	}
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantErr   error
+		funcGet   helpers.TestFuncCall
+		funcLeave helpers.TestFuncCall
+	}{
+		{
+			name: "success",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Gathering{gathering}, nil},
+			},
+			funcLeave: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(1), int64(2)},
+				Output: []interface{}{nil, nil},
+			},
+		},
+		{
+			name:    "creator",
+			ctx:     domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			wantErr: domain.ErrConflict,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Gathering{gathering}, nil},
+			},
+		},
+		{
+			name:    "not attending",
+			ctx:     domain.ContextWithMember(context.Background(), domain.Member{ID: 3}),
+			wantErr: domain.ErrNotFound,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Gathering{gathering}, nil},
+			},
+		},
+		{
+			name:    "anonymous",
+			ctx:     context.Background(),
+			wantErr: domain.ErrUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGathering := new(mocks.IGathering)
+			usecase := usecase.NewGatheringUsecase(usecase.GatheringUsecaseArgs{
+				GatheringRepository: mockGathering,
+			})
+			if tt.funcGet.Called {
+				mockGathering.On("Get", tt.funcGet.Input...).Return(tt.funcGet.Output...)
+			}
+			if tt.funcLeave.Called {
+				mockGathering.On("Leave", tt.funcLeave.Input...).Return(tt.funcLeave.Output...)
+			}
+			err := usecase.Leave(tt.ctx, 1)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				mockGathering.AssertNotCalled(t, "Leave")
+			} else {
+				require.NoError(t, err)
+			}
+			mockGathering.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_gatheringUsecase_Leave_promoted(t *testing.T) {
+	gathering := domain.Gathering{
+		ID:          1,
+		CreatorID:   1,
+		Type:        valueobject.PUBLIC,
+		ScheduledAt: time.Now().Add(24 * time.Hour),
+		Attendees:   []domain.Member{{ID: 1}, {ID: 2}},
+	}
+	promoted := domain.Invitation{ID: 3, MemberID: 4, GatheringID: 1, Status: valueobject.INVITATION_ACCEPT}
+	mockGathering := new(mocks.IGathering)
+	mockInvitation := new(mocks.IInvitation)
+	mockEvents := new(mocks.IEventPublisher)
+	usecase := usecase.NewGatheringUsecase(usecase.GatheringUsecaseArgs{
+		GatheringRepository:  mockGathering,
+		InvitationRepository: mockInvitation,
+		Events:               mockEvents,
+	})
+	mockGathering.On("Get", mock.Anything, mock.Anything).Return([]domain.Gathering{gathering}, nil)
+	// the freed seat goes to the waitlisted invitation 3
+	mockGathering.On("Leave", mock.Anything, int64(1), int64(2)).Return([]int64{3}, nil)
+	mockInvitation.On("Get", mock.Anything, domain.InvitationArgs{IDs: []int64{3}}).Return([]domain.Invitation{promoted}, nil)
+	mockEvents.On("Publish", mock.Anything, []domain.Event{domain.AttendeeRemoved{Gathering: gathering, MemberID: 2}}).Return()
+	mockEvents.On("Publish", mock.Anything, []domain.Event{domain.InvitationAccepted{Invitation: promoted}}).Return()
+
+	err := usecase.Leave(domain.ContextWithMember(context.Background(), domain.Member{ID: 2}), 1)
+	require.NoError(t, err)
+	mockInvitation.AssertExpectations(t)
+	mockEvents.AssertExpectations(t)
 }
@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/application/policy"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+type (
+	groupUsecase struct {
+		groupRepository      repository.IGroup
+		memberRepository     repository.IMember
+		gatheringRepository  repository.IGathering
+		invitationRepository repository.IInvitation
+		events               IEventPublisher
+	}
+
+	GroupUsecaseArgs struct {
+		GroupRepository  repository.IGroup
+		MemberRepository repository.IMember
+		// GatheringRepository and InvitationRepository invite members who join to the upcoming gatherings of the group
+		GatheringRepository  repository.IGathering
+		InvitationRepository repository.IInvitation
+		// Events is told about the invitations of members who join, optional
+		Events IEventPublisher
+	}
+
+	IGroupUsecase interface {
+		Create(ctx context.Context, group domain.Group) (newGroup domain.Group, err error)
+		List(ctx context.Context, args domain.GroupArgs) (groups []domain.Group, page domain.Page, err error)
+		GetByID(ctx context.Context, id int64) (group domain.Group, err error)
+		Update(ctx context.Context, group domain.Group) (err error)
+		Delete(ctx context.Context, args domain.GroupArgs) (err error)
+		AddMember(ctx context.Context, member domain.GroupMember) (err error)
+		UpdateMember(ctx context.Context, member domain.GroupMember) (err error)
+		RemoveMember(ctx context.Context, member domain.GroupMember) (err error)
+	}
+)
+
+func NewGroupUsecase(args GroupUsecaseArgs) IGroupUsecase {
+	return &groupUsecase{
+		groupRepository:      args.GroupRepository,
+		memberRepository:     args.MemberRepository,
+		gatheringRepository:  args.GatheringRepository,
+		invitationRepository: args.InvitationRepository,
+		events:               args.Events,
+	}
+}
+
+// Create stores a group owned by the authenticated member, who is its first member
+func (u *groupUsecase) Create(ctx context.Context, group domain.Group) (newGroup domain.Group, err error) {
+	creator, ok := domain.MemberFromContext(ctx)
+	if !ok {
+		return newGroup, domain.NewError(domain.ErrUnauthorized, "authentication required")
+	}
+	group.Members = []domain.GroupMember{{MemberID: creator.ID, Role: valueobject.GROUP_OWNER}}
+	id, err := u.groupRepository.Create(ctx, group)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	newGroup, err = u.GetByID(ctx, id)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// List returns a page of groups, it fetches one extra row to know whether there is a next page
+func (u *groupUsecase) List(ctx context.Context, args domain.GroupArgs) (groups []domain.Group, page domain.Page, err error) {
+	limit := args.Limit
+	if limit > 0 {
+		args.Limit = limit + 1
+	}
+	groups, err = u.groupRepository.Get(ctx, args)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	page = domain.Page{Limit: limit, Offset: args.Offset}
+	if limit > 0 && len(groups) > limit {
+		groups = groups[:limit]
+		field, _ := args.SortField()
+		last := groups[limit-1]
+		page.NextCursor = domain.EncodeCursor(last.CursorValue(field), last.ID)
+	}
+	page.Total, err = u.groupRepository.Count(ctx, args)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+func (u *groupUsecase) GetByID(ctx context.Context, id int64) (group domain.Group, err error) {
+	groups, err := u.groupRepository.Get(ctx, domain.GroupArgs{IDs: []int64{id}})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(groups) == 0 {
+		err = domain.NewError(domain.ErrNotFound, "cannot find group")
+		return
+	}
+	group = groups[0]
+	return
+}
+
+// Update renames the group, only an owner
+func (u *groupUsecase) Update(ctx context.Context, group domain.Group) (err error) {
+	current, err := u.GetByID(ctx, group.ID)
+	if err != nil {
+		return
+	}
+	if err = policy.CanManageGroup(ctx, current); err != nil {
+		return
+	}
+	err = u.groupRepository.Update(ctx, group)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// Delete removes the group, only an owner. Invitations already sent to its members are kept.
+func (u *groupUsecase) Delete(ctx context.Context, args domain.GroupArgs) (err error) {
+	group, err := u.GetByID(ctx, args.ID)
+	if err != nil {
+		return
+	}
+	if err = policy.CanManageGroup(ctx, group); err != nil {
+		return
+	}
+	err = u.groupRepository.Delete(ctx, args)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// AddMember adds a member to the group, only an owner. The new member is invited to the upcoming gatherings
+// the group was invited to with auto invite.
+func (u *groupUsecase) AddMember(ctx context.Context, member domain.GroupMember) (err error) {
+	if err = member.Validate(); err != nil {
+		return
+	}
+	group, err := u.GetByID(ctx, member.GroupID)
+	if err != nil {
+		return
+	}
+	if err = policy.CanManageGroup(ctx, group); err != nil {
+		return
+	}
+	members, err := u.memberRepository.Get(ctx, domain.MemberArgs{IDs: []int64{member.MemberID}})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(members) == 0 {
+		return domain.NewError(domain.ErrNotFound, "cannot find member")
+	}
+	if err = u.groupRepository.AddMember(ctx, member); err != nil {
+		log.Println(err)
+		return
+	}
+	return u.inviteToUpcoming(ctx, group, member.MemberID)
+}
+
+// inviteToUpcoming invites a member who joined the group to its upcoming auto invite gatherings,
+// gatherings the member attends or was invited to before, whatever the invitation status, are skipped
+func (u *groupUsecase) inviteToUpcoming(ctx context.Context, group domain.Group, memberID int64) (err error) {
+	if len(group.GatheringIDs) == 0 {
+		return
+	}
+	gatherings, err := u.gatheringRepository.Get(ctx, domain.GatheringArgs{IDs: group.GatheringIDs})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	invitations, err := u.invitationRepository.Get(ctx, domain.InvitationArgs{MemberID: memberID})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	invited := map[int64]bool{}
+	for _, inv := range invitations {
+		invited[inv.GatheringID] = true
+	}
+	now := time.Now()
+	created := []domain.Invitation{}
+	notifications := []domain.Notification{}
+	for _, g := range gatherings {
+		if invited[g.ID] || !g.IsUpcoming(now) || isAttending(g, memberID) {
+			continue
+		}
+		created = append(created, domain.Invitation{
+			Member:    domain.Member{ID: memberID},
+			Gathering: g,
+			Status:    valueobject.INVITATION_CREATED,
+		})
+		data := g.NotificationData()
+		data.ActorID = g.CreatorID
+		notifications = append(notifications, domain.NewNotifications(domain.NotificationInvitationCreated, []int64{memberID}, data)...)
+	}
+	if len(created) == 0 {
+		return
+	}
+	ids, err := u.invitationRepository.CreateBatch(ctx, created, notifications...)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	publishInvitations(ctx, u.events, u.invitationRepository, ids)
+	return
+}
+
+// UpdateMember changes the role of a member, only an owner. The last owner cannot step down.
+func (u *groupUsecase) UpdateMember(ctx context.Context, member domain.GroupMember) (err error) {
+	if err = member.Validate(); err != nil {
+		return
+	}
+	group, err := u.GetByID(ctx, member.GroupID)
+	if err != nil {
+		return
+	}
+	if err = policy.CanManageGroup(ctx, group); err != nil {
+		return
+	}
+	current, ok := group.Member(member.MemberID)
+	if !ok {
+		return domain.NewError(domain.ErrNotFound, "the member is not in the group")
+	}
+	if current.Role == valueobject.GROUP_OWNER && member.Role != valueobject.GROUP_OWNER && group.Owners() == 1 {
+		return domain.NewError(domain.ErrConflict, "a group must keep an owner")
+	}
+	err = u.groupRepository.UpdateMember(ctx, member)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// RemoveMember removes a member from the group, an owner removes anyone and a member may leave.
+// The last owner cannot leave, the group is deleted instead.
+func (u *groupUsecase) RemoveMember(ctx context.Context, member domain.GroupMember) (err error) {
+	group, err := u.GetByID(ctx, member.GroupID)
+	if err != nil {
+		return
+	}
+	if err = policy.CanRemoveGroupMember(ctx, group, member.MemberID); err != nil {
+		return
+	}
+	if _, ok := group.Member(member.MemberID); !ok {
+		return domain.NewError(domain.ErrNotFound, "the member is not in the group")
+	}
+	if group.IsOwner(member.MemberID) && group.Owners() == 1 {
+		return domain.NewError(domain.ErrConflict, "a group must keep an owner")
+	}
+	err = u.groupRepository.RemoveMember(ctx, member)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+func isAttending(gathering domain.Gathering, memberID int64) bool {
+	for _, m := range gathering.Attendees {
+		if m.ID == memberID {
+			return true
+		}
+	}
+	return false
+}
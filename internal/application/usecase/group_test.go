@@ -0,0 +1,228 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+	"github.com/hieronimusbudi/simple-go-api/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_groupUsecase_Create(t *testing.T) {
+	linus := domain.ContextWithMember(context.Background(), domain.Member{ID: 1})
+	created := domain.Group{ID: 4, Name: "book club", Members: []domain.GroupMember{{GroupID: 4, MemberID: 1, Role: valueobject.GROUP_OWNER}}}
+	mockGroup := new(mocks.IGroup)
+	usecase := usecase.NewGroupUsecase(usecase.GroupUsecaseArgs{GroupRepository: mockGroup})
+	mockGroup.On("Create", mock.Anything, domain.Group{Name: "book club", Members: []domain.GroupMember{{MemberID: 1, Role: valueobject.GROUP_OWNER}}}).Return(int64(4), nil)
+	mockGroup.On("Get", mock.Anything, domain.GroupArgs{IDs: []int64{4}}).Return([]domain.Group{created}, nil)
+
+	group, err := usecase.Create(linus, domain.Group{Name: "book club"})
+	require.NoError(t, err)
+	require.Equal(t, created, group)
+	_, err = usecase.Create(context.Background(), domain.Group{Name: "book club"})
+	require.ErrorIs(t, err, domain.ErrUnauthorized)
+	mockGroup.AssertExpectations(t)
+}
+
+func Test_groupUsecase_AddMember(t *testing.T) {
+	upcoming := domain.Gathering{ID: 1, ScheduledAt: time.Now().Add(24 * time.Hour), Attendees: []domain.Member{{ID: 1}}}
+	past := domain.Gathering{ID: 2, ScheduledAt: time.Now().Add(-24 * time.Hour), Attendees: []domain.Member{{ID: 1}}}
+	invitedBefore := domain.Gathering{ID: 3, ScheduledAt: time.Now().Add(24 * time.Hour), Attendees: []domain.Member{{ID: 1}}}
+	group := domain.Group{
+		ID:           4,
+		Members:      []domain.GroupMember{{GroupID: 4, MemberID: 1, Role: valueobject.GROUP_OWNER}},
+		GatheringIDs: []int64{1, 2, 3},
+	}
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		group           domain.Group
+		members         []domain.Member
+		wantErr         error
+		funcAddMember   helpers.TestFuncCall
+		funcCreateBatch helpers.TestFuncCall
+	}{
+		{
+			name:    "joiner is invited to upcoming gatherings",
+			ctx:     domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			group:   group,
+			members: []domain.Member{{ID: 2}},
+			funcAddMember: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GroupMember{GroupID: 4, MemberID: 2, Role: valueobject.GROUP_MEMBER}},
+				Output: []interface{}{nil},
+			},
+			funcCreateBatch: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, []domain.Invitation{
+					{Member: domain.Member{ID: 2}, Gathering: upcoming, Status: valueobject.INVITATION_CREATED},
+				}, domain.NewNotifications(domain.NotificationInvitationCreated, []int64{2}, upcoming.NotificationData())[0]},
+				Output: []interface{}{[]int64{10}, nil},
+			},
+		},
+		{
+			name:    "group without auto invite gatherings",
+			ctx:     domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			group:   domain.Group{ID: 4, Members: group.Members},
+			members: []domain.Member{{ID: 2}},
+			funcAddMember: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{nil},
+			},
+		},
+		{
+			name:    "already in the group",
+			ctx:     domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			group:   group,
+			members: []domain.Member{{ID: 2}},
+			wantErr: domain.ErrConflict,
+			funcAddMember: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{domain.NewError(domain.ErrConflict, "the member is already in the group")},
+			},
+		},
+		{
+			name:    "member not found",
+			ctx:     domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			group:   group,
+			members: []domain.Member{},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name:    "not an owner",
+			ctx:     domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			group:   group,
+			wantErr: domain.ErrForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGroup := new(mocks.IGroup)
+			mockMember := new(mocks.IMember)
+			mockGathering := new(mocks.IGathering)
+			mockInvitation := new(mocks.IInvitation)
+			usecase := usecase.NewGroupUsecase(usecase.GroupUsecaseArgs{
+				GroupRepository:      mockGroup,
+				MemberRepository:     mockMember,
+				GatheringRepository:  mockGathering,
+				InvitationRepository: mockInvitation,
+			})
+			mockGroup.On("Get", mock.Anything, domain.GroupArgs{IDs: []int64{4}}).Return([]domain.Group{tt.group}, nil)
+			mockMember.On("Get", mock.Anything, domain.MemberArgs{IDs: []int64{2}}).Return(tt.members, nil)
+			mockGathering.On("Get", mock.Anything, domain.GatheringArgs{IDs: []int64{1, 2, 3}}).Return([]domain.Gathering{upcoming, past, invitedBefore}, nil)
+			mockInvitation.On("Get", mock.Anything, domain.InvitationArgs{MemberID: 2}).Return([]domain.Invitation{{ID: 7, MemberID: 2, GatheringID: 3, Status: valueobject.INVITATION_REJECT}}, nil)
+			if tt.funcAddMember.Called {
+				mockGroup.On("AddMember", tt.funcAddMember.Input...).Return(tt.funcAddMember.Output...)
+			}
+			if tt.funcCreateBatch.Called {
+				mockInvitation.On("CreateBatch", tt.funcCreateBatch.Input...).Return(tt.funcCreateBatch.Output...)
+			}
+			err := usecase.AddMember(tt.ctx, domain.GroupMember{GroupID: 4, MemberID: 2})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			mockGroup.AssertExpectations(t)
+			if tt.funcCreateBatch.Called {
+				mockInvitation.AssertExpectations(t)
+			} else {
+				mockInvitation.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func Test_groupUsecase_Members(t *testing.T) {
+	group := domain.Group{ID: 4, Members: []domain.GroupMember{
+		{GroupID: 4, MemberID: 1, Role: valueobject.GROUP_OWNER},
+		{GroupID: 4, MemberID: 2, Role: valueobject.GROUP_MEMBER},
+	}}
+	linus := domain.ContextWithMember(context.Background(), domain.Member{ID: 1})
+	ron := domain.ContextWithMember(context.Background(), domain.Member{ID: 2})
+	tests := []struct {
+		name    string
+		change  func(u usecase.IGroupUsecase) error
+		wantErr error
+	}{
+		{
+			name: "owner promotes a member",
+			change: func(u usecase.IGroupUsecase) error {
+				return u.UpdateMember(linus, domain.GroupMember{GroupID: 4, MemberID: 2, Role: valueobject.GROUP_OWNER})
+			},
+		},
+		{
+			name: "last owner cannot step down",
+			change: func(u usecase.IGroupUsecase) error {
+				return u.UpdateMember(linus, domain.GroupMember{GroupID: 4, MemberID: 1, Role: valueobject.GROUP_MEMBER})
+			},
+			wantErr: domain.ErrConflict,
+		},
+		{
+			name: "member cannot change roles",
+			change: func(u usecase.IGroupUsecase) error {
+				return u.UpdateMember(ron, domain.GroupMember{GroupID: 4, MemberID: 2, Role: valueobject.GROUP_OWNER})
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name: "unknown role",
+			change: func(u usecase.IGroupUsecase) error {
+				return u.UpdateMember(linus, domain.GroupMember{GroupID: 4, MemberID: 2, Role: "admin"})
+			},
+			wantErr: domain.ErrValidation,
+		},
+		{
+			name: "member leaves",
+			change: func(u usecase.IGroupUsecase) error {
+				return u.RemoveMember(ron, domain.GroupMember{GroupID: 4, MemberID: 2})
+			},
+		},
+		{
+			name: "last owner cannot leave",
+			change: func(u usecase.IGroupUsecase) error {
+				return u.RemoveMember(linus, domain.GroupMember{GroupID: 4, MemberID: 1})
+			},
+			wantErr: domain.ErrConflict,
+		},
+		{
+			name: "member cannot remove the owner",
+			change: func(u usecase.IGroupUsecase) error {
+				return u.RemoveMember(ron, domain.GroupMember{GroupID: 4, MemberID: 1})
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name: "not in the group",
+			change: func(u usecase.IGroupUsecase) error {
+				return u.RemoveMember(linus, domain.GroupMember{GroupID: 4, MemberID: 3})
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGroup := new(mocks.IGroup)
+			usecase := usecase.NewGroupUsecase(usecase.GroupUsecaseArgs{GroupRepository: mockGroup})
+			mockGroup.On("Get", mock.Anything, domain.GroupArgs{IDs: []int64{4}}).Return([]domain.Group{group}, nil)
+			mockGroup.On("UpdateMember", mock.Anything, mock.Anything).Return(nil)
+			mockGroup.On("RemoveMember", mock.Anything, mock.Anything).Return(nil)
+			err := tt.change(usecase)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				mockGroup.AssertNotCalled(t, "UpdateMember", mock.Anything, mock.Anything)
+				mockGroup.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -2,27 +2,45 @@ package usecase
 
 import (
 	"context"
-	"errors"
 	"log"
+	"strings"
 
+	"github.com/hieronimusbudi/simple-go-api/internal/application/policy"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
 )
 
 type (
 	invitationUsecase struct {
 		invitationRepository repository.IInvitation
+		gatheringRepository  repository.IGathering
+		memberRepository     repository.IMember
+		groupRepository      repository.IGroup
+		events               IEventPublisher
 	}
 
 	InvitationUsecaseArgs struct {
 		InvitationRepository repository.IInvitation
+		// GatheringRepository finds the gathering creator who is allowed to cancel invitations
+		GatheringRepository repository.IGathering
+		// MemberRepository finds the recipients of bulk invitations
+		MemberRepository repository.IMember
+		// GroupRepository expands a group into invitations of its members
+		GroupRepository repository.IGroup
+		// Events is told about created invitations and every status change, optional
+		Events IEventPublisher
 	}
 
 	IInvitationUsecase interface {
 		Create(ctx context.Context, invitation domain.Invitation) (NewInvitation domain.Invitation, err error)
+		CreateBatch(ctx context.Context, gatheringID int64, batch domain.InvitationBatch) (results []domain.InvitationBatchResult, err error)
+		InviteGroup(ctx context.Context, gatheringID int64, invitation domain.GroupInvitation) (results []domain.InvitationBatchResult, err error)
 		Get(ctx context.Context, args domain.InvitationArgs) (invitations []domain.Invitation, err error)
+		List(ctx context.Context, args domain.InvitationArgs) (invitations []domain.Invitation, page domain.Page, err error)
 		GetByID(ctx context.Context, id int64) (invitation domain.Invitation, err error)
-		Accept(ctx context.Context, args domain.InvitationArgs) (err error)
+		Accept(ctx context.Context, args domain.InvitationArgs) (conflicts []domain.ScheduleConflict, err error)
+		Tentative(ctx context.Context, args domain.InvitationArgs) (err error)
 		Reject(ctx context.Context, args domain.InvitationArgs) (err error)
 		Cancel(ctx context.Context, args domain.InvitationArgs) (err error)
 	}
@@ -31,11 +49,26 @@ type (
 func NewInvitationUsecase(args InvitationUsecaseArgs) IInvitationUsecase {
 	return &invitationUsecase{
 		invitationRepository: args.InvitationRepository,
+		gatheringRepository:  args.GatheringRepository,
+		memberRepository:     args.MemberRepository,
+		groupRepository:      args.GroupRepository,
+		events:               args.Events,
 	}
 }
 
+// Create stores the invitation of invitation.Member to invitation.Gathering and notifies the member, only the creator
 func (u *invitationUsecase) Create(ctx context.Context, invitation domain.Invitation) (NewInvitation domain.Invitation, err error) {
-	id, err := u.invitationRepository.Create(ctx, invitation)
+	gathering, err := u.managedGathering(ctx, invitation.Gathering.ID)
+	if err != nil {
+		return
+	}
+	invitation.Gathering = gathering
+	data := gathering.NotificationData()
+	if actor, ok := domain.MemberFromContext(ctx); ok {
+		data.ActorID = actor.ID
+	}
+	notifications := domain.NewNotifications(domain.NotificationInvitationCreated, []int64{invitation.Member.ID}, data)
+	id, err := u.invitationRepository.Create(ctx, invitation, notifications...)
 	if err != nil {
 		log.Println(err)
 		return
@@ -43,52 +76,373 @@ func (u *invitationUsecase) Create(ctx context.Context, invitation domain.Invita
 	NewInvitation, err = u.GetByID(ctx, id)
 	if err != nil {
 		log.Println(err)
+		return
 	}
+	publish(ctx, u.events, domain.NewInvitationEvent(NewInvitation))
 	return
 }
 
+// CreateBatch invites the members listed by ID or email to the gathering, only the creator. Recipients are found in one query,
+// members already invited, whatever the invitation status, or attending are skipped and the rest are invited in one transaction.
+func (u *invitationUsecase) CreateBatch(ctx context.Context, gatheringID int64, batch domain.InvitationBatch) (results []domain.InvitationBatchResult, err error) {
+	if err = batch.Validate(); err != nil {
+		return
+	}
+	gathering, err := u.managedGathering(ctx, gatheringID)
+	if err != nil {
+		return
+	}
+	return u.invite(ctx, gathering, batch)
+}
+
+// InviteGroup invites every member of the group to the gathering, only the creator who must be a member of the group.
+// Members are skipped and reported like bulk invitations. With auto invite, members who join the group later are invited
+// too while the gathering is upcoming.
+func (u *invitationUsecase) InviteGroup(ctx context.Context, gatheringID int64, invitation domain.GroupInvitation) (results []domain.InvitationBatchResult, err error) {
+	if err = invitation.Validate(); err != nil {
+		return
+	}
+	gathering, err := u.managedGathering(ctx, gatheringID)
+	if err != nil {
+		return
+	}
+	groups, err := u.groupRepository.Get(ctx, domain.GroupArgs{IDs: []int64{invitation.GroupID}})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(groups) == 0 {
+		return nil, domain.NewError(domain.ErrNotFound, "cannot find group")
+	}
+	group := groups[0]
+	if err = policy.CanInviteGroup(ctx, group); err != nil {
+		return
+	}
+	if results, err = u.invite(ctx, gathering, domain.InvitationBatch{MemberIDs: group.MemberIDs()}); err != nil {
+		return
+	}
+	if invitation.AutoInvite {
+		if err = u.groupRepository.AddGathering(ctx, group.ID, gathering.ID); err != nil {
+			log.Println(err)
+			return nil, err
+		}
+	}
+	return
+}
+
+// managedGathering finds a gathering the authenticated member may invite to
+func (u *invitationUsecase) managedGathering(ctx context.Context, id int64) (gathering domain.Gathering, err error) {
+	gatherings, err := u.gatheringRepository.Get(ctx, domain.GatheringArgs{IDs: []int64{id}})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(gatherings) == 0 {
+		return gathering, domain.NewError(domain.ErrNotFound, "cannot find gathering")
+	}
+	gathering = gatherings[0]
+	err = policy.CanManageGathering(ctx, gathering)
+	return
+}
+
+// invite creates the invitations of a checked batch, recipients are reported in the order of the batch
+func (u *invitationUsecase) invite(ctx context.Context, gathering domain.Gathering, batch domain.InvitationBatch) (results []domain.InvitationBatchResult, err error) {
+	members, err := u.memberRepository.Get(ctx, domain.MemberArgs{AnyIDs: batch.MemberIDs, AnyEmails: batch.Emails})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	invitations, err := u.invitationRepository.Get(ctx, domain.InvitationArgs{GatheringID: gathering.ID})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	membersByID := map[int64]domain.Member{}
+	membersByEmail := map[string]domain.Member{}
+	for _, m := range members {
+		membersByID[m.ID] = m
+		membersByEmail[strings.ToLower(m.Email)] = m
+	}
+	invitationIDs := map[int64]int64{}
+	for _, inv := range invitations {
+		invitationIDs[inv.MemberID] = inv.ID
+	}
+	attending := map[int64]bool{}
+	for _, m := range gathering.Attendees {
+		attending[m.ID] = true
+	}
+
+	results = []domain.InvitationBatchResult{}
+	listed := map[int64]bool{}
+	add := func(result domain.InvitationBatchResult, member domain.Member, found bool) {
+		switch {
+		case !found:
+			result.Status = domain.BatchInvitationNotFound
+		case listed[member.ID]:
+			result.Status = domain.BatchInvitationDuplicate
+		case attending[member.ID]:
+			result.Status = domain.BatchInvitationAlreadyAttending
+		case invitationIDs[member.ID] > 0:
+			result.Status = domain.BatchInvitationAlreadyInvited
+			result.InvitationID = invitationIDs[member.ID]
+		default:
+			result.Status = domain.BatchInvitationInvited
+		}
+		if found {
+			result.MemberID = member.ID
+			listed[member.ID] = true
+		}
+		results = append(results, result)
+	}
+	for _, id := range batch.MemberIDs {
+		member, found := membersByID[id]
+		add(domain.InvitationBatchResult{MemberID: id}, member, found)
+	}
+	for _, email := range batch.Emails {
+		member, found := membersByEmail[strings.ToLower(email)]
+		add(domain.InvitationBatchResult{Email: email}, member, found)
+	}
+
+	created := []domain.Invitation{}
+	invitedIDs := []int64{}
+	for _, result := range results {
+		if result.Status == domain.BatchInvitationInvited {
+			created = append(created, domain.Invitation{
+				Member:    domain.Member{ID: result.MemberID},
+				Gathering: gathering,
+				Status:    valueobject.INVITATION_CREATED,
+			})
+			invitedIDs = append(invitedIDs, result.MemberID)
+		}
+	}
+	if len(created) == 0 {
+		return
+	}
+	data := gathering.NotificationData()
+	data.ActorID = gathering.CreatorID
+	notifications := domain.NewNotifications(domain.NotificationInvitationCreated, invitedIDs, data)
+	ids, err := u.invitationRepository.CreateBatch(ctx, created, notifications...)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	publishInvitations(ctx, u.events, u.invitationRepository, ids)
+	for i := range results {
+		if results[i].Status == domain.BatchInvitationInvited {
+			results[i].InvitationID, ids = ids[0], ids[1:]
+		}
+	}
+	return
+}
+
+// Get returns the invitations of gatherings the authenticated member can see, see visibleInvitations
 func (u *invitationUsecase) Get(ctx context.Context, args domain.InvitationArgs) (invitations []domain.Invitation, err error) {
+	invitations, err = u.invitationRepository.Get(ctx, visibleInvitations(ctx, args))
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// List returns a page of invitations, it fetches one extra row to know whether there is a next page
+func (u *invitationUsecase) List(ctx context.Context, args domain.InvitationArgs) (invitations []domain.Invitation, page domain.Page, err error) {
+	args = visibleInvitations(ctx, args)
+	limit := args.Limit
+	if limit > 0 {
+		args.Limit = limit + 1
+	}
 	invitations, err = u.invitationRepository.Get(ctx, args)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	page = domain.Page{Limit: limit, Offset: args.Offset}
+	if limit > 0 && len(invitations) > limit {
+		invitations = invitations[:limit]
+		field, _ := args.SortField()
+		last := invitations[limit-1]
+		page.NextCursor = domain.EncodeCursor(last.CursorValue(field), last.ID)
+	}
+	page.Total, err = u.invitationRepository.Count(ctx, args)
 	if err != nil {
 		log.Println(err)
 	}
 	return
 }
 
+// GetByID returns an invitation the authenticated member can see, one of a private gathering hidden from them is not found
 func (u *invitationUsecase) GetByID(ctx context.Context, id int64) (invitation domain.Invitation, err error) {
-	invitations, err := u.invitationRepository.Get(ctx, domain.InvitationArgs{IDs: []int64{id}})
+	invitations, err := u.invitationRepository.Get(ctx, visibleInvitations(ctx, domain.InvitationArgs{IDs: []int64{id}}))
 	if err != nil {
 		log.Println(err)
 		return
 	}
 	if len(invitations) == 0 {
-		err = errors.New("cannot find invitation")
+		err = domain.NewError(domain.ErrNotFound, "cannot find invitation")
 		return
 	}
 	invitation = invitations[0]
 	return
 }
 
-func (u *invitationUsecase) Accept(ctx context.Context, args domain.InvitationArgs) (err error) {
-	err = u.invitationRepository.UpdateStatus(ctx, args)
+// visibleInvitations limits args to invitations of public gatherings and the private ones the authenticated member
+// created, attends or is invited to, like visible does for gatherings
+func visibleInvitations(ctx context.Context, args domain.InvitationArgs) domain.InvitationArgs {
+	args.IsVisibleOnly = true
+	if member, ok := domain.MemberFromContext(ctx); ok {
+		args.ViewerID = member.ID
+	}
+	return args
+}
+
+// Accept moves the invitation to accepted with the guests and note of args.Response, only args.ID, args.Response and
+// args.IsIgnoreConflicts are read. A gathering overlapping one the member attends is refused with a domain.ScheduleConflictError,
+// or accepted and its conflicts returned when args.IsIgnoreConflicts is set.
+func (u *invitationUsecase) Accept(ctx context.Context, args domain.InvitationArgs) (conflicts []domain.ScheduleConflict, err error) {
+	invitation, err := u.respondable(ctx, args.ID, args.Response)
+	if err != nil {
+		return
+	}
+	// a closed invitation is reported before any conflict
+	next := invitation
+	if err = next.Transition(valueobject.INVITATION_ACCEPT); err != nil {
+		return
+	}
+	gatherings, err := u.gatheringRepository.Get(ctx, domain.GatheringArgs{IDs: []int64{invitation.GatheringID}})
 	if err != nil {
 		log.Println(err)
+		return
+	}
+	if len(gatherings) == 0 {
+		return nil, domain.NewError(domain.ErrNotFound, "cannot find gathering")
 	}
+	conflicts, err = checkSchedule(ctx, u.gatheringRepository, gatherings[0], []int64{invitation.MemberID}, args.IsIgnoreConflicts)
+	if err != nil {
+		return
+	}
+	err = u.updateStatus(ctx, invitation, gatherings[0], valueobject.INVITATION_ACCEPT, args.Response)
 	return
 }
 
+// Tentative moves the invitation to tentative with the guests and note of args.Response, only args.ID and args.Response are read
+func (u *invitationUsecase) Tentative(ctx context.Context, args domain.InvitationArgs) (err error) {
+	return u.respond(ctx, args.ID, valueobject.INVITATION_TENTATIVE, args.Response)
+}
+
+// Reject moves the invitation to rejected with the note of args.Response, only args.ID and args.Response are read
 func (u *invitationUsecase) Reject(ctx context.Context, args domain.InvitationArgs) (err error) {
-	err = u.invitationRepository.UpdateStatus(ctx, args)
+	// nobody comes along with a member who does not come
+	args.Response.Guests = 0
+	return u.respond(ctx, args.ID, valueobject.INVITATION_REJECT, args.Response)
+}
+
+// respond stores the answer of the invited member
+func (u *invitationUsecase) respond(ctx context.Context, id int64, to valueobject.InvitationStatus, response domain.InvitationResponse) (err error) {
+	invitation, err := u.respondable(ctx, id, response)
+	if err != nil {
+		return
+	}
+	gatherings, err := u.gatheringRepository.Get(ctx, domain.GatheringArgs{IDs: []int64{invitation.GatheringID}, IsIncludeDiscard: true})
 	if err != nil {
 		log.Println(err)
+		return
+	}
+	if len(gatherings) == 0 {
+		return domain.NewError(domain.ErrNotFound, "cannot find gathering")
 	}
+	return u.updateStatus(ctx, invitation, gatherings[0], to, response)
+}
+
+// respondable finds an invitation the authenticated member may answer with response
+func (u *invitationUsecase) respondable(ctx context.Context, id int64, response domain.InvitationResponse) (invitation domain.Invitation, err error) {
+	if err = response.Validate(); err != nil {
+		return
+	}
+	invitation, err = u.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	err = policy.CanRespondInvitation(ctx, invitation)
 	return
 }
 
+// Cancel moves the invitation to canceled, only args.ID is read
 func (u *invitationUsecase) Cancel(ctx context.Context, args domain.InvitationArgs) (err error) {
-	err = u.invitationRepository.UpdateStatus(ctx, args)
+	invitation, err := u.GetByID(ctx, args.ID)
+	if err != nil {
+		return
+	}
+	gatherings, err := u.gatheringRepository.Get(ctx, domain.GatheringArgs{IDs: []int64{invitation.GatheringID}, IsIncludeDiscard: true})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(gatherings) == 0 {
+		return domain.NewError(domain.ErrNotFound, "cannot find gathering")
+	}
+	if err = policy.CanManageGathering(ctx, gatherings[0]); err != nil {
+		return
+	}
+	// the member's answer is kept
+	return u.updateStatus(ctx, invitation, gatherings[0], valueobject.INVITATION_CANCELED, domain.InvitationResponse{Guests: invitation.Guests, Note: invitation.Note})
+}
+
+// updateStatus stores the new status and the response when the invitation state machine allows it,
+// along with a notification to the creator of an answer or to the member of a cancellation
+func (u *invitationUsecase) updateStatus(ctx context.Context, invitation domain.Invitation, gathering domain.Gathering, to valueobject.InvitationStatus, response domain.InvitationResponse) (err error) {
+	if err = invitation.Transition(to); err != nil {
+		return
+	}
+	data := gathering.NotificationData()
+	data.InvitationID = invitation.ID
+	data.Note = response.Note
+	var notifications []domain.Notification
+	switch invitation.Status {
+	case valueobject.INVITATION_ACCEPT, valueobject.INVITATION_WAITLISTED:
+		data.ActorID = invitation.MemberID
+		notifications = domain.NewNotifications(domain.NotificationInvitationAccepted, []int64{gathering.CreatorID}, data)
+	case valueobject.INVITATION_TENTATIVE:
+		data.ActorID = invitation.MemberID
+		notifications = domain.NewNotifications(domain.NotificationInvitationTentative, []int64{gathering.CreatorID}, data)
+	case valueobject.INVITATION_REJECT:
+		data.ActorID = invitation.MemberID
+		notifications = domain.NewNotifications(domain.NotificationInvitationRejected, []int64{gathering.CreatorID}, data)
+	case valueobject.INVITATION_CANCELED:
+		data.ActorID = gathering.CreatorID
+		notifications = domain.NewNotifications(domain.NotificationInvitationCanceled, []int64{invitation.MemberID}, data)
+	}
+	promotedIDs, err := u.invitationRepository.UpdateStatus(ctx, domain.InvitationArgs{
+		ID:          invitation.ID,
+		MemberID:    invitation.MemberID,
+		GatheringID: invitation.GatheringID,
+		Status:      invitation.Status,
+		Response:    response,
+	}, notifications...)
 	if err != nil {
 		log.Println(err)
+		return
 	}
+	// an accept may end up waitlisted, the event is named after the stored status. Invitations the answer freed seats
+	// for are told about as accepted
+	publishInvitations(ctx, u.events, u.invitationRepository, append([]int64{invitation.ID}, promotedIDs...))
 	return
 }
+
+// publishInvitations tells subscribers about invitations as they are stored now, the event of each follows its status
+func publishInvitations(ctx context.Context, publisher IEventPublisher, invitationRepository repository.IInvitation, ids []int64) {
+	if publisher == nil || invitationRepository == nil || len(ids) == 0 {
+		return
+	}
+	invitations, err := invitationRepository.Get(ctx, domain.InvitationArgs{IDs: ids})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	events := []domain.Event{}
+	for _, invitation := range invitations {
+		if event := domain.NewInvitationEvent(invitation); event != nil {
+			events = append(events, event)
+		}
+	}
+	publish(ctx, publisher, events...)
+}
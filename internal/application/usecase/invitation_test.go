@@ -3,6 +3,7 @@ package usecase_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain"
@@ -14,6 +15,7 @@ import (
 )
 
 func Test_invitationUsecase_Create(t *testing.T) {
+	gathering := domain.Gathering{ID: 2, CreatorID: 1, Creator: domain.Member{ID: 1}}
 	invitation := domain.Invitation{
 		Member: domain.Member{
 			ID: 2,
@@ -24,26 +26,34 @@ func Test_invitationUsecase_Create(t *testing.T) {
 	}
 	wantNewInvitation := invitation
 	wantNewInvitation.ID = 1
+	wantNewInvitation.Gathering = gathering
 	type args struct {
 		invitation domain.Invitation
 	}
 	tests := []struct {
 		name              string
+		ctx               context.Context
 		args              args
 		wantNewInvitation domain.Invitation
-		wantErr           bool
+		wantErr           error
 		funcCreate        helpers.TestFuncCall
 		funcGet           helpers.TestFuncCall
 	}{
 		{
 			name: "success",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
 			args: args{
 				invitation: invitation,
 			},
 			wantNewInvitation: wantNewInvitation,
 			funcCreate: helpers.TestFuncCall{
 				Called: true,
-				Input:  []interface{}{mock.Anything, mock.Anything},
+				Input: []interface{}{mock.Anything, mock.Anything, domain.Notification{
+					Event:    domain.NotificationInvitationCreated,
+					MemberID: 2,
+					Data:     domain.NotificationData{GatheringID: 2, ActorID: 1},
+					Status:   valueobject.NOTIFICATION_PENDING,
+				}},
 				Output: []interface{}{wantNewInvitation.ID, nil},
 			},
 			funcGet: helpers.TestFuncCall{
@@ -52,26 +62,264 @@ func Test_invitationUsecase_Create(t *testing.T) {
 				Output: []interface{}{[]domain.Invitation{wantNewInvitation}, nil},
 			},
 		},
+		{
+			name:    "not the creator",
+			ctx:     domain.ContextWithMember(context.Background(), domain.Member{ID: 3}),
+			args:    args{invitation: invitation},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name:    "anonymous",
+			ctx:     context.Background(),
+			args:    args{invitation: invitation},
+			wantErr: domain.ErrUnauthorized,
+		},
+		{
+			name: "gathering not found",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			args: args{invitation: domain.Invitation{
+				Member:    domain.Member{ID: 2},
+				Gathering: domain.Gathering{ID: 9},
+			}},
+			wantErr: domain.ErrNotFound,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockInvitation := new(mocks.IInvitation)
+			mockGathering := new(mocks.IGathering)
 			usecase := usecase.NewInvitationUsecase(usecase.InvitationUsecaseArgs{
 				InvitationRepository: mockInvitation,
+				GatheringRepository:  mockGathering,
 			})
+			mockGathering.On("Get", mock.Anything, domain.GatheringArgs{IDs: []int64{2}}).Return([]domain.Gathering{gathering}, nil)
+			mockGathering.On("Get", mock.Anything, domain.GatheringArgs{IDs: []int64{9}}).Return([]domain.Gathering{}, nil)
 			if tt.funcCreate.Called {
 				mockInvitation.On("Create", tt.funcCreate.Input...).Return(tt.funcCreate.Output...)
 			}
 			if tt.funcGet.Called {
 				mockInvitation.On("Get", tt.funcGet.Input...).Return(tt.funcGet.Output...)
 			}
-			gotNewInvitation, err := usecase.Create(context.Background(), tt.args.invitation)
-			if tt.wantErr {
-				require.Error(t, err)
+			gotNewInvitation, err := usecase.Create(tt.ctx, tt.args.invitation)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
 			} else {
 				require.NoError(t, err)
 				require.Equal(t, tt.wantNewInvitation, gotNewInvitation)
 			}
+			mockInvitation.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_invitationUsecase_CreateBatch(t *testing.T) {
+	gathering := domain.Gathering{ID: 1, CreatorID: 1, Creator: domain.Member{ID: 1}, Attendees: []domain.Member{{ID: 1}, {ID: 3}}}
+	batch := domain.InvitationBatch{
+		MemberIDs: []int64{2, 3, 4, 99},
+		Emails:    []string{"ken@mail.com", "DENNIS@mail.com", "nobody@mail.com"},
+	}
+	members := []domain.Member{
+		{ID: 2, Email: "ron@mail.com"},
+		{ID: 3, Email: "rob@mail.com"},
+		{ID: 4, Email: "ken@mail.com"},
+		{ID: 5, Email: "dennis@mail.com"},
+	}
+	invited := domain.NewNotifications(domain.NotificationInvitationCreated, []int64{4, 5}, domain.NotificationData{GatheringID: 1, ActorID: 1})
+	tests := []struct {
+		name              string
+		ctx               context.Context
+		wantResults       []domain.InvitationBatchResult
+		wantErr           error
+		funcGetMember     helpers.TestFuncCall
+		funcGetInvitation helpers.TestFuncCall
+		funcCreateBatch   helpers.TestFuncCall
+	}{
+		{
+			name: "success",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			wantResults: []domain.InvitationBatchResult{
+				{MemberID: 2, Status: domain.BatchInvitationAlreadyInvited, InvitationID: 7},
+				{MemberID: 3, Status: domain.BatchInvitationAlreadyAttending},
+				{MemberID: 4, Status: domain.BatchInvitationInvited, InvitationID: 10},
+				{MemberID: 99, Status: domain.BatchInvitationNotFound},
+				{MemberID: 4, Email: "ken@mail.com", Status: domain.BatchInvitationDuplicate},
+				{MemberID: 5, Email: "DENNIS@mail.com", Status: domain.BatchInvitationInvited, InvitationID: 11},
+				{Email: "nobody@mail.com", Status: domain.BatchInvitationNotFound},
+			},
+			funcGetMember: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.MemberArgs{AnyIDs: batch.MemberIDs, AnyEmails: batch.Emails}},
+				Output: []interface{}{members, nil},
+			},
+			funcGetInvitation: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{GatheringID: 1}},
+				Output: []interface{}{[]domain.Invitation{{ID: 7, MemberID: 2, GatheringID: 1, Status: valueobject.INVITATION_REJECT}}, nil},
+			},
+			funcCreateBatch: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, []domain.Invitation{
+					{Member: domain.Member{ID: 4}, Gathering: gathering, Status: valueobject.INVITATION_CREATED},
+					{Member: domain.Member{ID: 5}, Gathering: gathering, Status: valueobject.INVITATION_CREATED},
+				}, invited[0], invited[1]},
+				Output: []interface{}{[]int64{10, 11}, nil},
+			},
+		},
+		{
+			name:    "not the creator",
+			ctx:     domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name:    "error on insert",
+			ctx:     domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			wantErr: domain.ErrValidation,
+			funcGetMember: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{members, nil},
+			},
+			funcGetInvitation: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Invitation{}, nil},
+			},
+			funcCreateBatch: helpers.TestFuncCall{
+				Called: true,
+				// members 2, 4 and 5 are invited
+				Input:  []interface{}{mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything},
+				Output: []interface{}{[]int64(nil), domain.NewError(domain.ErrValidation, "referenced member or gathering does not exist")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInvitation := new(mocks.IInvitation)
+			mockGathering := new(mocks.IGathering)
+			mockMember := new(mocks.IMember)
+			usecase := usecase.NewInvitationUsecase(usecase.InvitationUsecaseArgs{
+				InvitationRepository: mockInvitation,
+				GatheringRepository:  mockGathering,
+				MemberRepository:     mockMember,
+			})
+			mockGathering.On("Get", mock.Anything, domain.GatheringArgs{IDs: []int64{1}}).Return([]domain.Gathering{gathering}, nil)
+			if tt.funcGetMember.Called {
+				mockMember.On("Get", tt.funcGetMember.Input...).Return(tt.funcGetMember.Output...)
+			}
+			if tt.funcGetInvitation.Called {
+				mockInvitation.On("Get", tt.funcGetInvitation.Input...).Return(tt.funcGetInvitation.Output...)
+			}
+			if tt.funcCreateBatch.Called {
+				mockInvitation.On("CreateBatch", tt.funcCreateBatch.Input...).Return(tt.funcCreateBatch.Output...)
+			}
+			gotResults, err := usecase.CreateBatch(tt.ctx, 1, batch)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantResults, gotResults)
+			}
+			mockInvitation.AssertExpectations(t)
+			mockMember.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_invitationUsecase_InviteGroup(t *testing.T) {
+	gathering := domain.Gathering{ID: 1, CreatorID: 1, Creator: domain.Member{ID: 1}, Attendees: []domain.Member{{ID: 1}}}
+	group := domain.Group{ID: 4, Members: []domain.GroupMember{
+		{GroupID: 4, MemberID: 1, Role: valueobject.GROUP_OWNER},
+		{GroupID: 4, MemberID: 2, Role: valueobject.GROUP_MEMBER},
+	}}
+	tests := []struct {
+		name             string
+		ctx              context.Context
+		invitation       domain.GroupInvitation
+		groups           []domain.Group
+		wantResults      []domain.InvitationBatchResult
+		wantErr          error
+		funcAddGathering helpers.TestFuncCall
+	}{
+		{
+			name:       "group members are invited",
+			ctx:        domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			invitation: domain.GroupInvitation{GroupID: 4},
+			groups:     []domain.Group{group},
+			wantResults: []domain.InvitationBatchResult{
+				{MemberID: 1, Status: domain.BatchInvitationAlreadyAttending},
+				{MemberID: 2, Status: domain.BatchInvitationInvited, InvitationID: 10},
+			},
+		},
+		{
+			name:       "auto invite links the gathering",
+			ctx:        domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			invitation: domain.GroupInvitation{GroupID: 4, AutoInvite: true},
+			groups:     []domain.Group{group},
+			wantResults: []domain.InvitationBatchResult{
+				{MemberID: 1, Status: domain.BatchInvitationAlreadyAttending},
+				{MemberID: 2, Status: domain.BatchInvitationInvited, InvitationID: 10},
+			},
+			funcAddGathering: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, int64(4), int64(1)},
+				Output: []interface{}{nil},
+			},
+		},
+		{
+			name:       "group not found",
+			ctx:        domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			invitation: domain.GroupInvitation{GroupID: 4},
+			groups:     []domain.Group{},
+			wantErr:    domain.ErrNotFound,
+		},
+		{
+			name:       "creator outside the group",
+			ctx:        domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			invitation: domain.GroupInvitation{GroupID: 4},
+			groups:     []domain.Group{{ID: 4, Members: []domain.GroupMember{{GroupID: 4, MemberID: 2, Role: valueobject.GROUP_OWNER}}}},
+			wantErr:    domain.ErrForbidden,
+		},
+		{
+			name:       "no group",
+			ctx:        domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			invitation: domain.GroupInvitation{},
+			wantErr:    domain.ErrValidation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInvitation := new(mocks.IInvitation)
+			mockGathering := new(mocks.IGathering)
+			mockMember := new(mocks.IMember)
+			mockGroup := new(mocks.IGroup)
+			usecase := usecase.NewInvitationUsecase(usecase.InvitationUsecaseArgs{
+				InvitationRepository: mockInvitation,
+				GatheringRepository:  mockGathering,
+				MemberRepository:     mockMember,
+				GroupRepository:      mockGroup,
+			})
+			mockGathering.On("Get", mock.Anything, domain.GatheringArgs{IDs: []int64{1}}).Return([]domain.Gathering{gathering}, nil)
+			if tt.groups != nil {
+				mockGroup.On("Get", mock.Anything, domain.GroupArgs{IDs: []int64{4}}).Return(tt.groups, nil)
+			}
+			mockMember.On("Get", mock.Anything, domain.MemberArgs{AnyIDs: []int64{1, 2}}).Return([]domain.Member{{ID: 1}, {ID: 2}}, nil)
+			mockInvitation.On("Get", mock.Anything, domain.InvitationArgs{GatheringID: 1}).Return([]domain.Invitation{}, nil)
+			mockInvitation.On("CreateBatch", mock.Anything, []domain.Invitation{
+				{Member: domain.Member{ID: 2}, Gathering: gathering, Status: valueobject.INVITATION_CREATED},
+			}, mock.Anything).Return([]int64{10}, nil)
+			if tt.funcAddGathering.Called {
+				mockGroup.On("AddGathering", tt.funcAddGathering.Input...).Return(tt.funcAddGathering.Output...)
+			}
+			gotResults, err := usecase.InviteGroup(tt.ctx, 1, tt.invitation)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				mockInvitation.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantResults, gotResults)
+				mockInvitation.AssertExpectations(t)
+			}
+			mockGroup.AssertExpectations(t)
 		})
 	}
 }
@@ -97,6 +345,7 @@ func Test_invitationUsecase_Get(t *testing.T) {
 	}
 	tests := []struct {
 		name            string
+		ctx             context.Context
 		args            args
 		wantInvitations []domain.Invitation
 		wantErr         bool
@@ -104,13 +353,26 @@ func Test_invitationUsecase_Get(t *testing.T) {
 	}{
 		{
 			name:            "success",
+			ctx:             domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			args:            args{domain.InvitationArgs{GatheringID: 1}},
 			wantInvitations: invitations,
 			funcGet: helpers.TestFuncCall{
 				Called: true,
-				Input:  []interface{}{mock.Anything, mock.Anything},
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{GatheringID: 1, IsVisibleOnly: true, ViewerID: 2}},
 				Output: []interface{}{invitations, nil},
 			},
 		},
+		{
+			name:            "anonymous sees invitations of public gatherings only",
+			ctx:             context.Background(),
+			args:            args{domain.InvitationArgs{GatheringID: 1}},
+			wantInvitations: []domain.Invitation{},
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{GatheringID: 1, IsVisibleOnly: true}},
+				Output: []interface{}{[]domain.Invitation{}, nil},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -121,7 +383,7 @@ func Test_invitationUsecase_Get(t *testing.T) {
 			if tt.funcGet.Called {
 				mockInvitation.On("Get", tt.funcGet.Input...).Return(tt.funcGet.Output...)
 			}
-			gotInvitations, err := usecase.Get(context.Background(), tt.args.args)
+			gotInvitations, err := usecase.Get(tt.ctx, tt.args.args)
 			if tt.wantErr {
 				require.Error(t, err)
 			} else {
@@ -192,124 +454,502 @@ func Test_invitationUsecase_GetByID(t *testing.T) {
 }
 
 func Test_invitationUsecase_Accept(t *testing.T) {
+	invitation := domain.Invitation{ID: 1, MemberID: 2, GatheringID: 1}
+	gathering := domain.Gathering{ID: 1, CreatorID: 1, ScheduledAt: time.Now().Add(24 * time.Hour)}
+	overlapping := domain.Gathering{ID: 2, ScheduledAt: gathering.ScheduledAt, Attendees: []domain.Member{{ID: 2}}}
+	getGathering := helpers.TestFuncCall{
+		Called: true,
+		Input:  []interface{}{mock.Anything, domain.GatheringArgs{IDs: []int64{1}}},
+		Output: []interface{}{[]domain.Gathering{gathering}, nil},
+	}
+	getAttended := helpers.TestFuncCall{
+		Called: true,
+		Input:  []interface{}{mock.Anything, domain.GatheringArgs{MemberIDs: []int64{2}}},
+		Output: []interface{}{[]domain.Gathering{}, nil},
+	}
+	getOverlapping := getAttended
+	getOverlapping.Output = []interface{}{[]domain.Gathering{overlapping}, nil}
 	type args struct {
 		args domain.InvitationArgs
 	}
 	tests := []struct {
 		name             string
+		ctx              context.Context
 		args             args
-		wantErr          bool
+		wantConflicts    int
+		wantErr          error
+		funcGet          helpers.TestFuncCall
+		funcGetGathering helpers.TestFuncCall
+		funcGetAttended  helpers.TestFuncCall
 		funcUpdateStatus helpers.TestFuncCall
 	}{
 		{
 			name: "success",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			args: args{domain.InvitationArgs{
+				ID:     int64(1),
+				Status: valueobject.INVITATION_ACCEPT,
+			}},
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 2}},
+				Output: []interface{}{[]domain.Invitation{invitation}, nil},
+			},
+			funcGetGathering: getGathering,
+			funcGetAttended:  getAttended,
+			funcUpdateStatus: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{ID: 1, MemberID: 2, GatheringID: 1, Status: valueobject.INVITATION_ACCEPT}, mock.Anything},
+				Output: []interface{}{nil, nil},
+			},
+		},
+		{
+			name: "with guests and note",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			args: args{domain.InvitationArgs{
+				ID:       int64(1),
+				Response: domain.InvitationResponse{Guests: 2, Note: "with my kids"},
+			}},
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 2}},
+				Output: []interface{}{[]domain.Invitation{invitation}, nil},
+			},
+			funcGetGathering: getGathering,
+			funcGetAttended:  getAttended,
+			funcUpdateStatus: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, domain.InvitationArgs{
+					ID:          1,
+					MemberID:    2,
+					GatheringID: 1,
+					Status:      valueobject.INVITATION_ACCEPT,
+					Response:    domain.InvitationResponse{Guests: 2, Note: "with my kids"},
+				}, domain.Notification{
+					Event:    domain.NotificationInvitationAccepted,
+					MemberID: 1,
+					Data:     domain.NotificationData{GatheringID: 1, ScheduledAt: gathering.ScheduledAt, InvitationID: 1, ActorID: 2, Note: "with my kids"},
+					Status:   valueobject.NOTIFICATION_PENDING,
+				}},
+				Output: []interface{}{nil, nil},
+			},
+		},
+		{
+			name: "schedule conflict",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
 			args: args{domain.InvitationArgs{
 				ID: int64(1),
 			}},
+			wantErr: domain.ErrConflict,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 2}},
+				Output: []interface{}{[]domain.Invitation{invitation}, nil},
+			},
+			funcGetGathering: getGathering,
+			funcGetAttended:  getOverlapping,
+		},
+		{
+			name: "schedule conflict with force",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			args: args{domain.InvitationArgs{
+				ID:                int64(1),
+				IsIgnoreConflicts: true,
+			}},
+			wantConflicts: 1,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 2}},
+				Output: []interface{}{[]domain.Invitation{invitation}, nil},
+			},
+			funcGetGathering: getGathering,
+			funcGetAttended:  getOverlapping,
 			funcUpdateStatus: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{ID: 1, MemberID: 2, GatheringID: 1, Status: valueobject.INVITATION_ACCEPT}, mock.Anything},
+				Output: []interface{}{nil, nil},
+			},
+		},
+		{
+			name: "too many guests",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			args: args{domain.InvitationArgs{
+				ID:       int64(1),
+				Response: domain.InvitationResponse{Guests: domain.MaxInvitationGuests + 1},
+			}},
+			wantErr: domain.ErrValidation,
+		},
+		{
+			name: "not the invited member",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			args: args{domain.InvitationArgs{
+				ID:     int64(1),
+				Status: valueobject.INVITATION_ACCEPT,
+			}},
+			wantErr: domain.ErrForbidden,
+			funcGet: helpers.TestFuncCall{
 				Called: true,
 				Input:  []interface{}{mock.Anything, mock.Anything},
-				Output: []interface{}{nil},
+				Output: []interface{}{[]domain.Invitation{invitation}, nil},
+			},
+		},
+		{
+			name: "invitation is closed",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			args: args{domain.InvitationArgs{
+				ID:     int64(1),
+				Status: valueobject.INVITATION_ACCEPT,
+			}},
+			wantErr: domain.ErrInvalidTransition,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Invitation{{ID: 1, MemberID: 2, GatheringID: 1, Status: valueobject.INVITATION_REJECT}}, nil},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockInvitation := new(mocks.IInvitation)
+			mockGathering := new(mocks.IGathering)
 			usecase := usecase.NewInvitationUsecase(usecase.InvitationUsecaseArgs{
 				InvitationRepository: mockInvitation,
+				GatheringRepository:  mockGathering,
 			})
+			if tt.funcGet.Called {
+				mockInvitation.On("Get", tt.funcGet.Input...).Return(tt.funcGet.Output...)
+			}
+			if tt.funcGetGathering.Called {
+				mockGathering.On("Get", tt.funcGetGathering.Input...).Return(tt.funcGetGathering.Output...)
+			}
+			if tt.funcGetAttended.Called {
+				mockGathering.On("Get", tt.funcGetAttended.Input...).Return(tt.funcGetAttended.Output...)
+			}
 			if tt.funcUpdateStatus.Called {
 				mockInvitation.On("UpdateStatus", tt.funcUpdateStatus.Input...).Return(tt.funcUpdateStatus.Output...)
 			}
-			err := usecase.Accept(context.Background(), tt.args.args)
-			if tt.wantErr {
-				require.Error(t, err)
+			conflicts, err := usecase.Accept(tt.ctx, tt.args.args)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				mockInvitation.AssertNotCalled(t, "UpdateStatus")
+			} else {
+				require.NoError(t, err)
+				require.Len(t, conflicts, tt.wantConflicts)
+			}
+			mockInvitation.AssertExpectations(t)
+			mockGathering.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_invitationUsecase_Tentative(t *testing.T) {
+	tests := []struct {
+		name             string
+		invitation       domain.Invitation
+		wantErr          error
+		funcUpdateStatus helpers.TestFuncCall
+	}{
+		{
+			name:       "accepted answers maybe",
+			invitation: domain.Invitation{ID: 1, MemberID: 2, GatheringID: 1, Status: valueobject.INVITATION_ACCEPT},
+			funcUpdateStatus: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, domain.InvitationArgs{
+					ID:          1,
+					MemberID:    2,
+					GatheringID: 1,
+					Status:      valueobject.INVITATION_TENTATIVE,
+					Response:    domain.InvitationResponse{Guests: 1, Note: "if I am back"},
+				}, domain.Notification{
+					Event:    domain.NotificationInvitationTentative,
+					MemberID: 1,
+					Data:     domain.NotificationData{GatheringID: 1, InvitationID: 1, ActorID: 2, Note: "if I am back"},
+					Status:   valueobject.NOTIFICATION_PENDING,
+				}},
+				Output: []interface{}{nil, nil},
+			},
+		},
+		{
+			name:       "waitlisted cannot answer maybe",
+			invitation: domain.Invitation{ID: 1, MemberID: 2, GatheringID: 1, Status: valueobject.INVITATION_WAITLISTED},
+			wantErr:    domain.ErrInvalidTransition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInvitation := new(mocks.IInvitation)
+			mockGathering := new(mocks.IGathering)
+			usecase := usecase.NewInvitationUsecase(usecase.InvitationUsecaseArgs{
+				InvitationRepository: mockInvitation,
+				GatheringRepository:  mockGathering,
+			})
+			mockInvitation.On("Get", mock.Anything, domain.InvitationArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 2}).Return([]domain.Invitation{tt.invitation}, nil)
+			mockGathering.On("Get", mock.Anything, domain.GatheringArgs{IDs: []int64{1}, IsIncludeDiscard: true}).Return([]domain.Gathering{{ID: 1, CreatorID: 1}}, nil)
+			if tt.funcUpdateStatus.Called {
+				mockInvitation.On("UpdateStatus", tt.funcUpdateStatus.Input...).Return(tt.funcUpdateStatus.Output...)
+			}
+			err := usecase.Tentative(domain.ContextWithMember(context.Background(), domain.Member{ID: 2}), domain.InvitationArgs{
+				ID:       1,
+				Response: domain.InvitationResponse{Guests: 1, Note: "if I am back"},
+			})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
 			} else {
 				require.NoError(t, err)
 			}
+			mockInvitation.AssertExpectations(t)
 		})
 	}
 }
 
 func Test_invitationUsecase_Reject(t *testing.T) {
+	invitation := domain.Invitation{ID: 1, MemberID: 2, GatheringID: 1}
 	type args struct {
 		args domain.InvitationArgs
 	}
 	tests := []struct {
 		name             string
+		ctx              context.Context
 		args             args
-		wantErr          bool
+		wantErr          error
+		funcGet          helpers.TestFuncCall
 		funcUpdateStatus helpers.TestFuncCall
 	}{
 		{
 			name: "success",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
 			args: args{domain.InvitationArgs{
-				ID: int64(1),
+				ID:     int64(1),
+				Status: valueobject.INVITATION_REJECT,
 			}},
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 2}},
+				Output: []interface{}{[]domain.Invitation{invitation}, nil},
+			},
 			funcUpdateStatus: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{ID: 1, MemberID: 2, GatheringID: 1, Status: valueobject.INVITATION_REJECT}, mock.Anything},
+				Output: []interface{}{nil, nil},
+			},
+		},
+		{
+			name: "note is kept, guests are not",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			args: args{domain.InvitationArgs{
+				ID:       int64(1),
+				Response: domain.InvitationResponse{Guests: 2, Note: "out of town"},
+			}},
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 2}},
+				Output: []interface{}{[]domain.Invitation{invitation}, nil},
+			},
+			funcUpdateStatus: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, domain.InvitationArgs{
+					ID:          1,
+					MemberID:    2,
+					GatheringID: 1,
+					Status:      valueobject.INVITATION_REJECT,
+					Response:    domain.InvitationResponse{Note: "out of town"},
+				}, domain.Notification{
+					Event:    domain.NotificationInvitationRejected,
+					MemberID: 1,
+					Data:     domain.NotificationData{GatheringID: 1, InvitationID: 1, ActorID: 2, Note: "out of town"},
+					Status:   valueobject.NOTIFICATION_PENDING,
+				}},
+				Output: []interface{}{nil, nil},
+			},
+		},
+		{
+			name: "not the invited member",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			args: args{domain.InvitationArgs{
+				ID:     int64(1),
+				Status: valueobject.INVITATION_REJECT,
+			}},
+			wantErr: domain.ErrForbidden,
+			funcGet: helpers.TestFuncCall{
 				Called: true,
 				Input:  []interface{}{mock.Anything, mock.Anything},
-				Output: []interface{}{nil},
+				Output: []interface{}{[]domain.Invitation{invitation}, nil},
+			},
+		},
+		{
+			name: "invitation is closed",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			args: args{domain.InvitationArgs{
+				ID:     int64(1),
+				Status: valueobject.INVITATION_REJECT,
+			}},
+			wantErr: domain.ErrInvalidTransition,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Invitation{{ID: 1, MemberID: 2, GatheringID: 1, Status: valueobject.INVITATION_CANCELED}}, nil},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockInvitation := new(mocks.IInvitation)
+			mockGathering := new(mocks.IGathering)
 			usecase := usecase.NewInvitationUsecase(usecase.InvitationUsecaseArgs{
 				InvitationRepository: mockInvitation,
+				GatheringRepository:  mockGathering,
 			})
+			if tt.funcGet.Called {
+				mockInvitation.On("Get", tt.funcGet.Input...).Return(tt.funcGet.Output...)
+			}
+			mockGathering.On("Get", mock.Anything, domain.GatheringArgs{IDs: []int64{1}, IsIncludeDiscard: true}).Return([]domain.Gathering{{ID: 1, CreatorID: 1}}, nil)
 			if tt.funcUpdateStatus.Called {
 				mockInvitation.On("UpdateStatus", tt.funcUpdateStatus.Input...).Return(tt.funcUpdateStatus.Output...)
 			}
-			err := usecase.Reject(context.Background(), tt.args.args)
-			if tt.wantErr {
-				require.Error(t, err)
+			err := usecase.Reject(tt.ctx, tt.args.args)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
 			} else {
 				require.NoError(t, err)
 			}
+			mockInvitation.AssertExpectations(t)
 		})
 	}
 }
 
+func Test_invitationUsecase_Reject_promoted(t *testing.T) {
+	rejected := domain.Invitation{ID: 1, MemberID: 2, GatheringID: 1, Status: valueobject.INVITATION_REJECT}
+	promoted := domain.Invitation{ID: 3, MemberID: 4, GatheringID: 1, Status: valueobject.INVITATION_ACCEPT}
+	mockInvitation := new(mocks.IInvitation)
+	mockGathering := new(mocks.IGathering)
+	mockEvents := new(mocks.IEventPublisher)
+	usecase := usecase.NewInvitationUsecase(usecase.InvitationUsecaseArgs{
+		InvitationRepository: mockInvitation,
+		GatheringRepository:  mockGathering,
+		Events:               mockEvents,
+	})
+	mockInvitation.On("Get", mock.Anything, domain.InvitationArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 2}).Return([]domain.Invitation{{ID: 1, MemberID: 2, GatheringID: 1}}, nil)
+	mockGathering.On("Get", mock.Anything, domain.GatheringArgs{IDs: []int64{1}, IsIncludeDiscard: true}).Return([]domain.Gathering{{ID: 1, CreatorID: 1}}, nil)
+	// the freed seat goes to the waitlisted invitation 3
+	mockInvitation.On("UpdateStatus", mock.Anything, domain.InvitationArgs{ID: 1, MemberID: 2, GatheringID: 1, Status: valueobject.INVITATION_REJECT}, mock.Anything).Return([]int64{3}, nil)
+	mockInvitation.On("Get", mock.Anything, domain.InvitationArgs{IDs: []int64{1, 3}}).Return([]domain.Invitation{rejected, promoted}, nil)
+	mockEvents.On("Publish", mock.Anything, []domain.Event{
+		domain.InvitationRejected{Invitation: rejected},
+		domain.InvitationAccepted{Invitation: promoted},
+	}).Return()
+
+	err := usecase.Reject(domain.ContextWithMember(context.Background(), domain.Member{ID: 2}), domain.InvitationArgs{ID: 1})
+	require.NoError(t, err)
+	mockInvitation.AssertExpectations(t)
+	mockEvents.AssertExpectations(t)
+}
+
 func Test_invitationUsecase_Cancel(t *testing.T) {
+	invitation := domain.Invitation{ID: 1, MemberID: 2, GatheringID: 1}
+	gathering := domain.Gathering{ID: 1, CreatorID: 1, Creator: domain.Member{ID: 1}}
 	type args struct {
 		args domain.InvitationArgs
 	}
 	tests := []struct {
 		name             string
+		ctx              context.Context
 		args             args
-		wantErr          bool
+		wantErr          error
+		funcGet          helpers.TestFuncCall
+		funcGetGathering helpers.TestFuncCall
 		funcUpdateStatus helpers.TestFuncCall
 	}{
 		{
 			name: "success",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
 			args: args{domain.InvitationArgs{
-				ID: int64(1),
+				ID:     int64(1),
+				Status: valueobject.INVITATION_CANCELED,
 			}},
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.InvitationArgs{IDs: []int64{1}, IsVisibleOnly: true, ViewerID: 1}},
+				Output: []interface{}{[]domain.Invitation{invitation}, nil},
+			},
+			funcGetGathering: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, domain.GatheringArgs{IDs: []int64{1}, IsIncludeDiscard: true}},
+				Output: []interface{}{[]domain.Gathering{gathering}, nil},
+			},
 			funcUpdateStatus: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, domain.InvitationArgs{ID: 1, MemberID: 2, GatheringID: 1, Status: valueobject.INVITATION_CANCELED}, domain.Notification{
+					Event:    domain.NotificationInvitationCanceled,
+					MemberID: 2,
+					Data:     domain.NotificationData{GatheringID: 1, InvitationID: 1, ActorID: 1},
+					Status:   valueobject.NOTIFICATION_PENDING,
+				}},
+				Output: []interface{}{nil, nil},
+			},
+		},
+		{
+			name: "invited member is not the creator",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			args: args{domain.InvitationArgs{
+				ID:     int64(1),
+				Status: valueobject.INVITATION_CANCELED,
+			}},
+			wantErr: domain.ErrForbidden,
+			funcGet: helpers.TestFuncCall{
 				Called: true,
 				Input:  []interface{}{mock.Anything, mock.Anything},
-				Output: []interface{}{nil},
+				Output: []interface{}{[]domain.Invitation{invitation}, nil},
+			},
+			funcGetGathering: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Gathering{gathering}, nil},
+			},
+		},
+		{
+			name: "invitation is closed",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
+			args: args{domain.InvitationArgs{
+				ID:     int64(1),
+				Status: valueobject.INVITATION_CANCELED,
+			}},
+			wantErr: domain.ErrInvalidTransition,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Invitation{{ID: 1, MemberID: 2, GatheringID: 1, Status: valueobject.INVITATION_REJECT}}, nil},
+			},
+			funcGetGathering: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Gathering{gathering}, nil},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockInvitation := new(mocks.IInvitation)
+			mockGathering := new(mocks.IGathering)
 			usecase := usecase.NewInvitationUsecase(usecase.InvitationUsecaseArgs{
 				InvitationRepository: mockInvitation,
+				GatheringRepository:  mockGathering,
 			})
+			if tt.funcGet.Called {
+				mockInvitation.On("Get", tt.funcGet.Input...).Return(tt.funcGet.Output...)
+			}
+			if tt.funcGetGathering.Called {
+				mockGathering.On("Get", tt.funcGetGathering.Input...).Return(tt.funcGetGathering.Output...)
+			}
 			if tt.funcUpdateStatus.Called {
 				mockInvitation.On("UpdateStatus", tt.funcUpdateStatus.Input...).Return(tt.funcUpdateStatus.Output...)
 			}
-			err := usecase.Cancel(context.Background(), tt.args.args)
-			if tt.wantErr {
-				require.Error(t, err)
+			err := usecase.Cancel(tt.ctx, tt.args.args)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
 			} else {
 				require.NoError(t, err)
 			}
+			mockInvitation.AssertExpectations(t)
 		})
 	}
 }
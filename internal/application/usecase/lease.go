@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+)
+
+// Leases of background jobs, each runs in one replica at a time
+const (
+	NotificationLease = "notifications"
+	ReminderLease     = "reminders"
+	WebhookLease      = "webhooks"
+)
+
+// leaseIntervals is how many intervals of its job a lease lasts. The holder renews it every interval, so it only expires
+// and moves to another replica once the holder stopped.
+const leaseIntervals = 3
+
+// leaseHolder holds the lease of a job for this process
+type leaseHolder struct {
+	leaseRepository repository.ILease
+	name            string
+	holder          string
+}
+
+// newLeaseHolder names this process by host, pid and a random suffix, leaseRepository is optional and without one the
+// process always holds the lease
+func newLeaseHolder(leaseRepository repository.ILease, name string) leaseHolder {
+	host, _ := os.Hostname()
+	suffix, _ := randomHex(4)
+	return leaseHolder{
+		leaseRepository: leaseRepository,
+		name:            name,
+		holder:          fmt.Sprintf("%s-%d-%s", host, os.Getpid(), suffix),
+	}
+}
+
+// hold takes or renews the lease for ttl, a failure is logged and the lease is not held
+func (l leaseHolder) hold(ctx context.Context, ttl time.Duration) bool {
+	if l.leaseRepository == nil {
+		return true
+	}
+	acquired, err := l.leaseRepository.Acquire(ctx, l.name, l.holder, ttl)
+	if err != nil {
+		log.Println(err)
+		return false
+	}
+	return acquired
+}
+
+// release gives the lease up when the job stops, its context is done by then so it is not used
+func (l leaseHolder) release() {
+	if l.leaseRepository == nil {
+		return
+	}
+	if err := l.leaseRepository.Release(context.Background(), l.name, l.holder); err != nil {
+		log.Println(err)
+	}
+}
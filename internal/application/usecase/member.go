@@ -2,9 +2,9 @@ package usecase
 
 import (
 	"context"
-	"errors"
 	"log"
 
+	"github.com/hieronimusbudi/simple-go-api/internal/application/policy"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
 )
@@ -12,15 +12,19 @@ import (
 type (
 	memberUsecase struct {
 		memberRepository repository.IMember
+		events           IEventPublisher
 	}
 
 	MemberUsecaseArgs struct {
 		MemberRepository repository.IMember
+		// Events is told about created, updated and deleted members, optional
+		Events IEventPublisher
 	}
 
 	IMemberUsecase interface {
 		Create(ctx context.Context, member domain.Member) (newMember domain.Member, err error)
 		Get(ctx context.Context, args domain.MemberArgs) (members []domain.Member, err error)
+		List(ctx context.Context, args domain.MemberArgs) (members []domain.Member, page domain.Page, err error)
 		GetByID(ctx context.Context, id int64) (member domain.Member, err error)
 		Update(ctx context.Context, member domain.Member) (err error)
 		Delete(ctx context.Context, args domain.MemberArgs) (err error)
@@ -30,6 +34,7 @@ type (
 func NewMemberUsecase(args MemberUsecaseArgs) IMemberUsecase {
 	return &memberUsecase{
 		memberRepository: args.MemberRepository,
+		events:           args.Events,
 	}
 }
 
@@ -42,7 +47,9 @@ func (u *memberUsecase) Create(ctx context.Context, member domain.Member) (newMe
 	newMember, err = u.GetByID(ctx, id)
 	if err != nil {
 		log.Println(err)
+		return
 	}
+	publish(ctx, u.events, domain.MemberCreated{Member: newMember})
 	return
 }
 
@@ -54,6 +61,31 @@ func (u *memberUsecase) Get(ctx context.Context, args domain.MemberArgs) (member
 	return
 }
 
+// List returns a page of members, it fetches one extra row to know whether there is a next page
+func (u *memberUsecase) List(ctx context.Context, args domain.MemberArgs) (members []domain.Member, page domain.Page, err error) {
+	limit := args.Limit
+	if limit > 0 {
+		args.Limit = limit + 1
+	}
+	members, err = u.memberRepository.Get(ctx, args)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	page = domain.Page{Limit: limit, Offset: args.Offset}
+	if limit > 0 && len(members) > limit {
+		members = members[:limit]
+		field, _ := args.SortField()
+		last := members[limit-1]
+		page.NextCursor = domain.EncodeCursor(last.CursorValue(field), last.ID)
+	}
+	page.Total, err = u.memberRepository.Count(ctx, args)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
 func (u *memberUsecase) GetByID(ctx context.Context, id int64) (member domain.Member, err error) {
 	members, err := u.memberRepository.Get(ctx, domain.MemberArgs{IDs: []int64{id}})
 	if err != nil {
@@ -61,7 +93,7 @@ func (u *memberUsecase) GetByID(ctx context.Context, id int64) (member domain.Me
 		return
 	}
 	if len(members) == 0 {
-		err = errors.New("cannot find member")
+		err = domain.NewError(domain.ErrNotFound, "cannot find member")
 		return
 	}
 	member = members[0]
@@ -69,17 +101,40 @@ func (u *memberUsecase) GetByID(ctx context.Context, id int64) (member domain.Me
 }
 
 func (u *memberUsecase) Update(ctx context.Context, member domain.Member) (err error) {
+	if err = policy.CanManageMember(ctx, member.ID); err != nil {
+		return
+	}
 	err = u.memberRepository.Update(ctx, member)
 	if err != nil {
 		log.Println(err)
+		return
 	}
+	u.publishMember(ctx, member.ID)
 	return
 }
 
 func (u *memberUsecase) Delete(ctx context.Context, args domain.MemberArgs) (err error) {
+	if err = policy.CanManageMember(ctx, args.ID); err != nil {
+		return
+	}
 	err = u.memberRepository.Delete(ctx, args)
 	if err != nil {
 		log.Println(err)
+		return
 	}
+	publish(ctx, u.events, domain.MemberDeleted{MemberID: args.ID})
 	return
 }
+
+// publishMember tells subscribers about an updated member as it is stored now
+func (u *memberUsecase) publishMember(ctx context.Context, id int64) {
+	if u.events == nil {
+		return
+	}
+	member, err := u.GetByID(ctx, id)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	publish(ctx, u.events, domain.MemberUpdated{Member: member})
+}
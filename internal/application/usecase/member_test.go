@@ -2,10 +2,12 @@ package usecase_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
 	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
 	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
 	"github.com/hieronimusbudi/simple-go-api/internal/mocks"
 	"github.com/stretchr/testify/mock"
@@ -53,8 +55,10 @@ func Test_memberUsecase_Create(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockMember := new(mocks.IMember)
+			mockEvents := new(mocks.IEventPublisher)
 			usecase := usecase.NewMemberUsecase(usecase.MemberUsecaseArgs{
 				MemberRepository: mockMember,
+				Events:           mockEvents,
 			})
 			if tt.funcCreate.Called {
 				mockMember.On("Create", tt.funcCreate.Input...).Return(tt.funcCreate.Output...)
@@ -62,6 +66,9 @@ func Test_memberUsecase_Create(t *testing.T) {
 			if tt.funcGet.Called {
 				mockMember.On("Get", tt.funcGet.Input...).Return(tt.funcGet.Output...)
 			}
+			if !tt.wantErr {
+				mockEvents.On("Publish", mock.Anything, []domain.Event{domain.MemberCreated{Member: tt.wantNewMember}}).Return()
+			}
 			gotNewMember, err := usecase.Create(context.Background(), tt.args.member)
 			if tt.wantErr {
 				require.Error(t, err)
@@ -69,6 +76,7 @@ func Test_memberUsecase_Create(t *testing.T) {
 				require.NoError(t, err)
 				require.Equal(t, tt.wantNewMember, gotNewMember)
 			}
+			mockEvents.AssertExpectations(t)
 		})
 	}
 }
@@ -120,6 +128,117 @@ func Test_memberUsecase_Get(t *testing.T) {
 	}
 }
 
+func Test_memberUsecase_List(t *testing.T) {
+	members := []domain.Member{
+		{
+			ID:        1,
+			FirstName: "john",
+			LastName:  "doe",
+			Email:     "john@mail.com",
+			CreatedAt: "2023-10-02T11:05:01Z",
+		},
+		{
+			ID:        2,
+			FirstName: "jane",
+			LastName:  "doe",
+			Email:     "jane@mail.com",
+			CreatedAt: "2023-10-02T11:05:43Z",
+		},
+	}
+	type args struct {
+		args domain.MemberArgs
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantMembers []domain.Member
+		wantPage    domain.Page
+		wantErr     bool
+		funcGet     helpers.TestFuncCall
+		funcCount   helpers.TestFuncCall
+	}{
+		{
+			name: "success with next page",
+			args: args{domain.MemberArgs{
+				Pagination: domain.Pagination{Limit: 1, Sort: "created_at"},
+			}},
+			wantMembers: members[:1],
+			wantPage: domain.Page{
+				Total:      2,
+				Limit:      1,
+				NextCursor: domain.EncodeCursor("2023-10-02 11:05:01", 1),
+			},
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, domain.MemberArgs{
+					Pagination: domain.Pagination{Limit: 2, Sort: "created_at"},
+				}},
+				Output: []interface{}{members, nil},
+			},
+			funcCount: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{int64(2), nil},
+			},
+		},
+		{
+			name: "success last page",
+			args: args{domain.MemberArgs{
+				Pagination: domain.Pagination{Limit: 2},
+			}},
+			wantMembers: members,
+			wantPage: domain.Page{
+				Total: 2,
+				Limit: 2,
+			},
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{members, nil},
+			},
+			funcCount: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{int64(2), nil},
+			},
+		},
+		{
+			name: "get fail",
+			args: args{domain.MemberArgs{
+				Pagination: domain.Pagination{Limit: 2},
+			}},
+			wantErr: true,
+			funcGet: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Member{}, errors.New("get error")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMember := new(mocks.IMember)
+			usecase := usecase.NewMemberUsecase(usecase.MemberUsecaseArgs{
+				MemberRepository: mockMember,
+			})
+			if tt.funcGet.Called {
+				mockMember.On("Get", tt.funcGet.Input...).Return(tt.funcGet.Output...)
+			}
+			if tt.funcCount.Called {
+				mockMember.On("Count", tt.funcCount.Input...).Return(tt.funcCount.Output...)
+			}
+			gotMembers, gotPage, err := usecase.List(context.Background(), tt.args.args)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantMembers, gotMembers)
+				require.Equal(t, tt.wantPage, gotPage)
+			}
+		})
+	}
+}
+
 func Test_memberUsecase_GetByID(t *testing.T) {
 	members := []domain.Member{{
 		ID:        1,
@@ -183,12 +302,14 @@ func Test_memberUsecase_Update(t *testing.T) {
 	}
 	tests := []struct {
 		name       string
+		ctx        context.Context
 		args       args
 		wantErr    bool
 		funcUpdate helpers.TestFuncCall
 	}{
 		{
 			name: "success",
+			ctx:  domain.ContextWithMember(context.Background(), member),
 			args: args{
 				member: member,
 			},
@@ -198,6 +319,22 @@ func Test_memberUsecase_Update(t *testing.T) {
 				Output: []interface{}{nil},
 			},
 		},
+		{
+			name:    "another member",
+			ctx:     domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			args:    args{member: member},
+			wantErr: true,
+		},
+		{
+			name: "admin",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 2, Role: valueobject.ROLE_ADMIN}),
+			args: args{member: member},
+			funcUpdate: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{nil},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -208,9 +345,9 @@ func Test_memberUsecase_Update(t *testing.T) {
 			if tt.funcUpdate.Called {
 				mockMember.On("Update", tt.funcUpdate.Input...).Return(tt.funcUpdate.Output...)
 			}
-			err := usecase.Update(context.Background(), tt.args.member)
+			err := usecase.Update(tt.ctx, tt.args.member)
 			if tt.wantErr {
-				require.Error(t, err)
+				require.ErrorIs(t, err, domain.ErrForbidden)
 			} else {
 				require.NoError(t, err)
 			}
@@ -224,12 +361,14 @@ func Test_memberUsecase_Delete(t *testing.T) {
 	}
 	tests := []struct {
 		name       string
+		ctx        context.Context
 		args       args
 		wantErr    bool
 		funcDelete helpers.TestFuncCall
 	}{
 		{
 			name: "success",
+			ctx:  domain.ContextWithMember(context.Background(), domain.Member{ID: 1}),
 			args: args{domain.MemberArgs{
 				ID: int64(1),
 			}},
@@ -239,6 +378,12 @@ func Test_memberUsecase_Delete(t *testing.T) {
 				Output: []interface{}{nil},
 			},
 		},
+		{
+			name:    "another member",
+			ctx:     domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			args:    args{domain.MemberArgs{ID: int64(1)}},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -249,9 +394,9 @@ func Test_memberUsecase_Delete(t *testing.T) {
 			if tt.funcDelete.Called {
 				mockMember.On("Delete", tt.funcDelete.Input...).Return(tt.funcDelete.Output...)
 			}
-			err := usecase.Delete(context.Background(), tt.args.args)
+			err := usecase.Delete(tt.ctx, tt.args.args)
 			if tt.wantErr {
-				require.Error(t, err)
+				require.ErrorIs(t, err, domain.ErrForbidden)
 			} else {
 				require.NoError(t, err)
 			}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+const (
+	// DefaultNotificationBatch is how many notifications one dispatch delivers at most
+	DefaultNotificationBatch = 50
+	// DefaultNotificationInterval is how long the dispatcher waits between dispatches
+	DefaultNotificationInterval = 10 * time.Second
+)
+
+// ErrNoRecipient fails a notification whose member no longer exists, it is not retried
+var ErrNoRecipient = errors.New("cannot find recipient")
+
+type (
+	// INotifier delivers a notification to its recipient, actor is the member who made the change and may be empty
+	INotifier interface {
+		Notify(ctx context.Context, recipient domain.Member, actor domain.Member, notification domain.Notification) (err error)
+	}
+
+	notificationUsecase struct {
+		notificationRepository repository.INotification
+		memberRepository       repository.IMember
+		notifier               INotifier
+		batch                  int
+		lease                  leaseHolder
+	}
+
+	NotificationUsecaseArgs struct {
+		NotificationRepository repository.INotification
+		// MemberRepository finds the recipients and actors, members are read at delivery so a changed email is used
+		MemberRepository repository.IMember
+		Notifier         INotifier
+		// Batch is how many notifications one dispatch delivers at most, DefaultNotificationBatch when zero
+		Batch int
+		// LeaseRepository lets one replica at a time dispatch, so a notification is not sent by two of them. Optional.
+		LeaseRepository repository.ILease
+	}
+
+	INotificationUsecase interface {
+		Dispatch(ctx context.Context) (sent int, err error)
+		Run(ctx context.Context, interval time.Duration)
+	}
+)
+
+func NewNotificationUsecase(args NotificationUsecaseArgs) INotificationUsecase {
+	batch := args.Batch
+	if batch <= 0 {
+		batch = DefaultNotificationBatch
+	}
+	return &notificationUsecase{
+		notificationRepository: args.NotificationRepository,
+		memberRepository:       args.MemberRepository,
+		notifier:               args.Notifier,
+		batch:                  batch,
+		lease:                  newLeaseHolder(args.LeaseRepository, NotificationLease),
+	}
+}
+
+// Dispatch delivers the oldest pending notifications of the outbox. A failed delivery is retried on later dispatches
+// until domain.MaxNotificationAttempts, a notification whose recipient is gone fails at once.
+func (u *notificationUsecase) Dispatch(ctx context.Context) (sent int, err error) {
+	notifications, err := u.notificationRepository.Get(ctx, domain.NotificationArgs{
+		Statuses: []valueobject.NotificationStatus{valueobject.NOTIFICATION_PENDING},
+		Limit:    u.batch,
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(notifications) == 0 {
+		return
+	}
+	memberIDs := []int64{}
+	for _, n := range notifications {
+		memberIDs = append(memberIDs, n.MemberID)
+		if n.Data.ActorID > 0 {
+			memberIDs = append(memberIDs, n.Data.ActorID)
+		}
+	}
+	members, err := u.memberRepository.Get(ctx, domain.MemberArgs{IDs: memberIDs})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	membersByID := map[int64]domain.Member{}
+	for _, m := range members {
+		membersByID[m.ID] = m
+	}
+	for _, n := range notifications {
+		recipient, ok := membersByID[n.MemberID]
+		if !ok {
+			n.Fail(ErrNoRecipient, true)
+		} else if err := u.notifier.Notify(ctx, recipient, membersByID[n.Data.ActorID], n); err != nil {
+			log.Println(err)
+			n.Fail(err, false)
+		} else {
+			n.Sent(time.Now())
+			sent++
+		}
+		if err = u.notificationRepository.Update(ctx, n); err != nil {
+			log.Println(err)
+			return
+		}
+	}
+	return
+}
+
+// Run dispatches every interval until ctx is done, a fully delivered batch is followed by another dispatch at once.
+// Only the replica holding NotificationLease dispatches.
+func (u *notificationUsecase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer u.lease.release()
+	for {
+		for u.lease.hold(ctx, leaseIntervals*interval) {
+			sent, err := u.Dispatch(ctx)
+			if err != nil || sent < u.batch {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
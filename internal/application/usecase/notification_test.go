@@ -0,0 +1,105 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+	"github.com/hieronimusbudi/simple-go-api/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_notificationUsecase_Dispatch(t *testing.T) {
+	linus := domain.Member{ID: 1, FirstName: "linus", Email: "linus@mail.com"}
+	ron := domain.Member{ID: 2, FirstName: "ron", Email: "ron@mail.com"}
+	accepted := domain.Notification{
+		ID:       10,
+		Event:    domain.NotificationInvitationAccepted,
+		MemberID: 1,
+		Data:     domain.NotificationData{GatheringID: 1, ActorID: 2},
+		Status:   valueobject.NOTIFICATION_PENDING,
+	}
+	pending := domain.NotificationArgs{Statuses: []valueobject.NotificationStatus{valueobject.NOTIFICATION_PENDING}, Limit: usecase.DefaultNotificationBatch}
+	tests := []struct {
+		name       string
+		members    []domain.Member
+		wantSent   int
+		funcNotify helpers.TestFuncCall
+		funcUpdate helpers.TestFuncCall
+	}{
+		{
+			name:     "delivered",
+			members:  []domain.Member{linus, ron},
+			wantSent: 1,
+			funcNotify: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, linus, ron, accepted},
+				Output: []interface{}{nil},
+			},
+			funcUpdate: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, mock.MatchedBy(func(n domain.Notification) bool {
+					return n.ID == 10 && n.Status == valueobject.NOTIFICATION_SENT && n.Attempts == 1 && n.SentAt != ""
+				})},
+				Output: []interface{}{nil},
+			},
+		},
+		{
+			name:    "failed delivery is retried",
+			members: []domain.Member{linus, ron},
+			funcNotify: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, linus, ron, accepted},
+				Output: []interface{}{errors.New("connection refused")},
+			},
+			funcUpdate: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, mock.MatchedBy(func(n domain.Notification) bool {
+					return n.Status == valueobject.NOTIFICATION_PENDING && n.Attempts == 1 && n.LastError == "connection refused"
+				})},
+				Output: []interface{}{nil},
+			},
+		},
+		{
+			name:    "missing recipient fails at once",
+			members: []domain.Member{ron},
+			funcUpdate: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, mock.MatchedBy(func(n domain.Notification) bool {
+					return n.Status == valueobject.NOTIFICATION_FAILED && n.LastError == usecase.ErrNoRecipient.Error()
+				})},
+				Output: []interface{}{nil},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNotification := new(mocks.INotification)
+			mockMember := new(mocks.IMember)
+			mockNotifier := new(mocks.INotifier)
+			usecase := usecase.NewNotificationUsecase(usecase.NotificationUsecaseArgs{
+				NotificationRepository: mockNotification,
+				MemberRepository:       mockMember,
+				Notifier:               mockNotifier,
+			})
+			mockNotification.On("Get", mock.Anything, pending).Return([]domain.Notification{accepted}, nil)
+			mockMember.On("Get", mock.Anything, domain.MemberArgs{IDs: []int64{1, 2}}).Return(tt.members, nil)
+			if tt.funcNotify.Called {
+				mockNotifier.On("Notify", tt.funcNotify.Input...).Return(tt.funcNotify.Output...)
+			}
+			if tt.funcUpdate.Called {
+				mockNotification.On("Update", tt.funcUpdate.Input...).Return(tt.funcUpdate.Output...)
+			}
+			sent, err := usecase.Dispatch(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSent, sent)
+			mockNotification.AssertExpectations(t)
+			mockNotifier.AssertExpectations(t)
+		})
+	}
+}
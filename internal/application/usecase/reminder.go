@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+// DefaultReminderInterval is how long the scheduler waits between scans, a reminder goes out at most this late
+const DefaultReminderInterval = time.Minute
+
+type (
+	reminderUsecase struct {
+		gatheringRepository  repository.IGathering
+		invitationRepository repository.IInvitation
+		reminderRepository   repository.IReminder
+		offsets              []time.Duration
+		lease                leaseHolder
+	}
+
+	ReminderUsecaseArgs struct {
+		GatheringRepository  repository.IGathering
+		InvitationRepository repository.IInvitation
+		// ReminderRepository stores each reminder with its notifications once
+		ReminderRepository repository.IReminder
+		// LeaseRepository lets one replica at a time scan, optional as the reminder repository keeps a reminder from going out twice
+		LeaseRepository repository.ILease
+		// Offsets are how long before an occurrence starts it is reminded of, e.g. 24h and 1h, no reminders without any
+		Offsets []time.Duration
+	}
+
+	IReminderUsecase interface {
+		Remind(ctx context.Context, now time.Time) (created int, err error)
+		Run(ctx context.Context, interval time.Duration)
+	}
+)
+
+func NewReminderUsecase(args ReminderUsecaseArgs) IReminderUsecase {
+	return &reminderUsecase{
+		gatheringRepository:  args.GatheringRepository,
+		invitationRepository: args.InvitationRepository,
+		reminderRepository:   args.ReminderRepository,
+		offsets:              args.Offsets,
+		lease:                newLeaseHolder(args.LeaseRepository, ReminderLease),
+	}
+}
+
+// Remind writes the reminders due at now to the outbox, for occurrences starting within the longest offset. Attendees
+// are reminded, and invitees who have not answered yet. A reminder stored before is skipped, created counts the new ones.
+func (u *reminderUsecase) Remind(ctx context.Context, now time.Time) (created int, err error) {
+	longest := domain.LongestReminderOffset(u.offsets)
+	if longest == 0 {
+		return
+	}
+	gatherings, err := u.gatheringRepository.Get(ctx, domain.GatheringArgs{
+		ScheduledFrom:   now,
+		ScheduledTo:     now.Add(longest),
+		IsIncludeSeries: true,
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	remindersByGatheringID := map[int64][]domain.Reminder{}
+	gatheringIDs := []int64{}
+	for _, g := range gatherings {
+		reminders, err := g.DueReminders(u.offsets, now)
+		if err != nil {
+			// a series whose rule cannot be read has nothing to remind of, the others still are
+			log.Println(err)
+			continue
+		}
+		if len(reminders) > 0 {
+			remindersByGatheringID[g.ID] = reminders
+			gatheringIDs = append(gatheringIDs, g.ID)
+		}
+	}
+	if len(gatheringIDs) == 0 {
+		return
+	}
+	invitations, err := u.invitationRepository.Get(ctx, domain.InvitationArgs{
+		GatheringIDs: gatheringIDs,
+		Statuses:     []valueobject.InvitationStatus{valueobject.INVITATION_CREATED},
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	invitationsByGatheringID := map[int64][]domain.Invitation{}
+	for _, i := range invitations {
+		invitationsByGatheringID[i.GatheringID] = append(invitationsByGatheringID[i.GatheringID], i)
+	}
+	for _, g := range gatherings {
+		for _, reminder := range remindersByGatheringID[g.ID] {
+			notifications := reminder.Notifications(g.AttendeeIDs(0), invitationsByGatheringID[g.ID])
+			if len(notifications) == 0 {
+				continue
+			}
+			isCreated, err := u.reminderRepository.Create(ctx, reminder, notifications...)
+			if err != nil {
+				log.Println(err)
+				return created, err
+			}
+			if isCreated {
+				created++
+			}
+		}
+	}
+	return
+}
+
+// Run scans every interval until ctx is done, only the replica holding ReminderLease scans. Without offsets it returns at once.
+func (u *reminderUsecase) Run(ctx context.Context, interval time.Duration) {
+	if len(u.offsets) == 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer u.lease.release()
+	for {
+		if u.lease.hold(ctx, leaseIntervals*interval) {
+			u.Remind(ctx, time.Now())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
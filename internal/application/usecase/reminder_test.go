@@ -0,0 +1,108 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+	"github.com/hieronimusbudi/simple-go-api/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_reminderUsecase_Remind(t *testing.T) {
+	now := time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC)
+	offsets := []time.Duration{24 * time.Hour, time.Hour}
+	soon := domain.Gathering{
+		ID:          1,
+		Name:        "standup",
+		Location:    "room 1",
+		ScheduledAt: now.Add(30 * time.Minute),
+		Attendees:   []domain.Member{{ID: 2}},
+	}
+	later := soon
+	later.ScheduledAt = now.Add(25 * time.Hour)
+	invitation := domain.Invitation{ID: 10, MemberID: 3, GatheringID: 1, Status: valueobject.INVITATION_CREATED}
+	reminder := domain.Reminder{
+		Occurrence: domain.Occurrence{GatheringID: 1, RecurrenceID: soon.ScheduledAt, ScheduledAt: soon.ScheduledAt, Name: "standup", Location: "room 1"},
+		Offset:     time.Hour,
+	}
+	notifications := reminder.Notifications([]int64{2}, []domain.Invitation{invitation})
+	tests := []struct {
+		name            string
+		gatherings      []domain.Gathering
+		wantCreated     int
+		funcInvitations helpers.TestFuncCall
+		funcCreate      helpers.TestFuncCall
+	}{
+		{
+			name:        "attendee and invitee reminded",
+			gatherings:  []domain.Gathering{soon},
+			wantCreated: 1,
+			funcInvitations: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, domain.InvitationArgs{
+					GatheringIDs: []int64{1},
+					Statuses:     []valueobject.InvitationStatus{valueobject.INVITATION_CREATED},
+				}},
+				Output: []interface{}{[]domain.Invitation{invitation}, nil},
+			},
+			funcCreate: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, reminder, notifications[0], notifications[1]},
+				Output: []interface{}{true, nil},
+			},
+		},
+		{
+			name:       "reminded before",
+			gatherings: []domain.Gathering{soon},
+			funcInvitations: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, mock.Anything},
+				Output: []interface{}{[]domain.Invitation{invitation}, nil},
+			},
+			funcCreate: helpers.TestFuncCall{
+				Called: true,
+				Input:  []interface{}{mock.Anything, reminder, notifications[0], notifications[1]},
+				Output: []interface{}{false, nil},
+			},
+		},
+		{
+			name:       "nothing due",
+			gatherings: []domain.Gathering{later},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGathering := new(mocks.IGathering)
+			mockInvitation := new(mocks.IInvitation)
+			mockReminder := new(mocks.IReminder)
+			usecase := usecase.NewReminderUsecase(usecase.ReminderUsecaseArgs{
+				GatheringRepository:  mockGathering,
+				InvitationRepository: mockInvitation,
+				ReminderRepository:   mockReminder,
+				Offsets:              offsets,
+			})
+			mockGathering.On("Get", mock.Anything, domain.GatheringArgs{
+				ScheduledFrom:   now,
+				ScheduledTo:     now.Add(24 * time.Hour),
+				IsIncludeSeries: true,
+			}).Return(tt.gatherings, nil)
+			if tt.funcInvitations.Called {
+				mockInvitation.On("Get", tt.funcInvitations.Input...).Return(tt.funcInvitations.Output...)
+			}
+			if tt.funcCreate.Called {
+				mockReminder.On("Create", tt.funcCreate.Input...).Return(tt.funcCreate.Output...)
+			}
+			created, err := usecase.Remind(context.Background(), now)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCreated, created)
+			mockInvitation.AssertExpectations(t)
+			mockReminder.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+)
+
+// checkSchedule finds occurrences of the gathering overlapping gatherings the members attend, within MaxOccurrenceRange
+// from now or from the start of the gathering when it is later.
+// Conflicts are refused with a domain.ScheduleConflictError, or returned as warnings when isIgnoreConflicts is set.
+func checkSchedule(ctx context.Context, gatheringRepository repository.IGathering, gathering domain.Gathering, memberIDs []int64, isIgnoreConflicts bool) (warnings []domain.ScheduleConflict, err error) {
+	if len(memberIDs) == 0 {
+		return
+	}
+	attended, err := gatheringRepository.Get(ctx, domain.GatheringArgs{MemberIDs: memberIDs})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	from := time.Now()
+	if gathering.ScheduledAt.After(from) {
+		from = gathering.ScheduledAt
+	}
+	conflicts := []domain.ScheduleConflict{}
+	for _, memberID := range memberIDs {
+		others := []domain.Gathering{}
+		for _, g := range attended {
+			if isAttending(g, memberID) {
+				others = append(others, g)
+			}
+		}
+		found, err := gathering.ScheduleConflicts(memberID, others, from, from.Add(domain.MaxOccurrenceRange))
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		conflicts = append(conflicts, found...)
+	}
+	if len(conflicts) == 0 {
+		return
+	}
+	if !isIgnoreConflicts {
+		return nil, &domain.ScheduleConflictError{Conflicts: conflicts}
+	}
+	return conflicts, nil
+}
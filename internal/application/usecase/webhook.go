@@ -0,0 +1,425 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/application/policy"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+const (
+	// DefaultWebhookBatch is how many deliveries one dispatch posts at most
+	DefaultWebhookBatch = 50
+	// DefaultWebhookInterval is how long the dispatcher waits between dispatches
+	DefaultWebhookInterval = 10 * time.Second
+	// DefaultWebhookTimeout bounds one delivery attempt, a receiver answering later is a failed attempt
+	DefaultWebhookTimeout = 10 * time.Second
+	// webhookSecretBytes is the size of a generated secret, it is hex encoded
+	webhookSecretBytes = 32
+)
+
+// ErrWebhookDisabled fails a delivery whose webhook was disabled or deleted after it was queued, it is not retried
+var ErrWebhookDisabled = errors.New("webhook is disabled")
+
+type (
+	webhookUsecase struct {
+		webhookRepository repository.IWebhook
+		client            *http.Client
+		batch             int
+		lease             leaseHolder
+	}
+
+	WebhookUsecaseArgs struct {
+		WebhookRepository repository.IWebhook
+		// Client posts deliveries, a client with DefaultWebhookTimeout when nil
+		Client *http.Client
+		// Batch is how many deliveries one dispatch posts at most, DefaultWebhookBatch when zero
+		Batch int
+		// LeaseRepository lets one replica at a time dispatch, so a delivery is not posted by two of them. Optional.
+		LeaseRepository repository.ILease
+	}
+
+	IWebhookUsecase interface {
+		Create(ctx context.Context, webhook domain.Webhook) (newWebhook domain.Webhook, err error)
+		List(ctx context.Context) (webhooks []domain.Webhook, err error)
+		GetByID(ctx context.Context, id int64) (webhook domain.Webhook, err error)
+		Update(ctx context.Context, webhook domain.Webhook) (err error)
+		Delete(ctx context.Context, id int64) (err error)
+		Deliveries(ctx context.Context, args domain.WebhookDeliveryArgs) (deliveries []domain.WebhookDelivery, page domain.Page, err error)
+		Replay(ctx context.Context, webhookID int64, deliveryID int64) (delivery domain.WebhookDelivery, err error)
+		Publish(ctx context.Context, event string, data interface{}) (err error)
+		Handle(ctx context.Context, event domain.Event) (err error)
+		Dispatch(ctx context.Context) (delivered int, err error)
+		Run(ctx context.Context, interval time.Duration)
+	}
+)
+
+func NewWebhookUsecase(args WebhookUsecaseArgs) IWebhookUsecase {
+	client := args.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultWebhookTimeout}
+	}
+	batch := args.Batch
+	if batch <= 0 {
+		batch = DefaultWebhookBatch
+	}
+	return &webhookUsecase{
+		webhookRepository: args.WebhookRepository,
+		client:            client,
+		batch:             batch,
+		lease:             newLeaseHolder(args.LeaseRepository, WebhookLease),
+	}
+}
+
+// Create stores a webhook, only an admin. A secret is generated when none is given, the returned webhook is the only
+// place it is shown.
+func (u *webhookUsecase) Create(ctx context.Context, webhook domain.Webhook) (newWebhook domain.Webhook, err error) {
+	if err = policy.CanManageWebhooks(ctx); err != nil {
+		return
+	}
+	if webhook.Secret == "" {
+		if webhook.Secret, err = randomHex(webhookSecretBytes); err != nil {
+			log.Println(err)
+			return
+		}
+	}
+	id, err := u.webhookRepository.Create(ctx, webhook)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	newWebhook, err = u.webhook(ctx, id)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// List returns every webhook without its secret, only an admin
+func (u *webhookUsecase) List(ctx context.Context) (webhooks []domain.Webhook, err error) {
+	if err = policy.CanManageWebhooks(ctx); err != nil {
+		return
+	}
+	webhooks, err = u.webhookRepository.Get(ctx, domain.WebhookArgs{})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return
+}
+
+// GetByID returns a webhook without its secret, only an admin
+func (u *webhookUsecase) GetByID(ctx context.Context, id int64) (webhook domain.Webhook, err error) {
+	if err = policy.CanManageWebhooks(ctx); err != nil {
+		return
+	}
+	webhook, err = u.webhook(ctx, id)
+	webhook.Secret = ""
+	return
+}
+
+// Update changes the url, events and disabled flag, and the secret when one is given, only an admin
+func (u *webhookUsecase) Update(ctx context.Context, webhook domain.Webhook) (err error) {
+	if _, err = u.GetByID(ctx, webhook.ID); err != nil {
+		return
+	}
+	err = u.webhookRepository.Update(ctx, webhook)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// Delete removes the webhook and its delivery log, only an admin
+func (u *webhookUsecase) Delete(ctx context.Context, id int64) (err error) {
+	if _, err = u.GetByID(ctx, id); err != nil {
+		return
+	}
+	err = u.webhookRepository.Delete(ctx, id)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// webhook finds a webhook with its secret
+func (u *webhookUsecase) webhook(ctx context.Context, id int64) (webhook domain.Webhook, err error) {
+	webhooks, err := u.webhookRepository.Get(ctx, domain.WebhookArgs{IDs: []int64{id}})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(webhooks) == 0 {
+		err = domain.NewError(domain.ErrNotFound, "cannot find webhook")
+		return
+	}
+	webhook = webhooks[0]
+	return
+}
+
+// Deliveries returns a page of the delivery log of args.WebhookID, only an admin. It fetches one extra row to know
+// whether there is a next page.
+func (u *webhookUsecase) Deliveries(ctx context.Context, args domain.WebhookDeliveryArgs) (deliveries []domain.WebhookDelivery, page domain.Page, err error) {
+	if _, err = u.GetByID(ctx, args.WebhookID); err != nil {
+		return
+	}
+	limit := args.Limit
+	if limit > 0 {
+		args.Limit = limit + 1
+	}
+	deliveries, err = u.webhookRepository.GetDeliveries(ctx, args)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	page = domain.Page{Limit: limit, Offset: args.Offset}
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+		field, _ := args.SortField()
+		last := deliveries[limit-1]
+		page.NextCursor = domain.EncodeCursor(last.CursorValue(field), last.ID)
+	}
+	page.Total, err = u.webhookRepository.CountDeliveries(ctx, args)
+	if err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// Replay queues the payload of a delivery again as a new pending delivery, only an admin. Any delivery can be replayed,
+// usually a dead one once the receiver is fixed.
+func (u *webhookUsecase) Replay(ctx context.Context, webhookID int64, deliveryID int64) (delivery domain.WebhookDelivery, err error) {
+	webhook, err := u.GetByID(ctx, webhookID)
+	if err != nil {
+		return
+	}
+	if webhook.IsDisabled {
+		return delivery, domain.NewError(domain.ErrConflict, "the webhook is disabled")
+	}
+	deliveries, err := u.webhookRepository.GetDeliveries(ctx, domain.WebhookDeliveryArgs{IDs: []int64{deliveryID}, WebhookID: webhookID})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(deliveries) == 0 {
+		return delivery, domain.NewError(domain.ErrNotFound, "cannot find delivery")
+	}
+	ids, err := u.webhookRepository.CreateDeliveries(ctx, []domain.WebhookDelivery{deliveries[0].Replay()})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	deliveries, err = u.webhookRepository.GetDeliveries(ctx, domain.WebhookDeliveryArgs{IDs: ids})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(deliveries) == 0 {
+		return delivery, domain.NewError(domain.ErrNotFound, "cannot find delivery")
+	}
+	delivery = deliveries[0]
+	return
+}
+
+// Publish queues a delivery of the event for each enabled webhook subscribing to it, data is the changed resource
+func (u *webhookUsecase) Publish(ctx context.Context, event string, data interface{}) (err error) {
+	webhooks, err := u.webhookRepository.Get(ctx, domain.WebhookArgs{IsEnabledOnly: true})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+	id, err := randomHex(16)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	payload, err := json.Marshal(domain.WebhookPayload{ID: id, Event: event, OccurredAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	deliveries := domain.NewWebhookDeliveries(webhooks, event, string(payload))
+	if len(deliveries) == 0 {
+		return
+	}
+	if _, err = u.webhookRepository.CreateDeliveries(ctx, deliveries); err != nil {
+		log.Println(err)
+	}
+	return
+}
+
+// Handle publishes a domain event to the webhooks, it is subscribed to the event bus. Every change of a gathering
+// is gathering.updated and a deleted resource is sent as its ID. Events webhooks do not know are skipped.
+func (u *webhookUsecase) Handle(ctx context.Context, event domain.Event) (err error) {
+	switch e := event.(type) {
+	case domain.MemberCreated:
+		return u.Publish(ctx, domain.WebhookMemberCreated, e.Member)
+	case domain.MemberUpdated:
+		return u.Publish(ctx, domain.WebhookMemberUpdated, e.Member)
+	case domain.MemberDeleted:
+		return u.Publish(ctx, domain.WebhookMemberDeleted, domain.WebhookDeletion{ID: e.MemberID})
+	case domain.GatheringCreated:
+		return u.Publish(ctx, domain.WebhookGatheringCreated, e.Gathering)
+	case domain.GatheringUpdated:
+		return u.Publish(ctx, domain.WebhookGatheringUpdated, e.Gathering)
+	case domain.GatheringRescheduled:
+		return u.Publish(ctx, domain.WebhookGatheringUpdated, e.Gathering)
+	case domain.OccurrenceUpdated:
+		return u.Publish(ctx, domain.WebhookGatheringUpdated, e.Gathering)
+	case domain.OccurrenceCanceled:
+		return u.Publish(ctx, domain.WebhookGatheringUpdated, e.Gathering)
+	case domain.AttendeeAdded:
+		return u.Publish(ctx, domain.WebhookGatheringUpdated, e.Gathering)
+	case domain.AttendeeRemoved:
+		return u.Publish(ctx, domain.WebhookGatheringUpdated, e.Gathering)
+	case domain.GatheringDeleted:
+		return u.Publish(ctx, domain.WebhookGatheringDeleted, domain.WebhookDeletion{ID: e.Gathering.ID})
+	case domain.InvitationCreated:
+		return u.Publish(ctx, domain.WebhookInvitationEvent(e.Invitation.Status), e.Invitation)
+	case domain.InvitationAccepted:
+		return u.Publish(ctx, domain.WebhookInvitationEvent(e.Invitation.Status), e.Invitation)
+	case domain.InvitationWaitlisted:
+		return u.Publish(ctx, domain.WebhookInvitationEvent(e.Invitation.Status), e.Invitation)
+	case domain.InvitationTentative:
+		return u.Publish(ctx, domain.WebhookInvitationEvent(e.Invitation.Status), e.Invitation)
+	case domain.InvitationRejected:
+		return u.Publish(ctx, domain.WebhookInvitationEvent(e.Invitation.Status), e.Invitation)
+	case domain.InvitationCanceled:
+		return u.Publish(ctx, domain.WebhookInvitationEvent(e.Invitation.Status), e.Invitation)
+	}
+	return
+}
+
+// Dispatch posts the oldest due deliveries. A failed delivery is tried again with exponential backoff until
+// domain.MaxWebhookAttempts, then it is dead and stays in the log to be replayed.
+func (u *webhookUsecase) Dispatch(ctx context.Context) (delivered int, err error) {
+	return u.dispatch(ctx, 0)
+}
+
+// dispatch renews WebhookLease for leaseTTL before each post when it is set, so the lease cannot expire while a receiver
+// is answering. It stops once the lease moved to another replica, the deliveries left are theirs.
+func (u *webhookUsecase) dispatch(ctx context.Context, leaseTTL time.Duration) (delivered int, err error) {
+	deliveries, err := u.webhookRepository.GetDeliveries(ctx, domain.WebhookDeliveryArgs{
+		Statuses:   []valueobject.WebhookDeliveryStatus{valueobject.WEBHOOK_DELIVERY_PENDING},
+		DueBefore:  time.Now(),
+		Pagination: domain.Pagination{Limit: u.batch},
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+	webhookIDs := []int64{}
+	for _, d := range deliveries {
+		webhookIDs = append(webhookIDs, d.WebhookID)
+	}
+	webhooks, err := u.webhookRepository.Get(ctx, domain.WebhookArgs{IDs: webhookIDs})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	webhooksByID := map[int64]domain.Webhook{}
+	for _, w := range webhooks {
+		webhooksByID[w.ID] = w
+	}
+	for _, d := range deliveries {
+		if leaseTTL > 0 && !u.lease.hold(ctx, leaseTTL) {
+			return
+		}
+		webhook, ok := webhooksByID[d.WebhookID]
+		if !ok || webhook.IsDisabled {
+			d.Fail(time.Now(), 0, ErrWebhookDisabled, true)
+		} else if status, err := u.post(ctx, webhook, d); err != nil {
+			log.Println(err)
+			d.Fail(time.Now(), status, err, false)
+		} else {
+			d.Delivered(time.Now(), status)
+			delivered++
+		}
+		if err = u.webhookRepository.UpdateDelivery(ctx, d); err != nil {
+			log.Println(err)
+			return
+		}
+	}
+	return
+}
+
+// post sends a delivery signed with the secret of the webhook, any 2xx answer delivers it.
+// status is the answer of the receiver, zero when there is none.
+func (u *webhookUsecase) post(ctx context.Context, webhook domain.Webhook, delivery domain.WebhookDelivery) (status int, err error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(domain.WebhookEventHeader, delivery.Event)
+	req.Header.Set(domain.WebhookDeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(domain.WebhookTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(domain.WebhookSignatureHeader, webhook.Sign(now, body))
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	// the answer is not read, draining a little of it lets the connection be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	status = resp.StatusCode
+	if status < 200 || status > 299 {
+		err = fmt.Errorf("webhook %d answered %s", webhook.ID, resp.Status)
+	}
+	return
+}
+
+// Run dispatches every interval until ctx is done, a fully delivered batch is followed by another dispatch at once.
+// Only the replica holding WebhookLease dispatches.
+func (u *webhookUsecase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer u.lease.release()
+	// the lease outlasts a post answered as late as the client allows
+	leaseTTL := leaseIntervals*interval + u.client.Timeout
+	for {
+		for u.lease.hold(ctx, leaseTTL) {
+			delivered, err := u.dispatch(ctx, leaseTTL)
+			if err != nil || delivered < u.batch {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func randomHex(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
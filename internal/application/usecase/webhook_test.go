@@ -0,0 +1,271 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/memory"
+	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+	"github.com/hieronimusbudi/simple-go-api/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_webhookUsecase_Dispatch(t *testing.T) {
+	delivery := domain.WebhookDelivery{
+		ID:        10,
+		WebhookID: 1,
+		Event:     domain.WebhookMemberCreated,
+		Payload:   `{"id":"a","event":"member.created"}`,
+		Status:    valueobject.WEBHOOK_DELIVERY_PENDING,
+	}
+	tests := []struct {
+		name          string
+		answer        int
+		isDisabled    bool
+		wantDelivered int
+		funcUpdate    helpers.TestFuncCall
+	}{
+		{
+			name:          "delivered",
+			answer:        http.StatusNoContent,
+			wantDelivered: 1,
+			funcUpdate: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+					return d.ID == 10 && d.Status == valueobject.WEBHOOK_DELIVERY_DELIVERED && d.Attempts == 1 &&
+						d.ResponseStatus == http.StatusNoContent && d.DeliveredAt != ""
+				})},
+				Output: []interface{}{nil},
+			},
+		},
+		{
+			name:   "failed delivery is retried",
+			answer: http.StatusBadGateway,
+			funcUpdate: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+					return d.Status == valueobject.WEBHOOK_DELIVERY_PENDING && d.Attempts == 1 &&
+						d.ResponseStatus == http.StatusBadGateway && d.NextAttemptAt != "" && d.LastError != ""
+				})},
+				Output: []interface{}{nil},
+			},
+		},
+		{
+			name:       "disabled webhook is dead at once",
+			isDisabled: true,
+			funcUpdate: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+					return d.Status == valueobject.WEBHOOK_DELIVERY_DEAD && d.LastError == usecase.ErrWebhookDisabled.Error()
+				})},
+				Output: []interface{}{nil},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := domain.Webhook{ID: 1, Secret: "0123456789abcdef", Events: []string{domain.WebhookEventAll}, IsDisabled: tt.isDisabled}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				unix, _ := strconv.ParseInt(r.Header.Get(domain.WebhookTimestampHeader), 10, 64)
+				require.Equal(t, webhook.Sign(time.Unix(unix, 0), body), r.Header.Get(domain.WebhookSignatureHeader))
+				require.Equal(t, domain.WebhookMemberCreated, r.Header.Get(domain.WebhookEventHeader))
+				require.Equal(t, "10", r.Header.Get(domain.WebhookDeliveryHeader))
+				require.Equal(t, delivery.Payload, string(body))
+				w.WriteHeader(tt.answer)
+			}))
+			defer server.Close()
+			webhook.URL = server.URL
+
+			mockWebhook := new(mocks.IWebhook)
+			usecase := usecase.NewWebhookUsecase(usecase.WebhookUsecaseArgs{
+				WebhookRepository: mockWebhook,
+			})
+			mockWebhook.On("GetDeliveries", mock.Anything, mock.MatchedBy(func(args domain.WebhookDeliveryArgs) bool {
+				return !args.DueBefore.IsZero() && len(args.Statuses) == 1 && args.Statuses[0] == valueobject.WEBHOOK_DELIVERY_PENDING
+			})).Return([]domain.WebhookDelivery{delivery}, nil)
+			mockWebhook.On("Get", mock.Anything, domain.WebhookArgs{IDs: []int64{1}}).Return([]domain.Webhook{webhook}, nil)
+			if tt.funcUpdate.Called {
+				mockWebhook.On("UpdateDelivery", tt.funcUpdate.Input...).Return(tt.funcUpdate.Output...)
+			}
+			delivered, err := usecase.Dispatch(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tt.wantDelivered, delivered)
+			mockWebhook.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_webhookUsecase_Run_lease(t *testing.T) {
+	var posts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts.Add(1)
+		// a slow receiver keeps the delivery pending while the other replica ticks
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	store := memory.NewStore()
+	webhookRepository := memory.NewWebhookRepository(memory.WebhookAdapterRepositoryArgs{Store: store})
+	leaseRepository := memory.NewLeaseRepository(memory.LeaseAdapterRepositoryArgs{Store: store})
+	ctx, cancel := context.WithCancel(context.Background())
+	id, err := webhookRepository.Create(ctx, domain.Webhook{URL: server.URL, Secret: "0123456789abcdef", Events: []string{domain.WebhookEventAll}})
+	require.NoError(t, err)
+	_, err = webhookRepository.CreateDeliveries(ctx, []domain.WebhookDelivery{
+		{WebhookID: id, Event: domain.WebhookMemberCreated, Payload: `{"id":"a"}`, Status: valueobject.WEBHOOK_DELIVERY_PENDING},
+	})
+	require.NoError(t, err)
+
+	var replicas sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		replica := usecase.NewWebhookUsecase(usecase.WebhookUsecaseArgs{
+			WebhookRepository: webhookRepository,
+			LeaseRepository:   leaseRepository,
+		})
+		replicas.Add(1)
+		go func() {
+			defer replicas.Done()
+			replica.Run(ctx, 10*time.Millisecond)
+		}()
+	}
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+	replicas.Wait()
+	require.Equal(t, int32(1), posts.Load())
+	deliveries, err := webhookRepository.GetDeliveries(context.Background(), domain.WebhookDeliveryArgs{WebhookID: id})
+	require.NoError(t, err)
+	require.Equal(t, valueobject.WEBHOOK_DELIVERY_DELIVERED, deliveries[0].Status)
+	require.Equal(t, 1, deliveries[0].Attempts)
+}
+
+func Test_webhookUsecase_Publish(t *testing.T) {
+	webhooks := []domain.Webhook{
+		{ID: 1, Events: []string{domain.WebhookGatheringCreated}},
+		{ID: 2, Events: []string{domain.WebhookMemberCreated, domain.WebhookMemberDeleted}},
+	}
+	mockWebhook := new(mocks.IWebhook)
+	usecase := usecase.NewWebhookUsecase(usecase.WebhookUsecaseArgs{
+		WebhookRepository: mockWebhook,
+	})
+	mockWebhook.On("Get", mock.Anything, domain.WebhookArgs{IsEnabledOnly: true}).Return(webhooks, nil)
+	mockWebhook.On("CreateDeliveries", mock.Anything, mock.MatchedBy(func(deliveries []domain.WebhookDelivery) bool {
+		if len(deliveries) != 1 || deliveries[0].WebhookID != 2 || deliveries[0].Event != domain.WebhookMemberDeleted {
+			return false
+		}
+		payload := domain.WebhookPayload{}
+		if err := json.Unmarshal([]byte(deliveries[0].Payload), &payload); err != nil {
+			return false
+		}
+		return payload.ID != "" && payload.Event == domain.WebhookMemberDeleted
+	})).Return([]int64{10}, nil)
+	err := usecase.Publish(context.Background(), domain.WebhookMemberDeleted, domain.WebhookDeletion{ID: 3})
+	require.NoError(t, err)
+	mockWebhook.AssertExpectations(t)
+}
+
+func Test_webhookUsecase_Handle(t *testing.T) {
+	invitation := domain.Invitation{ID: 4, Status: valueobject.INVITATION_WAITLISTED}
+	tests := []struct {
+		name      string
+		event     domain.Event
+		wantEvent string
+	}{
+		{name: "member deleted", event: domain.MemberDeleted{MemberID: 3}, wantEvent: domain.WebhookMemberDeleted},
+		{name: "rescheduled is updated", event: domain.GatheringRescheduled{Gathering: domain.Gathering{ID: 2}}, wantEvent: domain.WebhookGatheringUpdated},
+		{name: "attendee removed is updated", event: domain.AttendeeRemoved{Gathering: domain.Gathering{ID: 2}, MemberID: 3}, wantEvent: domain.WebhookGatheringUpdated},
+		{name: "gathering deleted", event: domain.GatheringDeleted{Gathering: domain.Gathering{ID: 2}}, wantEvent: domain.WebhookGatheringDeleted},
+		{name: "invitation waitlisted", event: domain.InvitationWaitlisted{Invitation: invitation}, wantEvent: domain.WebhookInvitationWaitlisted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWebhook := new(mocks.IWebhook)
+			usecase := usecase.NewWebhookUsecase(usecase.WebhookUsecaseArgs{
+				WebhookRepository: mockWebhook,
+			})
+			mockWebhook.On("Get", mock.Anything, domain.WebhookArgs{IsEnabledOnly: true}).Return([]domain.Webhook{{ID: 1, Events: []string{domain.WebhookEventAll}}}, nil)
+			mockWebhook.On("CreateDeliveries", mock.Anything, mock.MatchedBy(func(deliveries []domain.WebhookDelivery) bool {
+				return len(deliveries) == 1 && deliveries[0].Event == tt.wantEvent
+			})).Return([]int64{10}, nil)
+			err := usecase.Handle(context.Background(), tt.event)
+			require.NoError(t, err)
+			mockWebhook.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_webhookUsecase_Replay(t *testing.T) {
+	admin := domain.ContextWithMember(context.Background(), domain.Member{ID: 1, Role: valueobject.ROLE_ADMIN})
+	dead := domain.WebhookDelivery{ID: 10, WebhookID: 1, Event: domain.WebhookMemberCreated, Payload: "{}", Status: valueobject.WEBHOOK_DELIVERY_DEAD, Attempts: 8}
+	tests := []struct {
+		name               string
+		ctx                context.Context
+		isDisabled         bool
+		wantErr            error
+		funcCreateDelivery helpers.TestFuncCall
+	}{
+		{
+			name: "success",
+			ctx:  admin,
+			funcCreateDelivery: helpers.TestFuncCall{
+				Called: true,
+				Input: []interface{}{mock.Anything, []domain.WebhookDelivery{{
+					WebhookID: 1,
+					Event:     domain.WebhookMemberCreated,
+					Payload:   "{}",
+					Status:    valueobject.WEBHOOK_DELIVERY_PENDING,
+					ReplayOf:  10,
+				}}},
+				Output: []interface{}{[]int64{11}, nil},
+			},
+		},
+		{
+			name:       "disabled webhook",
+			ctx:        admin,
+			isDisabled: true,
+			wantErr:    domain.ErrConflict,
+		},
+		{
+			name:    "not an admin",
+			ctx:     domain.ContextWithMember(context.Background(), domain.Member{ID: 2}),
+			wantErr: domain.ErrForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWebhook := new(mocks.IWebhook)
+			usecase := usecase.NewWebhookUsecase(usecase.WebhookUsecaseArgs{
+				WebhookRepository: mockWebhook,
+			})
+			mockWebhook.On("Get", mock.Anything, domain.WebhookArgs{IDs: []int64{1}}).
+				Return([]domain.Webhook{{ID: 1, Secret: "0123456789abcdef", IsDisabled: tt.isDisabled}}, nil).Maybe()
+			mockWebhook.On("GetDeliveries", mock.Anything, domain.WebhookDeliveryArgs{IDs: []int64{10}, WebhookID: 1}).
+				Return([]domain.WebhookDelivery{dead}, nil).Maybe()
+			if tt.funcCreateDelivery.Called {
+				mockWebhook.On("CreateDeliveries", tt.funcCreateDelivery.Input...).Return(tt.funcCreateDelivery.Output...)
+				mockWebhook.On("GetDeliveries", mock.Anything, domain.WebhookDeliveryArgs{IDs: []int64{11}}).
+					Return([]domain.WebhookDelivery{{ID: 11, WebhookID: 1, Status: valueobject.WEBHOOK_DELIVERY_PENDING, ReplayOf: 10}}, nil)
+			}
+			delivery, err := usecase.Replay(tt.ctx, 1, 10)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, int64(11), delivery.ID)
+			require.Equal(t, int64(10), delivery.ReplayOf)
+			mockWebhook.AssertExpectations(t)
+		})
+	}
+}
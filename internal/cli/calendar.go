@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+// importCalendar imports through the usecases like the API does, acting as the creator
+func importCalendar(fs *flag.FlagSet) func(args []string) error {
+	creator := fs.String("creator", "", "email of the member who creates the gatherings, required")
+	file := fs.String("file", "", "iCalendar file, default from stdin")
+	return func(args []string) (err error) {
+		if *creator == "" {
+			return errors.New("creator is required")
+		}
+		var r io.Reader = stdin
+		if *file != "" {
+			f, err := os.Open(*file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		events, err := domain.ParseCalendar(r)
+		if err != nil {
+			return
+		}
+		repos, err := repositories()
+		if err != nil {
+			return
+		}
+		ctx := context.Background()
+		members, err := repos.Member.Get(ctx, domain.MemberArgs{Email: *creator})
+		if err != nil {
+			return
+		}
+		if len(members) == 0 {
+			return fmt.Errorf("cannot find member %s", *creator)
+		}
+		memberUsecase := usecase.NewMemberUsecase(usecase.MemberUsecaseArgs{
+			MemberRepository: repos.Member,
+		})
+		gatheringUsecase := usecase.NewGatheringUsecase(usecase.GatheringUsecaseArgs{
+			GatheringRepository: repos.Gathering,
+		})
+		invitationUsecase := usecase.NewInvitationUsecase(usecase.InvitationUsecaseArgs{
+			InvitationRepository: repos.Invitation,
+			GatheringRepository:  repos.Gathering,
+			MemberRepository:     repos.Member,
+		})
+		calendarUsecase := usecase.NewCalendarUsecase(usecase.CalendarUsecaseArgs{
+			GatheringUsecase:  gatheringUsecase,
+			InvitationUsecase: invitationUsecase,
+			MemberUsecase:     memberUsecase,
+		})
+		results, err := calendarUsecase.Import(domain.ContextWithMember(ctx, members[0]), events)
+		for _, result := range results {
+			printCalendarImportResult(result)
+		}
+		return
+	}
+}
+
+func printCalendarImportResult(result domain.CalendarImportResult) {
+	if result.Status == domain.CalendarImportFailed {
+		messages := []string{}
+		for _, e := range result.Errors {
+			messages = append(messages, e.Field+": "+e.Message)
+		}
+		fmt.Fprintf(stdout, "%s: failed, %s\n", result.UID, strings.Join(messages, "; "))
+		return
+	}
+	fmt.Fprintf(stdout, "%s: %s gathering %d, invited %d", result.UID, result.Status, result.GatheringID, len(result.Invited))
+	if len(result.UnknownAttendees) > 0 {
+		fmt.Fprintf(stdout, ", unknown attendees %s", strings.Join(result.UnknownAttendees, ", "))
+	}
+	if len(result.Conflicts) > 0 {
+		fmt.Fprintf(stdout, ", overlaps %d occurrences", len(result.Conflicts))
+	}
+	fmt.Fprintln(stdout)
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter"
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/migration"
+	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
+	"github.com/hieronimusbudi/simple-go-api/internal/config"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+func checkConfig(fs *flag.FlagSet) func(args []string) error {
+	return func(args []string) (err error) {
+		cfg := config.Get()
+		fmt.Fprintf(stdout, "PORT=%s\nDBDRIVER=%s\nDBPATH=%s\nDBMIGRATE=%s\nDBHOST=%s\nDBUSER=%s\nDBPASSWORD=%s\nDBNAME=%s\nJWTSECRET=%s\nJWTTTL=%s\n",
+			cfg.PORT, cfg.DBDRIVER, cfg.DBPATH, cfg.DBMIGRATE, cfg.DBHOST, cfg.DBUSER, strings.Repeat("*", len(cfg.DBPASSWORD)), cfg.DBNAME,
+			strings.Repeat("*", len(cfg.JWTSECRET)), cfg.JWTTTL)
+		fmt.Fprintf(stdout, "SMTPHOST=%s\nSMTPUSERNAME=%s\nSMTPPASSWORD=%s\nSMTPFROM=%s\nNOTIFYINTERVAL=%s\nWEBHOOKINTERVAL=%s\nREMINDEROFFSETS=%s\nREMINDERINTERVAL=%s\n\n",
+			cfg.SMTPHOST, cfg.SMTPUSERNAME, strings.Repeat("*", len(cfg.SMTPPASSWORD)), cfg.SMTPFROM, cfg.NOTIFYINTERVAL, cfg.WEBHOOKINTERVAL,
+			cfg.REMINDEROFFSETS, cfg.REMINDERINTERVAL)
+		if problems := validateConfig(cfg); len(problems) > 0 {
+			for _, p := range problems {
+				fmt.Fprintln(stdout, "invalid:", p)
+			}
+			return errors.New("config is invalid")
+		}
+
+		db, driver := adapter.Connection()
+		if db == nil {
+			fmt.Fprintln(stdout, "config is valid, memory driver needs no database")
+			return
+		}
+		defer db.Close()
+		migrator, err := migration.NewMigrator(migration.MigratorArgs{DB: db, Dialect: driver})
+		if err != nil {
+			return
+		}
+		pending, err := migrator.Pending(context.Background())
+		if err != nil {
+			return
+		}
+		if len(pending) > 0 {
+			fmt.Fprintf(stdout, "database is reachable, %d migration(s) pending\n", len(pending))
+			if strings.ToLower(cfg.DBMIGRATE) == adapter.MigrateCheck {
+				return errors.New("server would refuse to start, run \"migrate up\" first")
+			}
+			return
+		}
+		fmt.Fprintln(stdout, "config is valid, database is reachable and up to date")
+		return
+	}
+}
+
+func validateConfig(cfg *config.Config) (problems []string) {
+	if port, err := strconv.Atoi(cfg.PORT); err != nil || port <= 0 || port > 65535 {
+		problems = append(problems, fmt.Sprintf("PORT %q must be a number between 1 and 65535", cfg.PORT))
+	}
+	switch cfg.DBDRIVER {
+	case adapter.DriverMySQL, "":
+		if cfg.DBHOST == "" || cfg.DBUSER == "" || cfg.DBNAME == "" {
+			problems = append(problems, "DBHOST, DBUSER and DBNAME are required by mysql driver")
+		}
+	case adapter.DriverSQLite, adapter.DriverMemory:
+	default:
+		problems = append(problems, fmt.Sprintf("DBDRIVER %q must be mysql, sqlite or memory", cfg.DBDRIVER))
+	}
+	if err := usecase.ValidateSecret(cfg.JWTSECRET); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if cfg.JWTTTL != "" {
+		if ttl, err := time.ParseDuration(cfg.JWTTTL); err != nil || ttl <= 0 {
+			problems = append(problems, fmt.Sprintf("JWTTTL %q must be a positive duration e.g. 24h", cfg.JWTTTL))
+		}
+	}
+	if cfg.SMTPHOST != "" {
+		if _, _, err := net.SplitHostPort(cfg.SMTPHOST); err != nil {
+			problems = append(problems, fmt.Sprintf("SMTPHOST %q must be host:port e.g. smtp.example.com:587", cfg.SMTPHOST))
+		}
+		if _, err := mail.ParseAddress(cfg.SMTPFROM); err != nil {
+			problems = append(problems, fmt.Sprintf("SMTPFROM %q must be a mail address to send notifications", cfg.SMTPFROM))
+		}
+	}
+	if cfg.NOTIFYINTERVAL != "" {
+		if interval, err := time.ParseDuration(cfg.NOTIFYINTERVAL); err != nil || interval <= 0 {
+			problems = append(problems, fmt.Sprintf("NOTIFYINTERVAL %q must be a positive duration e.g. 10s", cfg.NOTIFYINTERVAL))
+		}
+	}
+	if cfg.WEBHOOKINTERVAL != "" {
+		if interval, err := time.ParseDuration(cfg.WEBHOOKINTERVAL); err != nil || interval <= 0 {
+			problems = append(problems, fmt.Sprintf("WEBHOOKINTERVAL %q must be a positive duration e.g. 10s", cfg.WEBHOOKINTERVAL))
+		}
+	}
+	if _, err := domain.ParseReminderOffsets(cfg.REMINDEROFFSETS); err != nil {
+		problems = append(problems, fmt.Sprintf("REMINDEROFFSETS %q is invalid, %s", cfg.REMINDEROFFSETS, err))
+	}
+	if cfg.REMINDERINTERVAL != "" {
+		if interval, err := time.ParseDuration(cfg.REMINDERINTERVAL); err != nil || interval <= 0 {
+			problems = append(problems, fmt.Sprintf("REMINDERINTERVAL %q must be a positive duration e.g. 1m", cfg.REMINDERINTERVAL))
+		}
+	}
+	switch strings.ToLower(cfg.DBMIGRATE) {
+	case "", adapter.MigrateOff, adapter.MigrateCheck, adapter.MigrateAuto:
+	default:
+		problems = append(problems, fmt.Sprintf("DBMIGRATE %q must be off, check or auto", cfg.DBMIGRATE))
+	}
+	return
+}
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter"
+	"github.com/hieronimusbudi/simple-go-api/internal/config"
+)
+
+type command struct {
+	usage   string
+	summary string
+	// setup defines command flags, the returned func runs the command with remaining args after flags are parsed
+	setup func(fs *flag.FlagSet) func(args []string) error
+}
+
+var (
+	commands = map[string]command{
+		"serve": {
+			usage:   "serve",
+			summary: "start HTTP server, default when no command is given",
+			setup:   serve,
+		},
+		"migrate": {
+			usage:   "migrate up|down|status",
+			summary: "apply, revert or list schema migrations",
+			setup:   migrate,
+		},
+		"seed": {
+			usage:   "seed",
+			summary: "load sample members, gatherings and invitations into an empty database",
+			setup:   seed,
+		},
+		"export": {
+			usage:   "export [-file path]",
+			summary: "write members, gatherings and invitations as JSON, default to stdout",
+			setup:   export,
+		},
+		"import": {
+			usage:   "import [-file path]",
+			summary: "create members, gatherings and invitations from an export file, default from stdin",
+			setup:   importFile,
+		},
+		"import-ics": {
+			usage:   "import-ics -creator <email> [-file path]",
+			summary: "create gatherings of a member from an iCalendar file and invite attendees who are members, default from stdin",
+			setup:   importCalendar,
+		},
+		"purge-discarded": {
+			usage:   "purge-discarded [-older-than 720h]",
+			summary: "hard delete members and gatherings discarded longer than the given duration ago",
+			setup:   purgeDiscarded,
+		},
+		"set-password": {
+			usage:   "set-password -email <email> [-password <password>]",
+			summary: "set login password of a member, e.g. one created before authentication existed",
+			setup:   setPassword,
+		},
+		"set-role": {
+			usage:   "set-role -email <email> [-role admin]",
+			summary: "set role of a member, an admin can update and delete any member",
+			setup:   setRole,
+		},
+		"check-config": {
+			usage:   "check-config",
+			summary: "validate config, database connection and pending migrations",
+			setup:   checkConfig,
+		},
+	}
+
+	// configFlags maps flag names to config keys, a flag given on command line wins over .env
+	configFlags = map[string]string{
+		"port":              "PORT",
+		"db-driver":         "DBDRIVER",
+		"db-path":           "DBPATH",
+		"db-migrate":        "DBMIGRATE",
+		"db-host":           "DBHOST",
+		"db-user":           "DBUSER",
+		"db-password":       "DBPASSWORD",
+		"db-name":           "DBNAME",
+		"jwt-secret":        "JWTSECRET",
+		"jwt-ttl":           "JWTTTL",
+		"smtp-host":         "SMTPHOST",
+		"smtp-username":     "SMTPUSERNAME",
+		"smtp-password":     "SMTPPASSWORD",
+		"smtp-from":         "SMTPFROM",
+		"notify-interval":   "NOTIFYINTERVAL",
+		"webhook-interval":  "WEBHOOKINTERVAL",
+		"reminder-offsets":  "REMINDEROFFSETS",
+		"reminder-interval": "REMINDERINTERVAL",
+	}
+
+	stdout io.Writer = os.Stdout
+	stdin  io.Reader = os.Stdin
+)
+
+// Run loads config then runs the command named by the first arg, e.g. ["migrate", "-db-driver", "sqlite", "up"]
+func Run(args []string) (err error) {
+	name := "serve"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+	if name == "help" {
+		printUsage(stdout)
+		return
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		printUsage(stdout)
+		return fmt.Errorf("unknown command %s", name)
+	}
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stdout)
+	configPath := fs.String("config", ".", "directory of .env config file")
+	overrides := map[string]*string{}
+	for name, key := range configFlags {
+		overrides[name] = fs.String(name, "", fmt.Sprintf("override %s config", key))
+	}
+	run := cmd.setup(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(stdout, "Usage: gathering_app %s\n\n%s\n\nFlags:\n", cmd.usage, cmd.summary)
+		fs.PrintDefaults()
+	}
+	if err = fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return
+	}
+
+	config.SetConfig(*configPath)
+	values := map[string]string{}
+	fs.Visit(func(f *flag.Flag) {
+		if key, ok := configFlags[f.Name]; ok {
+			values[key] = *overrides[f.Name]
+		}
+	})
+	if len(values) > 0 {
+		config.Override(values)
+	}
+	return run(fs.Args())
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: gathering_app <command> [flags]\n\nCommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-16s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(w, "\nRun \"gathering_app <command> -h\" for flags, every command accepts -config and config override flags e.g. -db-driver sqlite.")
+}
+
+func serve(fs *flag.FlagSet) func(args []string) error {
+	return func(args []string) error {
+		r := adapter.Router()
+		return r.Run(fmt.Sprintf(":%s", config.Get().PORT))
+	}
+}
+
+func migrate(fs *flag.FlagSet) func(args []string) error {
+	return func(args []string) error {
+		if len(args) != 1 {
+			return errors.New("usage: migrate up|down|status")
+		}
+		return adapter.Migrate(args[0], stdout)
+	}
+}
@@ -0,0 +1,167 @@
+package cli_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/repository"
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/sqlite"
+	"github.com/hieronimusbudi/simple-go-api/internal/cli"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+// setupConfig writes a mysql .env, commands switch to sqlite with override flags so no database server is needed
+func setupConfig(t *testing.T) (flags []string, dbPath string) {
+	dir := t.TempDir()
+	env := "PORT=3000\nDBDRIVER=mysql\nDBMIGRATE=check\nDBHOST=localhost:3306\nDBUSER=root\nDBNAME=gathering_db\nJWTSECRET=0123456789abcdef0123456789abcdef\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte(env), 0o600))
+	dbPath = filepath.Join(dir, "gathering.db")
+	return []string{"-config", dir, "-db-driver", "sqlite", "-db-path", dbPath}, dbPath
+}
+
+func TestRun_environment(t *testing.T) {
+	flags, _ := setupConfig(t)
+	// an environment variable wins over .env, a flag wins over both
+	t.Setenv("JWTSECRET", "short")
+	require.Error(t, cli.Run(append([]string{"check-config", "-db-driver", "memory"}, flags[:2]...)))
+	require.NoError(t, cli.Run(append([]string{"check-config", "-db-driver", "memory", "-jwt-secret", "0123456789abcdef0123456789abcdef"}, flags[:2]...)))
+}
+
+func TestRun(t *testing.T) {
+	flags, dbPath := setupConfig(t)
+	exportPath := filepath.Join(filepath.Dir(dbPath), "export.json")
+	calendarPath := filepath.Join(filepath.Dir(dbPath), "standup.ics")
+	calendar := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:standup@example.com\r\nDTSTART;TZID=Asia/Jakarta:20231002T090000\r\n" +
+		"SUMMARY:standup\r\nLOCATION:room 1\r\nATTENDEE:mailto:ron@mail.com\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	require.NoError(t, os.WriteFile(calendarPath, []byte(calendar), 0o600))
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name:    "unknown command",
+			args:    []string{"bogus"},
+			wantErr: true,
+		},
+		{
+			name:    "check config with pending migrations",
+			args:    append([]string{"check-config"}, flags...),
+			wantErr: true,
+		},
+		{
+			name:    "migrate without direction",
+			args:    append([]string{"migrate"}, flags...),
+			wantErr: true,
+		},
+		{
+			name: "migrate up",
+			args: append(append([]string{"migrate"}, flags...), "up"),
+		},
+		{
+			name: "check config",
+			args: append([]string{"check-config"}, flags...),
+		},
+		{
+			name:    "check config with short JWT secret override",
+			args:    append(append([]string{"check-config"}, flags...), "-jwt-secret", "short"),
+			wantErr: true,
+		},
+		{
+			name:    "check config with SMTP host override without sender",
+			args:    append(append([]string{"check-config"}, flags...), "-smtp-host", "smtp.example.com:587"),
+			wantErr: true,
+		},
+		{
+			name: "check config with SMTP overrides",
+			args: append(append([]string{"check-config"}, flags...), "-smtp-host", "smtp.example.com:587", "-smtp-from", "no-reply@example.com"),
+		},
+		{
+			name: "seed",
+			args: append([]string{"seed"}, flags...),
+		},
+		{
+			name:    "seed non empty database",
+			args:    append([]string{"seed"}, flags...),
+			wantErr: true,
+		},
+		{
+			name: "export",
+			args: append(append([]string{"export"}, flags...), "-file", exportPath),
+		},
+		{
+			name: "import",
+			args: append(append([]string{"import"}, flags...), "-file", exportPath),
+		},
+		{
+			name:    "import ics without creator",
+			args:    append(append([]string{"import-ics"}, flags...), "-file", calendarPath),
+			wantErr: true,
+		},
+		{
+			name: "import ics",
+			args: append(append([]string{"import-ics"}, flags...), "-creator", "linus@mail.com", "-file", calendarPath),
+		},
+		{
+			name: "import ics again",
+			args: append(append([]string{"import-ics"}, flags...), "-creator", "linus@mail.com", "-file", calendarPath),
+		},
+		{
+			name:    "set password of unknown member",
+			args:    append(append([]string{"set-password"}, flags...), "-email", "nobody@mail.com", "-password", "secret-password"),
+			wantErr: true,
+		},
+		{
+			name: "set password",
+			args: append(append([]string{"set-password"}, flags...), "-email", "linus@mail.com", "-password", "secret-password"),
+		},
+		{
+			name:    "set invalid role",
+			args:    append(append([]string{"set-role"}, flags...), "-email", "linus@mail.com", "-role", "owner"),
+			wantErr: true,
+		},
+		{
+			name: "set role",
+			args: append(append([]string{"set-role"}, flags...), "-email", "linus@mail.com", "-role", "admin"),
+		},
+		{
+			name: "purge discarded",
+			args: append(append([]string{"purge-discarded"}, flags...), "-older-than", "0s"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := cli.Run(tt.args)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	// check data, import reuses members by email and creates a copy of gatherings, invitations and groups,
+	// import-ics creates a gathering and an invitation once
+	db, err := sqlite.Open(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+	members, err := repository.NewMemberRepository(repository.MemberAdapterRepositoryArgs{DB: db}).Get(context.Background(), domain.MemberArgs{})
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	gatherings, err := repository.NewGatheringRepository(repository.GatheringAdapterRepositoryArgs{DB: db}).Get(context.Background(), domain.GatheringArgs{})
+	require.NoError(t, err)
+	require.Len(t, gatherings, 3)
+	require.Equal(t, gatherings[0].Attendees, gatherings[1].Attendees)
+	require.Equal(t, "standup@example.com", gatherings[2].CalendarUID)
+	invitations, err := repository.NewInvitationRepository(repository.InvitationAdapterRepositoryArgs{DB: db}).Get(context.Background(), domain.InvitationArgs{})
+	require.NoError(t, err)
+	require.Len(t, invitations, 3)
+	groups, err := repository.NewGroupRepository(repository.GroupAdapterRepositoryArgs{DB: db}).Get(context.Background(), domain.GroupArgs{})
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	require.Equal(t, groups[0].MemberIDs(), groups[1].MemberIDs())
+	require.Equal(t, []int64{gatherings[1].ID}, groups[1].GatheringIDs)
+}
@@ -0,0 +1,458 @@
+package cli
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter"
+	"github.com/hieronimusbudi/simple-go-api/internal/config"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/hieronimusbudi/simple-go-api/internal/helpers"
+)
+
+const timeFormat = "2006-01-02 15:04:05"
+
+//go:embed seed.json
+var seedData []byte
+
+type (
+	// Dump is the export and import file format, IDs only link rows inside the file
+	Dump struct {
+		Members     []memberRecord     `json:"members"`
+		Gatherings  []gatheringRecord  `json:"gatherings"`
+		Invitations []invitationRecord `json:"invitations"`
+		// Groups are missing from older dumps
+		Groups []groupRecord `json:"groups,omitempty"`
+	}
+
+	memberRecord struct {
+		ID        int64  `json:"id"`
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+		Email     string `json:"email"`
+		// Role is omitted for plain members
+		Role        valueobject.MemberRole `json:"role,omitempty"`
+		CreatedAt   string                 `json:"created_at,omitempty"`
+		DiscardedAt string                 `json:"discarded_at,omitempty"`
+	}
+
+	gatheringRecord struct {
+		ID        int64                     `json:"id"`
+		CreatorID int64                     `json:"creator_id"`
+		Type      valueobject.GatheringType `json:"type"`
+		// ScheduledAt and EndsAt use RFC 3339 format, older dumps have UTC (YYYY-MM-DD HH:MM:SS) format
+		ScheduledAt string `json:"scheduled_at"`
+		EndsAt      string `json:"ends_at,omitempty"`
+		TimeZone    string `json:"time_zone,omitempty"`
+		Recurrence  string `json:"recurrence,omitempty"`
+		// Exceptions are edited or canceled occurrences of a recurring gathering
+		Exceptions []domain.Occurrence `json:"exceptions,omitempty"`
+		// CalendarUID is set on gatherings imported from iCalendar
+		CalendarUID string  `json:"calendar_uid,omitempty"`
+		Name        string  `json:"name"`
+		Location    string  `json:"location"`
+		Capacity    int     `json:"capacity,omitempty"`
+		AttendeeIDs []int64 `json:"attendee_ids"`
+		CreatedAt   string  `json:"created_at,omitempty"`
+		DiscardedAt string  `json:"discarded_at,omitempty"`
+	}
+
+	invitationRecord struct {
+		ID          int64                        `json:"id"`
+		MemberID    int64                        `json:"member_id"`
+		GatheringID int64                        `json:"gathering_id"`
+		Status      valueobject.InvitationStatus `json:"status"`
+		// WaitlistedAt keeps the waitlist order of waitlisted invitations
+		WaitlistedAt string `json:"waitlisted_at,omitempty"`
+		Guests       int    `json:"guests,omitempty"`
+		Note         string `json:"note,omitempty"`
+		CreatedAt    string `json:"created_at,omitempty"`
+	}
+
+	groupRecord struct {
+		ID      int64               `json:"id"`
+		Name    string              `json:"name"`
+		Members []groupMemberRecord `json:"members"`
+		// GatheringIDs are the gatherings members joining later are invited to
+		GatheringIDs []int64 `json:"gathering_ids"`
+	}
+
+	groupMemberRecord struct {
+		MemberID int64                 `json:"member_id"`
+		Role     valueobject.GroupRole `json:"role"`
+	}
+
+	// importResult counts created rows, members already existing by email are reused
+	importResult struct {
+		Members        int
+		ExistedMembers int
+		Gatherings     int
+		Invitations    int
+		Groups         int
+	}
+)
+
+// repositories opens repositories of DBDRIVER config, memory driver data only lives inside a running server
+func repositories() (repos adapter.Repositories, err error) {
+	if config.Get().DBDRIVER == adapter.DriverMemory {
+		return repos, errors.New("memory driver keeps data inside the server process, nothing to maintain")
+	}
+	return adapter.NewRepositories(), nil
+}
+
+func seed(fs *flag.FlagSet) func(args []string) error {
+	return func(args []string) error {
+		repos, err := repositories()
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		total, err := repos.Member.Count(ctx, domain.MemberArgs{IsIncludeDiscard: true})
+		if err != nil {
+			return err
+		}
+		if total > 0 {
+			return errors.New("database is not empty, seed only loads into an empty database")
+		}
+		var dump Dump
+		if err = json.Unmarshal(seedData, &dump); err != nil {
+			return err
+		}
+		result, err := importDump(ctx, repos, dump)
+		printImportResult(result)
+		return err
+	}
+}
+
+func export(fs *flag.FlagSet) func(args []string) error {
+	file := fs.String("file", "", "output file, default to stdout")
+	return func(args []string) (err error) {
+		repos, err := repositories()
+		if err != nil {
+			return
+		}
+		dump, err := exportDump(context.Background(), repos)
+		if err != nil {
+			return
+		}
+		var w io.Writer = stdout
+		if *file != "" {
+			f, err := os.Create(*file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(dump)
+	}
+}
+
+func importFile(fs *flag.FlagSet) func(args []string) error {
+	file := fs.String("file", "", "input file, default from stdin")
+	return func(args []string) (err error) {
+		var r io.Reader = stdin
+		if *file != "" {
+			f, err := os.Open(*file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		var dump Dump
+		if err = json.NewDecoder(r).Decode(&dump); err != nil {
+			return fmt.Errorf("invalid import file: %w", err)
+		}
+		repos, err := repositories()
+		if err != nil {
+			return
+		}
+		result, err := importDump(context.Background(), repos, dump)
+		printImportResult(result)
+		return
+	}
+}
+
+func purgeDiscarded(fs *flag.FlagSet) func(args []string) error {
+	olderThan := fs.Duration("older-than", 30*24*time.Hour, "only purge rows discarded longer than this ago, 0 purges every discarded row")
+	return func(args []string) (err error) {
+		repos, err := repositories()
+		if err != nil {
+			return
+		}
+		ctx := context.Background()
+		before := time.Now().UTC().Add(-*olderThan).Format(timeFormat)
+		// gatherings first, so members who only created discarded gatherings can be purged too
+		gatherings, err := repos.Gathering.Purge(ctx, before)
+		if err != nil {
+			return
+		}
+		members, err := repos.Member.Purge(ctx, before)
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(stdout, "purged %d gathering(s) and %d member(s) discarded before %s\n", gatherings, members, before)
+		return
+	}
+}
+
+func exportDump(ctx context.Context, repos adapter.Repositories) (dump Dump, err error) {
+	members, err := repos.Member.Get(ctx, domain.MemberArgs{IsIncludeDiscard: true})
+	if err != nil {
+		return
+	}
+	gatherings, err := repos.Gathering.Get(ctx, domain.GatheringArgs{IsIncludeDiscard: true})
+	if err != nil {
+		return
+	}
+	invitations, err := repos.Invitation.Get(ctx, domain.InvitationArgs{})
+	if err != nil {
+		return
+	}
+	groups, err := repos.Group.Get(ctx, domain.GroupArgs{})
+	if err != nil {
+		return
+	}
+	dump = Dump{Members: []memberRecord{}, Gatherings: []gatheringRecord{}, Invitations: []invitationRecord{}, Groups: []groupRecord{}}
+	for _, m := range members {
+		role := m.Role
+		if role == valueobject.ROLE_MEMBER {
+			role = ""
+		}
+		dump.Members = append(dump.Members, memberRecord{
+			ID:          m.ID,
+			FirstName:   m.FirstName,
+			LastName:    m.LastName,
+			Email:       m.Email,
+			Role:        role,
+			CreatedAt:   dbTime(m.CreatedAt),
+			DiscardedAt: dbTime(m.DiscardedAt),
+		})
+	}
+	for _, g := range gatherings {
+		attendeeIDs := []int64{}
+		for _, a := range g.Attendees {
+			attendeeIDs = append(attendeeIDs, a.ID)
+		}
+		endsAt := ""
+		if g.EndsAt != nil {
+			endsAt = g.EndsAt.Format(time.RFC3339)
+		}
+		dump.Gatherings = append(dump.Gatherings, gatheringRecord{
+			ID:          g.ID,
+			CreatorID:   g.Creator.ID,
+			Type:        g.Type,
+			ScheduledAt: g.ScheduledAt.Format(time.RFC3339),
+			EndsAt:      endsAt,
+			TimeZone:    g.TimeZone,
+			Recurrence:  g.Recurrence,
+			Exceptions:  g.Exceptions,
+			CalendarUID: g.CalendarUID,
+			Name:        g.Name,
+			Location:    g.Location,
+			Capacity:    g.Capacity,
+			AttendeeIDs: attendeeIDs,
+			CreatedAt:   dbTime(g.CreatedAt),
+			DiscardedAt: dbTime(g.DiscardedAt),
+		})
+	}
+	for _, inv := range invitations {
+		dump.Invitations = append(dump.Invitations, invitationRecord{
+			ID:           inv.ID,
+			MemberID:     inv.Member.ID,
+			GatheringID:  inv.Gathering.ID,
+			Status:       inv.Status,
+			WaitlistedAt: dbTime(inv.WaitlistedAt),
+			Guests:       inv.Guests,
+			Note:         inv.Note,
+			CreatedAt:    dbTime(inv.CreatedAt),
+		})
+	}
+	for _, g := range groups {
+		members := []groupMemberRecord{}
+		for _, m := range g.Members {
+			members = append(members, groupMemberRecord{MemberID: m.MemberID, Role: m.Role})
+		}
+		dump.Groups = append(dump.Groups, groupRecord{
+			ID:           g.ID,
+			Name:         g.Name,
+			Members:      members,
+			GatheringIDs: g.GatheringIDs,
+		})
+	}
+	return
+}
+
+// importDump creates rows through repositories with new IDs, rows created before an error are kept.
+// Discarded rows are created then discarded again, created_at becomes the import time.
+func importDump(ctx context.Context, repos adapter.Repositories, dump Dump) (result importResult, err error) {
+	memberIDs := map[int64]int64{}
+	for _, m := range dump.Members {
+		member := domain.Member{FirstName: m.FirstName, LastName: m.LastName, Email: m.Email}
+		if err = member.Validate(); err != nil {
+			return result, fmt.Errorf("member %d: %w", m.ID, err)
+		}
+		existing, err := repos.Member.Get(ctx, domain.MemberArgs{Email: m.Email, IsIncludeDiscard: true})
+		if err != nil {
+			return result, err
+		}
+		if len(existing) > 0 {
+			memberIDs[m.ID] = existing[0].ID
+			result.ExistedMembers++
+			continue
+		}
+		if memberIDs[m.ID], err = repos.Member.Create(ctx, member); err != nil {
+			return result, fmt.Errorf("member %d: %w", m.ID, err)
+		}
+		if m.Role != "" && m.Role != valueobject.ROLE_MEMBER {
+			member.ID, member.Role = memberIDs[m.ID], m.Role
+			if err = repos.Member.UpdateRole(ctx, member); err != nil {
+				return result, fmt.Errorf("member %d: %w", m.ID, err)
+			}
+		}
+		if m.DiscardedAt != "" {
+			if err = repos.Member.Delete(ctx, domain.MemberArgs{ID: memberIDs[m.ID]}); err != nil {
+				return result, err
+			}
+		}
+		result.Members++
+	}
+
+	gatheringIDs := map[int64]int64{}
+	for _, g := range dump.Gatherings {
+		creatorID, ok := memberIDs[g.CreatorID]
+		if !ok {
+			return result, fmt.Errorf("gathering %d: unknown creator %d", g.ID, g.CreatorID)
+		}
+		gathering := domain.Gathering{
+			Creator:     domain.Member{ID: creatorID},
+			Type:        g.Type,
+			TimeZone:    g.TimeZone,
+			Recurrence:  g.Recurrence,
+			Exceptions:  g.Exceptions,
+			CalendarUID: g.CalendarUID,
+			Name:        g.Name,
+			Location:    g.Location,
+			Capacity:    g.Capacity,
+		}
+		if gathering.TimeZone == "" {
+			gathering.TimeZone = domain.DefaultTimeZone
+		}
+		var loc *time.Location
+		if loc, err = time.LoadLocation(gathering.TimeZone); err != nil {
+			return result, fmt.Errorf("gathering %d: %w", g.ID, err)
+		}
+		if gathering.Recurrence != "" {
+			if _, err = domain.ParseRecurrenceRule(gathering.Recurrence, loc); err != nil {
+				return result, fmt.Errorf("gathering %d: %w", g.ID, err)
+			}
+		}
+		if gathering.ScheduledAt, err = helpers.ParseDBTime(g.ScheduledAt); err != nil {
+			return result, fmt.Errorf("gathering %d: invalid scheduled_at: %w", g.ID, err)
+		}
+		if g.EndsAt != "" {
+			endsAt, err := helpers.ParseDBTime(g.EndsAt)
+			if err != nil {
+				return result, fmt.Errorf("gathering %d: invalid ends_at: %w", g.ID, err)
+			}
+			gathering.EndsAt = &endsAt
+		}
+		for _, attendeeID := range g.AttendeeIDs {
+			memberID, ok := memberIDs[attendeeID]
+			if !ok {
+				return result, fmt.Errorf("gathering %d: unknown attendee %d", g.ID, attendeeID)
+			}
+			gathering.Attendees = append(gathering.Attendees, domain.Member{ID: memberID})
+		}
+		if gatheringIDs[g.ID], err = repos.Gathering.Create(ctx, gathering); err != nil {
+			return result, fmt.Errorf("gathering %d: %w", g.ID, err)
+		}
+		if g.DiscardedAt != "" {
+			if err = repos.Gathering.Delete(ctx, domain.GatheringArgs{ID: gatheringIDs[g.ID]}); err != nil {
+				return
+			}
+		}
+		result.Gatherings++
+	}
+
+	for _, inv := range dump.Invitations {
+		memberID, ok := memberIDs[inv.MemberID]
+		if !ok {
+			return result, fmt.Errorf("invitation %d: unknown member %d", inv.ID, inv.MemberID)
+		}
+		gatheringID, ok := gatheringIDs[inv.GatheringID]
+		if !ok {
+			return result, fmt.Errorf("invitation %d: unknown gathering %d", inv.ID, inv.GatheringID)
+		}
+		invitation := domain.Invitation{
+			Member:       domain.Member{ID: memberID},
+			Gathering:    domain.Gathering{ID: gatheringID},
+			Status:       inv.Status,
+			WaitlistedAt: inv.WaitlistedAt,
+			Guests:       inv.Guests,
+			Note:         inv.Note,
+		}
+		if _, err = repos.Invitation.Create(ctx, invitation); err != nil {
+			return result, fmt.Errorf("invitation %d: %w", inv.ID, err)
+		}
+		result.Invitations++
+	}
+
+	for _, g := range dump.Groups {
+		group := domain.Group{Name: g.Name, Members: []domain.GroupMember{}}
+		if err = group.Validate(); err != nil {
+			return result, fmt.Errorf("group %d: %w", g.ID, err)
+		}
+		for _, m := range g.Members {
+			memberID, ok := memberIDs[m.MemberID]
+			if !ok {
+				return result, fmt.Errorf("group %d: unknown member %d", g.ID, m.MemberID)
+			}
+			member := domain.GroupMember{MemberID: memberID, Role: m.Role}
+			if err = member.Validate(); err != nil {
+				return result, fmt.Errorf("group %d: %w", g.ID, err)
+			}
+			group.Members = append(group.Members, member)
+		}
+		groupID, err := repos.Group.Create(ctx, group)
+		if err != nil {
+			return result, fmt.Errorf("group %d: %w", g.ID, err)
+		}
+		for _, id := range g.GatheringIDs {
+			gatheringID, ok := gatheringIDs[id]
+			if !ok {
+				return result, fmt.Errorf("group %d: unknown gathering %d", g.ID, id)
+			}
+			if err = repos.Group.AddGathering(ctx, groupID, gatheringID); err != nil {
+				return result, fmt.Errorf("group %d: %w", g.ID, err)
+			}
+		}
+		result.Groups++
+	}
+	return
+}
+
+func printImportResult(result importResult) {
+	fmt.Fprintf(stdout, "created %d member(s), %d gathering(s), %d invitation(s), %d group(s), reused %d existing member(s)\n",
+		result.Members, result.Gatherings, result.Invitations, result.Groups, result.ExistedMembers)
+}
+
+// dbTime normalizes RFC 3339 timestamps scanned with parseTime into the format every adapter stores
+func dbTime(value string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return t.UTC().Format(timeFormat)
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/application/usecase"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+func setPassword(fs *flag.FlagSet) func(args []string) error {
+	email := fs.String("email", "", "email of the member, required")
+	password := fs.String("password", "", "new password, read from the first line of stdin when empty")
+	return func(args []string) (err error) {
+		if *email == "" {
+			return errors.New("email is required")
+		}
+		if *password == "" {
+			line, err := bufio.NewReader(stdin).ReadString('\n')
+			if err != nil && line == "" {
+				return errors.New("password is required")
+			}
+			*password = strings.TrimRight(line, "\r\n")
+		}
+		repos, err := repositories()
+		if err != nil {
+			return
+		}
+		ctx := context.Background()
+		members, err := repos.Member.Get(ctx, domain.MemberArgs{Email: *email})
+		if err != nil {
+			return
+		}
+		if len(members) == 0 {
+			return fmt.Errorf("cannot find member %s", *email)
+		}
+		authUsecase := usecase.NewAuthUsecase(usecase.AuthUsecaseArgs{
+			MemberRepository:     repos.Member,
+			CredentialRepository: repos.Credential,
+		})
+		if err = authUsecase.SetPassword(ctx, members[0].ID, *password); err != nil {
+			return
+		}
+		fmt.Fprintf(stdout, "password of %s is set\n", members[0].Email)
+		return
+	}
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+// setRole is the only way to grant admin role, the API never changes roles
+func setRole(fs *flag.FlagSet) func(args []string) error {
+	email := fs.String("email", "", "email of the member, required")
+	role := fs.String("role", string(valueobject.ROLE_ADMIN), "member or admin")
+	return func(args []string) (err error) {
+		if *email == "" {
+			return errors.New("email is required")
+		}
+		switch valueobject.MemberRole(*role) {
+		case valueobject.ROLE_MEMBER, valueobject.ROLE_ADMIN:
+		default:
+			return fmt.Errorf("invalid role %s", *role)
+		}
+		repos, err := repositories()
+		if err != nil {
+			return
+		}
+		ctx := context.Background()
+		members, err := repos.Member.Get(ctx, domain.MemberArgs{Email: *email})
+		if err != nil {
+			return
+		}
+		if len(members) == 0 {
+			return fmt.Errorf("cannot find member %s", *email)
+		}
+		member := members[0]
+		member.Role = valueobject.MemberRole(*role)
+		if err = repos.Member.UpdateRole(ctx, member); err != nil {
+			return
+		}
+		fmt.Fprintf(stdout, "role of %s is set to %s\n", member.Email, member.Role)
+		return
+	}
+}
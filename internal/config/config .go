@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"log"
+	"reflect"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
@@ -10,16 +11,33 @@ import (
 
 type Config struct {
 	PORT       string `mapstructure:"PORT"`
+	DBDRIVER   string `mapstructure:"DBDRIVER"`  // mysql (default), sqlite or memory
+	DBPATH     string `mapstructure:"DBPATH"`    // database file of sqlite driver
+	DBMIGRATE  string `mapstructure:"DBMIGRATE"` // off (default), check refuses to start on pending migrations, auto applies them
 	DBHOST     string `mapstructure:"DBHOST"`
 	DBUSER     string `mapstructure:"DBUSER"`
 	DBPASSWORD string `mapstructure:"DBPASSWORD"`
 	DBNAME     string `mapstructure:"DBNAME"`
+	JWTSECRET  string `mapstructure:"JWTSECRET"` // signs access tokens, required
+	JWTTTL     string `mapstructure:"JWTTTL"`    // access token lifetime e.g. 24h (default)
+	// SMTPHOST is host:port of the mail server notifications are sent through, notifications are only logged when empty
+	SMTPHOST        string `mapstructure:"SMTPHOST"`
+	SMTPUSERNAME    string `mapstructure:"SMTPUSERNAME"`
+	SMTPPASSWORD    string `mapstructure:"SMTPPASSWORD"`
+	SMTPFROM        string `mapstructure:"SMTPFROM"`        // sender of notifications e.g. "Gathering App <no-reply@example.com>"
+	NOTIFYINTERVAL  string `mapstructure:"NOTIFYINTERVAL"`  // how often pending notifications are delivered e.g. 10s (default)
+	WEBHOOKINTERVAL string `mapstructure:"WEBHOOKINTERVAL"` // how often due webhook deliveries are posted e.g. 10s (default)
+	// REMINDEROFFSETS are how long before a gathering starts reminders are sent e.g. 24h,1h (default), off sends none
+	REMINDEROFFSETS  string `mapstructure:"REMINDEROFFSETS"`
+	REMINDERINTERVAL string `mapstructure:"REMINDERINTERVAL"` // how often upcoming gatherings are scanned for reminders e.g. 1m (default)
 }
 
 var c *Config
 
 func SetConfig(configPath string) {
 	var err error
+	// a fresh viper forgets overrides of a previous call
+	viper.Reset()
 	viper.SetConfigType("env")
 	viper.AddConfigPath(configPath)
 	viper.SetConfigName(".env")
@@ -27,6 +45,11 @@ func SetConfig(configPath string) {
 	if err != nil {
 		panic(fmt.Sprintf("config not found: %s", err.Error()))
 	}
+	// an environment variable of the same name wins over .env, e.g. JWTSECRET passed by docker compose
+	fields := reflect.TypeOf(Config{})
+	for i := 0; i < fields.NumField(); i++ {
+		viper.BindEnv(fields.Field(i).Tag.Get("mapstructure"))
+	}
 	err = viper.Unmarshal(&c)
 	if err != nil {
 		log.Fatalf("could not parse config: %v", err)
@@ -38,6 +61,16 @@ func SetConfig(configPath string) {
 	viper.WatchConfig()
 }
 
+// Override replaces values read from .env or environment, keys are mapstructure names e.g. "PORT"
+func Override(values map[string]string) {
+	for key, value := range values {
+		viper.Set(key, value)
+	}
+	if err := viper.Unmarshal(&c); err != nil {
+		log.Fatalf("could not parse config: %v", err)
+	}
+}
+
 func Get() *Config {
 	return c
 }
@@ -1,154 +1 @@
 CREATE DATABASE IF NOT EXISTS `gathering_db`;
-USE `gathering_db`;
-
--- MySQL dump 10.13  Distrib 8.0.19, for Win64 (x86_64)
---
--- Host: localhost    Database: gathering_db
--- ------------------------------------------------------
--- Server version	8.0.34-0ubuntu0.20.04.1
-
-/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;
-/*!40101 SET @OLD_CHARACTER_SET_RESULTS=@@CHARACTER_SET_RESULTS */;
-/*!40101 SET @OLD_COLLATION_CONNECTION=@@COLLATION_CONNECTION */;
-/*!50503 SET NAMES utf8mb4 */;
-/*!40103 SET @OLD_TIME_ZONE=@@TIME_ZONE */;
-/*!40103 SET TIME_ZONE='+00:00' */;
-/*!40014 SET @OLD_UNIQUE_CHECKS=@@UNIQUE_CHECKS, UNIQUE_CHECKS=0 */;
-/*!40014 SET @OLD_FOREIGN_KEY_CHECKS=@@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS=0 */;
-/*!40101 SET @OLD_SQL_MODE=@@SQL_MODE, SQL_MODE='NO_AUTO_VALUE_ON_ZERO' */;
-/*!40111 SET @OLD_SQL_NOTES=@@SQL_NOTES, SQL_NOTES=0 */;
-
---
--- Table structure for table `attendees`
---
-
-DROP TABLE IF EXISTS `attendees`;
-/*!40101 SET @saved_cs_client     = @@character_set_client */;
-/*!50503 SET character_set_client = utf8mb4 */;
-CREATE TABLE `attendees` (
-  `member_id` mediumint NOT NULL,
-  `gathering_id` mediumint NOT NULL,
-  UNIQUE KEY `unique_index` (`member_id`,`gathering_id`),
-  KEY `gathering_id` (`gathering_id`),
-  CONSTRAINT `attendees_ibfk_1` FOREIGN KEY (`member_id`) REFERENCES `members` (`id`),
-  CONSTRAINT `attendees_ibfk_2` FOREIGN KEY (`gathering_id`) REFERENCES `gatherings` (`id`)
-) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
-/*!40101 SET character_set_client = @saved_cs_client */;
-
---
--- Dumping data for table `attendees`
---
-
-LOCK TABLES `attendees` WRITE;
-/*!40000 ALTER TABLE `attendees` DISABLE KEYS */;
-INSERT INTO `attendees` VALUES (1,1);
-/*!40000 ALTER TABLE `attendees` ENABLE KEYS */;
-UNLOCK TABLES;
-
---
--- Table structure for table `gatherings`
---
-
-DROP TABLE IF EXISTS `gatherings`;
-/*!40101 SET @saved_cs_client     = @@character_set_client */;
-/*!50503 SET character_set_client = utf8mb4 */;
-CREATE TABLE `gatherings` (
-  `id` mediumint NOT NULL AUTO_INCREMENT,
-  `creator` mediumint NOT NULL,
-  `type` int NOT NULL,
-  `scheduled_at` timestamp NOT NULL,
-  `name` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NOT NULL,
-  `location` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci NOT NULL,
-  `created_at` timestamp NOT NULL,
-  `updated_at` timestamp NULL DEFAULT NULL,
-  `discarded_at` timestamp NULL DEFAULT NULL,
-  PRIMARY KEY (`id`),
-  KEY `creator` (`creator`),
-  CONSTRAINT `gatherings_ibfk_1` FOREIGN KEY (`creator`) REFERENCES `members` (`id`)
-) ENGINE=InnoDB AUTO_INCREMENT=2 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
-/*!40101 SET character_set_client = @saved_cs_client */;
-
---
--- Dumping data for table `gatherings`
---
-
-LOCK TABLES `gatherings` WRITE;
-/*!40000 ALTER TABLE `gatherings` DISABLE KEYS */;
-INSERT INTO `gatherings` VALUES (1,1,0,'2023-10-06 05:00:00','Private Meeting','pramuka street','2023-10-02 11:06:52',NULL,NULL);
-/*!40000 ALTER TABLE `gatherings` ENABLE KEYS */;
-UNLOCK TABLES;
-
---
--- Table structure for table `invitations`
---
-
-DROP TABLE IF EXISTS `invitations`;
-/*!40101 SET @saved_cs_client     = @@character_set_client */;
-/*!50503 SET character_set_client = utf8mb4 */;
-CREATE TABLE `invitations` (
-  `id` mediumint NOT NULL AUTO_INCREMENT,
-  `member_id` mediumint NOT NULL,
-  `gathering_id` mediumint NOT NULL,
-  `status` int DEFAULT NULL,
-  `created_at` timestamp NULL DEFAULT NULL,
-  PRIMARY KEY (`id`),
-  KEY `member_id` (`member_id`),
-  KEY `gathering_id` (`gathering_id`),
-  CONSTRAINT `invitations_ibfk_1` FOREIGN KEY (`member_id`) REFERENCES `members` (`id`),
-  CONSTRAINT `invitations_ibfk_2` FOREIGN KEY (`gathering_id`) REFERENCES `gatherings` (`id`)
-) ENGINE=InnoDB AUTO_INCREMENT=2 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
-/*!40101 SET character_set_client = @saved_cs_client */;
-
---
--- Dumping data for table `invitations`
---
-
-LOCK TABLES `invitations` WRITE;
-/*!40000 ALTER TABLE `invitations` DISABLE KEYS */;
-INSERT INTO `invitations` VALUES (1,2,1,0,'2023-10-02 11:09:22');
-/*!40000 ALTER TABLE `invitations` ENABLE KEYS */;
-UNLOCK TABLES;
-
---
--- Table structure for table `members`
---
-
-DROP TABLE IF EXISTS `members`;
-/*!40101 SET @saved_cs_client     = @@character_set_client */;
-/*!50503 SET character_set_client = utf8mb4 */;
-CREATE TABLE `members` (
-  `id` mediumint NOT NULL AUTO_INCREMENT,
-  `first_name` varchar(255) NOT NULL,
-  `last_name` varchar(255) DEFAULT NULL,
-  `email` varchar(255) NOT NULL,
-  `created_at` timestamp NOT NULL,
-  `updated_at` timestamp NULL DEFAULT NULL,
-  `discarded_at` timestamp NULL DEFAULT NULL,
-  PRIMARY KEY (`id`),
-  UNIQUE KEY `unique_email` (`email`)
-) ENGINE=InnoDB AUTO_INCREMENT=3 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
-/*!40101 SET character_set_client = @saved_cs_client */;
-
---
--- Dumping data for table `members`
---
-
-LOCK TABLES `members` WRITE;
-/*!40000 ALTER TABLE `members` DISABLE KEYS */;
-INSERT INTO `members` VALUES (1,'linus','torvalds','linus@mail.com','2023-10-02 11:05:01',NULL,NULL),(2,'ron','west','ron@mail.com','2023-10-02 11:05:43',NULL,NULL);
-/*!40000 ALTER TABLE `members` ENABLE KEYS */;
-UNLOCK TABLES;
-
---
--- Dumping routines for database 'gathering_db'
---
-/*!40103 SET TIME_ZONE=@OLD_TIME_ZONE */;
-
-/*!40101 SET SQL_MODE=@OLD_SQL_MODE */;
-/*!40014 SET FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS */;
-/*!40014 SET UNIQUE_CHECKS=@OLD_UNIQUE_CHECKS */;
-/*!40101 SET CHARACTER_SET_CLIENT=@OLD_CHARACTER_SET_CLIENT */;
-/*!40101 SET CHARACTER_SET_RESULTS=@OLD_CHARACTER_SET_RESULTS */;
-/*!40101 SET COLLATION_CONNECTION=@OLD_COLLATION_CONNECTION */;
-/*!40111 SET SQL_NOTES=@OLD_SQL_NOTES */;
-
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+// CalendarProductID identifies the app in exported calendars
+const CalendarProductID = "-//simple-go-api//Gathering App//EN"
+
+// calendarLineLength is the longest content line in octets before it is folded
+const calendarLineLength = 75
+
+// Calendar is an iCalendar (RFC 5545) document of gatherings
+type Calendar struct {
+	Name string
+	// Gatherings need Creator and Attendees with names and emails
+	Gatherings []Gathering
+	// Invitations need Member, invitees who are not attendees are listed with the invitation status
+	Invitations []Invitation
+	// Stamp is when the calendar is generated
+	Stamp time.Time
+}
+
+// UID is the iCalendar UID of a gathering, the one it was imported with or its own, a split series gets a new one
+func (d Gathering) UID() string {
+	if d.CalendarUID != "" {
+		return d.CalendarUID
+	}
+	return fmt.Sprintf("gathering-%d@simple-go-api", d.ID)
+}
+
+// Encode returns the calendar with a VEVENT per gathering, plus one per edited occurrence of a series
+func (d Calendar) Encode() string {
+	w := &calendarWriter{}
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + CalendarProductID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	if d.Name != "" {
+		w.line("X-WR-CALNAME:" + calendarText(d.Name))
+	}
+	invitationsByGatheringID := map[int64][]Invitation{}
+	for _, inv := range d.Invitations {
+		invitationsByGatheringID[inv.GatheringID] = append(invitationsByGatheringID[inv.GatheringID], inv)
+	}
+	for _, g := range d.Gatherings {
+		d.event(w, g, invitationsByGatheringID[g.ID])
+	}
+	w.line("END:VCALENDAR")
+	return w.String()
+}
+
+func (d Calendar) event(w *calendarWriter, g Gathering, invitations []Invitation) {
+	people := calendarPeople(g, invitations)
+	w.line("BEGIN:VEVENT")
+	w.line("UID:" + g.UID())
+	w.line("DTSTAMP:" + d.Stamp.UTC().Format("20060102T150405Z"))
+	w.line("DTSTART" + calendarTime(g.ScheduledAt))
+	if g.EndsAt != nil {
+		w.line("DTEND" + calendarTime(*g.EndsAt))
+	}
+	w.line("SUMMARY:" + calendarText(g.Name))
+	w.line("LOCATION:" + calendarText(g.Location))
+	w.line("CLASS:" + calendarClass(g.Type))
+	w.lines(people)
+	if g.IsRecurring() {
+		w.line("RRULE:" + g.Recurrence)
+		for _, e := range g.Exceptions {
+			if e.Canceled {
+				w.line("EXDATE" + calendarTime(e.RecurrenceID))
+			}
+		}
+	}
+	w.line("END:VEVENT")
+	if !g.IsRecurring() {
+		return
+	}
+	for _, e := range g.Exceptions {
+		if e.Canceled {
+			continue
+		}
+		if _, ok := g.Occurrence(e.RecurrenceID); !ok {
+			continue
+		}
+		w.line("BEGIN:VEVENT")
+		w.line("UID:" + g.UID())
+		w.line("DTSTAMP:" + d.Stamp.UTC().Format("20060102T150405Z"))
+		w.line("RECURRENCE-ID" + calendarTime(e.RecurrenceID))
+		w.line("DTSTART" + calendarTime(e.ScheduledAt))
+		if e.EndsAt != nil {
+			w.line("DTEND" + calendarTime(*e.EndsAt))
+		}
+		w.line("SUMMARY:" + calendarText(e.Name))
+		w.line("LOCATION:" + calendarText(e.Location))
+		w.line("CLASS:" + calendarClass(g.Type))
+		w.lines(people)
+		w.line("END:VEVENT")
+	}
+}
+
+// calendarPeople returns the ORGANIZER and ATTENDEE lines, attendees have accepted and invitees answer with the invitation status
+func calendarPeople(g Gathering, invitations []Invitation) (lines []string) {
+	if g.Creator.Email != "" {
+		lines = append(lines, "ORGANIZER;CN="+calendarParam(g.Creator.FullName())+":mailto:"+g.Creator.Email)
+	}
+	listed := map[int64]bool{}
+	attendee := func(m Member, partStat string) {
+		if listed[m.ID] || m.Email == "" || partStat == "" {
+			return
+		}
+		listed[m.ID] = true
+		lines = append(lines, "ATTENDEE;CN="+calendarParam(m.FullName())+";ROLE=REQ-PARTICIPANT;PARTSTAT="+partStat+":mailto:"+m.Email)
+	}
+	for _, m := range g.Attendees {
+		attendee(m, valueobject.INVITATION_ACCEPT.PartStat())
+	}
+	for _, inv := range invitations {
+		attendee(inv.Member, inv.Status.PartStat())
+	}
+	return
+}
+
+// calendarTime formats a time as a property value with its TZID, UTC times use the Z form
+func calendarTime(t time.Time) string {
+	if name := t.Location().String(); name != "UTC" && name != "Local" {
+		return ";TZID=" + name + ":" + t.Format("20060102T150405")
+	}
+	return ":" + t.UTC().Format("20060102T150405Z")
+}
+
+func calendarClass(t valueobject.GatheringType) string {
+	if t == valueobject.PRIVATE {
+		return "PRIVATE"
+	}
+	return "PUBLIC"
+}
+
+// calendarText escapes a TEXT value
+func calendarText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// calendarParam quotes a parameter value, which cannot contain a double quote
+func calendarParam(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "'") + `"`
+}
+
+// calendarWriter writes content lines ended by CRLF and folded at calendarLineLength octets
+type calendarWriter struct {
+	strings.Builder
+}
+
+func (w *calendarWriter) lines(lines []string) {
+	for _, l := range lines {
+		w.line(l)
+	}
+}
+
+func (w *calendarWriter) line(l string) {
+	length := 0
+	for _, r := range l {
+		size := len(string(r))
+		if length+size > calendarLineLength {
+			// a folded line starts with a space, which counts toward its length
+			w.WriteString("\r\n ")
+			length = 1
+		}
+		w.WriteRune(r)
+		length += size
+	}
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,365 @@
+package domain
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+// Statuses of an imported calendar event
+const (
+	CalendarImportCreated  = "created"
+	CalendarImportExisting = "existing"
+	CalendarImportFailed   = "failed"
+)
+
+// calendarDurationPattern is the DURATION subset with weeks, days, hours, minutes and seconds, e.g. PT1H30M
+var calendarDurationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+type (
+	// CalendarEvent is a VEVENT read as a gathering without creator, edited or canceled occurrences of a series are its Exceptions
+	CalendarEvent struct {
+		UID       string
+		Gathering Gathering
+		// AttendeeEmails are the ATTENDEE addresses, members with them are invited
+		AttendeeEmails []string
+		// Err is why the event cannot be imported, e.g. DTSTART is missing
+		Err error
+	}
+
+	// CalendarImportResult is what an import did with an event
+	CalendarImportResult struct {
+		UID         string `json:"uid"`
+		GatheringID int64  `json:"gathering_id,omitempty"`
+		// Status is CalendarImportCreated, CalendarImportExisting for a gathering imported before or CalendarImportFailed
+		Status string `json:"status"`
+		// Invited are emails of members invited by the import
+		Invited []string `json:"invited"`
+		// UnknownAttendees are emails of attendees who are not members
+		UnknownAttendees []string `json:"unknown_attendees"`
+		// Conflicts are gatherings of the creator a created gathering overlaps, it is imported anyway
+		Conflicts []ScheduleConflict `json:"conflicts,omitempty"`
+		Errors    []FieldError       `json:"errors,omitempty"`
+	}
+
+	// calendarLine is a content line with its parameters, names are upper case
+	calendarLine struct {
+		Name   string
+		Params map[string]string
+		Value  string
+	}
+
+	// calendarComponent is a VEVENT as its content lines
+	calendarComponent []calendarLine
+)
+
+// ParseCalendar reads the VEVENTs of an iCalendar (RFC 5545) document. TZID must be an IANA time zone name,
+// floating times are in X-WR-TIMEZONE or UTC. Overrides with a RECURRENCE-ID become exceptions of their series.
+func ParseCalendar(r io.Reader) (events []CalendarEvent, err error) {
+	lines, err := unfoldCalendar(r)
+	if err != nil {
+		return
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, NewFieldError("file", CodeInvalid, "file is not an iCalendar document")
+	}
+	loc := time.UTC
+	components := []calendarComponent{}
+	var current calendarComponent
+	depth := 0
+	for _, l := range lines {
+		line, ok := parseCalendarLine(l)
+		if !ok {
+			continue
+		}
+		switch line.Name {
+		case "BEGIN":
+			depth++
+			if depth == 2 && strings.EqualFold(line.Value, "VEVENT") {
+				current = calendarComponent{}
+			}
+			continue
+		case "END":
+			if depth == 2 && current != nil {
+				components = append(components, current)
+				current = nil
+			}
+			depth--
+			continue
+		}
+		switch {
+		case depth == 1 && line.Name == "X-WR-TIMEZONE":
+			if zone, err := time.LoadLocation(line.Value); err == nil {
+				loc = zone
+			}
+		case depth == 2 && current != nil:
+			// lines of nested components such as VALARM are at a deeper depth and skipped
+			current = append(current, line)
+		}
+	}
+
+	masters := map[string]int{}
+	overrides := []calendarComponent{}
+	for _, c := range components {
+		if c.get("RECURRENCE-ID") != nil {
+			overrides = append(overrides, c)
+			continue
+		}
+		event := c.event(loc)
+		if event.UID != "" {
+			masters[event.UID] = len(events)
+		}
+		events = append(events, event)
+	}
+	for _, c := range overrides {
+		uid := c.value("UID")
+		i, ok := masters[uid]
+		if !ok {
+			// an occurrence shared without its series is imported on its own
+			events = append(events, c.event(loc))
+			continue
+		}
+		if events[i].Err != nil {
+			continue
+		}
+		occurrence, err := c.occurrence(loc)
+		if err != nil {
+			events[i].Err = err
+			continue
+		}
+		events[i].Gathering.Exceptions = append(events[i].Gathering.Exceptions, occurrence)
+	}
+	return
+}
+
+// unfoldCalendar splits content lines, joining folded ones
+func unfoldCalendar(r io.Reader) (lines []string, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		l := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		err = WrapError(ErrValidation, "cannot read calendar", err)
+	}
+	return
+}
+
+// parseCalendarLine reads name;param=value:value, a colon or semicolon in a quoted parameter value does not end it
+func parseCalendarLine(l string) (line calendarLine, ok bool) {
+	line.Params = map[string]string{}
+	quoted := false
+	start := 0
+	name := ""
+	for i, r := range l {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == ';' || r == ':':
+			part := l[start:i]
+			if name == "" {
+				name = part
+			} else if key, val, found := strings.Cut(part, "="); found {
+				line.Params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+			}
+			start = i + 1
+			if r == ':' {
+				line.Name = strings.ToUpper(name)
+				line.Value = l[i+1:]
+				return line, line.Name != ""
+			}
+		}
+	}
+	return line, false
+}
+
+func (c calendarComponent) get(name string) *calendarLine {
+	for i := range c {
+		if c[i].Name == name {
+			return &c[i]
+		}
+	}
+	return nil
+}
+
+func (c calendarComponent) value(name string) string {
+	if line := c.get(name); line != nil {
+		return strings.TrimSpace(line.Value)
+	}
+	return ""
+}
+
+// event reads the component as a gathering
+func (c calendarComponent) event(loc *time.Location) (event CalendarEvent) {
+	v := &ValidationError{}
+	event.UID = c.value("UID")
+	if event.UID == "" {
+		v.Add("uid", CodeRequired, "uid is required")
+	}
+	g := &event.Gathering
+	g.CalendarUID = event.UID
+	g.Name = calendarUnescape(c.value("SUMMARY"))
+	g.Location = calendarUnescape(c.value("LOCATION"))
+	g.Type = valueobject.PUBLIC
+	if class := strings.ToUpper(c.value("CLASS")); class == "PRIVATE" || class == "CONFIDENTIAL" {
+		g.Type = valueobject.PRIVATE
+	}
+	if strings.EqualFold(c.value("STATUS"), "CANCELLED") {
+		v.Add("status", CodeNotAllowed, "canceled events are not imported")
+	}
+	g.ScheduledAt, g.EndsAt, g.TimeZone = c.times(v, loc)
+	if rrule := c.value("RRULE"); rrule != "" && c.get("RECURRENCE-ID") == nil {
+		g.Recurrence = rrule
+	}
+	for _, line := range c {
+		switch line.Name {
+		case "ATTENDEE":
+			if email := calendarEmail(line.Value); email != "" {
+				event.AttendeeEmails = append(event.AttendeeEmails, email)
+			}
+		case "EXDATE":
+			if g.ScheduledAt.IsZero() {
+				continue
+			}
+			for _, value := range strings.Split(line.Value, ",") {
+				t, _, err := calendarLine{Name: line.Name, Params: line.Params, Value: value}.time(loc)
+				if err != nil {
+					v.Errors = append(v.Errors, err.(*ValidationError).Errors...)
+					continue
+				}
+				if occurrence, ok := g.Occurrence(t); ok {
+					occurrence.Canceled = true
+					g.Exceptions = append(g.Exceptions, occurrence)
+				}
+			}
+		}
+	}
+	event.Err = v.Err()
+	return
+}
+
+// occurrence reads an override of one occurrence of a series
+func (c calendarComponent) occurrence(loc *time.Location) (occurrence Occurrence, err error) {
+	v := &ValidationError{}
+	occurrence.RecurrenceID, _, err = c.get("RECURRENCE-ID").time(loc)
+	if err != nil {
+		return
+	}
+	occurrence.ScheduledAt, occurrence.EndsAt, _ = c.times(v, loc)
+	occurrence.Name = calendarUnescape(c.value("SUMMARY"))
+	occurrence.Location = calendarUnescape(c.value("LOCATION"))
+	occurrence.Canceled = strings.EqualFold(c.value("STATUS"), "CANCELLED")
+	return occurrence, v.Err()
+}
+
+// times reads DTSTART with DTEND or DURATION, an all-day event without them lasts a day
+func (c calendarComponent) times(v *ValidationError, loc *time.Location) (start time.Time, end *time.Time, timeZone string) {
+	dtstart := c.get("DTSTART")
+	if dtstart == nil {
+		v.Add("dtstart", CodeRequired, "dtstart is required")
+		return
+	}
+	start, allDay, err := dtstart.time(loc)
+	if err != nil {
+		v.Errors = append(v.Errors, err.(*ValidationError).Errors...)
+		return
+	}
+	timeZone = start.Location().String()
+	if tzid := dtstart.Params["TZID"]; tzid == "" && start.Location() == time.UTC && loc != time.UTC {
+		// a UTC time is shown in the calendar time zone
+		start = start.In(loc)
+		timeZone = loc.String()
+	}
+	if dtend := c.get("DTEND"); dtend != nil {
+		t, _, err := dtend.time(loc)
+		if err != nil {
+			v.Errors = append(v.Errors, err.(*ValidationError).Errors...)
+			return
+		}
+		end = &t
+	} else if duration := c.value("DURATION"); duration != "" {
+		d, err := calendarDuration(duration)
+		if err != nil {
+			v.Add("duration", CodeInvalid, err.Error())
+			return
+		}
+		t := start.Add(d)
+		end = &t
+	} else if allDay {
+		t := start.AddDate(0, 0, 1)
+		end = &t
+	}
+	return
+}
+
+// time reads a DATE-TIME in UTC, its TZID or loc when floating, or a DATE at midnight, allDay reports the latter
+func (l calendarLine) time(loc *time.Location) (t time.Time, allDay bool, err error) {
+	field := strings.ToLower(l.Name)
+	value := strings.TrimSpace(l.Value)
+	if tzid := l.Params["TZID"]; tzid != "" {
+		if loc, err = time.LoadLocation(tzid); err != nil {
+			return t, false, NewFieldError(field, CodeInvalid, fmt.Sprintf("unknown time zone %s, please use IANA time zone names", tzid))
+		}
+	}
+	switch {
+	case strings.EqualFold(l.Params["VALUE"], "DATE") || len(value) == len("20060102"):
+		t, err = time.ParseInLocation("20060102", value, loc)
+		allDay = true
+	case strings.HasSuffix(value, "Z"):
+		t, err = time.Parse("20060102T150405Z", value)
+	default:
+		t, err = time.ParseInLocation("20060102T150405", value, loc)
+	}
+	if err != nil {
+		return t, false, NewFieldError(field, CodeInvalid, fmt.Sprintf("invalid %s %s", field, value))
+	}
+	return
+}
+
+// calendarDuration reads a DURATION value, e.g. PT1H30M or P1D
+func calendarDuration(value string) (d time.Duration, err error) {
+	m := calendarDurationPattern.FindStringSubmatch(strings.ToUpper(value))
+	if m == nil || value == "P" || strings.HasSuffix(strings.ToUpper(value), "T") {
+		return 0, fmt.Errorf("invalid duration %s", value)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(m[i+2])
+		d += time.Duration(n) * unit
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return
+}
+
+// calendarEmail returns the address of a mailto: value
+func calendarEmail(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) < len("mailto:") || !strings.EqualFold(value[:len("mailto:")], "mailto:") {
+		return ""
+	}
+	return strings.TrimSpace(value[len("mailto:"):])
+}
+
+// calendarUnescape reverses calendarText
+func calendarUnescape(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(s)
+}
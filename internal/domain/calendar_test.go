@@ -0,0 +1,187 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendar_Encode(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	start := time.Date(2023, 10, 2, 9, 0, 0, 0, jakarta)
+	endsAt := start.Add(30 * time.Minute)
+	linus := domain.Member{ID: 1, FirstName: "linus", LastName: "torvalds", Email: "linus@mail.com"}
+	ron := domain.Member{ID: 2, FirstName: "ron", Email: "ron@mail.com"}
+	ken := domain.Member{ID: 3, FirstName: "ken", Email: "ken@mail.com"}
+	calendar := domain.Calendar{
+		Name: "Gatherings of linus torvalds",
+		Gatherings: []domain.Gathering{{
+			ID:          7,
+			Creator:     linus,
+			Type:        valueobject.PUBLIC,
+			ScheduledAt: start,
+			EndsAt:      &endsAt,
+			TimeZone:    "Asia/Jakarta",
+			Recurrence:  "FREQ=WEEKLY;COUNT=3",
+			Name:        "standup, daily; sort of",
+			Location:    "room 1",
+			Attendees:   []domain.Member{linus},
+			Exceptions: []domain.Occurrence{
+				{GatheringID: 7, RecurrenceID: start.AddDate(0, 0, 7), ScheduledAt: start.AddDate(0, 0, 7), Name: "standup", Location: "room 1", Canceled: true},
+				{GatheringID: 7, RecurrenceID: start.AddDate(0, 0, 14), ScheduledAt: start.AddDate(0, 0, 14).Add(time.Hour), Name: "standup", Location: "room 2"},
+			},
+		}},
+		Invitations: []domain.Invitation{
+			{GatheringID: 7, Member: ron, Status: valueobject.INVITATION_CREATED},
+			{GatheringID: 7, Member: ken, Status: valueobject.INVITATION_CANCELED},
+		},
+		Stamp: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+	people := []string{
+		`ORGANIZER;CN="linus torvalds":mailto:linus@mail.com`,
+		`ATTENDEE;CN="linus torvalds";ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED:mailto:`,
+		` linus@mail.com`,
+		`ATTENDEE;CN="ron";ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION:mailto:ron@mai`,
+		` l.com`,
+	}
+	want := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:" + domain.CalendarProductID,
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Gatherings of linus torvalds",
+		"BEGIN:VEVENT",
+		"UID:gathering-7@simple-go-api",
+		"DTSTAMP:20231001T000000Z",
+		"DTSTART;TZID=Asia/Jakarta:20231002T090000",
+		"DTEND;TZID=Asia/Jakarta:20231002T093000",
+		`SUMMARY:standup\, daily\; sort of`,
+		"LOCATION:room 1",
+		"CLASS:PUBLIC",
+	}
+	want = append(want, people...)
+	want = append(want,
+		"RRULE:FREQ=WEEKLY;COUNT=3",
+		"EXDATE;TZID=Asia/Jakarta:20231009T090000",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:gathering-7@simple-go-api",
+		"DTSTAMP:20231001T000000Z",
+		"RECURRENCE-ID;TZID=Asia/Jakarta:20231016T090000",
+		"DTSTART;TZID=Asia/Jakarta:20231016T100000",
+		"SUMMARY:standup",
+		"LOCATION:room 2",
+		"CLASS:PUBLIC",
+	)
+	want = append(want, people...)
+	want = append(want, "END:VEVENT", "END:VCALENDAR", "")
+	require.Equal(t, strings.Join(want, "\r\n"), calendar.Encode())
+}
+
+func TestParseCalendar(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	start := time.Date(2023, 10, 2, 9, 0, 0, 0, jakarta)
+	endsAt := start.Add(30 * time.Minute)
+	movedAt := start.AddDate(0, 0, 14).Add(time.Hour)
+	allDay := time.Date(2023, 10, 5, 0, 0, 0, 0, jakarta)
+	allDayEnd := allDay.AddDate(0, 0, 1)
+	utcStart := time.Date(2023, 10, 6, 2, 0, 0, 0, time.UTC).In(jakarta)
+	utcEnd := utcStart.Add(90 * time.Minute)
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"X-WR-TIMEZONE:Asia/Jakarta",
+		"BEGIN:VEVENT",
+		"UID:standup@example.com",
+		"DTSTART;TZID=Asia/Jakarta:20231002T090000",
+		"DTEND;TZID=Asia/Jakarta:20231002T093000",
+		`SUMMARY:standup\, daily\; sort o`,
+		" f",
+		"LOCATION:room 1",
+		"CLASS:CONFIDENTIAL",
+		"RRULE:FREQ=WEEKLY;COUNT=3",
+		"EXDATE;TZID=Asia/Jakarta:20231009T090000",
+		`ATTENDEE;CN="torvalds; linus":mailto:linus@mail.com`,
+		"ATTENDEE;CN=ron:MAILTO:ron@mail.com",
+		"BEGIN:VALARM",
+		"TRIGGER:-PT15M",
+		"DESCRIPTION:not a gathering",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:standup@example.com",
+		"RECURRENCE-ID;TZID=Asia/Jakarta:20231016T090000",
+		"DTSTART;TZID=Asia/Jakarta:20231016T100000",
+		"SUMMARY:standup",
+		"LOCATION:room 2",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:holiday@example.com",
+		"DTSTART;VALUE=DATE:20231005",
+		"SUMMARY:holiday",
+		"LOCATION:home",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:review@example.com",
+		"DTSTART:20231006T020000Z",
+		"DURATION:PT1H30M",
+		"SUMMARY:review",
+		"LOCATION:room 3",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:broken@example.com",
+		"SUMMARY:no start",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	events, err := domain.ParseCalendar(strings.NewReader(calendar))
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	require.NoError(t, events[0].Err)
+	require.Equal(t, []string{"linus@mail.com", "ron@mail.com"}, events[0].AttendeeEmails)
+	require.Equal(t, domain.Gathering{
+		Type:        valueobject.PRIVATE,
+		ScheduledAt: start,
+		EndsAt:      &endsAt,
+		TimeZone:    "Asia/Jakarta",
+		Recurrence:  "FREQ=WEEKLY;COUNT=3",
+		Exceptions: []domain.Occurrence{
+			{RecurrenceID: start.AddDate(0, 0, 7), ScheduledAt: start.AddDate(0, 0, 7), EndsAt: timePtr(endsAt.AddDate(0, 0, 7)), Name: "standup, daily; sort of", Location: "room 1", Canceled: true},
+			{RecurrenceID: start.AddDate(0, 0, 14), ScheduledAt: movedAt, Name: "standup", Location: "room 2"},
+		},
+		CalendarUID: "standup@example.com",
+		Name:        "standup, daily; sort of",
+		Location:    "room 1",
+	}, events[0].Gathering)
+
+	require.NoError(t, events[1].Err)
+	require.Equal(t, valueobject.PUBLIC, events[1].Gathering.Type)
+	require.Equal(t, allDay, events[1].Gathering.ScheduledAt)
+	require.Equal(t, &allDayEnd, events[1].Gathering.EndsAt)
+
+	require.NoError(t, events[2].Err)
+	require.Equal(t, "Asia/Jakarta", events[2].Gathering.TimeZone)
+	require.Equal(t, utcStart, events[2].Gathering.ScheduledAt)
+	require.Equal(t, &utcEnd, events[2].Gathering.EndsAt)
+
+	require.Equal(t, "broken@example.com", events[3].UID)
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, events[3].Err, &validationErr)
+	require.Equal(t, "dtstart", validationErr.Errors[0].Field)
+
+	_, err = domain.ParseCalendar(strings.NewReader("name,location\nstandup,room 1\n"))
+	require.ErrorAs(t, err, &validationErr)
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+)
+
+// MinPasswordLength is the shortest password accepted when setting credentials
+const MinPasswordLength = 8
+
+var (
+	ErrInvalidCredentials = NewError(ErrUnauthorized, "invalid email or password")
+	ErrInvalidToken       = NewError(ErrUnauthorized, "invalid or expired token")
+)
+
+type (
+	// Credential is a member password stored as a bcrypt hash, never the plain password
+	Credential struct {
+		MemberID     int64  `json:"member_id" db:"member_id"`
+		PasswordHash string `json:"-" db:"password_hash"`
+		CreatedAt    string `json:"created_at" db:"created_at"`
+	}
+
+	CredentialArgs struct {
+		MemberID int64
+	}
+
+	Login struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+
+	Token struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresAt   string `json:"expires_at"`
+	}
+
+	memberContextKey struct{}
+)
+
+func (d *Login) Validate() (err error) {
+	v := &ValidationError{}
+	if d.Email == "" {
+		v.Add("email", CodeRequired, "email is required")
+	}
+	if d.Password == "" {
+		v.Add("password", CodeRequired, "password is required")
+	}
+	return v.Err()
+}
+
+func ValidatePassword(password string) (err error) {
+	if len(password) < MinPasswordLength {
+		return NewFieldError("password", CodeTooShort, fmt.Sprintf("password must be at least %d characters", MinPasswordLength))
+	}
+	// bcrypt ignores bytes after 72
+	if len(password) > 72 {
+		return NewFieldError("password", CodeTooLong, "password must be at most 72 characters")
+	}
+	return
+}
+
+// ContextWithMember attaches the authenticated member to a request context
+func ContextWithMember(ctx context.Context, member Member) context.Context {
+	return context.WithValue(ctx, memberContextKey{}, member)
+}
+
+// MemberFromContext returns the authenticated member, ok is false for an anonymous request
+func MemberFromContext(ctx context.Context) (member Member, ok bool) {
+	member, ok = ctx.Value(memberContextKey{}).(Member)
+	return
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "errors"
+
+// Error kinds, match them with errors.Is to decide how a failure is reported.
+// An error of no kind is unexpected, e.g. a database outage, and treated as internal.
+var (
+	ErrValidation   = errors.New("validation failed")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrInternal     = errors.New("internal error")
+)
+
+// Error is a failure of a kind with a message safe to show to clients, Err keeps the cause for logs
+type Error struct {
+	Kind    error
+	Message string
+	Err     error
+}
+
+// NewError returns an error of the given kind, e.g. NewError(ErrNotFound, "cannot find member")
+func NewError(kind error, message string) error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// WrapError returns an error of the given kind caused by err, err still matches errors.Is and errors.As
+func WrapError(kind error, message string, err error) error {
+	return &Error{Kind: kind, Message: message, Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
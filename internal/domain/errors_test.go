@@ -0,0 +1,45 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	cause := errors.New("duplicate entry")
+	tests := []struct {
+		name     string
+		err      error
+		kind     error
+		notKind  error
+		message  string
+		wantWrap bool
+	}{
+		{
+			name:    "new error",
+			err:     domain.NewError(domain.ErrNotFound, "cannot find member"),
+			kind:    domain.ErrNotFound,
+			notKind: domain.ErrConflict,
+			message: "cannot find member",
+		},
+		{
+			name:     "wrapped error",
+			err:      domain.WrapError(domain.ErrConflict, "email is already used", cause),
+			kind:     domain.ErrConflict,
+			notKind:  domain.ErrNotFound,
+			message:  "email is already used",
+			wantWrap: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.EqualError(t, tt.err, tt.message)
+			require.ErrorIs(t, tt.err, tt.kind)
+			require.NotErrorIs(t, tt.err, tt.notKind)
+			require.Equal(t, tt.wantWrap, errors.Is(tt.err, cause))
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+// Event names, a bus subscriber listens to some of them or to every one
+const (
+	EventMemberCreated = "member.created"
+	EventMemberUpdated = "member.updated"
+	EventMemberDeleted = "member.deleted"
+
+	EventGatheringCreated     = "gathering.created"
+	EventGatheringUpdated     = "gathering.updated"
+	EventGatheringRescheduled = "gathering.rescheduled"
+	EventGatheringDeleted     = "gathering.deleted"
+	EventOccurrenceUpdated    = "occurrence.updated"
+	EventOccurrenceCanceled   = "occurrence.canceled"
+	EventAttendeeAdded        = "attendee.added"
+	EventAttendeeRemoved      = "attendee.removed"
+
+	EventInvitationCreated    = "invitation.created"
+	EventInvitationAccepted   = "invitation.accepted"
+	EventInvitationWaitlisted = "invitation.waitlisted"
+	EventInvitationTentative  = "invitation.tentative"
+	EventInvitationRejected   = "invitation.rejected"
+	EventInvitationCanceled   = "invitation.canceled"
+
+	// EventStreamReset tells a client resuming a stream that events were missed, it reloads the gathering instead
+	EventStreamReset = "stream.reset"
+)
+
+type (
+	// Event is a change usecases publish once it is stored, subscribers tell events apart by their type or name
+	Event interface {
+		EventName() string
+	}
+
+	// GatheringEvent is an event about one gathering, such as a change of its attendees or invitations
+	GatheringEvent interface {
+		Event
+		EventGatheringID() int64
+	}
+
+	// StreamEvent is an event sent on the stream of a gathering, ID orders it so a client resumes after the last one it got.
+	// Event is nil for EventStreamReset.
+	StreamEvent struct {
+		ID    string
+		Name  string
+		Event Event
+	}
+
+	MemberCreated struct {
+		Member Member `json:"member"`
+	}
+
+	MemberUpdated struct {
+		Member Member `json:"member"`
+	}
+
+	MemberDeleted struct {
+		MemberID int64 `json:"member_id"`
+	}
+
+	// GatheringCreated is a new gathering, or the new series split off by editing the following occurrences
+	GatheringCreated struct {
+		Gathering Gathering `json:"gathering"`
+	}
+
+	// GatheringUpdated is an edit of the gathering that kept its times, see GatheringRescheduled
+	GatheringUpdated struct {
+		Gathering Gathering `json:"gathering"`
+	}
+
+	// GatheringRescheduled is an edit of the gathering that moved its start or end, Previous* are the times it had before
+	GatheringRescheduled struct {
+		Gathering           Gathering  `json:"gathering"`
+		PreviousScheduledAt time.Time  `json:"previous_scheduled_at"`
+		PreviousEndsAt      *time.Time `json:"previous_ends_at,omitempty"`
+	}
+
+	// GatheringDeleted carries the gathering as it was before it was deleted
+	GatheringDeleted struct {
+		Gathering Gathering `json:"gathering"`
+	}
+
+	// OccurrenceUpdated is an edit of one occurrence of a series, or with OccurrenceScopeFollowing of it and every later one
+	OccurrenceUpdated struct {
+		Gathering  Gathering  `json:"gathering"`
+		Occurrence Occurrence `json:"occurrence"`
+		Scope      string     `json:"scope"`
+	}
+
+	// OccurrenceCanceled is a canceled occurrence of a series, or with OccurrenceScopeFollowing the end of the series before it
+	OccurrenceCanceled struct {
+		Gathering  Gathering  `json:"gathering"`
+		Occurrence Occurrence `json:"occurrence"`
+		Scope      string     `json:"scope"`
+	}
+
+	AttendeeAdded struct {
+		Gathering Gathering `json:"gathering"`
+		MemberID  int64     `json:"member_id"`
+	}
+
+	AttendeeRemoved struct {
+		Gathering Gathering `json:"gathering"`
+		MemberID  int64     `json:"member_id"`
+	}
+
+	InvitationCreated struct {
+		Invitation Invitation `json:"invitation"`
+	}
+
+	// InvitationAccepted is an accepted invitation with a seat, a full gathering waitlists it instead, see InvitationWaitlisted
+	InvitationAccepted struct {
+		Invitation Invitation `json:"invitation"`
+	}
+
+	InvitationWaitlisted struct {
+		Invitation Invitation `json:"invitation"`
+	}
+
+	InvitationTentative struct {
+		Invitation Invitation `json:"invitation"`
+	}
+
+	InvitationRejected struct {
+		Invitation Invitation `json:"invitation"`
+	}
+
+	InvitationCanceled struct {
+		Invitation Invitation `json:"invitation"`
+	}
+)
+
+func (MemberCreated) EventName() string        { return EventMemberCreated }
+func (MemberUpdated) EventName() string        { return EventMemberUpdated }
+func (MemberDeleted) EventName() string        { return EventMemberDeleted }
+func (GatheringCreated) EventName() string     { return EventGatheringCreated }
+func (GatheringUpdated) EventName() string     { return EventGatheringUpdated }
+func (GatheringRescheduled) EventName() string { return EventGatheringRescheduled }
+func (GatheringDeleted) EventName() string     { return EventGatheringDeleted }
+func (OccurrenceUpdated) EventName() string    { return EventOccurrenceUpdated }
+func (OccurrenceCanceled) EventName() string   { return EventOccurrenceCanceled }
+func (AttendeeAdded) EventName() string        { return EventAttendeeAdded }
+func (AttendeeRemoved) EventName() string      { return EventAttendeeRemoved }
+func (InvitationCreated) EventName() string    { return EventInvitationCreated }
+func (InvitationAccepted) EventName() string   { return EventInvitationAccepted }
+func (InvitationWaitlisted) EventName() string { return EventInvitationWaitlisted }
+func (InvitationTentative) EventName() string  { return EventInvitationTentative }
+func (InvitationRejected) EventName() string   { return EventInvitationRejected }
+func (InvitationCanceled) EventName() string   { return EventInvitationCanceled }
+
+func (e GatheringCreated) EventGatheringID() int64     { return e.Gathering.ID }
+func (e GatheringUpdated) EventGatheringID() int64     { return e.Gathering.ID }
+func (e GatheringRescheduled) EventGatheringID() int64 { return e.Gathering.ID }
+func (e GatheringDeleted) EventGatheringID() int64     { return e.Gathering.ID }
+func (e OccurrenceUpdated) EventGatheringID() int64    { return e.Gathering.ID }
+func (e OccurrenceCanceled) EventGatheringID() int64   { return e.Gathering.ID }
+func (e AttendeeAdded) EventGatheringID() int64        { return e.Gathering.ID }
+func (e AttendeeRemoved) EventGatheringID() int64      { return e.Gathering.ID }
+func (e InvitationCreated) EventGatheringID() int64    { return e.Invitation.GatheringID }
+func (e InvitationAccepted) EventGatheringID() int64   { return e.Invitation.GatheringID }
+func (e InvitationWaitlisted) EventGatheringID() int64 { return e.Invitation.GatheringID }
+func (e InvitationTentative) EventGatheringID() int64  { return e.Invitation.GatheringID }
+func (e InvitationRejected) EventGatheringID() int64   { return e.Invitation.GatheringID }
+func (e InvitationCanceled) EventGatheringID() int64   { return e.Invitation.GatheringID }
+
+// NewGatheringUpdatedEvent is GatheringRescheduled when the times of gathering differ from previous, else GatheringUpdated
+func NewGatheringUpdatedEvent(previous Gathering, gathering Gathering) Event {
+	if gathering.ScheduledAt.Equal(previous.ScheduledAt) && sameTime(gathering.EndsAt, previous.EndsAt) {
+		return GatheringUpdated{Gathering: gathering}
+	}
+	return GatheringRescheduled{Gathering: gathering, PreviousScheduledAt: previous.ScheduledAt, PreviousEndsAt: previous.EndsAt}
+}
+
+// NewInvitationEvent is the event of an invitation stored with its current status, nil for a status without one
+func NewInvitationEvent(invitation Invitation) Event {
+	switch invitation.Status {
+	case valueobject.INVITATION_CREATED:
+		return InvitationCreated{Invitation: invitation}
+	case valueobject.INVITATION_ACCEPT:
+		return InvitationAccepted{Invitation: invitation}
+	case valueobject.INVITATION_WAITLISTED:
+		return InvitationWaitlisted{Invitation: invitation}
+	case valueobject.INVITATION_TENTATIVE:
+		return InvitationTentative{Invitation: invitation}
+	case valueobject.INVITATION_REJECT:
+		return InvitationRejected{Invitation: invitation}
+	case valueobject.INVITATION_CANCELED:
+		return InvitationCanceled{Invitation: invitation}
+	}
+	return nil
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,73 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGatheringUpdatedEvent(t *testing.T) {
+	start := time.Date(2026, 10, 1, 18, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	later := end.Add(time.Hour)
+	previous := domain.Gathering{ID: 1, Name: "book club", ScheduledAt: start, EndsAt: &end}
+	tests := []struct {
+		name      string
+		gathering domain.Gathering
+		want      domain.Event
+	}{
+		{
+			name:      "renamed",
+			gathering: domain.Gathering{ID: 1, Name: "reading club", ScheduledAt: start, EndsAt: &end},
+			want:      domain.GatheringUpdated{Gathering: domain.Gathering{ID: 1, Name: "reading club", ScheduledAt: start, EndsAt: &end}},
+		},
+		{
+			name:      "longer",
+			gathering: domain.Gathering{ID: 1, Name: "book club", ScheduledAt: start, EndsAt: &later},
+			want: domain.GatheringRescheduled{
+				Gathering:           domain.Gathering{ID: 1, Name: "book club", ScheduledAt: start, EndsAt: &later},
+				PreviousScheduledAt: start,
+				PreviousEndsAt:      &end,
+			},
+		},
+		{
+			name:      "moved",
+			gathering: domain.Gathering{ID: 1, Name: "book club", ScheduledAt: end},
+			want: domain.GatheringRescheduled{
+				Gathering:           domain.Gathering{ID: 1, Name: "book club", ScheduledAt: end},
+				PreviousScheduledAt: start,
+				PreviousEndsAt:      &end,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, domain.NewGatheringUpdatedEvent(previous, tt.gathering))
+		})
+	}
+}
+
+func TestNewInvitationEvent(t *testing.T) {
+	tests := []struct {
+		status   valueobject.InvitationStatus
+		wantName string
+	}{
+		{status: valueobject.INVITATION_CREATED, wantName: domain.EventInvitationCreated},
+		{status: valueobject.INVITATION_ACCEPT, wantName: domain.EventInvitationAccepted},
+		{status: valueobject.INVITATION_WAITLISTED, wantName: domain.EventInvitationWaitlisted},
+		{status: valueobject.INVITATION_TENTATIVE, wantName: domain.EventInvitationTentative},
+		{status: valueobject.INVITATION_REJECT, wantName: domain.EventInvitationRejected},
+		{status: valueobject.INVITATION_CANCELED, wantName: domain.EventInvitationCanceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			event := domain.NewInvitationEvent(domain.Invitation{ID: 1, Status: tt.status})
+			require.Equal(t, tt.wantName, event.EventName())
+			// webhooks name invitation events the same way
+			require.Equal(t, domain.WebhookInvitationEvent(tt.status), event.EventName())
+		})
+	}
+}
@@ -1,24 +1,53 @@
 package domain
 
 import (
-	"errors"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
 )
 
+// DefaultTimeZone is the time zone of gatherings created without one
+const DefaultTimeZone = "UTC"
+
 type (
 	Gathering struct {
-		ID          int64                     `json:"id" db:"id"`
-		CreatorID   int64                     `json:"-" db:"creator"`
-		Creator     Member                    `json:"creator"`
-		Type        valueobject.GatheringType `json:"type" db:"type"`
-		ScheduledAt string                    `json:"scheduled_at" db:"scheduled_at"`
-		Name        string                    `json:"name" db:"name"`
-		Location    string                    `json:"location" db:"location"`
-		Attendees   []Member                  `json:"attendees"`
-		CreatedAt   string                    `json:"created_at" db:"created_at"`
-		DiscardedAt string                    `json:"discarded_at,omitempty" db:"discarded_at"`
+		ID        int64                     `json:"id" db:"id"`
+		CreatorID int64                     `json:"-" db:"creator"`
+		Creator   Member                    `json:"creator"`
+		Type      valueobject.GatheringType `json:"type" db:"type"`
+		// ScheduledAt and EndsAt are in TimeZone, they are stored in UTC
+		ScheduledAt time.Time  `json:"scheduled_at" db:"-"`
+		EndsAt      *time.Time `json:"ends_at,omitempty" db:"-"`
+		// DurationMinutes is an alternative to EndsAt on input, it is derived from EndsAt on output
+		DurationMinutes int `json:"duration_minutes,omitempty" db:"-"`
+		// TimeZone is an IANA time zone name such as Asia/Jakarta
+		TimeZone string `json:"time_zone" db:"time_zone"`
+		// Recurrence is a RRULE making the gathering a series that starts at ScheduledAt, empty for a single gathering
+		Recurrence string `json:"recurrence,omitempty" db:"recurrence"`
+		// Exceptions are occurrences of a series edited or canceled on their own
+		Exceptions []Occurrence `json:"exceptions,omitempty"`
+		// CalendarUID is the UID of the iCalendar event the gathering was imported from
+		CalendarUID string `json:"calendar_uid,omitempty" db:"calendar_uid"`
+		Name        string `json:"name" db:"name"`
+		Location    string `json:"location" db:"location"`
+		// Capacity is the most people, the creator and guests of accepted invitations included, 0 is unlimited. Accepts beyond it are waitlisted.
+		Capacity  int      `json:"capacity,omitempty" db:"capacity"`
+		Attendees []Member `json:"attendees"`
+		// Headcount is only reported for a single gathering
+		Headcount   *Headcount `json:"headcount,omitempty" db:"-"`
+		CreatedAt   string     `json:"created_at" db:"created_at"`
+		DiscardedAt string     `json:"discarded_at,omitempty" db:"discarded_at"`
+	}
+
+	// Headcount counts people coming to a gathering, plus-ones included
+	Headcount struct {
+		// Confirmed are attendees and the guests of accepted invitations
+		Confirmed int `json:"confirmed"`
+		// Tentative are members who answered maybe and their guests
+		Tentative int `json:"tentative"`
+		Total     int `json:"total"`
 	}
 
 	GatheringArgs struct {
@@ -26,36 +55,185 @@ type (
 		MemberIDs        []int64
 		ID               int64
 		IsIncludeDiscard bool
+		CreatorID        int64
+		// IsVisibleOnly keeps public gatherings and private ones ViewerID created, attends or is invited to,
+		// a ViewerID of 0 sees public gatherings only
+		IsVisibleOnly bool
+		ViewerID      int64
+		// CalendarUID finds a gathering imported from an iCalendar event
+		CalendarUID string
+		Types       []valueobject.GatheringType
+		Name        string
+		Location    string
+		// ScheduledFrom and ScheduledTo are inclusive bounds, zero means unbounded
+		ScheduledFrom time.Time
+		ScheduledTo   time.Time
+		// IsIncludeSeries keeps series starting before ScheduledFrom, a later occurrence of them may be in range
+		IsIncludeSeries bool
+		Pagination
 	}
 )
 
+// UnmarshalJSON reads scheduled_at and ends_at in RFC 3339 format, a bad value is reported as a field error
+func (d *Gathering) UnmarshalJSON(b []byte) (err error) {
+	type gathering Gathering
+	aux := struct {
+		*gathering
+		ScheduledAt string `json:"scheduled_at"`
+		EndsAt      string `json:"ends_at"`
+	}{gathering: (*gathering)(d)}
+	if err = json.Unmarshal(b, &aux); err != nil {
+		return
+	}
+	v := &ValidationError{}
+	d.ScheduledAt, d.EndsAt = parseTimes(v, aux.ScheduledAt, aux.EndsAt)
+	return v.Err()
+}
+
+// parseTimes reads scheduled_at and ends_at of a payload, empty values are left zero
+func parseTimes(v *ValidationError, scheduledAt string, endsAt string) (start time.Time, end *time.Time) {
+	if scheduledAt != "" {
+		var err error
+		if start, err = time.Parse(time.RFC3339, scheduledAt); err != nil {
+			v.Add("scheduled_at", CodeInvalid, "invalid scheduled at, please use RFC 3339 format, e.g. 2023-10-06T19:00:00+07:00")
+		}
+	}
+	if endsAt != "" {
+		t, err := time.Parse(time.RFC3339, endsAt)
+		if err != nil {
+			v.Add("ends_at", CodeInvalid, "invalid ends at, please use RFC 3339 format, e.g. 2023-10-06T21:00:00+07:00")
+		} else {
+			end = &t
+		}
+	}
+	return
+}
+
 func (d *Gathering) Validate() (err error) {
+	v := &ValidationError{}
 	if d.Creator.ID <= 0 {
-		return errors.New("creator is required")
+		v.Add("creator.id", CodeRequired, "creator is required")
+	}
+	if d.ScheduledAt.IsZero() {
+		v.Add("scheduled_at", CodeRequired, "scheduled at is required")
 	}
-	if d.ScheduledAt == "" {
-		return errors.New("scheduled at is required")
-	} else {
-		_, err = time.Parse("2006-01-02 15:04", d.ScheduledAt)
+	if d.TimeZone == "" {
+		d.TimeZone = DefaultTimeZone
+	}
+	loc, err := time.LoadLocation(d.TimeZone)
+	if err != nil {
+		v.Addf("time_zone", CodeInvalid, "unknown time zone %s", d.TimeZone)
+		loc = time.UTC
+	}
+	switch {
+	case d.DurationMinutes < 0:
+		v.Add("duration_minutes", CodeOutOfRange, "duration minutes must not be negative")
+	case d.EndsAt != nil && d.DurationMinutes > 0 && !d.EndsAt.Equal(d.ScheduledAt.Add(time.Duration(d.DurationMinutes)*time.Minute)):
+		v.Add("duration_minutes", CodeNotAllowed, "set either ends at or duration minutes")
+	case d.DurationMinutes > 0:
+		endsAt := d.ScheduledAt.Add(time.Duration(d.DurationMinutes) * time.Minute)
+		d.EndsAt = &endsAt
+	case d.EndsAt != nil && !d.EndsAt.After(d.ScheduledAt):
+		v.Add("ends_at", CodeOutOfRange, "ends at must be after scheduled at")
+	}
+	if d.Recurrence != "" && !d.ScheduledAt.IsZero() {
+		rule, err := ParseRecurrenceRule(d.Recurrence, loc)
 		if err != nil {
-			return errors.New("invalid time format, please use (YYYY-MM-DD MM:SS) format")
+			v.Errors = append(v.Errors, err.(*ValidationError).Errors...)
+		} else if starts := rule.Starts(d.ScheduledAt.In(loc), d.ScheduledAt, d.ScheduledAt); len(starts) == 0 {
+			v.Add("recurrence", CodeInvalid, "scheduled at must be the first occurrence of the recurrence, e.g. a monday for BYDAY=MO")
+		} else {
+			d.Recurrence = rule.String()
 		}
 	}
 	if d.Location == "" {
-		return errors.New("location at is required")
+		v.Add("location", CodeRequired, "location is required")
 	}
 	if d.Name == "" {
-		return errors.New("gathering name at is required")
+		v.Add("name", CodeRequired, "gathering name is required")
+	}
+	if d.Capacity < 0 {
+		v.Add("capacity", CodeOutOfRange, "capacity must not be negative")
 	}
 	if d.Type != valueobject.PRIVATE && d.Type != valueobject.PUBLIC {
 		d.Type = valueobject.PRIVATE
 	}
-	if len(d.Attendees) > 0 {
-		for _, a := range d.Attendees {
-			if a.ID <= 0 {
-				return errors.New("attendee is required")
-			}
+	for i, a := range d.Attendees {
+		if a.ID <= 0 {
+			v.Addf(fmt.Sprintf("attendees[%d].id", i), CodeRequired, "attendee %d is required", i+1)
+		}
+	}
+	if err = v.Err(); err != nil {
+		return
+	}
+	d.Localize()
+	return
+}
+
+// Localize shows ScheduledAt and EndsAt in the gathering time zone and derives DurationMinutes,
+// repositories call it on read since they store UTC
+func (d *Gathering) Localize() {
+	if d.TimeZone == "" {
+		d.TimeZone = DefaultTimeZone
+	}
+	loc, err := time.LoadLocation(d.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	d.ScheduledAt = d.ScheduledAt.In(loc)
+	d.DurationMinutes = 0
+	if d.EndsAt != nil {
+		endsAt := d.EndsAt.In(loc)
+		d.EndsAt = &endsAt
+		d.DurationMinutes = int(endsAt.Sub(d.ScheduledAt) / time.Minute)
+	}
+	for i, e := range d.Exceptions {
+		e.RecurrenceID = e.RecurrenceID.In(loc)
+		e.ScheduledAt = e.ScheduledAt.In(loc)
+		if e.EndsAt != nil {
+			endsAt := e.EndsAt.In(loc)
+			e.EndsAt = &endsAt
+		}
+		d.Exceptions[i] = e
+	}
+}
+
+func (d *GatheringArgs) Validate() (err error) {
+	v := &ValidationError{}
+	if !d.ScheduledFrom.IsZero() && !d.ScheduledTo.IsZero() && d.ScheduledTo.Before(d.ScheduledFrom) {
+		v.Add("scheduled_to", CodeOutOfRange, "scheduled to must not be before scheduled from")
+	}
+	d.Pagination.validate(v, SortByCreatedAt, SortByScheduledAt, SortByName)
+	return v.Err()
+}
+
+// CursorValue returns the value of the sort field, used to build next page cursor
+func (d Gathering) CursorValue(field string) string {
+	switch field {
+	case SortByCreatedAt:
+		return cursorTime(d.CreatedAt)
+	case SortByScheduledAt:
+		return d.ScheduledAt.UTC().Format("2006-01-02 15:04:05")
+	case SortByName:
+		return d.Name
+	}
+	return ""
+}
+
+// CountHeads counts the attendees and the members of the invitations to the gathering who accepted or answered maybe, with their guests
+func (d Gathering) CountHeads(invitations []Invitation) (headcount Headcount) {
+	headcount.Confirmed = len(d.Attendees)
+	for _, inv := range invitations {
+		if inv.GatheringID != d.ID {
+			continue
+		}
+		switch inv.Status {
+		case valueobject.INVITATION_ACCEPT:
+			headcount.Confirmed += inv.Guests
+		case valueobject.INVITATION_TENTATIVE:
+			headcount.Tentative += 1 + inv.Guests
 		}
 	}
+	headcount.Total = headcount.Confirmed + headcount.Tentative
 	return
 }
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"unicode/utf8"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+// MaxGroupNameLength is the longest group name in characters
+const MaxGroupNameLength = 255
+
+type (
+	// Group is a named set of members, its owners manage it. A gathering can invite every member of a group at once.
+	Group struct {
+		ID      int64         `json:"id" db:"id"`
+		Name    string        `json:"name" db:"name"`
+		Members []GroupMember `json:"members"`
+		// GatheringIDs are the gatherings the group was invited to with auto invite, members joining later are invited to the upcoming ones
+		GatheringIDs []int64 `json:"gathering_ids"`
+		CreatedAt    string  `json:"created_at" db:"created_at"`
+	}
+
+	GroupMember struct {
+		GroupID   int64                 `json:"-" db:"group_id"`
+		MemberID  int64                 `json:"member_id" db:"member_id"`
+		Role      valueobject.GroupRole `json:"role" db:"role"`
+		CreatedAt string                `json:"created_at" db:"created_at"`
+	}
+
+	GroupArgs struct {
+		IDs []int64
+		ID  int64
+		// MemberID finds the groups a member belongs to
+		MemberID int64
+		// Name matches the group name partially
+		Name string
+		Pagination
+	}
+
+	// GroupInvitation invites every member of a group to a gathering
+	GroupInvitation struct {
+		GroupID int64 `json:"group_id"`
+		// AutoInvite also invites members who join the group later, while the gathering is upcoming
+		AutoInvite bool `json:"auto_invite"`
+	}
+)
+
+func (d *Group) Validate() (err error) {
+	v := &ValidationError{}
+	if d.Name == "" {
+		v.Add("name", CodeRequired, "name is required")
+	} else if utf8.RuneCountInString(d.Name) > MaxGroupNameLength {
+		v.Addf("name", CodeTooLong, "name must be at most %d characters", MaxGroupNameLength)
+	}
+	return v.Err()
+}
+
+// Member returns the membership of a member, ok is false when they are not in the group
+func (d Group) Member(memberID int64) (member GroupMember, ok bool) {
+	for _, m := range d.Members {
+		if m.MemberID == memberID {
+			return m, true
+		}
+	}
+	return
+}
+
+// IsOwner reports whether the member owns the group
+func (d Group) IsOwner(memberID int64) bool {
+	m, ok := d.Member(memberID)
+	return ok && m.Role == valueobject.GROUP_OWNER
+}
+
+// Owners counts the owners of the group, a group always keeps at least one
+func (d Group) Owners() (total int) {
+	for _, m := range d.Members {
+		if m.Role == valueobject.GROUP_OWNER {
+			total++
+		}
+	}
+	return
+}
+
+// MemberIDs lists the members of the group in order of membership
+func (d Group) MemberIDs() (ids []int64) {
+	ids = []int64{}
+	for _, m := range d.Members {
+		ids = append(ids, m.MemberID)
+	}
+	return
+}
+
+// CursorValue returns the value of the sort field, used to build next page cursor
+func (d Group) CursorValue(field string) string {
+	switch field {
+	case SortByCreatedAt:
+		return cursorTime(d.CreatedAt)
+	case SortByName:
+		return d.Name
+	}
+	return ""
+}
+
+func (d *GroupMember) Validate() (err error) {
+	v := &ValidationError{}
+	if d.MemberID <= 0 {
+		v.Add("member_id", CodeRequired, "member id is required")
+	}
+	if d.Role == "" {
+		d.Role = valueobject.GROUP_MEMBER
+	} else if !d.Role.IsValid() {
+		v.Addf("role", CodeInvalid, "role must be %s or %s", valueobject.GROUP_MEMBER, valueobject.GROUP_OWNER)
+	}
+	return v.Err()
+}
+
+func (d *GroupArgs) Validate() (err error) {
+	return d.Pagination.Validate(SortByCreatedAt, SortByName)
+}
+
+func (d *GroupInvitation) Validate() (err error) {
+	if d.GroupID <= 0 {
+		return NewFieldError("group_id", CodeRequired, "group id is required")
+	}
+	return
+}
@@ -0,0 +1,63 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		group      domain.Group
+		wantFields []string
+	}{
+		{name: "named", group: domain.Group{Name: "book club"}},
+		{name: "no name", group: domain.Group{}, wantFields: []string{"name"}},
+		{name: "name too long", group: domain.Group{Name: strings.Repeat("é", domain.MaxGroupNameLength+1)}, wantFields: []string{"name"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.group.Validate()
+			if len(tt.wantFields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			var validationErr *domain.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			fields := []string{}
+			for _, e := range validationErr.Errors {
+				fields = append(fields, e.Field)
+			}
+			require.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func TestGroupMember_Validate(t *testing.T) {
+	member := domain.GroupMember{MemberID: 2}
+	require.NoError(t, member.Validate())
+	require.Equal(t, valueobject.GROUP_MEMBER, member.Role, "role defaults to member")
+
+	member = domain.GroupMember{Role: "admin"}
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, member.Validate(), &validationErr)
+	require.Len(t, validationErr.Errors, 2)
+}
+
+func TestGroup_Members(t *testing.T) {
+	group := domain.Group{Members: []domain.GroupMember{
+		{MemberID: 1, Role: valueobject.GROUP_OWNER},
+		{MemberID: 2, Role: valueobject.GROUP_MEMBER},
+	}}
+	require.True(t, group.IsOwner(1))
+	require.False(t, group.IsOwner(2))
+	require.False(t, group.IsOwner(3))
+	require.Equal(t, 1, group.Owners())
+	require.Equal(t, []int64{1, 2}, group.MemberIDs())
+	_, ok := group.Member(3)
+	require.False(t, ok)
+}
@@ -2,10 +2,31 @@ package domain
 
 import (
 	"errors"
+	"fmt"
+	"unicode/utf8"
 
 	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
 )
 
+const (
+	// MaxInvitationGuests is the most plus-ones a member can bring
+	MaxInvitationGuests = 10
+	// MaxInvitationNoteLength is the most characters of a response note
+	MaxInvitationNoteLength = 500
+)
+
+// ErrInvalidTransition is matched by every TransitionError, which is a conflict
+var ErrInvalidTransition = errors.New("invalid invitation status transition")
+
+// invitationTransitions lists the statuses an invitation can move to, rejected and canceled are final.
+// An accept becomes waitlisted when the gathering is full, repositories decide it and promote waitlisted invitations.
+var invitationTransitions = map[valueobject.InvitationStatus][]valueobject.InvitationStatus{
+	valueobject.INVITATION_CREATED:    {valueobject.INVITATION_ACCEPT, valueobject.INVITATION_TENTATIVE, valueobject.INVITATION_REJECT, valueobject.INVITATION_CANCELED},
+	valueobject.INVITATION_ACCEPT:     {valueobject.INVITATION_TENTATIVE, valueobject.INVITATION_REJECT, valueobject.INVITATION_CANCELED},
+	valueobject.INVITATION_TENTATIVE:  {valueobject.INVITATION_ACCEPT, valueobject.INVITATION_REJECT, valueobject.INVITATION_CANCELED},
+	valueobject.INVITATION_WAITLISTED: {valueobject.INVITATION_REJECT, valueobject.INVITATION_CANCELED},
+}
+
 type (
 	Invitation struct {
 		ID          int64                        `json:"id" db:"id"`
@@ -14,7 +35,24 @@ type (
 		Status      valueobject.InvitationStatus `json:"status" db:"status"`
 		Member      Member                       `json:"member"`
 		Gathering   Gathering                    `json:"gathering"`
-		CreatedAt   string                       `json:"created_at" db:"created_at"`
+		// WaitlistedAt orders the waitlist of a full gathering, it is set while the invitation is waitlisted
+		WaitlistedAt string `json:"waitlisted_at,omitempty" db:"waitlisted_at"`
+		// Guests are the plus-ones the member brings, they count toward headcount and capacity
+		Guests    int    `json:"guests" db:"guests"`
+		Note      string `json:"note,omitempty" db:"note"`
+		CreatedAt string `json:"created_at" db:"created_at"`
+	}
+
+	// InvitationResponse is what the member tells along with accepting, tentatively accepting or rejecting
+	InvitationResponse struct {
+		Guests int    `json:"guests"`
+		Note   string `json:"note"`
+	}
+
+	// TransitionError tells why an invitation cannot move from its status to another
+	TransitionError struct {
+		From valueobject.InvitationStatus
+		To   valueobject.InvitationStatus
 	}
 
 	InvitationArgs struct {
@@ -22,16 +60,74 @@ type (
 		ID          int64
 		MemberID    int64
 		GatheringID int64
-		Status      valueobject.InvitationStatus
+		// GatheringIDs filters invitations of any of the gatherings on listing
+		GatheringIDs []int64
+		Status       valueobject.InvitationStatus
+		// Statuses filters invitations on listing, Status is the target of status update
+		Statuses []valueobject.InvitationStatus
+		// Response is stored along with Status on status update
+		Response InvitationResponse
+		// IsIgnoreConflicts accepts despite schedule conflicts, they are returned as warnings
+		IsIgnoreConflicts bool
+		// IsVisibleOnly keeps invitations of gatherings ViewerID can see, see GatheringArgs.IsVisibleOnly
+		IsVisibleOnly bool
+		ViewerID      int64
+		Pagination
 	}
 )
 
 func (d *Invitation) Validate() (err error) {
+	v := &ValidationError{}
 	if d.Member.ID <= 0 {
-		return errors.New("member is required")
+		v.Add("member.id", CodeRequired, "member is required")
 	}
 	if d.Gathering.ID <= 0 {
-		return errors.New("gathering is required")
+		v.Add("gathering.id", CodeRequired, "gathering is required")
+	}
+	return v.Err()
+}
+
+func (d InvitationResponse) Validate() (err error) {
+	v := &ValidationError{}
+	if d.Guests < 0 || d.Guests > MaxInvitationGuests {
+		v.Addf("guests", CodeOutOfRange, "guests must be between 0 and %d", MaxInvitationGuests)
+	}
+	if utf8.RuneCountInString(d.Note) > MaxInvitationNoteLength {
+		v.Addf("note", CodeTooLong, "note must be at most %d characters", MaxInvitationNoteLength)
+	}
+	return v.Err()
+}
+
+// Transition moves the invitation to the given status when the transition table allows it
+func (d *Invitation) Transition(to valueobject.InvitationStatus) (err error) {
+	for _, allowed := range invitationTransitions[d.Status] {
+		if allowed == to {
+			d.Status = to
+			return
+		}
+	}
+	return &TransitionError{From: d.Status, To: to}
+}
+
+func (e *TransitionError) Error() string {
+	if e.From == e.To {
+		return fmt.Sprintf("the invitation is already %s", e.From)
+	}
+	return fmt.Sprintf("the invitation is %s, it cannot be %s", e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() []error {
+	return []error{ErrInvalidTransition, ErrConflict}
+}
+
+func (d *InvitationArgs) Validate() (err error) {
+	return d.Pagination.Validate(SortByCreatedAt)
+}
+
+// CursorValue returns the value of the sort field, used to build next page cursor
+func (d Invitation) CursorValue(field string) string {
+	if field == SortByCreatedAt {
+		return cursorTime(d.CreatedAt)
 	}
-	return
+	return ""
 }
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"net/mail"
+)
+
+// MaxBatchInvitations is the most recipients of one bulk invitation request
+const MaxBatchInvitations = 100
+
+// Statuses of a recipient of bulk invitations
+const (
+	BatchInvitationInvited          = "invited"
+	BatchInvitationAlreadyInvited   = "already_invited"
+	BatchInvitationAlreadyAttending = "already_attending"
+	BatchInvitationNotFound         = "not_found"
+	// BatchInvitationDuplicate is a recipient listed before in the same request, by ID or email
+	BatchInvitationDuplicate = "duplicate"
+)
+
+type (
+	// InvitationBatch lists the recipients of bulk invitations to a gathering
+	InvitationBatch struct {
+		MemberIDs []int64  `json:"member_ids"`
+		Emails    []string `json:"emails"`
+	}
+
+	// InvitationBatchResult is what happened to one recipient, in the order of the request, IDs before emails
+	InvitationBatchResult struct {
+		// MemberID is set for a recipient listed by ID or found by email
+		MemberID int64  `json:"member_id,omitempty"`
+		Email    string `json:"email,omitempty"`
+		Status   string `json:"status"`
+		// InvitationID is the new invitation, or the existing one of an already invited member
+		InvitationID int64 `json:"invitation_id,omitempty"`
+	}
+)
+
+func (d *InvitationBatch) Validate() (err error) {
+	v := &ValidationError{}
+	total := len(d.MemberIDs) + len(d.Emails)
+	if total == 0 {
+		v.Add("member_ids", CodeRequired, "member ids or emails are required")
+	} else if total > MaxBatchInvitations {
+		v.Addf("member_ids", CodeOutOfRange, "at most %d recipients can be invited at once", MaxBatchInvitations)
+	}
+	for i, id := range d.MemberIDs {
+		if id <= 0 {
+			v.Addf(fmt.Sprintf("member_ids[%d]", i), CodeInvalid, "member id %d is invalid", id)
+		}
+	}
+	for i, email := range d.Emails {
+		// a display name such as "Ron <ron@mail.com>" is not an email
+		if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+			v.Addf(fmt.Sprintf("emails[%d]", i), CodeInvalid, "email %s is invalid", email)
+		}
+	}
+	return v.Err()
+}
@@ -0,0 +1,163 @@
+package domain_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvitation_Transition(t *testing.T) {
+	var (
+		created    = valueobject.INVITATION_CREATED
+		accepted   = valueobject.INVITATION_ACCEPT
+		rejected   = valueobject.INVITATION_REJECT
+		canceled   = valueobject.INVITATION_CANCELED
+		waitlisted = valueobject.INVITATION_WAITLISTED
+		tentative  = valueobject.INVITATION_TENTATIVE
+	)
+	tests := []struct {
+		from    valueobject.InvitationStatus
+		to      valueobject.InvitationStatus
+		wantErr string
+	}{
+		{from: created, to: created, wantErr: "the invitation is already created"},
+		{from: created, to: accepted},
+		{from: created, to: rejected},
+		{from: created, to: canceled},
+		{from: created, to: tentative},
+		{from: created, to: waitlisted, wantErr: "the invitation is created, it cannot be waitlisted"},
+		{from: accepted, to: created, wantErr: "the invitation is accepted, it cannot be created"},
+		{from: accepted, to: accepted, wantErr: "the invitation is already accepted"},
+		{from: accepted, to: rejected},
+		{from: accepted, to: canceled},
+		{from: accepted, to: tentative},
+		{from: accepted, to: waitlisted, wantErr: "the invitation is accepted, it cannot be waitlisted"},
+		{from: tentative, to: created, wantErr: "the invitation is tentative, it cannot be created"},
+		{from: tentative, to: tentative, wantErr: "the invitation is already tentative"},
+		{from: tentative, to: accepted},
+		{from: tentative, to: rejected},
+		{from: tentative, to: canceled},
+		{from: tentative, to: waitlisted, wantErr: "the invitation is tentative, it cannot be waitlisted"},
+		{from: rejected, to: tentative, wantErr: "the invitation is rejected, it cannot be tentative"},
+		{from: rejected, to: created, wantErr: "the invitation is rejected, it cannot be created"},
+		{from: rejected, to: accepted, wantErr: "the invitation is rejected, it cannot be accepted"},
+		{from: rejected, to: rejected, wantErr: "the invitation is already rejected"},
+		{from: rejected, to: canceled, wantErr: "the invitation is rejected, it cannot be canceled"},
+		{from: rejected, to: waitlisted, wantErr: "the invitation is rejected, it cannot be waitlisted"},
+		{from: canceled, to: created, wantErr: "the invitation is canceled, it cannot be created"},
+		{from: canceled, to: accepted, wantErr: "the invitation is canceled, it cannot be accepted"},
+		{from: canceled, to: rejected, wantErr: "the invitation is canceled, it cannot be rejected"},
+		{from: canceled, to: canceled, wantErr: "the invitation is already canceled"},
+		{from: canceled, to: tentative, wantErr: "the invitation is canceled, it cannot be tentative"},
+		{from: canceled, to: waitlisted, wantErr: "the invitation is canceled, it cannot be waitlisted"},
+		{from: waitlisted, to: created, wantErr: "the invitation is waitlisted, it cannot be created"},
+		// a waitlisted member cannot skip the queue, only a freed seat promotes them
+		{from: waitlisted, to: accepted, wantErr: "the invitation is waitlisted, it cannot be accepted"},
+		{from: waitlisted, to: rejected},
+		{from: waitlisted, to: canceled},
+		{from: waitlisted, to: tentative, wantErr: "the invitation is waitlisted, it cannot be tentative"},
+		{from: waitlisted, to: waitlisted, wantErr: "the invitation is already waitlisted"},
+	}
+	// every pair of the 6 statuses is listed once
+	pairs := map[[2]valueobject.InvitationStatus]bool{}
+	for _, tt := range tests {
+		pairs[[2]valueobject.InvitationStatus{tt.from, tt.to}] = true
+	}
+	require.Len(t, pairs, 36)
+	for _, tt := range tests {
+		t.Run(tt.from.String()+" to "+tt.to.String(), func(t *testing.T) {
+			invitation := domain.Invitation{ID: 1, Status: tt.from}
+			err := invitation.Transition(tt.to)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				require.Equal(t, tt.to, invitation.Status)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+			require.ErrorIs(t, err, domain.ErrInvalidTransition)
+			var transitionErr *domain.TransitionError
+			require.True(t, errors.As(err, &transitionErr))
+			require.Equal(t, domain.TransitionError{From: tt.from, To: tt.to}, *transitionErr)
+			require.Equal(t, tt.from, invitation.Status, "status is unchanged")
+		})
+	}
+}
+
+func TestInvitationResponse_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   domain.InvitationResponse
+		wantFields []string
+	}{
+		{name: "no guests", response: domain.InvitationResponse{}},
+		{name: "guests and note", response: domain.InvitationResponse{Guests: domain.MaxInvitationGuests, Note: strings.Repeat("é", domain.MaxInvitationNoteLength)}},
+		{name: "negative guests", response: domain.InvitationResponse{Guests: -1}, wantFields: []string{"guests"}},
+		{name: "too many guests and long note", response: domain.InvitationResponse{Guests: domain.MaxInvitationGuests + 1, Note: strings.Repeat("a", domain.MaxInvitationNoteLength+1)}, wantFields: []string{"guests", "note"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.response.Validate()
+			if len(tt.wantFields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			var validationErr *domain.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			fields := []string{}
+			for _, e := range validationErr.Errors {
+				fields = append(fields, e.Field)
+			}
+			require.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func TestGathering_CountHeads(t *testing.T) {
+	gathering := domain.Gathering{ID: 1, Attendees: []domain.Member{{ID: 1}, {ID: 2}}}
+	invitations := []domain.Invitation{
+		{GatheringID: 1, MemberID: 2, Status: valueobject.INVITATION_ACCEPT, Guests: 2},
+		{GatheringID: 1, MemberID: 3, Status: valueobject.INVITATION_TENTATIVE, Guests: 1},
+		{GatheringID: 1, MemberID: 4, Status: valueobject.INVITATION_TENTATIVE},
+		{GatheringID: 1, MemberID: 5, Status: valueobject.INVITATION_WAITLISTED, Guests: 3},
+		{GatheringID: 1, MemberID: 6, Status: valueobject.INVITATION_REJECT},
+		{GatheringID: 2, MemberID: 7, Status: valueobject.INVITATION_ACCEPT, Guests: 5},
+	}
+	require.Equal(t, domain.Headcount{Confirmed: 4, Tentative: 3, Total: 7}, gathering.CountHeads(invitations))
+}
+
+func TestInvitationBatch_Validate(t *testing.T) {
+	tooMany := make([]int64, domain.MaxBatchInvitations+1)
+	for i := range tooMany {
+		tooMany[i] = int64(i + 1)
+	}
+	tests := []struct {
+		name       string
+		batch      domain.InvitationBatch
+		wantFields []string
+	}{
+		{name: "ids and emails", batch: domain.InvitationBatch{MemberIDs: []int64{2}, Emails: []string{"ken@mail.com"}}},
+		{name: "empty", batch: domain.InvitationBatch{}, wantFields: []string{"member_ids"}},
+		{name: "too many", batch: domain.InvitationBatch{MemberIDs: tooMany}, wantFields: []string{"member_ids"}},
+		{name: "invalid recipients", batch: domain.InvitationBatch{MemberIDs: []int64{2, 0}, Emails: []string{"ken", "Ken <ken@mail.com>"}}, wantFields: []string{"member_ids[1]", "emails[0]", "emails[1]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.batch.Validate()
+			if len(tt.wantFields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			var validationErr *domain.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			fields := []string{}
+			for _, e := range validationErr.Errors {
+				fields = append(fields, e.Field)
+			}
+			require.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
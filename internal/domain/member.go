@@ -1,8 +1,10 @@
 package domain
 
 import (
-	"errors"
 	"net/mail"
+	"strings"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
 )
 
 type (
@@ -13,23 +15,60 @@ type (
 		Email       string `json:"email" db:"email"`
 		CreatedAt   string `json:"created_at" db:"created_at"`
 		DiscardedAt string `json:"discarded_at,omitempty" db:"discarded_at"`
+		// Role is only changed with UpdateRole, create and update ignore it
+		Role valueobject.MemberRole `json:"role,omitempty" db:"role"`
+		// Password is only read on create to set the member credential, it is never stored here
+		Password string `json:"password,omitempty" db:"-"`
 	}
 
 	MemberArgs struct {
 		IDs              []int64
 		ID               int64
 		IsIncludeDiscard bool
+		Email            string
+		// AnyIDs and AnyEmails find members with any of the IDs or any of the emails in one query, e.g. recipients of bulk invitations
+		AnyIDs    []int64
+		AnyEmails []string
+		// Name matches first name or last name partially
+		Name string
+		Pagination
 	}
 )
 
 func (d *Member) Validate() (err error) {
+	v := &ValidationError{}
 	if d.Email == "" {
-		return errors.New("email is required")
+		v.Add("email", CodeRequired, "email is required")
 	} else if _, err := mail.ParseAddress(d.Email); err != nil {
-		return errors.New("invalid email format")
+		v.Add("email", CodeInvalid, "invalid email format")
 	}
 	if d.FirstName == "" {
-		return errors.New("first name is required")
+		v.Add("first_name", CodeRequired, "first name is required")
+	}
+	return v.Err()
+}
+
+// IsAdmin reports whether the member has admin role
+func (d Member) IsAdmin() bool {
+	return d.Role == valueobject.ROLE_ADMIN
+}
+
+// FullName joins first and last name
+func (d Member) FullName() string {
+	return strings.TrimSpace(d.FirstName + " " + d.LastName)
+}
+
+func (d *MemberArgs) Validate() (err error) {
+	return d.Pagination.Validate(SortByCreatedAt, SortByName)
+}
+
+// CursorValue returns the value of the sort field, used to build next page cursor
+func (d Member) CursorValue(field string) string {
+	switch field {
+	case SortByCreatedAt:
+		return cursorTime(d.CreatedAt)
+	case SortByName:
+		return d.FirstName
 	}
-	return
+	return ""
 }
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+// Notification events, each has a template of the notifier
+const (
+	// NotificationInvitationCreated tells the member they are invited
+	NotificationInvitationCreated = "invitation_created"
+	// NotificationInvitationAccepted, NotificationInvitationTentative and NotificationInvitationRejected tell the creator how the member answered
+	NotificationInvitationAccepted  = "invitation_accepted"
+	NotificationInvitationTentative = "invitation_tentative"
+	NotificationInvitationRejected  = "invitation_rejected"
+	// NotificationInvitationCanceled tells the member the creator canceled their invitation
+	NotificationInvitationCanceled = "invitation_canceled"
+	// NotificationWaitlistPromoted tells the member a seat freed up and their waitlisted invitation is accepted
+	NotificationWaitlistPromoted = "waitlist_promoted"
+	// NotificationGatheringUpdated, NotificationGatheringCanceled and NotificationOccurrenceCanceled tell attendees what the creator changed
+	NotificationGatheringUpdated   = "gathering_updated"
+	NotificationGatheringCanceled  = "gathering_canceled"
+	NotificationOccurrenceCanceled = "occurrence_canceled"
+	// NotificationGatheringReminder tells attendees and invitees who have not answered that a gathering starts soon
+	NotificationGatheringReminder = "gathering_reminder"
+)
+
+// MaxNotificationAttempts is how many times delivery is tried before a notification is failed
+const MaxNotificationAttempts = 5
+
+type (
+	// Notification is a message to a member in the outbox. Repositories store it in the transaction of the change it tells about,
+	// a dispatcher delivers it later.
+	Notification struct {
+		ID    int64  `json:"id" db:"id"`
+		Event string `json:"event" db:"event"`
+		// MemberID is the recipient
+		MemberID  int64                          `json:"member_id" db:"member_id"`
+		Data      NotificationData               `json:"data" db:"-"`
+		Status    valueobject.NotificationStatus `json:"status" db:"status"`
+		Attempts  int                            `json:"attempts" db:"attempts"`
+		LastError string                         `json:"last_error,omitempty" db:"last_error"`
+		CreatedAt string                         `json:"created_at" db:"created_at"`
+		SentAt    string                         `json:"sent_at,omitempty" db:"sent_at"`
+	}
+
+	// NotificationData is what templates tell about, taken when the change is made
+	NotificationData struct {
+		GatheringID   int64      `json:"gathering_id"`
+		GatheringName string     `json:"gathering_name"`
+		Location      string     `json:"location"`
+		ScheduledAt   time.Time  `json:"scheduled_at"`
+		EndsAt        *time.Time `json:"ends_at,omitempty"`
+		InvitationID  int64      `json:"invitation_id,omitempty"`
+		// ActorID is the member who made the change, e.g. who answered an invitation
+		ActorID int64  `json:"actor_id,omitempty"`
+		Note    string `json:"note,omitempty"`
+		// Scope is OccurrenceScopeFollowing when an occurrence and every later one are edited or canceled
+		Scope string `json:"scope,omitempty"`
+	}
+
+	NotificationArgs struct {
+		IDs      []int64
+		Statuses []valueobject.NotificationStatus
+		// Limit is the most notifications returned, oldest first
+		Limit int
+	}
+)
+
+// NewNotifications makes a pending notification of the event for each member, a member listed twice gets one
+func NewNotifications(event string, memberIDs []int64, data NotificationData) (notifications []Notification) {
+	listed := map[int64]bool{}
+	for _, id := range memberIDs {
+		if listed[id] {
+			continue
+		}
+		listed[id] = true
+		notifications = append(notifications, Notification{
+			Event:    event,
+			MemberID: id,
+			Data:     data,
+			Status:   valueobject.NOTIFICATION_PENDING,
+		})
+	}
+	return
+}
+
+// NotificationData is the gathering as notifications tell about it
+func (d Gathering) NotificationData() NotificationData {
+	return NotificationData{
+		GatheringID:   d.ID,
+		GatheringName: d.Name,
+		Location:      d.Location,
+		ScheduledAt:   d.ScheduledAt,
+		EndsAt:        d.EndsAt,
+	}
+}
+
+// PromotedNotifications tells the members of invitations promoted from the waitlist of the gathering they got a seat
+func (d Gathering) PromotedNotifications(promoted []Invitation) (notifications []Notification) {
+	for _, invitation := range promoted {
+		data := d.NotificationData()
+		data.InvitationID = invitation.ID
+		notifications = append(notifications, NewNotifications(NotificationWaitlistPromoted, []int64{invitation.MemberID}, data)...)
+	}
+	return
+}
+
+// NotificationData is the occurrence as notifications tell about it
+func (d Occurrence) NotificationData() NotificationData {
+	return NotificationData{
+		GatheringID:   d.GatheringID,
+		GatheringName: d.Name,
+		Location:      d.Location,
+		ScheduledAt:   d.ScheduledAt,
+		EndsAt:        d.EndsAt,
+	}
+}
+
+// Sent marks the notification delivered
+func (d *Notification) Sent(now time.Time) {
+	d.Attempts++
+	d.Status = valueobject.NOTIFICATION_SENT
+	d.LastError = ""
+	d.SentAt = now.UTC().Format("2006-01-02 15:04:05")
+}
+
+// Fail records a failed delivery, the notification stays pending until MaxNotificationAttempts,
+// a final failure such as a missing recipient fails it at once
+func (d *Notification) Fail(err error, final bool) {
+	d.Attempts++
+	d.LastError = err.Error()
+	if final || d.Attempts >= MaxNotificationAttempts {
+		d.Status = valueobject.NOTIFICATION_FAILED
+	}
+}
+
+// AttendeeIDs are the IDs of the attendees of the gathering except the member, e.g. the member who changed it
+func (d Gathering) AttendeeIDs(exceptID int64) (ids []int64) {
+	for _, m := range d.Attendees {
+		if m.ID != exceptID {
+			ids = append(ids, m.ID)
+		}
+	}
+	return
+}
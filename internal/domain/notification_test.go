@@ -0,0 +1,63 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNotifications(t *testing.T) {
+	data := domain.NotificationData{GatheringID: 1, GatheringName: "standup"}
+	got := domain.NewNotifications(domain.NotificationGatheringUpdated, []int64{2, 3, 2}, data)
+	require.Len(t, got, 2)
+	require.Equal(t, int64(2), got[0].MemberID)
+	require.Equal(t, int64(3), got[1].MemberID)
+	require.Equal(t, valueobject.NOTIFICATION_PENDING, got[1].Status)
+	require.Equal(t, data, got[1].Data)
+}
+
+func TestNotification_Fail(t *testing.T) {
+	tests := []struct {
+		name       string
+		attempts   int
+		final      bool
+		wantStatus valueobject.NotificationStatus
+	}{
+		{
+			name:       "retried",
+			wantStatus: valueobject.NOTIFICATION_PENDING,
+		},
+		{
+			name:       "last attempt",
+			attempts:   domain.MaxNotificationAttempts - 1,
+			wantStatus: valueobject.NOTIFICATION_FAILED,
+		},
+		{
+			name:       "final failure",
+			final:      true,
+			wantStatus: valueobject.NOTIFICATION_FAILED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := domain.Notification{Status: valueobject.NOTIFICATION_PENDING, Attempts: tt.attempts}
+			n.Fail(errors.New("connection refused"), tt.final)
+			require.Equal(t, tt.wantStatus, n.Status)
+			require.Equal(t, tt.attempts+1, n.Attempts)
+			require.Equal(t, "connection refused", n.LastError)
+		})
+	}
+}
+
+func TestNotification_Sent(t *testing.T) {
+	n := domain.Notification{Status: valueobject.NOTIFICATION_PENDING, Attempts: 1, LastError: "connection refused"}
+	n.Sent(time.Date(2023, 10, 2, 9, 0, 0, 0, time.FixedZone("WIB", 7*60*60)))
+	require.Equal(t, valueobject.NOTIFICATION_SENT, n.Status)
+	require.Equal(t, 2, n.Attempts)
+	require.Empty(t, n.LastError)
+	require.Equal(t, "2023-10-02 02:00:00", n.SentAt)
+}
@@ -0,0 +1,282 @@
+package domain
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// Occurrence scopes, an edit or cancel applies to one occurrence or to it and every later one
+const (
+	OccurrenceScopeThis      = "this"
+	OccurrenceScopeFollowing = "following"
+)
+
+// MaxOccurrenceRange is the longest range occurrences are expanded for at once
+const MaxOccurrenceRange = 366 * 24 * time.Hour
+
+type (
+	// Occurrence is one time a gathering takes place. RecurrenceID is the start generated by the recurrence rule,
+	// it identifies the occurrence even after ScheduledAt is moved.
+	Occurrence struct {
+		GatheringID  int64      `json:"gathering_id" db:"gathering_id"`
+		RecurrenceID time.Time  `json:"recurrence_id" db:"-"`
+		ScheduledAt  time.Time  `json:"scheduled_at" db:"-"`
+		EndsAt       *time.Time `json:"ends_at,omitempty" db:"-"`
+		Name         string     `json:"name" db:"name"`
+		Location     string     `json:"location" db:"location"`
+		Canceled     bool       `json:"canceled" db:"canceled"`
+	}
+
+	OccurrenceArgs struct {
+		GatheringID  int64
+		RecurrenceID time.Time
+		// Scope is OccurrenceScopeThis or OccurrenceScopeFollowing
+		Scope string
+		From  time.Time
+		To    time.Time
+	}
+)
+
+func (d *OccurrenceArgs) Validate() (err error) {
+	v := &ValidationError{}
+	if d.Scope == "" {
+		d.Scope = OccurrenceScopeThis
+	}
+	if d.Scope != OccurrenceScopeThis && d.Scope != OccurrenceScopeFollowing {
+		v.Addf("scope", CodeInvalid, "scope must be %s or %s", OccurrenceScopeThis, OccurrenceScopeFollowing)
+	}
+	return v.Err()
+}
+
+// ValidateRange checks From and To of an occurrence listing
+func (d *OccurrenceArgs) ValidateRange() (err error) {
+	v := &ValidationError{}
+	if d.From.IsZero() {
+		v.Add("from", CodeRequired, "from is required")
+	}
+	if d.To.IsZero() {
+		v.Add("to", CodeRequired, "to is required")
+	}
+	if !d.From.IsZero() && !d.To.IsZero() {
+		if d.To.Before(d.From) {
+			v.Add("to", CodeOutOfRange, "to must not be before from")
+		} else if d.To.Sub(d.From) > MaxOccurrenceRange {
+			v.Add("to", CodeOutOfRange, "range must be at most 366 days")
+		}
+	}
+	return v.Err()
+}
+
+// UnmarshalJSON reads scheduled_at and ends_at in RFC 3339 format like Gathering does
+func (d *Occurrence) UnmarshalJSON(b []byte) (err error) {
+	type occurrence Occurrence
+	aux := struct {
+		*occurrence
+		ScheduledAt string `json:"scheduled_at"`
+		EndsAt      string `json:"ends_at"`
+	}{occurrence: (*occurrence)(d)}
+	if err = json.Unmarshal(b, &aux); err != nil {
+		return
+	}
+	v := &ValidationError{}
+	d.ScheduledAt, d.EndsAt = parseTimes(v, aux.ScheduledAt, aux.EndsAt)
+	return v.Err()
+}
+
+// Edit returns the occurrence with the fields set in edit, an occurrence moved without a new end keeps its duration
+func (d Occurrence) Edit(edit Occurrence) Occurrence {
+	edited := d
+	if !edit.ScheduledAt.IsZero() {
+		edited.ScheduledAt = edit.ScheduledAt
+		if d.EndsAt != nil {
+			endsAt := edit.ScheduledAt.Add(d.EndsAt.Sub(d.ScheduledAt))
+			edited.EndsAt = &endsAt
+		}
+	}
+	if edit.EndsAt != nil {
+		edited.EndsAt = edit.EndsAt
+	}
+	if edit.Name != "" {
+		edited.Name = edit.Name
+	}
+	if edit.Location != "" {
+		edited.Location = edit.Location
+	}
+	edited.Canceled = false
+	return edited
+}
+
+// Validate checks an edited occurrence, EndsAt must come after ScheduledAt
+func (d *Occurrence) Validate() (err error) {
+	v := &ValidationError{}
+	if d.ScheduledAt.IsZero() {
+		v.Add("scheduled_at", CodeRequired, "scheduled at is required")
+	}
+	if d.EndsAt != nil && !d.EndsAt.After(d.ScheduledAt) {
+		v.Add("ends_at", CodeOutOfRange, "ends at must be after scheduled at")
+	}
+	if d.Name == "" {
+		v.Add("name", CodeRequired, "gathering name is required")
+	}
+	if d.Location == "" {
+		v.Add("location", CodeRequired, "location is required")
+	}
+	return v.Err()
+}
+
+// IsRecurring reports whether the gathering is a series
+func (d Gathering) IsRecurring() bool {
+	return d.Recurrence != ""
+}
+
+// Rule returns the parsed recurrence rule of a series
+func (d Gathering) Rule() (rule RecurrenceRule, err error) {
+	return ParseRecurrenceRule(d.Recurrence, d.ScheduledAt.Location())
+}
+
+// IsUpcoming reports whether the gathering, or an occurrence of a series that is not canceled, starts after now
+func (d Gathering) IsUpcoming(now time.Time) (upcoming bool) {
+	if !d.IsRecurring() {
+		return d.ScheduledAt.After(now)
+	}
+	replaced := map[int64]bool{}
+	for _, e := range d.Exceptions {
+		if !e.Canceled && e.ScheduledAt.After(now) {
+			return true
+		}
+		replaced[e.RecurrenceID.Unix()] = true
+	}
+	rule, err := d.Rule()
+	if err != nil {
+		return false
+	}
+	rule.Each(d.ScheduledAt, func(t time.Time) bool {
+		upcoming = t.After(now) && !replaced[t.Unix()]
+		return !upcoming
+	})
+	return
+}
+
+// Occurrence returns the occurrence generated at recurrenceID with its exception applied, ok is false when the series has none there
+func (d Gathering) Occurrence(recurrenceID time.Time) (occurrence Occurrence, ok bool) {
+	occurrences, err := d.occurrences(recurrenceID, recurrenceID)
+	if err != nil {
+		return
+	}
+	for _, o := range occurrences {
+		if o.RecurrenceID.Equal(recurrenceID) {
+			return o, true
+		}
+	}
+	return
+}
+
+// Occurrences expands the gathering into occurrences starting within from and to inclusive, sorted by start.
+// Exceptions replace the occurrence they were made for, a canceled one is returned with Canceled set.
+func (d Gathering) Occurrences(from time.Time, to time.Time) (occurrences []Occurrence, err error) {
+	occurrences, err = d.occurrences(from, to)
+	if err != nil {
+		return
+	}
+	// an occurrence moved into the range from outside of it
+	for _, e := range d.Exceptions {
+		if (e.RecurrenceID.Before(from) || e.RecurrenceID.After(to)) && !e.ScheduledAt.Before(from) && !e.ScheduledAt.After(to) {
+			if _, ok := d.Occurrence(e.RecurrenceID); ok {
+				occurrences = append(occurrences, e)
+			}
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool { return occurrences[i].ScheduledAt.Before(occurrences[j].ScheduledAt) })
+	return
+}
+
+// occurrences generates occurrences by recurrence id within from and to
+func (d Gathering) occurrences(from time.Time, to time.Time) (occurrences []Occurrence, err error) {
+	occurrences = []Occurrence{}
+	starts := []time.Time{}
+	if !d.IsRecurring() {
+		if !d.ScheduledAt.Before(from) && !d.ScheduledAt.After(to) {
+			starts = append(starts, d.ScheduledAt)
+		}
+	} else {
+		rule, err := d.Rule()
+		if err != nil {
+			return occurrences, err
+		}
+		starts = rule.Starts(d.ScheduledAt, from, to)
+	}
+	exceptions := map[int64]Occurrence{}
+	for _, e := range d.Exceptions {
+		exceptions[e.RecurrenceID.Unix()] = e
+	}
+	for _, start := range starts {
+		if e, ok := exceptions[start.Unix()]; ok {
+			occurrences = append(occurrences, e)
+			continue
+		}
+		occurrence := Occurrence{
+			GatheringID:  d.ID,
+			RecurrenceID: start,
+			ScheduledAt:  start,
+			Name:         d.Name,
+			Location:     d.Location,
+		}
+		if d.EndsAt != nil {
+			endsAt := start.Add(d.EndsAt.Sub(d.ScheduledAt))
+			occurrence.EndsAt = &endsAt
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	return
+}
+
+// Split ends the series before the occurrence at recurrenceID and returns the series of that occurrence and every later one.
+// The following series starts at the occurrence with the same values, the caller changes what is edited.
+// Exceptions are divided between the two series. ok is false when recurrenceID is the first occurrence, nothing is split then.
+func (d *Gathering) Split(recurrenceID time.Time) (following Gathering, ok bool, err error) {
+	rule, err := d.Rule()
+	if err != nil {
+		return
+	}
+	before := 0
+	rule.Each(d.ScheduledAt, func(t time.Time) bool {
+		if !t.Before(recurrenceID) {
+			return false
+		}
+		before++
+		return true
+	})
+	if before == 0 {
+		return following, false, nil
+	}
+	following = *d
+	following.ID = 0
+	// the UID belongs to the imported series, which is the current one
+	following.CalendarUID = ""
+	following.ScheduledAt = recurrenceID
+	if d.EndsAt != nil {
+		endsAt := recurrenceID.Add(d.EndsAt.Sub(d.ScheduledAt))
+		following.EndsAt = &endsAt
+	}
+	followingRule := rule
+	if rule.Count > 0 {
+		followingRule.Count = rule.Count - before
+		rule.Count = before
+	} else {
+		rule.Until = recurrenceID.Add(-time.Second)
+	}
+	d.Recurrence = rule.String()
+	following.Recurrence = followingRule.String()
+	exceptions := d.Exceptions
+	d.Exceptions, following.Exceptions = nil, nil
+	for _, e := range exceptions {
+		if e.RecurrenceID.Before(recurrenceID) {
+			d.Exceptions = append(d.Exceptions, e)
+		} else {
+			e.GatheringID = 0
+			following.Exceptions = append(following.Exceptions, e)
+		}
+	}
+	return following, true, nil
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+
+	SortByID          = "id"
+	SortByCreatedAt   = "created_at"
+	SortByScheduledAt = "scheduled_at"
+	SortByName        = "name"
+)
+
+type (
+	// Pagination is embedded in list args, zero Limit means no limit
+	Pagination struct {
+		Limit  int
+		Offset int
+		Cursor string
+		// Sort field, prefix with "-" for descending order, e.g. "-created_at"
+		Sort string
+	}
+
+	// Page is pagination metadata of a list result
+	Page struct {
+		Total      int64  `json:"total"`
+		Limit      int    `json:"limit"`
+		Offset     int    `json:"offset"`
+		NextCursor string `json:"next_cursor,omitempty"`
+	}
+
+	// Cursor points to the last row of a page, by its sort value and ID
+	Cursor struct {
+		Value string `json:"v"`
+		ID    int64  `json:"id"`
+	}
+)
+
+// SortField returns the sort field and whether it is descending, default is ID ascending
+func (p Pagination) SortField() (field string, desc bool) {
+	field = p.Sort
+	if strings.HasPrefix(field, "-") {
+		field = strings.TrimPrefix(field, "-")
+		desc = true
+	}
+	if field == "" {
+		field = SortByID
+	}
+	return
+}
+
+func (p Pagination) Validate(sortFields ...string) (err error) {
+	v := &ValidationError{}
+	p.validate(v, sortFields...)
+	return v.Err()
+}
+
+// validate adds pagination field errors to v, list args validate their filters along with it
+func (p Pagination) validate(v *ValidationError, sortFields ...string) {
+	if p.Limit < 0 || p.Limit > MaxLimit {
+		v.Addf("limit", CodeOutOfRange, "limit must be between 1 and %d", MaxLimit)
+	}
+	if p.Offset < 0 {
+		v.Add("offset", CodeOutOfRange, "offset must not be negative")
+	}
+	if p.Cursor != "" {
+		if p.Offset > 0 {
+			v.Add("offset", CodeNotAllowed, "offset cannot be combined with cursor")
+		}
+		if _, err := DecodeCursor(p.Cursor); err != nil {
+			v.Add("cursor", CodeInvalid, "invalid cursor")
+		}
+	}
+	field, _ := p.SortField()
+	if field == SortByID {
+		return
+	}
+	for _, f := range sortFields {
+		if f == field {
+			return
+		}
+	}
+	v.Addf("sort", CodeNotAllowed, "cannot sort by %s, allowed fields: %s", field, strings.Join(append([]string{SortByID}, sortFields...), ", "))
+}
+
+func EncodeCursor(value string, id int64) string {
+	b, _ := json.Marshal(Cursor{Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeCursor(s string) (cursor Cursor, err error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor, NewFieldError("cursor", CodeInvalid, "invalid cursor")
+	}
+	if err = json.Unmarshal(b, &cursor); err != nil || cursor.ID <= 0 {
+		return cursor, NewFieldError("cursor", CodeInvalid, "invalid cursor")
+	}
+	return
+}
+
+// cursorTime normalizes a scanned timestamp so it can be compared against a database column
+func cursorTime(value string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
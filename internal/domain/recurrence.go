@@ -0,0 +1,288 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence frequencies of RFC 5545 supported by RecurrenceRule
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+// maxRecurrencePeriods stops expanding a rule without COUNT or UNTIL, e.g. 100 years of daily periods
+const maxRecurrencePeriods = 36600
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+type (
+	// RecurrenceRule is the RRULE subset of RFC 5545 with FREQ, INTERVAL, BYDAY, COUNT and UNTIL,
+	// e.g. FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10 or FREQ=MONTHLY;BYDAY=1TU
+	RecurrenceRule struct {
+		Freq     string
+		Interval int
+		ByDay    []RecurrenceDay
+		// Count and Until are exclusive, none of them means the rule never ends
+		Count int
+		Until time.Time
+	}
+
+	// RecurrenceDay is a BYDAY value, Ordinal is the nth weekday of a month (negative from the end) and only used with FREQ=MONTHLY
+	RecurrenceDay struct {
+		Ordinal int
+		Weekday time.Weekday
+	}
+)
+
+// ParseRecurrenceRule reads a RRULE value, with or without the "RRULE:" prefix. A floating UNTIL is read in loc.
+func ParseRecurrenceRule(value string, loc *time.Location) (rule RecurrenceRule, err error) {
+	v := &ValidationError{}
+	rule.Interval = 1
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimSpace(value), "RRULE:"), ";") {
+		name, val, ok := strings.Cut(part, "=")
+		if !ok {
+			v.Addf("recurrence", CodeInvalid, "invalid recurrence part %s", part)
+			continue
+		}
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(val)
+		case "INTERVAL":
+			if rule.Interval, err = strconv.Atoi(val); err != nil || rule.Interval <= 0 {
+				v.Add("recurrence", CodeInvalid, "recurrence interval must be a positive number")
+			}
+		case "COUNT":
+			if rule.Count, err = strconv.Atoi(val); err != nil || rule.Count <= 0 {
+				v.Add("recurrence", CodeInvalid, "recurrence count must be a positive number")
+			}
+		case "UNTIL":
+			if rule.Until, err = parseUntil(val, loc); err != nil {
+				v.Add("recurrence", CodeInvalid, "recurrence until must be a date (YYYYMMDD) or a time (YYYYMMDDTHHMMSSZ)")
+			}
+		case "BYDAY":
+			for _, code := range strings.Split(strings.ToUpper(val), ",") {
+				day, err := parseRecurrenceDay(code)
+				if err != nil {
+					v.Addf("recurrence", CodeInvalid, "invalid recurrence day %s", code)
+					continue
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		default:
+			v.Addf("recurrence", CodeNotAllowed, "recurrence part %s is not supported, use FREQ, INTERVAL, BYDAY, COUNT or UNTIL", name)
+		}
+	}
+	switch rule.Freq {
+	case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+	case "":
+		v.Add("recurrence", CodeRequired, "recurrence frequency is required")
+	default:
+		v.Addf("recurrence", CodeNotAllowed, "recurrence frequency %s is not supported, use DAILY, WEEKLY, MONTHLY or YEARLY", rule.Freq)
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		v.Add("recurrence", CodeNotAllowed, "recurrence cannot have both count and until")
+	}
+	for _, day := range rule.ByDay {
+		if day.Ordinal != 0 && rule.Freq != FreqMonthly {
+			v.Add("recurrence", CodeNotAllowed, "numbered recurrence days such as 1MO are only supported with FREQ=MONTHLY")
+			break
+		}
+	}
+	if rule.Freq == FreqYearly && len(rule.ByDay) > 0 {
+		v.Add("recurrence", CodeNotAllowed, "recurrence days are not supported with FREQ=YEARLY")
+	}
+	return rule, v.Err()
+}
+
+func parseUntil(value string, loc *time.Location) (until time.Time, err error) {
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		return time.Parse("20060102T150405Z", value)
+	case strings.Contains(value, "T"):
+		return time.ParseInLocation("20060102T150405", value, loc)
+	}
+	// a date includes the whole day
+	until, err = time.ParseInLocation("20060102", value, loc)
+	return until.AddDate(0, 0, 1).Add(-time.Second), err
+}
+
+func parseRecurrenceDay(code string) (day RecurrenceDay, err error) {
+	if len(code) < 2 {
+		return day, fmt.Errorf("invalid day %s", code)
+	}
+	weekday, ok := weekdayCodes[code[len(code)-2:]]
+	if !ok {
+		return day, fmt.Errorf("invalid day %s", code)
+	}
+	day.Weekday = weekday
+	if ordinal := code[:len(code)-2]; ordinal != "" {
+		day.Ordinal, err = strconv.Atoi(ordinal)
+		if err != nil || day.Ordinal == 0 || day.Ordinal < -5 || day.Ordinal > 5 {
+			return day, fmt.Errorf("invalid day %s", code)
+		}
+	}
+	return
+}
+
+// String returns the rule as a RRULE value without the "RRULE:" prefix
+func (r RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := []string{}
+		for _, day := range r.ByDay {
+			days = append(days, day.String())
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+func (d RecurrenceDay) String() string {
+	for code, weekday := range weekdayCodes {
+		if weekday == d.Weekday {
+			if d.Ordinal != 0 {
+				return strconv.Itoa(d.Ordinal) + code
+			}
+			return code
+		}
+	}
+	return ""
+}
+
+// Starts returns occurrence starts of a series beginning at start, within from and to inclusive.
+// Starts keep the wall clock time of start in its location, so a 09:00 standup stays at 09:00 across daylight saving changes.
+func (r RecurrenceRule) Starts(start time.Time, from time.Time, to time.Time) (starts []time.Time) {
+	r.Each(start, func(t time.Time) bool {
+		if t.After(to) {
+			return false
+		}
+		if !t.Before(from) {
+			starts = append(starts, t)
+		}
+		return true
+	})
+	return
+}
+
+// Each calls fn with every occurrence start in order until fn returns false or the rule ends, start is the first occurrence
+func (r RecurrenceRule) Each(start time.Time, fn func(t time.Time) bool) {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+	count := 0
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		for _, t := range r.periodStarts(start, period*interval) {
+			if t.Before(start) {
+				continue
+			}
+			if !r.Until.IsZero() && t.After(r.Until) {
+				return
+			}
+			if !fn(t) {
+				return
+			}
+			count++
+			if r.Count > 0 && count >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// periodStarts returns the candidate starts of the nth period after start in ascending order
+func (r RecurrenceRule) periodStarts(start time.Time, n int) (starts []time.Time) {
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+	}
+	switch r.Freq {
+	case FreqDaily:
+		t := at(start.Year(), start.Month(), start.Day()+n)
+		if len(r.ByDay) == 0 || r.hasWeekday(t.Weekday()) {
+			starts = append(starts, t)
+		}
+	case FreqWeekly:
+		// weeks start on Monday
+		monday := start.Day() - (int(start.Weekday())+6)%7 + 7*n
+		if len(r.ByDay) == 0 {
+			return []time.Time{at(start.Year(), start.Month(), start.Day()+7*n)}
+		}
+		for offset := 0; offset < 7; offset++ {
+			t := at(start.Year(), start.Month(), monday+offset)
+			if r.hasWeekday(t.Weekday()) {
+				starts = append(starts, t)
+			}
+		}
+	case FreqMonthly:
+		first := at(start.Year(), start.Month()+time.Month(n), 1)
+		if len(r.ByDay) == 0 {
+			// months without the day are skipped, e.g. the 31st
+			if t := at(first.Year(), first.Month(), start.Day()); t.Month() == first.Month() {
+				starts = append(starts, t)
+			}
+			return
+		}
+		daysInMonth := at(first.Year(), first.Month()+1, 0).Day()
+		for _, day := range r.ByDay {
+			for d := 1; d <= daysInMonth; d++ {
+				t := at(first.Year(), first.Month(), d)
+				if t.Weekday() != day.Weekday {
+					continue
+				}
+				nth, fromEnd := (d-1)/7+1, -((daysInMonth-d)/7 + 1)
+				if day.Ordinal == 0 || day.Ordinal == nth || day.Ordinal == fromEnd {
+					starts = append(starts, t)
+				}
+			}
+		}
+		sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+		starts = uniqueTimes(starts)
+	case FreqYearly:
+		// Feb 29 is skipped on other years
+		if t := at(start.Year()+n, start.Month(), start.Day()); t.Day() == start.Day() {
+			starts = append(starts, t)
+		}
+	}
+	return
+}
+
+func (r RecurrenceRule) hasWeekday(weekday time.Weekday) bool {
+	for _, day := range r.ByDay {
+		if day.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueTimes(times []time.Time) (unique []time.Time) {
+	for i, t := range times {
+		if i == 0 || !t.Equal(times[i-1]) {
+			unique = append(unique, t)
+		}
+	}
+	return
+}
@@ -0,0 +1,256 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurrenceRule_Starts(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	day := func(year int, month time.Month, day int, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		from  time.Time
+		to    time.Time
+		want  []string
+	}{
+		{
+			name:  "weekly by day with count",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=5",
+			start: day(2023, 10, 2, 9),
+			from:  day(2023, 10, 1, 0),
+			to:    day(2023, 12, 31, 0),
+			want:  []string{"2023-10-02", "2023-10-04", "2023-10-09", "2023-10-11", "2023-10-16"},
+		},
+		{
+			name:  "count is counted from the start, not from the range",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=5",
+			start: day(2023, 10, 2, 9),
+			from:  day(2023, 10, 10, 0),
+			to:    day(2023, 12, 31, 0),
+			want:  []string{"2023-10-11", "2023-10-16"},
+		},
+		{
+			name:  "every other day until a date",
+			rule:  "FREQ=DAILY;INTERVAL=2;UNTIL=20231008",
+			start: day(2023, 10, 2, 9),
+			from:  day(2023, 10, 1, 0),
+			to:    day(2023, 12, 31, 0),
+			want:  []string{"2023-10-02", "2023-10-04", "2023-10-06", "2023-10-08"},
+		},
+		{
+			name:  "first tuesday of the month",
+			rule:  "FREQ=MONTHLY;BYDAY=1TU",
+			start: day(2023, 10, 3, 18),
+			from:  day(2023, 10, 1, 0),
+			to:    day(2024, 1, 31, 0),
+			want:  []string{"2023-10-03", "2023-11-07", "2023-12-05", "2024-01-02"},
+		},
+		{
+			name:  "last friday of the month",
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			start: day(2023, 10, 27, 18),
+			from:  day(2023, 10, 1, 0),
+			to:    day(2024, 12, 31, 0),
+			want:  []string{"2023-10-27", "2023-11-24", "2023-12-29"},
+		},
+		{
+			name:  "monthly on the 31st skips shorter months",
+			rule:  "FREQ=MONTHLY;COUNT=3",
+			start: day(2023, 10, 31, 9),
+			from:  day(2023, 10, 1, 0),
+			to:    day(2024, 12, 31, 0),
+			want:  []string{"2023-10-31", "2023-12-31", "2024-01-31"},
+		},
+		{
+			name:  "yearly on feb 29 skips other years",
+			rule:  "FREQ=YEARLY;COUNT=2",
+			start: day(2024, 2, 29, 9),
+			from:  day(2024, 1, 1, 0),
+			to:    day(2030, 12, 31, 0),
+			want:  []string{"2024-02-29", "2028-02-29"},
+		},
+		{
+			name:  "daily limited to weekdays",
+			rule:  "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;COUNT=6",
+			start: day(2023, 10, 5, 9),
+			from:  day(2023, 10, 1, 0),
+			to:    day(2023, 12, 31, 0),
+			want:  []string{"2023-10-05", "2023-10-06", "2023-10-09", "2023-10-10", "2023-10-11", "2023-10-12"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := domain.ParseRecurrenceRule(tt.rule, time.UTC)
+			require.NoError(t, err)
+			got := []string{}
+			for _, s := range rule.Starts(tt.start, tt.from, tt.to) {
+				got = append(got, s.Format("2006-01-02"))
+				require.Equal(t, tt.start.Hour(), s.Hour())
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("wall clock time is kept across daylight saving", func(t *testing.T) {
+		rule, err := domain.ParseRecurrenceRule("FREQ=WEEKLY;COUNT=2", newYork)
+		require.NoError(t, err)
+		start := time.Date(2023, 10, 30, 9, 0, 0, 0, newYork)
+		starts := rule.Starts(start, start, start.AddDate(0, 1, 0))
+		require.Equal(t, []string{"2023-10-30T09:00:00-04:00", "2023-11-06T09:00:00-05:00"}, []string{
+			starts[0].Format(time.RFC3339), starts[1].Format(time.RFC3339),
+		})
+	})
+}
+
+func TestParseRecurrenceRule(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr string
+	}{
+		{value: "RRULE:FREQ=weekly;INTERVAL=2;BYDAY=MO", want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO"},
+		{value: "FREQ=MONTHLY;BYDAY=1TU;UNTIL=20231231T170000Z", want: "FREQ=MONTHLY;BYDAY=1TU;UNTIL=20231231T170000Z"},
+		{value: "INTERVAL=2", wantErr: "recurrence frequency is required"},
+		{value: "FREQ=HOURLY", wantErr: "recurrence frequency HOURLY is not supported, use DAILY, WEEKLY, MONTHLY or YEARLY"},
+		{value: "FREQ=DAILY;COUNT=2;UNTIL=20231231", wantErr: "recurrence cannot have both count and until"},
+		{value: "FREQ=WEEKLY;BYDAY=1MO", wantErr: "numbered recurrence days such as 1MO are only supported with FREQ=MONTHLY"},
+		{value: "FREQ=WEEKLY;BYMONTH=1", wantErr: "recurrence part BYMONTH is not supported, use FREQ, INTERVAL, BYDAY, COUNT or UNTIL"},
+		{value: "FREQ=WEEKLY;BYDAY=XX;COUNT=0", wantErr: "invalid recurrence day XX, recurrence count must be a positive number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			rule, err := domain.ParseRecurrenceRule(tt.value, time.UTC)
+			if tt.wantErr != "" {
+				require.ErrorIs(t, err, domain.ErrValidation)
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, rule.String())
+		})
+	}
+}
+
+func TestGathering_Occurrences(t *testing.T) {
+	start := time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC)
+	endsAt := start.Add(30 * time.Minute)
+	gathering := domain.Gathering{
+		ID:          1,
+		ScheduledAt: start,
+		EndsAt:      &endsAt,
+		Recurrence:  "FREQ=WEEKLY;COUNT=4",
+		Name:        "standup",
+		Location:    "room 1",
+		Exceptions: []domain.Occurrence{
+			// second week canceled
+			{GatheringID: 1, RecurrenceID: start.AddDate(0, 0, 7), ScheduledAt: start.AddDate(0, 0, 7), Name: "standup", Location: "room 1", Canceled: true},
+			// fourth week moved a day earlier, before the third one
+			{GatheringID: 1, RecurrenceID: start.AddDate(0, 0, 21), ScheduledAt: start.AddDate(0, 0, 13), Name: "standup", Location: "room 2"},
+		},
+	}
+	occurrences, err := gathering.Occurrences(start, start.AddDate(0, 0, 14))
+	require.NoError(t, err)
+	got := []string{}
+	for _, o := range occurrences {
+		got = append(got, o.ScheduledAt.Format("2006-01-02")+" "+o.Location)
+		require.Equal(t, o.RecurrenceID.Equal(start.AddDate(0, 0, 7)), o.Canceled)
+	}
+	require.Equal(t, []string{"2023-10-02 room 1", "2023-10-09 room 1", "2023-10-15 room 2", "2023-10-16 room 1"}, got)
+	require.Equal(t, start.Add(30*time.Minute), *occurrences[0].EndsAt)
+
+	_, ok := gathering.Occurrence(start.Add(time.Hour))
+	require.False(t, ok, "not an occurrence of the series")
+}
+
+func TestGathering_Split(t *testing.T) {
+	start := time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		recurrence    string
+		recurrenceID  time.Time
+		wantOk        bool
+		wantCurrent   string
+		wantFollowing string
+	}{
+		{
+			name:          "count is divided",
+			recurrence:    "FREQ=WEEKLY;COUNT=5",
+			recurrenceID:  start.AddDate(0, 0, 14),
+			wantOk:        true,
+			wantCurrent:   "FREQ=WEEKLY;COUNT=2",
+			wantFollowing: "FREQ=WEEKLY;COUNT=3",
+		},
+		{
+			name:          "until ends the current series",
+			recurrence:    "FREQ=WEEKLY",
+			recurrenceID:  start.AddDate(0, 0, 14),
+			wantOk:        true,
+			wantCurrent:   "FREQ=WEEKLY;UNTIL=20231016T085959Z",
+			wantFollowing: "FREQ=WEEKLY",
+		},
+		{
+			name:         "first occurrence",
+			recurrence:   "FREQ=WEEKLY;COUNT=5",
+			recurrenceID: start,
+			wantCurrent:  "FREQ=WEEKLY;COUNT=5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gathering := domain.Gathering{
+				ID:          1,
+				ScheduledAt: start,
+				Recurrence:  tt.recurrence,
+				Exceptions: []domain.Occurrence{
+					{GatheringID: 1, RecurrenceID: start.AddDate(0, 0, 7), Canceled: true},
+					{GatheringID: 1, RecurrenceID: start.AddDate(0, 0, 21), Canceled: true},
+				},
+			}
+			following, ok, err := gathering.Split(tt.recurrenceID)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.wantCurrent, gathering.Recurrence)
+			if !ok {
+				return
+			}
+			require.Equal(t, tt.wantFollowing, following.Recurrence)
+			require.Equal(t, tt.recurrenceID, following.ScheduledAt)
+			require.Zero(t, following.ID)
+			require.Len(t, gathering.Exceptions, 1)
+			require.Len(t, following.Exceptions, 1)
+			require.Equal(t, start.AddDate(0, 0, 21), following.Exceptions[0].RecurrenceID)
+		})
+	}
+}
+
+func TestGathering_IsUpcoming(t *testing.T) {
+	start := time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC)
+	single := domain.Gathering{ScheduledAt: start}
+	require.True(t, single.IsUpcoming(start.Add(-time.Minute)))
+	require.False(t, single.IsUpcoming(start))
+
+	series := domain.Gathering{
+		ID:          1,
+		ScheduledAt: start,
+		Recurrence:  "FREQ=WEEKLY;COUNT=3",
+		Exceptions: []domain.Occurrence{
+			// the last week canceled
+			{GatheringID: 1, RecurrenceID: start.AddDate(0, 0, 14), ScheduledAt: start.AddDate(0, 0, 14), Canceled: true},
+		},
+	}
+	require.True(t, series.IsUpcoming(start.AddDate(0, 0, 1)), "second week is ahead")
+	require.False(t, series.IsUpcoming(start.AddDate(0, 0, 8)), "only the canceled week is left")
+
+	// the second week moved past the end of the series
+	series.Exceptions = append(series.Exceptions, domain.Occurrence{GatheringID: 1, RecurrenceID: start.AddDate(0, 0, 7), ScheduledAt: start.AddDate(0, 0, 30)})
+	require.True(t, series.IsUpcoming(start.AddDate(0, 0, 20)))
+}
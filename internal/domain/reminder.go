@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+// ReminderOffsetsOff turns reminders off when given as offsets
+const ReminderOffsetsOff = "off"
+
+// DefaultReminderOffsets are how long before an occurrence starts its reminders are due when none are configured
+var DefaultReminderOffsets = []time.Duration{24 * time.Hour, time.Hour}
+
+// Reminder is sent once per occurrence and offset, repositories store it with its notifications so it is not sent again
+// by a later scan, a restart or another replica
+type Reminder struct {
+	// Occurrence is reminded of, it is identified by its gathering and start so a moved occurrence is reminded of again
+	Occurrence Occurrence
+	// Offset is how long before the start the reminder is due
+	Offset    time.Duration
+	CreatedAt string
+}
+
+// ParseReminderOffsets reads comma separated durations such as 24h,1h longest first, empty is DefaultReminderOffsets and
+// ReminderOffsetsOff is none
+func ParseReminderOffsets(value string) (offsets []time.Duration, err error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return append([]time.Duration{}, DefaultReminderOffsets...), nil
+	}
+	if value == ReminderOffsetsOff {
+		return nil, nil
+	}
+	seen := map[time.Duration]bool{}
+	for _, s := range strings.Split(value, ",") {
+		offset, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil || offset <= 0 {
+			return nil, fmt.Errorf("reminder offset %q must be a positive duration e.g. 24h", s)
+		}
+		if seen[offset] {
+			return nil, fmt.Errorf("reminder offset %q is given twice", s)
+		}
+		seen[offset] = true
+		offsets = append(offsets, offset)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] > offsets[j] })
+	return
+}
+
+// DueReminderOffset is the offset whose reminder is due at now for an occurrence starting at start, the shortest offset
+// not shorter than the time left. A longer reminder missed, e.g. for a gathering created an hour before it starts or
+// while the server was down, is skipped rather than sent late. ok is false when the occurrence started or is further away
+// than every offset.
+func DueReminderOffset(offsets []time.Duration, start time.Time, now time.Time) (offset time.Duration, ok bool) {
+	left := start.Sub(now)
+	if left <= 0 {
+		return
+	}
+	for _, o := range offsets {
+		if o >= left && (!ok || o < offset) {
+			offset, ok = o, true
+		}
+	}
+	return
+}
+
+// LongestReminderOffset is how far ahead reminders look for occurrences, zero without offsets
+func LongestReminderOffset(offsets []time.Duration) (longest time.Duration) {
+	for _, o := range offsets {
+		if o > longest {
+			longest = o
+		}
+	}
+	return
+}
+
+// DueReminders returns a reminder for each occurrence of the gathering a reminder is due for at now, canceled ones aside
+func (d Gathering) DueReminders(offsets []time.Duration, now time.Time) (reminders []Reminder, err error) {
+	longest := LongestReminderOffset(offsets)
+	if longest == 0 {
+		return
+	}
+	occurrences, err := d.Occurrences(now, now.Add(longest))
+	if err != nil {
+		return
+	}
+	for _, o := range occurrences {
+		if o.Canceled {
+			continue
+		}
+		if offset, ok := DueReminderOffset(offsets, o.ScheduledAt, now); ok {
+			reminders = append(reminders, Reminder{Occurrence: o, Offset: offset})
+		}
+	}
+	return
+}
+
+// Notifications makes the reminder of each attendee and of each invitee who has not answered yet, whose notification
+// carries the invitation to answer. A member both attending and invited is reminded once as an attendee.
+func (d Reminder) Notifications(attendeeIDs []int64, invitations []Invitation) (notifications []Notification) {
+	data := d.Occurrence.NotificationData()
+	notifications = NewNotifications(NotificationGatheringReminder, attendeeIDs, data)
+	listed := map[int64]bool{}
+	for _, id := range attendeeIDs {
+		listed[id] = true
+	}
+	for _, i := range invitations {
+		if i.Status != valueobject.INVITATION_CREATED || listed[i.MemberID] {
+			continue
+		}
+		listed[i.MemberID] = true
+		invited := data
+		invited.InvitationID = i.ID
+		notifications = append(notifications, NewNotifications(NotificationGatheringReminder, []int64{i.MemberID}, invited)...)
+	}
+	return
+}
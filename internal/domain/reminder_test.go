@@ -0,0 +1,148 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReminderOffsets(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []time.Duration
+		wantErr bool
+	}{
+		{
+			name: "default",
+			want: domain.DefaultReminderOffsets,
+		},
+		{
+			name:  "off",
+			value: domain.ReminderOffsetsOff,
+		},
+		{
+			name:  "sorted longest first",
+			value: "30m, 48h,2h",
+			want:  []time.Duration{48 * time.Hour, 2 * time.Hour, 30 * time.Minute},
+		},
+		{
+			name:    "not a duration",
+			value:   "24h,1d",
+			wantErr: true,
+		},
+		{
+			name:    "negative",
+			value:   "-1h",
+			wantErr: true,
+		},
+		{
+			name:    "twice",
+			value:   "1h,60m",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.ParseReminderOffsets(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDueReminderOffset(t *testing.T) {
+	now := time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC)
+	offsets := []time.Duration{24 * time.Hour, time.Hour}
+	tests := []struct {
+		name   string
+		start  time.Time
+		want   time.Duration
+		wantOK bool
+	}{
+		{
+			name:  "further than every offset",
+			start: now.Add(25 * time.Hour),
+		},
+		{
+			name:   "day before",
+			start:  now.Add(24 * time.Hour),
+			want:   24 * time.Hour,
+			wantOK: true,
+		},
+		{
+			name:   "hour before skips the missed day before",
+			start:  now.Add(30 * time.Minute),
+			want:   time.Hour,
+			wantOK: true,
+		},
+		{
+			name:  "started",
+			start: now,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := domain.DueReminderOffset(offsets, tt.start, now)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGathering_DueReminders(t *testing.T) {
+	now := time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC)
+	offsets := []time.Duration{50 * time.Hour, time.Hour}
+	series := domain.Gathering{
+		ID:          1,
+		Name:        "standup",
+		Location:    "room 1",
+		ScheduledAt: time.Date(2023, 9, 1, 9, 30, 0, 0, time.UTC),
+		Recurrence:  "FREQ=DAILY",
+		Exceptions: []domain.Occurrence{
+			// tomorrow is canceled, it would be due for its 50h reminder
+			{GatheringID: 1, RecurrenceID: time.Date(2023, 10, 3, 9, 30, 0, 0, time.UTC), ScheduledAt: time.Date(2023, 10, 3, 9, 30, 0, 0, time.UTC), Name: "standup", Location: "room 1", Canceled: true},
+		},
+	}
+	got, err := series.DueReminders(offsets, now)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, time.Date(2023, 10, 2, 9, 30, 0, 0, time.UTC), got[0].Occurrence.ScheduledAt)
+	require.Equal(t, time.Hour, got[0].Offset)
+	require.Equal(t, time.Date(2023, 10, 4, 9, 30, 0, 0, time.UTC), got[1].Occurrence.ScheduledAt)
+	require.Equal(t, 50*time.Hour, got[1].Offset)
+
+	got, err = series.DueReminders(nil, now)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func TestReminder_Notifications(t *testing.T) {
+	reminder := domain.Reminder{
+		Occurrence: domain.Occurrence{GatheringID: 1, ScheduledAt: time.Date(2023, 10, 2, 9, 30, 0, 0, time.UTC), Name: "standup", Location: "room 1"},
+		Offset:     time.Hour,
+	}
+	invitations := []domain.Invitation{
+		{ID: 10, MemberID: 3, Status: valueobject.INVITATION_CREATED},
+		{ID: 11, MemberID: 4, Status: valueobject.INVITATION_REJECT},
+		{ID: 12, MemberID: 5, Status: valueobject.INVITATION_TENTATIVE},
+		// an attendee invited again is reminded as an attendee
+		{ID: 13, MemberID: 2, Status: valueobject.INVITATION_CREATED},
+	}
+	got := reminder.Notifications([]int64{1, 2}, invitations)
+	require.Len(t, got, 3)
+	for _, n := range got {
+		require.Equal(t, domain.NotificationGatheringReminder, n.Event)
+		require.Equal(t, "standup", n.Data.GatheringName)
+	}
+	require.Equal(t, int64(0), got[1].Data.InvitationID)
+	require.Equal(t, int64(3), got[2].MemberID)
+	require.Equal(t, int64(10), got[2].Data.InvitationID)
+}
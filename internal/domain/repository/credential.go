@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+type ICredential interface {
+	Save(ctx context.Context, credential domain.Credential) (err error)
+	Get(ctx context.Context, args domain.CredentialArgs) (credentials []domain.Credential, err error)
+}
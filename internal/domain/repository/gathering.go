@@ -2,13 +2,23 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/hieronimusbudi/simple-go-api/internal/domain"
 )
 
+// IGathering writes the notifications given to Update, Delete, SaveOccurrence and Split to the outbox in the transaction of the change
 type IGathering interface {
 	Create(ctx context.Context, gathering domain.Gathering) (ID int64, err error)
 	Get(ctx context.Context, args domain.GatheringArgs) (gatherings []domain.Gathering, err error)
-	Update(ctx context.Context, gathering domain.Gathering) (err error)
-	Delete(ctx context.Context, args domain.GatheringArgs) (err error)
+	Count(ctx context.Context, args domain.GatheringArgs) (total int64, err error)
+	// Update and Leave return the IDs of invitations the seats they freed promoted from the waitlist
+	Update(ctx context.Context, gathering domain.Gathering, notifications ...domain.Notification) (promotedIDs []int64, err error)
+	Delete(ctx context.Context, args domain.GatheringArgs, notifications ...domain.Notification) (err error)
+	Purge(ctx context.Context, discardedBefore string) (total int64, err error)
+	SaveOccurrence(ctx context.Context, occurrence domain.Occurrence, notifications ...domain.Notification) (err error)
+	Split(ctx context.Context, current domain.Gathering, following domain.Gathering, splitAt time.Time, notifications ...domain.Notification) (id int64, err error)
+	// Join and Leave add and remove an attendee, Join fails with domain.ErrConflict when the gathering is full
+	Join(ctx context.Context, gatheringID int64, memberID int64) (err error)
+	Leave(ctx context.Context, gatheringID int64, memberID int64) (promotedIDs []int64, err error)
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+type IGroup interface {
+	// Create stores the group together with its members in one transaction
+	Create(ctx context.Context, group domain.Group) (ID int64, err error)
+	Get(ctx context.Context, args domain.GroupArgs) (groups []domain.Group, err error)
+	Count(ctx context.Context, args domain.GroupArgs) (total int64, err error)
+	Update(ctx context.Context, group domain.Group) (err error)
+	Delete(ctx context.Context, args domain.GroupArgs) (err error)
+	AddMember(ctx context.Context, member domain.GroupMember) (err error)
+	UpdateMember(ctx context.Context, member domain.GroupMember) (err error)
+	RemoveMember(ctx context.Context, member domain.GroupMember) (err error)
+	AddGathering(ctx context.Context, groupID int64, gatheringID int64) (err error)
+}
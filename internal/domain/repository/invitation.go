@@ -6,8 +6,13 @@ import (
 	"github.com/hieronimusbudi/simple-go-api/internal/domain"
 )
 
+// IInvitation writes the notifications given to Create, CreateBatch and UpdateStatus to the outbox in the transaction of the change
 type IInvitation interface {
-	Create(ctx context.Context, invitation domain.Invitation) (ID int64, err error)
+	Create(ctx context.Context, invitation domain.Invitation, notifications ...domain.Notification) (ID int64, err error)
+	// CreateBatch creates all invitations in one transaction or none of them, IDs are in the order of invitations
+	CreateBatch(ctx context.Context, invitations []domain.Invitation, notifications ...domain.Notification) (IDs []int64, err error)
 	Get(ctx context.Context, args domain.InvitationArgs) (invitations []domain.Invitation, err error)
-	UpdateStatus(ctx context.Context, args domain.InvitationArgs) (err error)
+	Count(ctx context.Context, args domain.InvitationArgs) (total int64, err error)
+	// UpdateStatus returns the IDs of invitations the seats it freed promoted from the waitlist
+	UpdateStatus(ctx context.Context, args domain.InvitationArgs, notifications ...domain.Notification) (promotedIDs []int64, err error)
 }
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// ILease lets one of several processes sharing the database run a job at a time
+type ILease interface {
+	// Acquire takes the lease named name for holder until ttl from now, or renews it when holder has it already.
+	// acquired is false while another holder has it and it has not expired.
+	Acquire(ctx context.Context, name string, holder string, ttl time.Duration) (acquired bool, err error)
+	// Release gives the lease up if holder has it, so another process need not wait for it to expire
+	Release(ctx context.Context, name string, holder string) (err error)
+}
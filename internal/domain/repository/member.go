@@ -9,6 +9,9 @@ import (
 type IMember interface {
 	Create(ctx context.Context, member domain.Member) (id int64, err error)
 	Get(ctx context.Context, args domain.MemberArgs) (members []domain.Member, err error)
+	Count(ctx context.Context, args domain.MemberArgs) (total int64, err error)
 	Update(ctx context.Context, member domain.Member) (err error)
 	Delete(ctx context.Context, args domain.MemberArgs) (err error)
+	UpdateRole(ctx context.Context, member domain.Member) (err error)
+	Purge(ctx context.Context, discardedBefore string) (total int64, err error)
 }
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+// INotification is the outbox, notifications are added by the repository methods making the change they tell about
+type INotification interface {
+	// Get returns notifications oldest first
+	Get(ctx context.Context, args domain.NotificationArgs) (notifications []domain.Notification, err error)
+	// Update stores the delivery state: status, attempts, last error and sent at
+	Update(ctx context.Context, notification domain.Notification) (err error)
+}
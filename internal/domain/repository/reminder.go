@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+type IReminder interface {
+	// Create stores the reminder and writes its notifications to the outbox in one transaction. created is false and nothing
+	// is written when the reminder of the same occurrence and offset was stored before.
+	Create(ctx context.Context, reminder domain.Reminder, notifications ...domain.Notification) (created bool, err error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+)
+
+type IWebhook interface {
+	Create(ctx context.Context, webhook domain.Webhook) (ID int64, err error)
+	Get(ctx context.Context, args domain.WebhookArgs) (webhooks []domain.Webhook, err error)
+	// Update stores the url, events and disabled flag, the secret only when it is set
+	Update(ctx context.Context, webhook domain.Webhook) (err error)
+	// Delete removes the webhook together with its delivery log
+	Delete(ctx context.Context, id int64) (err error)
+	// CreateDeliveries stores pending deliveries in one transaction, IDs are in the order of deliveries
+	CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) (IDs []int64, err error)
+	GetDeliveries(ctx context.Context, args domain.WebhookDeliveryArgs) (deliveries []domain.WebhookDelivery, err error)
+	CountDeliveries(ctx context.Context, args domain.WebhookDeliveryArgs) (total int64, err error)
+	// UpdateDelivery stores the delivery state: status, attempts, next attempt, response status, last error and delivered at
+	UpdateDelivery(ctx context.Context, delivery domain.WebhookDelivery) (err error)
+}
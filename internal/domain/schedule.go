@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultConflictDuration is how long a gathering without an end is taken to last when looking for schedule conflicts
+const DefaultConflictDuration = time.Hour
+
+type (
+	// ScheduleConflict is an occurrence the member attends or is about to attend overlapping an occurrence of another gathering they attend
+	ScheduleConflict struct {
+		MemberID      int64      `json:"member_id"`
+		Occurrence    Occurrence `json:"occurrence"`
+		ConflictsWith Occurrence `json:"conflicts_with"`
+	}
+
+	// ScheduleConflictError refuses to make a member attend overlapping gatherings, it matches ErrConflict
+	ScheduleConflictError struct {
+		Conflicts []ScheduleConflict
+	}
+
+	// ScheduleArgs is a range of a member schedule, zero From is now and zero To is MaxOccurrenceRange after From
+	ScheduleArgs struct {
+		MemberID int64
+		From     time.Time
+		To       time.Time
+	}
+)
+
+func (e *ScheduleConflictError) Error() string {
+	if len(e.Conflicts) == 1 {
+		return "the gathering overlaps a gathering the member attends, use force to attend anyway"
+	}
+	return fmt.Sprintf("the gathering overlaps %d occurrences of gatherings the member attends, use force to attend anyway", len(e.Conflicts))
+}
+
+func (e *ScheduleConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// Validate fills the default range and checks it is at most MaxOccurrenceRange long
+func (d *ScheduleArgs) Validate(now time.Time) (err error) {
+	v := &ValidationError{}
+	if d.From.IsZero() {
+		d.From = now
+	}
+	if d.To.IsZero() {
+		d.To = d.From.Add(MaxOccurrenceRange)
+	}
+	if d.To.Before(d.From) {
+		v.Add("to", CodeOutOfRange, "to must not be before from")
+	} else if d.To.Sub(d.From) > MaxOccurrenceRange {
+		v.Add("to", CodeOutOfRange, "range must be at most 366 days")
+	}
+	return v.Err()
+}
+
+// End is EndsAt, or DefaultConflictDuration after ScheduledAt for an occurrence without an end
+func (d Occurrence) End() time.Time {
+	if d.EndsAt != nil {
+		return *d.EndsAt
+	}
+	return d.ScheduledAt.Add(DefaultConflictDuration)
+}
+
+// Overlaps reports whether two occurrences share some time, one ending when the other starts does not overlap
+func (d Occurrence) Overlaps(other Occurrence) bool {
+	return d.ScheduledAt.Before(other.End()) && other.ScheduledAt.Before(d.End())
+}
+
+// ScheduleConflicts returns the occurrences of the gathering starting within from and to that overlap occurrences of the others.
+// Canceled occurrences do not conflict and the gathering itself is skipped among the others.
+func (d Gathering) ScheduleConflicts(memberID int64, others []Gathering, from time.Time, to time.Time) (conflicts []ScheduleConflict, err error) {
+	conflicts = []ScheduleConflict{}
+	occurrences, err := d.Occurrences(from, to)
+	if err != nil {
+		return
+	}
+	for _, other := range others {
+		if other.ID == d.ID {
+			continue
+		}
+		// an occurrence that started the day before may still be going on at from
+		otherOccurrences, err := other.Occurrences(from.Add(-24*time.Hour), to)
+		if err != nil {
+			return conflicts, err
+		}
+		for _, o := range occurrences {
+			if o.Canceled {
+				continue
+			}
+			for _, with := range otherOccurrences {
+				if !with.Canceled && o.Overlaps(with) {
+					conflicts = append(conflicts, ScheduleConflict{MemberID: memberID, Occurrence: o, ConflictsWith: with})
+				}
+			}
+		}
+	}
+	return
+}
+
+// ScheduleConflicts returns every pair of overlapping occurrences among the gatherings a member attends, each pair once
+func ScheduleConflicts(memberID int64, gatherings []Gathering, from time.Time, to time.Time) (conflicts []ScheduleConflict, err error) {
+	conflicts = []ScheduleConflict{}
+	for i, g := range gatherings {
+		found, err := g.ScheduleConflicts(memberID, gatherings[i+1:], from, to)
+		if err != nil {
+			return conflicts, err
+		}
+		conflicts = append(conflicts, found...)
+	}
+	return
+}
@@ -0,0 +1,122 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGathering_ScheduleConflicts(t *testing.T) {
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2023, 10, day, hour, minute, 0, 0, time.UTC)
+	}
+	endsAt := func(t time.Time) *time.Time { return &t }
+	from, to := at(1, 0, 0), at(31, 0, 0)
+	standup := domain.Gathering{ID: 1, ScheduledAt: at(2, 9, 0), EndsAt: endsAt(at(2, 10, 0)), Recurrence: "FREQ=DAILY;COUNT=5"}
+	tests := []struct {
+		name      string
+		gathering domain.Gathering
+		others    []domain.Gathering
+		want      []time.Time
+	}{
+		{
+			name:      "overlapping start",
+			gathering: domain.Gathering{ID: 2, ScheduledAt: at(3, 9, 30), EndsAt: endsAt(at(3, 11, 0))},
+			others:    []domain.Gathering{standup},
+			want:      []time.Time{at(3, 9, 0)},
+		},
+		{
+			name:      "starting when the other ends",
+			gathering: domain.Gathering{ID: 2, ScheduledAt: at(3, 10, 0), EndsAt: endsAt(at(3, 11, 0))},
+			others:    []domain.Gathering{standup},
+		},
+		{
+			name:      "without an end lasts an hour",
+			gathering: domain.Gathering{ID: 2, ScheduledAt: at(4, 8, 30)},
+			others:    []domain.Gathering{standup},
+			want:      []time.Time{at(4, 9, 0)},
+		},
+		{
+			name:      "every occurrence of a series",
+			gathering: domain.Gathering{ID: 2, ScheduledAt: at(5, 9, 45), Recurrence: "FREQ=DAILY;COUNT=3"},
+			others:    []domain.Gathering{standup},
+			want:      []time.Time{at(5, 9, 0), at(6, 9, 0)},
+		},
+		{
+			name:      "canceled occurrence",
+			gathering: domain.Gathering{ID: 2, ScheduledAt: at(3, 9, 30)},
+			others: []domain.Gathering{func() domain.Gathering {
+				g := standup
+				g.Exceptions = []domain.Occurrence{{GatheringID: 1, RecurrenceID: at(3, 9, 0), ScheduledAt: at(3, 9, 0), Canceled: true}}
+				return g
+			}()},
+		},
+		{
+			name:      "the gathering itself",
+			gathering: standup,
+			others:    []domain.Gathering{standup},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflicts, err := tt.gathering.ScheduleConflicts(1, tt.others, from, to)
+			require.NoError(t, err)
+			got := []time.Time{}
+			for _, c := range conflicts {
+				require.Equal(t, int64(1), c.MemberID)
+				require.True(t, c.Occurrence.Overlaps(c.ConflictsWith))
+				got = append(got, c.ConflictsWith.ScheduledAt)
+			}
+			if tt.want == nil {
+				tt.want = []time.Time{}
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestScheduleConflicts(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2023, 10, 2, hour, 0, 0, 0, time.UTC)
+	}
+	gatherings := []domain.Gathering{
+		{ID: 1, ScheduledAt: at(9)},
+		{ID: 2, ScheduledAt: at(9)},
+		{ID: 3, ScheduledAt: at(12)},
+	}
+	conflicts, err := domain.ScheduleConflicts(1, gatherings, at(0), at(23))
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+	require.Equal(t, int64(1), conflicts[0].Occurrence.GatheringID)
+	require.Equal(t, int64(2), conflicts[0].ConflictsWith.GatheringID)
+}
+
+func TestScheduleArgs_Validate(t *testing.T) {
+	now := time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		args     domain.ScheduleArgs
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  bool
+	}{
+		{name: "defaults", wantFrom: now, wantTo: now.Add(domain.MaxOccurrenceRange)},
+		{name: "from only", args: domain.ScheduleArgs{From: now.Add(time.Hour)}, wantFrom: now.Add(time.Hour), wantTo: now.Add(time.Hour + domain.MaxOccurrenceRange)},
+		{name: "to before from", args: domain.ScheduleArgs{From: now, To: now.Add(-time.Hour)}, wantErr: true},
+		{name: "too long", args: domain.ScheduleArgs{From: now, To: now.Add(domain.MaxOccurrenceRange + time.Hour)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.args.Validate(now)
+			if tt.wantErr {
+				require.ErrorIs(t, err, domain.ErrValidation)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantFrom, tt.args.From)
+			require.Equal(t, tt.wantTo, tt.args.To)
+		})
+	}
+}
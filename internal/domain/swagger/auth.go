@@ -0,0 +1,14 @@
+package swaggermodel
+
+type (
+	Login struct {
+		Email    string `json:"email" validate:"required" example:"john@mail.com"`
+		Password string `json:"password" validate:"required" example:"secret-password"`
+	}
+
+	Token struct {
+		AccessToken string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+		TokenType   string `json:"token_type" example:"Bearer"`
+		ExpiresAt   string `json:"expires_at" example:"2023-10-03T11:05:01Z"`
+	}
+)
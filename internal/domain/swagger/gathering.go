@@ -6,17 +6,38 @@ import (
 
 type (
 	Gathering struct {
-		ID      int64         `json:"id" db:"id"`
-		Creator MemberPayload `json:"creator" validate:"required"`
+		ID int64 `json:"id" db:"id"`
+		// Default to the authenticated member, which is the only creator allowed
+		Creator MemberPayload `json:"creator" validate:"optional"`
 		// Gathering type
 		// * 0 -> Private
 		// * 1 -> Public
 		Type valueobject.GatheringType `json:"type" db:"type" validate:"required"  example:"1"`
-		// Date using (YYYY-MM-DD MM:SS) format
-		ScheduledAt string          `json:"scheduled_at" db:"scheduled_at" validate:"required" example:"2023-10-06 04:53"`
-		Name        string          `json:"name" db:"name" validate:"required" example:"Gathering Name"`
-		Location    string          `json:"location" db:"location" validate:"required" example:"gathering street"`
-		Attendees   []MemberPayload `json:"attendees" validate:"optional"`
+		// Start time in RFC 3339 format, returned in the gathering time zone
+		ScheduledAt string `json:"scheduled_at" validate:"required" example:"2023-10-06T19:00:00+07:00"`
+		// Optional end time in RFC 3339 format, or set duration_minutes instead
+		EndsAt          string `json:"ends_at" validate:"optional" example:"2023-10-06T21:00:00+07:00"`
+		DurationMinutes int    `json:"duration_minutes" validate:"optional" example:"120"`
+		// IANA time zone name, default to UTC
+		TimeZone string `json:"time_zone" validate:"optional" example:"Asia/Jakarta"`
+		// Optional RRULE making the gathering a series of FREQ, INTERVAL, BYDAY, COUNT and UNTIL, scheduled_at is the first occurrence
+		Recurrence string `json:"recurrence" validate:"optional" example:"FREQ=WEEKLY;BYDAY=FR;COUNT=10"`
+		Name       string `json:"name" db:"name" validate:"required" example:"Gathering Name"`
+		Location   string `json:"location" db:"location" validate:"required" example:"gathering street"`
+		// Optional most people including the creator and guests, accepts beyond it are waitlisted, 0 is unlimited
+		Capacity  int             `json:"capacity" validate:"optional" example:"20"`
+		Attendees []MemberPayload `json:"attendees" validate:"optional"`
+		// Only returned by Get Gathering By ID
+		Headcount Headcount `json:"headcount" validate:"optional"`
+	}
+
+	// Headcount counts people coming, plus-ones included
+	Headcount struct {
+		// Attendees and the guests of accepted invitations
+		Confirmed int `json:"confirmed" example:"12"`
+		// Members who answered maybe and their guests
+		Tentative int `json:"tentative" example:"3"`
+		Total     int `json:"total" example:"15"`
 	}
 
 	UpdateGathering struct {
@@ -24,10 +45,40 @@ type (
 		// * 0 -> Private
 		// * 1 -> Public
 		Type valueobject.GatheringType `json:"type" db:"type" validate:"required"  example:"1"`
-		// Date using (YYYY-MM-DD MM:SS) format
-		ScheduledAt string `json:"scheduled_at" db:"scheduled_at" validate:"required" example:"2023-10-06 04:53"`
-		Name        string `json:"name" db:"name" validate:"required" example:"Gathering Name"`
-		Location    string `json:"location" db:"location" validate:"required" example:"gathering street"`
+		// Start time in RFC 3339 format
+		ScheduledAt string `json:"scheduled_at" validate:"required" example:"2023-10-06T19:00:00+07:00"`
+		// Optional end time in RFC 3339 format, or set duration_minutes instead
+		EndsAt          string `json:"ends_at" validate:"optional" example:"2023-10-06T21:00:00+07:00"`
+		DurationMinutes int    `json:"duration_minutes" validate:"optional" example:"120"`
+		// IANA time zone name, default to UTC
+		TimeZone string `json:"time_zone" validate:"optional" example:"Asia/Jakarta"`
+		// Optional RRULE, default to the current one
+		Recurrence string `json:"recurrence" validate:"optional" example:"FREQ=WEEKLY;BYDAY=FR;COUNT=10"`
+		Name       string `json:"name" db:"name" validate:"required" example:"Gathering Name"`
+		Location   string `json:"location" db:"location" validate:"required" example:"gathering street"`
+		// Optional most people including the creator and guests, 0 is unlimited
+		Capacity int `json:"capacity" validate:"optional" example:"20"`
+	}
+
+	// Occurrence is one time a gathering takes place
+	Occurrence struct {
+		GatheringID int64 `json:"gathering_id" example:"1"`
+		// Start generated by the recurrence, it identifies the occurrence in the occurrence endpoints
+		RecurrenceID string `json:"recurrence_id" example:"2023-10-06T19:00:00+07:00"`
+		ScheduledAt  string `json:"scheduled_at" example:"2023-10-06T19:00:00+07:00"`
+		EndsAt       string `json:"ends_at" example:"2023-10-06T21:00:00+07:00"`
+		Name         string `json:"name" example:"Gathering Name"`
+		Location     string `json:"location" example:"gathering street"`
+		Canceled     bool   `json:"canceled" example:"false"`
+	}
+
+	UpdateOccurrence struct {
+		// Optional start time in RFC 3339 format, default to the occurrence start
+		ScheduledAt string `json:"scheduled_at" validate:"optional" example:"2023-10-06T20:00:00+07:00"`
+		// Optional end time in RFC 3339 format, default to keep the duration
+		EndsAt   string `json:"ends_at" validate:"optional" example:"2023-10-06T22:00:00+07:00"`
+		Name     string `json:"name" validate:"optional" example:"Gathering Name"`
+		Location string `json:"location" validate:"optional" example:"gathering street"`
 	}
 
 	GatheringPayload struct {
@@ -0,0 +1,37 @@
+package swaggermodel
+
+import (
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+type (
+	Group struct {
+		ID      int64         `json:"id"`
+		Name    string        `json:"name" validate:"required" example:"book club"`
+		Members []GroupMember `json:"members"`
+		// Gatherings the group was invited to with auto invite
+		GatheringIDs []int64 `json:"gathering_ids" example:"3"`
+		CreatedAt    string  `json:"created_at"`
+	}
+
+	GroupPayload struct {
+		Name string `json:"name" validate:"required" example:"book club"`
+	}
+
+	GroupMember struct {
+		MemberID int64 `json:"member_id" validate:"required" example:"2"`
+		// Owners manage the group, member or owner, default member
+		Role      valueobject.GroupRole `json:"role" validate:"optional" example:"member"`
+		CreatedAt string                `json:"created_at"`
+	}
+
+	GroupMemberRole struct {
+		Role valueobject.GroupRole `json:"role" validate:"required" example:"owner"`
+	}
+
+	GroupInvitation struct {
+		GroupID int64 `json:"group_id" validate:"required" example:"1"`
+		// Also invite members who join the group later while the gathering is upcoming
+		AutoInvite bool `json:"auto_invite" validate:"optional" example:"true"`
+	}
+)
@@ -12,9 +12,35 @@ type (
 		// * 1 -> Accepted
 		// * 2 -> Rejected
 		// * 3 -> Cancelled
+		// * 4 -> Waitlisted, accepted while the gathering is full
+		// * 5 -> Tentative
 		Status    valueobject.InvitationStatus `json:"status" validate:"optional"`
 		Member    MemberPayload                `json:"member" validate:"required"`
 		Gathering GatheringPayload             `json:"gathering" validate:"required"`
-		CreatedAt string                       `json:"created_at"`
+		// Set while waitlisted, the waitlist is promoted in this order
+		WaitlistedAt string `json:"waitlisted_at" example:"2023-10-03 11:05:01"`
+		// Plus-ones of the member, set by accepting or answering maybe
+		Guests    int    `json:"guests" validate:"optional" example:"1"`
+		Note      string `json:"note" validate:"optional" example:"coming with my partner"`
+		CreatedAt string `json:"created_at"`
+	}
+
+	InvitationResponse struct {
+		// Plus-ones, at most 10, they count toward headcount and capacity
+		Guests int `json:"guests" validate:"optional" example:"1"`
+		// At most 500 characters
+		Note string `json:"note" validate:"optional" example:"coming with my partner"`
+	}
+
+	RejectInvitation struct {
+		// At most 500 characters
+		Note string `json:"note" validate:"optional" example:"out of town that week"`
+	}
+
+	InvitationBatch struct {
+		// Members to invite by ID
+		MemberIDs []int64 `json:"member_ids" validate:"optional" example:"2,3"`
+		// Members to invite by email
+		Emails []string `json:"emails" validate:"optional" example:"ken@mail.com"`
 	}
 )
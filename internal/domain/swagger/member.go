@@ -8,6 +8,11 @@ type (
 		Email     string `json:"email" db:"email" validate:"required" example:"john@mail.com"`
 	}
 
+	CreateMember struct {
+		Member
+		Password string `json:"password,omitempty" example:"secret-password"`
+	}
+
 	MemberPayload struct {
 		ID int64 `json:"id" validate:"required" example:"1"`
 	}
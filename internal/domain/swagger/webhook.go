@@ -0,0 +1,48 @@
+package swaggermodel
+
+import (
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+type (
+	Webhook struct {
+		ID  int64  `json:"id"`
+		URL string `json:"url" validate:"required" example:"https://hooks.example.com/gatherings"`
+		// Only returned when the webhook is created
+		Secret string `json:"secret,omitempty" example:"9f2c4e0b7d1a4c3e8b6f5a2d1c0e9f8a"`
+		// Events to deliver, * for every event
+		Events     []string `json:"events" validate:"required" example:"gathering.created,invitation.accepted"`
+		IsDisabled bool     `json:"is_disabled" example:"false"`
+		CreatedAt  string   `json:"created_at"`
+	}
+
+	WebhookPayload struct {
+		URL string `json:"url" validate:"required" example:"https://hooks.example.com/gatherings"`
+		// Signs deliveries, at least 16 characters. Generated on create when empty, kept on update when empty.
+		Secret string `json:"secret" validate:"optional" example:""`
+		// member.created, member.updated, member.deleted, gathering.created, gathering.updated, gathering.deleted,
+		// invitation.created, invitation.accepted, invitation.waitlisted, invitation.tentative, invitation.rejected,
+		// invitation.canceled or * for every event
+		Events []string `json:"events" validate:"required" example:"gathering.created,invitation.accepted"`
+		// Stops new deliveries
+		IsDisabled bool `json:"is_disabled" validate:"optional" example:"false"`
+	}
+
+	WebhookDelivery struct {
+		ID        int64  `json:"id"`
+		WebhookID int64  `json:"webhook_id"`
+		Event     string `json:"event" example:"gathering.created"`
+		// JSON body posted: id, event, occurred_at and data
+		Payload string `json:"payload"`
+		// pending, delivered or dead
+		Status         valueobject.WebhookDeliveryStatus `json:"status" example:"delivered"`
+		Attempts       int                               `json:"attempts" example:"1"`
+		NextAttemptAt  string                            `json:"next_attempt_at,omitempty"`
+		ResponseStatus int                               `json:"response_status,omitempty" example:"200"`
+		LastError      string                            `json:"last_error,omitempty"`
+		// The delivery this one replays
+		ReplayOf    int64  `json:"replay_of,omitempty"`
+		CreatedAt   string `json:"created_at"`
+		DeliveredAt string `json:"delivered_at,omitempty"`
+	}
+)
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Validation codes, clients match them instead of messages to show their own text
+const (
+	CodeRequired    = "required"
+	CodeInvalid     = "invalid"
+	CodeInvalidType = "invalid_type"
+	CodeTooShort    = "too_short"
+	CodeTooLong     = "too_long"
+	CodeOutOfRange  = "out_of_range"
+	CodeNotAllowed  = "not_allowed"
+)
+
+type (
+	// FieldError is a problem with one field, Field is its path in the request, e.g. attendees[2].id
+	FieldError struct {
+		Field   string `json:"field"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+
+	// ValidationError collects every invalid field of a request, it is an ErrValidation
+	ValidationError struct {
+		Errors []FieldError
+	}
+)
+
+// NewFieldError returns a validation error of a single field
+func NewFieldError(field, code, message string) error {
+	return &ValidationError{Errors: []FieldError{{Field: field, Code: code, Message: message}}}
+}
+
+// Add records a field error, validation goes on to report the other fields too
+func (e *ValidationError) Add(field, code, message string) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Code: code, Message: message})
+}
+
+// Addf is Add with a formatted message
+func (e *ValidationError) Addf(field, code, format string, a ...interface{}) {
+	e.Add(field, code, fmt.Sprintf(format, a...))
+}
+
+// Err returns nil when no field error is recorded, so Validate can end with return v.Err()
+func (e *ValidationError) Err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, f := range e.Errors {
+		messages[i] = f.Message
+	}
+	return strings.Join(messages, ", ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
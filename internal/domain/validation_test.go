@@ -0,0 +1,194 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGathering_Validate(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	scheduledAt := time.Date(2023, 10, 2, 12, 0, 0, 0, time.UTC)
+	valid := domain.Gathering{
+		Creator:     domain.Member{ID: 1},
+		ScheduledAt: scheduledAt,
+		Name:        "dinner",
+		Location:    "home",
+		Attendees:   []domain.Member{{ID: 2}},
+	}
+	tests := []struct {
+		name       string
+		gathering  func() domain.Gathering
+		wantErrors []domain.FieldError
+		// want is checked on success, times in the gathering time zone
+		want func(t *testing.T, gathering domain.Gathering)
+	}{
+		{
+			name:      "valid",
+			gathering: func() domain.Gathering { return valid },
+			want: func(t *testing.T, gathering domain.Gathering) {
+				require.Equal(t, domain.DefaultTimeZone, gathering.TimeZone)
+				require.Nil(t, gathering.EndsAt)
+			},
+		},
+		{
+			name: "time zone and duration",
+			gathering: func() domain.Gathering {
+				g := valid
+				g.TimeZone = "Asia/Jakarta"
+				g.DurationMinutes = 90
+				return g
+			},
+			want: func(t *testing.T, gathering domain.Gathering) {
+				require.Equal(t, time.Date(2023, 10, 2, 19, 0, 0, 0, jakarta), gathering.ScheduledAt)
+				require.Equal(t, time.Date(2023, 10, 2, 20, 30, 0, 0, jakarta), *gathering.EndsAt)
+				require.Equal(t, 90, gathering.DurationMinutes)
+			},
+		},
+		{
+			name: "ends at",
+			gathering: func() domain.Gathering {
+				g := valid
+				endsAt := scheduledAt.Add(2 * time.Hour)
+				g.EndsAt = &endsAt
+				return g
+			},
+			want: func(t *testing.T, gathering domain.Gathering) {
+				require.Equal(t, 120, gathering.DurationMinutes)
+			},
+		},
+		{
+			name: "ends before scheduled",
+			gathering: func() domain.Gathering {
+				g := valid
+				endsAt := scheduledAt.Add(-time.Hour)
+				g.EndsAt = &endsAt
+				return g
+			},
+			wantErrors: []domain.FieldError{
+				{Field: "ends_at", Code: domain.CodeOutOfRange, Message: "ends at must be after scheduled at"},
+			},
+		},
+		{
+			name: "ends at and another duration",
+			gathering: func() domain.Gathering {
+				g := valid
+				endsAt := scheduledAt.Add(time.Hour)
+				g.EndsAt = &endsAt
+				g.DurationMinutes = 30
+				return g
+			},
+			wantErrors: []domain.FieldError{
+				{Field: "duration_minutes", Code: domain.CodeNotAllowed, Message: "set either ends at or duration minutes"},
+			},
+		},
+		{
+			name: "every invalid field",
+			gathering: func() domain.Gathering {
+				return domain.Gathering{
+					TimeZone:  "Mars/Olympus",
+					Attendees: []domain.Member{{ID: 2}, {ID: 3}, {}},
+				}
+			},
+			wantErrors: []domain.FieldError{
+				{Field: "creator.id", Code: domain.CodeRequired, Message: "creator is required"},
+				{Field: "scheduled_at", Code: domain.CodeRequired, Message: "scheduled at is required"},
+				{Field: "time_zone", Code: domain.CodeInvalid, Message: "unknown time zone Mars/Olympus"},
+				{Field: "location", Code: domain.CodeRequired, Message: "location is required"},
+				{Field: "name", Code: domain.CodeRequired, Message: "gathering name is required"},
+				{Field: "attendees[2].id", Code: domain.CodeRequired, Message: "attendee 3 is required"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gathering := tt.gathering()
+			err := gathering.Validate()
+			if tt.wantErrors == nil {
+				require.NoError(t, err)
+				tt.want(t, gathering)
+				return
+			}
+			require.ErrorIs(t, err, domain.ErrValidation)
+			var validationErr *domain.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Equal(t, tt.wantErrors, validationErr.Errors)
+		})
+	}
+}
+
+func TestMember_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		member  domain.Member
+		wantErr string
+	}{
+		{name: "valid", member: domain.Member{FirstName: "john", Email: "john@mail.com"}},
+		{name: "every invalid field", member: domain.Member{Email: "john"}, wantErr: "invalid email format, first name is required"},
+		{name: "missing email", member: domain.Member{FirstName: "john"}, wantErr: "email is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.member.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, domain.ErrValidation)
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPagination_Validate(t *testing.T) {
+	err := domain.Pagination{Limit: 500, Offset: 1, Cursor: "x", Sort: "email"}.Validate(domain.SortByName)
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	fields := []string{}
+	for _, f := range validationErr.Errors {
+		fields = append(fields, f.Field)
+	}
+	require.Equal(t, []string{"limit", "offset", "cursor", "sort"}, fields)
+}
+
+func TestGathering_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		want       time.Time
+		wantErrors []domain.FieldError
+	}{
+		{
+			name: "rfc 3339",
+			body: `{"scheduled_at":"2023-10-06T19:00:00+07:00","name":"dinner"}`,
+			want: time.Date(2023, 10, 6, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "without zone",
+			body: `{"scheduled_at":"2023-10-06 19:00","ends_at":"tomorrow"}`,
+			wantErrors: []domain.FieldError{
+				{Field: "scheduled_at", Code: domain.CodeInvalid, Message: "invalid scheduled at, please use RFC 3339 format, e.g. 2023-10-06T19:00:00+07:00"},
+				{Field: "ends_at", Code: domain.CodeInvalid, Message: "invalid ends at, please use RFC 3339 format, e.g. 2023-10-06T21:00:00+07:00"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gathering := domain.Gathering{}
+			err := json.Unmarshal([]byte(tt.body), &gathering)
+			if tt.wantErrors == nil {
+				require.NoError(t, err)
+				require.True(t, tt.want.Equal(gathering.ScheduledAt))
+				require.Equal(t, "dinner", gathering.Name)
+				return
+			}
+			var validationErr *domain.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Equal(t, tt.wantErrors, validationErr.Errors)
+		})
+	}
+}
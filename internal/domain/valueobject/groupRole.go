@@ -0,0 +1,14 @@
+package valueobject
+
+// GroupRole is the role of a member within a group, owners manage the group and its members
+type GroupRole string
+
+const (
+	GROUP_MEMBER GroupRole = "member"
+	GROUP_OWNER  GroupRole = "owner"
+)
+
+// IsValid reports whether the role is known
+func (r GroupRole) IsValid() bool {
+	return r == GROUP_MEMBER || r == GROUP_OWNER
+}
@@ -7,4 +7,41 @@ const (
 	INVITATION_ACCEPT   InvitationStatus = 1
 	INVITATION_REJECT   InvitationStatus = 2
 	INVITATION_CANCELED InvitationStatus = 3
+	// INVITATION_WAITLISTED is an accept of a full gathering, the member attends once a seat is free
+	INVITATION_WAITLISTED InvitationStatus = 4
+	// INVITATION_TENTATIVE is a maybe, the member does not attend yet but counts toward tentative headcount
+	INVITATION_TENTATIVE InvitationStatus = 5
 )
+
+func (s InvitationStatus) String() string {
+	switch s {
+	case INVITATION_CREATED:
+		return "created"
+	case INVITATION_ACCEPT:
+		return "accepted"
+	case INVITATION_REJECT:
+		return "rejected"
+	case INVITATION_CANCELED:
+		return "canceled"
+	case INVITATION_WAITLISTED:
+		return "waitlisted"
+	case INVITATION_TENTATIVE:
+		return "tentative"
+	}
+	return "unknown"
+}
+
+// PartStat is the iCalendar participation status of an invitee, a canceled invitation has none
+func (s InvitationStatus) PartStat() string {
+	switch s {
+	case INVITATION_CREATED, INVITATION_WAITLISTED:
+		return "NEEDS-ACTION"
+	case INVITATION_ACCEPT:
+		return "ACCEPTED"
+	case INVITATION_REJECT:
+		return "DECLINED"
+	case INVITATION_TENTATIVE:
+		return "TENTATIVE"
+	}
+	return ""
+}
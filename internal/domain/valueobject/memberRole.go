@@ -0,0 +1,8 @@
+package valueobject
+
+type MemberRole string
+
+const (
+	ROLE_MEMBER MemberRole = "member"
+	ROLE_ADMIN  MemberRole = "admin"
+)
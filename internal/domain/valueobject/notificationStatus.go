@@ -0,0 +1,11 @@
+package valueobject
+
+// NotificationStatus is the delivery state of an outbox notification
+type NotificationStatus string
+
+const (
+	NOTIFICATION_PENDING NotificationStatus = "pending"
+	NOTIFICATION_SENT    NotificationStatus = "sent"
+	// NOTIFICATION_FAILED is given up on after domain.MaxNotificationAttempts
+	NOTIFICATION_FAILED NotificationStatus = "failed"
+)
@@ -0,0 +1,16 @@
+package valueobject
+
+// WebhookDeliveryStatus is the state of a webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WEBHOOK_DELIVERY_PENDING   WebhookDeliveryStatus = "pending"
+	WEBHOOK_DELIVERY_DELIVERED WebhookDeliveryStatus = "delivered"
+	// WEBHOOK_DELIVERY_DEAD is given up on after domain.MaxWebhookAttempts, it can be replayed
+	WEBHOOK_DELIVERY_DEAD WebhookDeliveryStatus = "dead"
+)
+
+// IsValid reports whether the status is known
+func (s WebhookDeliveryStatus) IsValid() bool {
+	return s == WEBHOOK_DELIVERY_PENDING || s == WEBHOOK_DELIVERY_DELIVERED || s == WEBHOOK_DELIVERY_DEAD
+}
@@ -0,0 +1,268 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+)
+
+// Webhook events, a webhook subscribes to some of them or to every one with WebhookEventAll
+const (
+	WebhookEventAll = "*"
+
+	WebhookMemberCreated = "member.created"
+	WebhookMemberUpdated = "member.updated"
+	WebhookMemberDeleted = "member.deleted"
+
+	WebhookGatheringCreated = "gathering.created"
+	WebhookGatheringUpdated = "gathering.updated"
+	WebhookGatheringDeleted = "gathering.deleted"
+
+	// invitation events are named after the status the invitation moved to, see WebhookInvitationEvent
+	WebhookInvitationCreated    = "invitation.created"
+	WebhookInvitationAccepted   = "invitation.accepted"
+	WebhookInvitationWaitlisted = "invitation.waitlisted"
+	WebhookInvitationTentative  = "invitation.tentative"
+	WebhookInvitationRejected   = "invitation.rejected"
+	WebhookInvitationCanceled   = "invitation.canceled"
+)
+
+// Headers of a delivery, receivers check the signature before trusting the body
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+const (
+	// MinWebhookSecretLength is the shortest secret a client may choose, a generated one is longer
+	MinWebhookSecretLength = 16
+	// MaxWebhookAttempts is how many times a delivery is tried before it is dead
+	MaxWebhookAttempts = 8
+	// WebhookRetryDelay is the wait after the first failed attempt, it doubles after each further one up to MaxWebhookRetryDelay
+	WebhookRetryDelay    = 30 * time.Second
+	MaxWebhookRetryDelay = time.Hour
+)
+
+// WebhookEvents lists every event a webhook can subscribe to
+var WebhookEvents = []string{
+	WebhookMemberCreated,
+	WebhookMemberUpdated,
+	WebhookMemberDeleted,
+	WebhookGatheringCreated,
+	WebhookGatheringUpdated,
+	WebhookGatheringDeleted,
+	WebhookInvitationCreated,
+	WebhookInvitationAccepted,
+	WebhookInvitationWaitlisted,
+	WebhookInvitationTentative,
+	WebhookInvitationRejected,
+	WebhookInvitationCanceled,
+}
+
+type (
+	// Webhook is a subscription of another tool to changes, deliveries are posted to URL and signed with Secret
+	Webhook struct {
+		ID  int64  `json:"id" db:"id"`
+		URL string `json:"url" db:"url"`
+		// Secret is only shown when the webhook is created, an update without one keeps it
+		Secret string   `json:"secret,omitempty" db:"secret"`
+		Events []string `json:"events" db:"-"`
+		// IsDisabled stops new deliveries, pending ones are dead when their turn comes
+		IsDisabled bool   `json:"is_disabled" db:"is_disabled"`
+		CreatedAt  string `json:"created_at" db:"created_at"`
+	}
+
+	WebhookArgs struct {
+		IDs []int64
+		// IsEnabledOnly leaves out disabled webhooks
+		IsEnabledOnly bool
+	}
+
+	// WebhookDelivery is one event posted to one webhook, the delivery log keeps it after it is delivered or dead
+	WebhookDelivery struct {
+		ID        int64  `json:"id" db:"id"`
+		WebhookID int64  `json:"webhook_id" db:"webhook_id"`
+		Event     string `json:"event" db:"event"`
+		// Payload is the JSON body posted, a replay posts it again as is
+		Payload  string                            `json:"payload" db:"payload"`
+		Status   valueobject.WebhookDeliveryStatus `json:"status" db:"status"`
+		Attempts int                               `json:"attempts" db:"attempts"`
+		// NextAttemptAt is when a pending delivery is tried next
+		NextAttemptAt  string `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+		ResponseStatus int    `json:"response_status,omitempty" db:"response_status"`
+		LastError      string `json:"last_error,omitempty" db:"last_error"`
+		// ReplayOf is the delivery this one replays
+		ReplayOf    int64  `json:"replay_of,omitempty" db:"replay_of"`
+		CreatedAt   string `json:"created_at" db:"created_at"`
+		DeliveredAt string `json:"delivered_at,omitempty" db:"delivered_at"`
+	}
+
+	WebhookDeliveryArgs struct {
+		IDs       []int64
+		WebhookID int64
+		Statuses  []valueobject.WebhookDeliveryStatus
+		// DueBefore finds pending deliveries to try, whose next attempt is not after it
+		DueBefore time.Time
+		Pagination
+	}
+
+	// WebhookPayload is the body of a delivery, ID identifies the event so a receiver can skip one it has seen,
+	// e.g. on a replay
+	WebhookPayload struct {
+		ID         string      `json:"id"`
+		Event      string      `json:"event"`
+		OccurredAt time.Time   `json:"occurred_at"`
+		Data       interface{} `json:"data"`
+	}
+
+	// WebhookDeletion is the data of a deleted event
+	WebhookDeletion struct {
+		ID int64 `json:"id"`
+	}
+)
+
+func (d *Webhook) Validate() (err error) {
+	v := &ValidationError{}
+	if d.URL == "" {
+		v.Add("url", CodeRequired, "url is required")
+	} else if u, err := url.Parse(d.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.Add("url", CodeInvalid, "url must be an absolute http or https URL")
+	}
+	if d.Secret != "" && len(d.Secret) < MinWebhookSecretLength {
+		v.Addf("secret", CodeTooShort, "secret must be at least %d characters", MinWebhookSecretLength)
+	}
+	if len(d.Events) == 0 {
+		v.Add("events", CodeRequired, "events is required")
+	}
+	for i, event := range d.Events {
+		if event != WebhookEventAll && !IsWebhookEvent(event) {
+			v.Addf(fmt.Sprintf("events[%d]", i), CodeInvalid, "unknown event %s", event)
+		}
+	}
+	return v.Err()
+}
+
+func (d *WebhookDeliveryArgs) Validate() (err error) {
+	v := &ValidationError{}
+	d.Pagination.validate(v, SortByCreatedAt)
+	for _, s := range d.Statuses {
+		if !s.IsValid() {
+			v.Addf("status", CodeInvalid, "invalid status %s", s)
+		}
+	}
+	return v.Err()
+}
+
+// IsWebhookEvent reports whether a webhook can subscribe to the event
+func IsWebhookEvent(event string) bool {
+	for _, e := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookInvitationEvent is the event of an invitation moving to status
+func WebhookInvitationEvent(status valueobject.InvitationStatus) string {
+	return "invitation." + status.String()
+}
+
+// Matches reports whether the webhook subscribes to the event
+func (d Webhook) Matches(event string) bool {
+	for _, e := range d.Events {
+		if e == WebhookEventAll || e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign is the X-Webhook-Signature of a body posted at timestamp, receivers compute it the same way to check a delivery:
+// sha256= followed by the hex HMAC-SHA256 of the unix timestamp, a dot and the body, keyed by the secret
+func (d Webhook) Sign(timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(d.Secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewWebhookDeliveries makes a pending delivery of the payload for each webhook subscribing to its event
+func NewWebhookDeliveries(webhooks []Webhook, event string, payload string) (deliveries []WebhookDelivery) {
+	for _, w := range webhooks {
+		if w.IsDisabled || !w.Matches(event) {
+			continue
+		}
+		deliveries = append(deliveries, WebhookDelivery{
+			WebhookID: w.ID,
+			Event:     event,
+			Payload:   payload,
+			Status:    valueobject.WEBHOOK_DELIVERY_PENDING,
+		})
+	}
+	return
+}
+
+// Replay is a pending delivery posting the payload of this one again
+func (d WebhookDelivery) Replay() WebhookDelivery {
+	return WebhookDelivery{
+		WebhookID: d.WebhookID,
+		Event:     d.Event,
+		Payload:   d.Payload,
+		Status:    valueobject.WEBHOOK_DELIVERY_PENDING,
+		ReplayOf:  d.ID,
+	}
+}
+
+// Delivered marks the delivery accepted by the receiver with responseStatus
+func (d *WebhookDelivery) Delivered(now time.Time, responseStatus int) {
+	d.Attempts++
+	d.Status = valueobject.WEBHOOK_DELIVERY_DELIVERED
+	d.ResponseStatus = responseStatus
+	d.LastError = ""
+	d.NextAttemptAt = ""
+	d.DeliveredAt = now.UTC().Format("2006-01-02 15:04:05")
+}
+
+// Fail records a failed attempt, responseStatus is zero when the receiver did not answer. The delivery is tried again
+// after WebhookRetryDelay doubled per attempt until MaxWebhookAttempts, then it is dead. A final failure is dead at once.
+func (d *WebhookDelivery) Fail(now time.Time, responseStatus int, err error, final bool) {
+	d.Attempts++
+	d.ResponseStatus = responseStatus
+	d.LastError = err.Error()
+	if final || d.Attempts >= MaxWebhookAttempts {
+		d.Status = valueobject.WEBHOOK_DELIVERY_DEAD
+		d.NextAttemptAt = ""
+		return
+	}
+	d.NextAttemptAt = now.Add(WebhookRetryBackoff(d.Attempts)).UTC().Format("2006-01-02 15:04:05")
+}
+
+// WebhookRetryBackoff is the wait before the next attempt after attempts failed ones
+func WebhookRetryBackoff(attempts int) time.Duration {
+	delay := WebhookRetryDelay
+	for i := 1; i < attempts && delay < MaxWebhookRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > MaxWebhookRetryDelay {
+		delay = MaxWebhookRetryDelay
+	}
+	return delay
+}
+
+// CursorValue returns the value of the sort field, used to build next page cursor
+func (d WebhookDelivery) CursorValue(field string) string {
+	if field == SortByCreatedAt {
+		return cursorTime(d.CreatedAt)
+	}
+	return ""
+}
@@ -0,0 +1,127 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/domain"
+	"github.com/hieronimusbudi/simple-go-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		webhook    domain.Webhook
+		wantFields []string
+	}{
+		{
+			name:    "valid",
+			webhook: domain.Webhook{URL: "https://hooks.example.com/gatherings", Events: []string{domain.WebhookGatheringCreated}},
+		},
+		{
+			name:       "missing fields",
+			webhook:    domain.Webhook{},
+			wantFields: []string{"url", "events"},
+		},
+		{
+			name:       "relative url, short secret and unknown event",
+			webhook:    domain.Webhook{URL: "/hooks", Secret: "short", Events: []string{domain.WebhookEventAll, "member.purged"}},
+			wantFields: []string{"url", "secret", "events[1]"},
+		},
+		{
+			name:       "not http",
+			webhook:    domain.Webhook{URL: "ftp://hooks.example.com", Events: []string{domain.WebhookEventAll}},
+			wantFields: []string{"url"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.webhook.Validate()
+			if len(tt.wantFields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			var v *domain.ValidationError
+			require.ErrorAs(t, err, &v)
+			fields := []string{}
+			for _, f := range v.Errors {
+				fields = append(fields, f.Field)
+			}
+			require.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func TestNewWebhookDeliveries(t *testing.T) {
+	webhooks := []domain.Webhook{
+		{ID: 1, Events: []string{domain.WebhookMemberCreated}},
+		{ID: 2, Events: []string{domain.WebhookEventAll}},
+		{ID: 3, Events: []string{domain.WebhookEventAll}, IsDisabled: true},
+		{ID: 4, Events: []string{domain.WebhookGatheringCreated}},
+	}
+	got := domain.NewWebhookDeliveries(webhooks, domain.WebhookMemberCreated, `{"id":"a"}`)
+	require.Len(t, got, 2)
+	require.Equal(t, int64(1), got[0].WebhookID)
+	require.Equal(t, int64(2), got[1].WebhookID)
+	require.Equal(t, valueobject.WEBHOOK_DELIVERY_PENDING, got[1].Status)
+	require.Equal(t, `{"id":"a"}`, got[1].Payload)
+}
+
+func TestWebhook_Sign(t *testing.T) {
+	webhook := domain.Webhook{Secret: "0123456789abcdef"}
+	// echo -n '1696237200.{"id":"a"}' | openssl dgst -sha256 -hmac 0123456789abcdef
+	got := webhook.Sign(time.Unix(1696237200, 0), []byte(`{"id":"a"}`))
+	require.Equal(t, "sha256=2ac4db947a5a11f91378d23ea83ee93f02983e1d44c55e80fb12d64297078eb7", got)
+}
+
+func TestWebhookDelivery_Fail(t *testing.T) {
+	now := time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name              string
+		attempts          int
+		final             bool
+		wantStatus        valueobject.WebhookDeliveryStatus
+		wantNextAttemptAt string
+	}{
+		{
+			name:              "first failure waits the retry delay",
+			wantStatus:        valueobject.WEBHOOK_DELIVERY_PENDING,
+			wantNextAttemptAt: "2023-10-02 09:00:30",
+		},
+		{
+			name:              "delay doubles",
+			attempts:          3,
+			wantStatus:        valueobject.WEBHOOK_DELIVERY_PENDING,
+			wantNextAttemptAt: "2023-10-02 09:04:00",
+		},
+		{
+			name:       "last attempt is dead",
+			attempts:   domain.MaxWebhookAttempts - 1,
+			wantStatus: valueobject.WEBHOOK_DELIVERY_DEAD,
+		},
+		{
+			name:       "final failure is dead at once",
+			final:      true,
+			wantStatus: valueobject.WEBHOOK_DELIVERY_DEAD,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := domain.WebhookDelivery{Status: valueobject.WEBHOOK_DELIVERY_PENDING, Attempts: tt.attempts}
+			d.Fail(now, 502, errors.New("bad gateway"), tt.final)
+			require.Equal(t, tt.wantStatus, d.Status)
+			require.Equal(t, tt.attempts+1, d.Attempts)
+			require.Equal(t, 502, d.ResponseStatus)
+			require.Equal(t, "bad gateway", d.LastError)
+			require.Equal(t, tt.wantNextAttemptAt, d.NextAttemptAt)
+		})
+	}
+}
+
+func TestWebhookRetryBackoff(t *testing.T) {
+	require.Equal(t, domain.WebhookRetryDelay, domain.WebhookRetryBackoff(1))
+	require.Equal(t, 2*domain.WebhookRetryDelay, domain.WebhookRetryBackoff(2))
+	require.Equal(t, domain.MaxWebhookRetryDelay, domain.WebhookRetryBackoff(20))
+}
@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"database/sql"
 	"strconv"
 	"strings"
 )
@@ -12,3 +13,8 @@ func IntSliceToString(numbers []int64) string {
 	}
 	return strings.Join(result, ",")
 }
+
+// NullString stores an empty string as NULL, e.g. for a nullable unique column
+func NullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -9,6 +9,11 @@ type (
 		StatusCode int         `json:"status_code"`
 		Message    string      `json:"message"`
 		Data       interface{} `json:"data,omitempty"`
+		Meta       interface{} `json:"meta,omitempty"`
+		// Errors lists the invalid fields of a failed request
+		Errors interface{} `json:"errors,omitempty"`
+		// Warnings lists what a successful request let through, such as schedule conflicts
+		Warnings interface{} `json:"warnings,omitempty"`
 	}
 )
 
@@ -20,3 +25,37 @@ func NewResponse(c *gin.Context, statusCode int, message string, data interface{
 	}
 	c.JSON(statusCode, response)
 }
+
+// NewResponseWithMeta is NewResponse with metadata such as pagination
+func NewResponseWithMeta(c *gin.Context, statusCode int, message string, data interface{}, meta interface{}) {
+	response := ResponsePayload{
+		StatusCode: statusCode,
+		Message:    message,
+		Data:       data,
+		Meta:       meta,
+	}
+	c.JSON(statusCode, response)
+}
+
+// NewResponseWithWarnings is NewResponse with warnings, an empty list is left out
+func NewResponseWithWarnings[T any](c *gin.Context, statusCode int, message string, data interface{}, warnings []T) {
+	response := ResponsePayload{
+		StatusCode: statusCode,
+		Message:    message,
+		Data:       data,
+	}
+	if len(warnings) > 0 {
+		response.Warnings = warnings
+	}
+	c.JSON(statusCode, response)
+}
+
+// NewErrorResponse is NewResponse for a failed request, errors details the message such as invalid fields
+func NewErrorResponse(c *gin.Context, statusCode int, message string, errors interface{}) {
+	response := ResponsePayload{
+		StatusCode: statusCode,
+		Message:    message,
+		Errors:     errors,
+	}
+	c.JSON(statusCode, response)
+}
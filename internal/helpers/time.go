@@ -0,0 +1,30 @@
+package helpers
+
+import (
+	"database/sql"
+	"time"
+)
+
+// DBTimeFormat is how timestamps are written to the database, always in UTC
+const DBTimeFormat = "2006-01-02 15:04:05"
+
+// FormatDBTime returns t in UTC as a database timestamp
+func FormatDBTime(t time.Time) string {
+	return t.UTC().Format(DBTimeFormat)
+}
+
+// NullDBTime is FormatDBTime for an optional time, nil is stored as NULL
+func NullDBTime(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: FormatDBTime(*t), Valid: true}
+}
+
+// ParseDBTime reads a UTC timestamp scanned as text, mysql with parseTime returns it in RFC 3339 format
+func ParseDBTime(value string) (t time.Time, err error) {
+	if t, err = time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Parse(DBTimeFormat, value)
+}
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	domain "github.com/hieronimusbudi/simple-go-api/internal/domain"
 	mock "github.com/stretchr/testify/mock"
@@ -14,6 +15,30 @@ type IGathering struct {
 	mock.Mock
 }
 
+// Count provides a mock function with given fields: ctx, args
+func (_m *IGathering) Count(ctx context.Context, args domain.GatheringArgs) (int64, error) {
+	ret := _m.Called(ctx, args)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GatheringArgs) (int64, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GatheringArgs) int64); ok {
+		r0 = rf(ctx, args)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.GatheringArgs) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, gathering
 func (_m *IGathering) Create(ctx context.Context, gathering domain.Gathering) (int64, error) {
 	ret := _m.Called(ctx, gathering)
@@ -38,13 +63,20 @@ func (_m *IGathering) Create(ctx context.Context, gathering domain.Gathering) (i
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: ctx, args
-func (_m *IGathering) Delete(ctx context.Context, args domain.GatheringArgs) error {
-	ret := _m.Called(ctx, args)
+// Delete provides a mock function with given fields: ctx, args, notifications
+func (_m *IGathering) Delete(ctx context.Context, args domain.GatheringArgs, notifications ...domain.Notification) error {
+	_va := make([]interface{}, len(notifications))
+	for _i := range notifications {
+		_va[_i] = notifications[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, args)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.GatheringArgs) error); ok {
-		r0 = rf(ctx, args)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GatheringArgs, ...domain.Notification) error); ok {
+		r0 = rf(ctx, args, notifications...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -78,13 +110,13 @@ func (_m *IGathering) Get(ctx context.Context, args domain.GatheringArgs) ([]dom
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: ctx, gathering
-func (_m *IGathering) Update(ctx context.Context, gathering domain.Gathering) error {
-	ret := _m.Called(ctx, gathering)
+// Join provides a mock function with given fields: ctx, gatheringID, memberID
+func (_m *IGathering) Join(ctx context.Context, gatheringID int64, memberID int64) error {
+	ret := _m.Called(ctx, gatheringID, memberID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.Gathering) error); ok {
-		r0 = rf(ctx, gathering)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, gatheringID, memberID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -92,6 +124,141 @@ func (_m *IGathering) Update(ctx context.Context, gathering domain.Gathering) er
 	return r0
 }
 
+// Leave provides a mock function with given fields: ctx, gatheringID, memberID
+func (_m *IGathering) Leave(ctx context.Context, gatheringID int64, memberID int64) ([]int64, error) {
+	ret := _m.Called(ctx, gatheringID, memberID)
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) ([]int64, error)); ok {
+		return rf(ctx, gatheringID, memberID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []int64); ok {
+		r0 = rf(ctx, gatheringID, memberID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, gatheringID, memberID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: ctx, discardedBefore
+func (_m *IGathering) Purge(ctx context.Context, discardedBefore string) (int64, error) {
+	ret := _m.Called(ctx, discardedBefore)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, discardedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, discardedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, discardedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveOccurrence provides a mock function with given fields: ctx, occurrence, notifications
+func (_m *IGathering) SaveOccurrence(ctx context.Context, occurrence domain.Occurrence, notifications ...domain.Notification) error {
+	_va := make([]interface{}, len(notifications))
+	for _i := range notifications {
+		_va[_i] = notifications[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, occurrence)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Occurrence, ...domain.Notification) error); ok {
+		r0 = rf(ctx, occurrence, notifications...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Split provides a mock function with given fields: ctx, current, following, splitAt, notifications
+func (_m *IGathering) Split(ctx context.Context, current domain.Gathering, following domain.Gathering, splitAt time.Time, notifications ...domain.Notification) (int64, error) {
+	_va := make([]interface{}, len(notifications))
+	for _i := range notifications {
+		_va[_i] = notifications[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, current, following, splitAt)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Gathering, domain.Gathering, time.Time, ...domain.Notification) (int64, error)); ok {
+		return rf(ctx, current, following, splitAt, notifications...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Gathering, domain.Gathering, time.Time, ...domain.Notification) int64); ok {
+		r0 = rf(ctx, current, following, splitAt, notifications...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Gathering, domain.Gathering, time.Time, ...domain.Notification) error); ok {
+		r1 = rf(ctx, current, following, splitAt, notifications...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, gathering, notifications
+func (_m *IGathering) Update(ctx context.Context, gathering domain.Gathering, notifications ...domain.Notification) ([]int64, error) {
+	_va := make([]interface{}, len(notifications))
+	for _i := range notifications {
+		_va[_i] = notifications[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, gathering)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Gathering, ...domain.Notification) ([]int64, error)); ok {
+		return rf(ctx, gathering, notifications...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Gathering, ...domain.Notification) []int64); ok {
+		r0 = rf(ctx, gathering, notifications...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Gathering, ...domain.Notification) error); ok {
+		r1 = rf(ctx, gathering, notifications...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewIGathering creates a new instance of IGathering. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIGathering(t interface {
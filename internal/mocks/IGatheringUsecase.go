@@ -14,28 +14,51 @@ type IGatheringUsecase struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, gathering
-func (_m *IGatheringUsecase) Create(ctx context.Context, gathering domain.Gathering) (domain.Gathering, error) {
-	ret := _m.Called(ctx, gathering)
+// CancelOccurrence provides a mock function with given fields: ctx, args
+func (_m *IGatheringUsecase) CancelOccurrence(ctx context.Context, args domain.OccurrenceArgs) error {
+	ret := _m.Called(ctx, args)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OccurrenceArgs) error); ok {
+		r0 = rf(ctx, args)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: ctx, gathering, isIgnoreConflicts
+func (_m *IGatheringUsecase) Create(ctx context.Context, gathering domain.Gathering, isIgnoreConflicts bool) (domain.Gathering, []domain.ScheduleConflict, error) {
+	ret := _m.Called(ctx, gathering, isIgnoreConflicts)
 
 	var r0 domain.Gathering
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.Gathering) (domain.Gathering, error)); ok {
-		return rf(ctx, gathering)
+	var r1 []domain.ScheduleConflict
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Gathering, bool) (domain.Gathering, []domain.ScheduleConflict, error)); ok {
+		return rf(ctx, gathering, isIgnoreConflicts)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.Gathering) domain.Gathering); ok {
-		r0 = rf(ctx, gathering)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Gathering, bool) domain.Gathering); ok {
+		r0 = rf(ctx, gathering, isIgnoreConflicts)
 	} else {
 		r0 = ret.Get(0).(domain.Gathering)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.Gathering) error); ok {
-		r1 = rf(ctx, gathering)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Gathering, bool) []domain.ScheduleConflict); ok {
+		r1 = rf(ctx, gathering, isIgnoreConflicts)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]domain.ScheduleConflict)
+		}
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, domain.Gathering, bool) error); ok {
+		r2 = rf(ctx, gathering, isIgnoreConflicts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // Delete provides a mock function with given fields: ctx, args
@@ -102,6 +125,131 @@ func (_m *IGatheringUsecase) GetByID(ctx context.Context, id int64) (domain.Gath
 	return r0, r1
 }
 
+// Join provides a mock function with given fields: ctx, id, isIgnoreConflicts
+func (_m *IGatheringUsecase) Join(ctx context.Context, id int64, isIgnoreConflicts bool) ([]domain.ScheduleConflict, error) {
+	ret := _m.Called(ctx, id, isIgnoreConflicts)
+
+	var r0 []domain.ScheduleConflict
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) ([]domain.ScheduleConflict, error)); ok {
+		return rf(ctx, id, isIgnoreConflicts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) []domain.ScheduleConflict); ok {
+		r0 = rf(ctx, id, isIgnoreConflicts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ScheduleConflict)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, bool) error); ok {
+		r1 = rf(ctx, id, isIgnoreConflicts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Leave provides a mock function with given fields: ctx, id
+func (_m *IGatheringUsecase) Leave(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// List provides a mock function with given fields: ctx, args
+func (_m *IGatheringUsecase) List(ctx context.Context, args domain.GatheringArgs) ([]domain.Gathering, domain.Page, error) {
+	ret := _m.Called(ctx, args)
+
+	var r0 []domain.Gathering
+	var r1 domain.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GatheringArgs) ([]domain.Gathering, domain.Page, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GatheringArgs) []domain.Gathering); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Gathering)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.GatheringArgs) domain.Page); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Get(1).(domain.Page)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.GatheringArgs) error); ok {
+		r2 = rf(ctx, args)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Occurrences provides a mock function with given fields: ctx, args
+func (_m *IGatheringUsecase) Occurrences(ctx context.Context, args domain.OccurrenceArgs) ([]domain.Occurrence, error) {
+	ret := _m.Called(ctx, args)
+
+	var r0 []domain.Occurrence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OccurrenceArgs) ([]domain.Occurrence, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OccurrenceArgs) []domain.Occurrence); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Occurrence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.OccurrenceArgs) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleConflicts provides a mock function with given fields: ctx, args
+func (_m *IGatheringUsecase) ScheduleConflicts(ctx context.Context, args domain.ScheduleArgs) ([]domain.ScheduleConflict, error) {
+	ret := _m.Called(ctx, args)
+
+	var r0 []domain.ScheduleConflict
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ScheduleArgs) ([]domain.ScheduleConflict, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ScheduleArgs) []domain.ScheduleConflict); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ScheduleConflict)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ScheduleArgs) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, gathering
 func (_m *IGatheringUsecase) Update(ctx context.Context, gathering domain.Gathering) error {
 	ret := _m.Called(ctx, gathering)
@@ -116,6 +264,30 @@ func (_m *IGatheringUsecase) Update(ctx context.Context, gathering domain.Gather
 	return r0
 }
 
+// UpdateOccurrence provides a mock function with given fields: ctx, args, edit
+func (_m *IGatheringUsecase) UpdateOccurrence(ctx context.Context, args domain.OccurrenceArgs, edit domain.Occurrence) (domain.Occurrence, error) {
+	ret := _m.Called(ctx, args, edit)
+
+	var r0 domain.Occurrence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OccurrenceArgs, domain.Occurrence) (domain.Occurrence, error)); ok {
+		return rf(ctx, args, edit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OccurrenceArgs, domain.Occurrence) domain.Occurrence); ok {
+		r0 = rf(ctx, args, edit)
+	} else {
+		r0 = ret.Get(0).(domain.Occurrence)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.OccurrenceArgs, domain.Occurrence) error); ok {
+		r1 = rf(ctx, args, edit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewIGatheringUsecase creates a new instance of IGatheringUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIGatheringUsecase(t interface {
@@ -14,23 +14,87 @@ type IInvitation struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, invitation
-func (_m *IInvitation) Create(ctx context.Context, invitation domain.Invitation) (int64, error) {
-	ret := _m.Called(ctx, invitation)
+// Count provides a mock function with given fields: ctx, args
+func (_m *IInvitation) Count(ctx context.Context, args domain.InvitationArgs) (int64, error) {
+	ret := _m.Called(ctx, args)
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.Invitation) (int64, error)); ok {
-		return rf(ctx, invitation)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.InvitationArgs) (int64, error)); ok {
+		return rf(ctx, args)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.Invitation) int64); ok {
-		r0 = rf(ctx, invitation)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.InvitationArgs) int64); ok {
+		r0 = rf(ctx, args)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.Invitation) error); ok {
-		r1 = rf(ctx, invitation)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.InvitationArgs) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, invitation, notifications
+func (_m *IInvitation) Create(ctx context.Context, invitation domain.Invitation, notifications ...domain.Notification) (int64, error) {
+	_va := make([]interface{}, len(notifications))
+	for _i := range notifications {
+		_va[_i] = notifications[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, invitation)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Invitation, ...domain.Notification) (int64, error)); ok {
+		return rf(ctx, invitation, notifications...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Invitation, ...domain.Notification) int64); ok {
+		r0 = rf(ctx, invitation, notifications...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Invitation, ...domain.Notification) error); ok {
+		r1 = rf(ctx, invitation, notifications...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateBatch provides a mock function with given fields: ctx, invitations, notifications
+func (_m *IInvitation) CreateBatch(ctx context.Context, invitations []domain.Invitation, notifications ...domain.Notification) ([]int64, error) {
+	_va := make([]interface{}, len(notifications))
+	for _i := range notifications {
+		_va[_i] = notifications[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, invitations)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Invitation, ...domain.Notification) ([]int64, error)); ok {
+		return rf(ctx, invitations, notifications...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Invitation, ...domain.Notification) []int64); ok {
+		r0 = rf(ctx, invitations, notifications...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.Invitation, ...domain.Notification) error); ok {
+		r1 = rf(ctx, invitations, notifications...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -64,18 +128,37 @@ func (_m *IInvitation) Get(ctx context.Context, args domain.InvitationArgs) ([]d
 	return r0, r1
 }
 
-// UpdateStatus provides a mock function with given fields: ctx, args
-func (_m *IInvitation) UpdateStatus(ctx context.Context, args domain.InvitationArgs) error {
-	ret := _m.Called(ctx, args)
+// UpdateStatus provides a mock function with given fields: ctx, args, notifications
+func (_m *IInvitation) UpdateStatus(ctx context.Context, args domain.InvitationArgs, notifications ...domain.Notification) ([]int64, error) {
+	_va := make([]interface{}, len(notifications))
+	for _i := range notifications {
+		_va[_i] = notifications[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, args)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.InvitationArgs) error); ok {
-		r0 = rf(ctx, args)
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.InvitationArgs, ...domain.Notification) ([]int64, error)); ok {
+		return rf(ctx, args, notifications...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.InvitationArgs, ...domain.Notification) []int64); ok {
+		r0 = rf(ctx, args, notifications...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.InvitationArgs, ...domain.Notification) error); ok {
+		r1 = rf(ctx, args, notifications...)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
 // NewIInvitation creates a new instance of IInvitation. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
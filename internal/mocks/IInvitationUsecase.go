@@ -15,17 +15,29 @@ type IInvitationUsecase struct {
 }
 
 // Accept provides a mock function with given fields: ctx, args
-func (_m *IInvitationUsecase) Accept(ctx context.Context, args domain.InvitationArgs) error {
+func (_m *IInvitationUsecase) Accept(ctx context.Context, args domain.InvitationArgs) ([]domain.ScheduleConflict, error) {
 	ret := _m.Called(ctx, args)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.InvitationArgs) error); ok {
+	var r0 []domain.ScheduleConflict
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.InvitationArgs) ([]domain.ScheduleConflict, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.InvitationArgs) []domain.ScheduleConflict); ok {
 		r0 = rf(ctx, args)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ScheduleConflict)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, domain.InvitationArgs) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // Cancel provides a mock function with given fields: ctx, args
@@ -66,6 +78,32 @@ func (_m *IInvitationUsecase) Create(ctx context.Context, invitation domain.Invi
 	return r0, r1
 }
 
+// CreateBatch provides a mock function with given fields: ctx, gatheringID, batch
+func (_m *IInvitationUsecase) CreateBatch(ctx context.Context, gatheringID int64, batch domain.InvitationBatch) ([]domain.InvitationBatchResult, error) {
+	ret := _m.Called(ctx, gatheringID, batch)
+
+	var r0 []domain.InvitationBatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.InvitationBatch) ([]domain.InvitationBatchResult, error)); ok {
+		return rf(ctx, gatheringID, batch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.InvitationBatch) []domain.InvitationBatchResult); ok {
+		r0 = rf(ctx, gatheringID, batch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.InvitationBatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.InvitationBatch) error); ok {
+		r1 = rf(ctx, gatheringID, batch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: ctx, args
 func (_m *IInvitationUsecase) Get(ctx context.Context, args domain.InvitationArgs) ([]domain.Invitation, error) {
 	ret := _m.Called(ctx, args)
@@ -116,6 +154,65 @@ func (_m *IInvitationUsecase) GetByID(ctx context.Context, id int64) (domain.Inv
 	return r0, r1
 }
 
+// InviteGroup provides a mock function with given fields: ctx, gatheringID, invitation
+func (_m *IInvitationUsecase) InviteGroup(ctx context.Context, gatheringID int64, invitation domain.GroupInvitation) ([]domain.InvitationBatchResult, error) {
+	ret := _m.Called(ctx, gatheringID, invitation)
+
+	var r0 []domain.InvitationBatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.GroupInvitation) ([]domain.InvitationBatchResult, error)); ok {
+		return rf(ctx, gatheringID, invitation)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.GroupInvitation) []domain.InvitationBatchResult); ok {
+		r0 = rf(ctx, gatheringID, invitation)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.InvitationBatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.GroupInvitation) error); ok {
+		r1 = rf(ctx, gatheringID, invitation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, args
+func (_m *IInvitationUsecase) List(ctx context.Context, args domain.InvitationArgs) ([]domain.Invitation, domain.Page, error) {
+	ret := _m.Called(ctx, args)
+
+	var r0 []domain.Invitation
+	var r1 domain.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.InvitationArgs) ([]domain.Invitation, domain.Page, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.InvitationArgs) []domain.Invitation); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.InvitationArgs) domain.Page); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Get(1).(domain.Page)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.InvitationArgs) error); ok {
+		r2 = rf(ctx, args)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Reject provides a mock function with given fields: ctx, args
 func (_m *IInvitationUsecase) Reject(ctx context.Context, args domain.InvitationArgs) error {
 	ret := _m.Called(ctx, args)
@@ -130,6 +227,20 @@ func (_m *IInvitationUsecase) Reject(ctx context.Context, args domain.Invitation
 	return r0
 }
 
+// Tentative provides a mock function with given fields: ctx, args
+func (_m *IInvitationUsecase) Tentative(ctx context.Context, args domain.InvitationArgs) error {
+	ret := _m.Called(ctx, args)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.InvitationArgs) error); ok {
+		r0 = rf(ctx, args)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewIInvitationUsecase creates a new instance of IInvitationUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIInvitationUsecase(t interface {
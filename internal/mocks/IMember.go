@@ -14,6 +14,30 @@ type IMember struct {
 	mock.Mock
 }
 
+// Count provides a mock function with given fields: ctx, args
+func (_m *IMember) Count(ctx context.Context, args domain.MemberArgs) (int64, error) {
+	ret := _m.Called(ctx, args)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MemberArgs) (int64, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MemberArgs) int64); ok {
+		r0 = rf(ctx, args)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.MemberArgs) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, member
 func (_m *IMember) Create(ctx context.Context, member domain.Member) (int64, error) {
 	ret := _m.Called(ctx, member)
@@ -78,6 +102,30 @@ func (_m *IMember) Get(ctx context.Context, args domain.MemberArgs) ([]domain.Me
 	return r0, r1
 }
 
+// Purge provides a mock function with given fields: ctx, discardedBefore
+func (_m *IMember) Purge(ctx context.Context, discardedBefore string) (int64, error) {
+	ret := _m.Called(ctx, discardedBefore)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, discardedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, discardedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, discardedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, member
 func (_m *IMember) Update(ctx context.Context, member domain.Member) error {
 	ret := _m.Called(ctx, member)
@@ -92,6 +140,20 @@ func (_m *IMember) Update(ctx context.Context, member domain.Member) error {
 	return r0
 }
 
+// UpdateRole provides a mock function with given fields: ctx, member
+func (_m *IMember) UpdateRole(ctx context.Context, member domain.Member) error {
+	ret := _m.Called(ctx, member)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Member) error); ok {
+		r0 = rf(ctx, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewIMember creates a new instance of IMember. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIMember(t interface {
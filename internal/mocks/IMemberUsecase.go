@@ -102,6 +102,39 @@ func (_m *IMemberUsecase) GetByID(ctx context.Context, id int64) (domain.Member,
 	return r0, r1
 }
 
+// List provides a mock function with given fields: ctx, args
+func (_m *IMemberUsecase) List(ctx context.Context, args domain.MemberArgs) ([]domain.Member, domain.Page, error) {
+	ret := _m.Called(ctx, args)
+
+	var r0 []domain.Member
+	var r1 domain.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MemberArgs) ([]domain.Member, domain.Page, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MemberArgs) []domain.Member); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Member)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.MemberArgs) domain.Page); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Get(1).(domain.Page)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.MemberArgs) error); ok {
+		r2 = rf(ctx, args)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Update provides a mock function with given fields: ctx, member
 func (_m *IMemberUsecase) Update(ctx context.Context, member domain.Member) error {
 	ret := _m.Called(ctx, member)
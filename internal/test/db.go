@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/migration"
 	"github.com/testcontainers/testcontainers-go/modules/mysql"
 
 	"github.com/jmoiron/sqlx"
@@ -21,24 +22,19 @@ const (
 
 var (
 	db *sqlx.DB
+	// address is the host and port of the container started by SetupMySQLContainer
+	address string
 )
 
 func SetupMySQLContainer() (func(), *sqlx.DB, error) {
 	log.Println("setup MySQL Container")
 	ctx := context.Background()
 
-	seedDataPath, err := os.Getwd()
-	if err != nil {
-		log.Println(err)
-		panic(fmt.Sprintf("%v", err))
-	}
-
 	mysqlC, err := mysql.RunContainer(ctx,
 		testcontainers.WithImage("mysql:latest"),
 		mysql.WithDatabase(dbName),
 		mysql.WithUsername(dbUsername),
 		mysql.WithPassword(dbPassword),
-		mysql.WithScripts(filepath.Join(seedDataPath, "/../../test", "data.sql")),
 	)
 
 	if err != nil {
@@ -56,12 +52,9 @@ func SetupMySQLContainer() (func(), *sqlx.DB, error) {
 
 	host, _ := mysqlC.Host(ctx)
 	p, _ := mysqlC.MappedPort(ctx, "3306/tcp")
-	port := p.Int()
+	address = fmt.Sprintf("%s:%d", host, p.Int())
 
-	connectionString := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?tls=skip-verify&parseTime=true&multiStatements=true",
-		dbUsername, dbPassword, host, port, dbName)
-
-	db, err = sqlx.Connect("mysql", connectionString)
+	db, err = sqlx.Connect("mysql", connectionString(dbName))
 	if err != nil {
 		log.Println(err)
 		return closeContainer, db, err
@@ -72,5 +65,43 @@ func SetupMySQLContainer() (func(), *sqlx.DB, error) {
 		return closeContainer, db, err
 	}
 
+	if err = migrateAndSeed(db, migration.DialectMySQL); err != nil {
+		log.Println(err)
+		return closeContainer, db, err
+	}
+
 	return closeContainer, db, nil
 }
+
+// SetupMySQLDatabase creates another database in the container of SetupMySQLContainer, migrated and seeded with data.sql,
+// for a test that changes rows other tests rely on
+func SetupMySQLDatabase(name string) (*sqlx.DB, error) {
+	log.Println("setup MySQL database", name)
+	if _, err := db.Exec(fmt.Sprintf("CREATE DATABASE `%s`", name)); err != nil {
+		return nil, err
+	}
+	isolated, err := sqlx.Connect("mysql", connectionString(name))
+	if err != nil {
+		return nil, err
+	}
+	if err = migrateAndSeed(isolated, migration.DialectMySQL); err != nil {
+		isolated.Close()
+		return nil, err
+	}
+	return isolated, nil
+}
+
+func connectionString(name string) string {
+	return fmt.Sprintf("%s:%s@tcp(%s)/%s?tls=skip-verify&parseTime=true&multiStatements=true",
+		dbUsername, dbPassword, address, name)
+}
+
+// seedData reads data.sql, tests run from a package directory two levels below internal
+func seedData() (string, error) {
+	seedDataPath, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	seed, err := os.ReadFile(filepath.Join(seedDataPath, "/../../test", "data.sql"))
+	return string(seed), err
+}
@@ -0,0 +1,41 @@
+package test
+
+import (
+	"context"
+	"log"
+
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/migration"
+	"github.com/hieronimusbudi/simple-go-api/internal/adapter/sqlite"
+	"github.com/jmoiron/sqlx"
+)
+
+// SetupSQLite opens a temporary in-memory database, migrated and seeded with data.sql
+func SetupSQLite() (*sqlx.DB, error) {
+	log.Println("setup SQLite in-memory database")
+	db, err := sqlite.Open(":memory:")
+	if err != nil {
+		return nil, err
+	}
+	if err = migrateAndSeed(db, migration.DialectSQLite); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// migrateAndSeed creates tables with the app migrations then inserts test data
+func migrateAndSeed(db *sqlx.DB, dialect string) (err error) {
+	migrator, err := migration.NewMigrator(migration.MigratorArgs{DB: db, Dialect: dialect})
+	if err != nil {
+		return
+	}
+	if _, err = migrator.Up(context.Background()); err != nil {
+		return
+	}
+	seed, err := seedData()
+	if err != nil {
+		return
+	}
+	_, err = db.Exec(seed)
+	return
+}